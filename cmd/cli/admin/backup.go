@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/backup"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	backupLong = templates.LongDesc(`
+		Take a backup of the state of a running orchestrator.
+
+		The backup is a versioned archive holding a consistent snapshot of the job
+		store, including the event log used to resume watchers, along with the
+		state of the nodes known to the orchestrator. It can be restored with
+		'bacalhau admin restore' while the orchestrator is stopped.
+
+		Taking a backup requires an access token with write access to all namespaces.
+`)
+
+	backupExample = templates.Examples(`
+		# Back up the orchestrator to a timestamped archive in the current directory
+		bacalhau admin backup
+
+		# Back up the orchestrator to a given file
+		bacalhau admin backup --output /backups/orchestrator.zip
+`)
+)
+
+// BackupOptions is a struct to support the backup command
+type BackupOptions struct {
+	Output string // Path of the archive to write
+}
+
+func NewBackupOptions() *BackupOptions {
+	return &BackupOptions{}
+}
+
+func NewBackupCmd() *cobra.Command {
+	o := NewBackupOptions()
+	backupCmd := &cobra.Command{
+		Use:           "backup",
+		Short:         "Download a backup archive of the state of the orchestrator",
+		Long:          backupLong,
+		Example:       backupExample,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, api)
+		},
+	}
+	backupCmd.Flags().StringVarP(&o.Output, "output", "o", "",
+		"Path of the archive to write. Defaults to a timestamped archive in the current directory.")
+	return backupCmd
+}
+
+func (o *BackupOptions) run(cmd *cobra.Command, api client.API) error {
+	output := o.Output
+	if output == "" {
+		output = fmt.Sprintf("bacalhau-backup-%s.zip", time.Now().UTC().Format("20060102T150405Z"))
+	}
+	if _, err := os.Stat(output); err == nil {
+		return fmt.Errorf("%s already exists", output)
+	}
+
+	// download to a temporary file, so that a failed backup never leaves a truncated archive behind
+	partial := output + ".partial"
+	f, err := os.Create(partial)
+	if err != nil {
+		return fmt.Errorf("failed to create backup archive: %w", err)
+	}
+	defer os.Remove(partial) //nolint:errcheck // removing the partial file fails once renamed
+
+	err = api.Admin().Backup(cmd.Context(), &apimodels.GetBackupRequest{}, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	// the orchestrator can only report failures that happen after the archive
+	// started streaming by truncating it, so make sure it is complete
+	archive, err := backup.OpenArchive(partial)
+	if err != nil {
+		return fmt.Errorf("orchestrator returned an incomplete backup: %w", err)
+	}
+	manifest := archive.Manifest()
+	if err = archive.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(partial, output); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	cmd.Printf("Backed up %s job store and %d nodes of bacalhau %s to %s\n",
+		manifest.JobStoreType, manifest.Nodes, manifest.BacalhauVersion, output)
+	return nil
+}
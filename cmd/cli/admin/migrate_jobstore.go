@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+	sqlitejobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/sqlite"
+)
+
+// MigrateJobStoreOptions is a struct to support the migrate-jobstore command
+type MigrateJobStoreOptions struct {
+	From string
+	To   string
+}
+
+func NewMigrateJobStoreOptions() *MigrateJobStoreOptions {
+	return &MigrateJobStoreOptions{}
+}
+
+func NewMigrateJobStoreCmd() *cobra.Command {
+	o := NewMigrateJobStoreOptions()
+	cmd := &cobra.Command{
+		Use:   "migrate-jobstore",
+		Short: "Copy the BoltDB job store of an orchestrator into a new SQLite job store",
+		Long: `Copy the jobs, executions, evaluations, job history and events of the BoltDB job store
+into a new SQLite job store. The orchestrator must be stopped while migrating.
+Once migrated, start the orchestrator with the Orchestrator.JobStore.Type=SQLite config to use the new store.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := util.SetupConfig(cmd)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd, cfg)
+		},
+	}
+	cmd.Flags().StringVar(&o.From, "from", "",
+		"Path of the BoltDB job store to migrate. Defaults to the job store of the orchestrator in the data dir.")
+	cmd.Flags().StringVar(&o.To, "to", "",
+		"Path of the SQLite job store to create. Defaults to the SQLite job store of the orchestrator in the data dir.")
+	return cmd
+}
+
+func (o *MigrateJobStoreOptions) run(cmd *cobra.Command, cfg types.Bacalhau) error {
+	ctx := cmd.Context()
+
+	from, to := o.From, o.To
+	var err error
+	if from == "" {
+		if from, err = cfg.JobStoreFilePath(); err != nil {
+			return err
+		}
+	}
+	if to == "" {
+		if to, err = cfg.JobStoreSQLiteFilePath(); err != nil {
+			return err
+		}
+	}
+
+	store, err := sqlitejobstore.NewSQLiteJobStore(to)
+	if err != nil {
+		return err
+	}
+	defer store.Close(ctx) //nolint:errcheck
+
+	summary, err := store.MigrateFromBoltDB(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Migrated %s to %s:\n", from, to)
+	cmd.Printf("  jobs:            %d\n", summary.Jobs)
+	cmd.Printf("  executions:      %d\n", summary.Executions)
+	cmd.Printf("  evaluations:     %d\n", summary.Evaluations)
+	cmd.Printf("  history entries: %d\n", summary.HistoryEntries)
+	cmd.Printf("  events:          %d\n", summary.Events)
+	cmd.Printf("  checkpoints:     %d\n", summary.Checkpoints)
+	cmd.Println()
+	cmd.Printf("Set %s=%s to start the orchestrator with the migrated job store.\n",
+		types.OrchestratorJobStoreTypeKey, types.JobStoreTypeSQLite)
+	return nil
+}
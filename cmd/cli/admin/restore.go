@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/backup"
+)
+
+var (
+	restoreLong = templates.LongDesc(`
+		Restore a backup archive taken with 'bacalhau admin backup' into the local
+		data dir of an orchestrator.
+
+		The orchestrator must be stopped while restoring. The job store is replaced
+		with the one held by the archive, and the node states are imported into the
+		node store when the orchestrator next starts. Backups can only be restored
+		into a repo of the same version as the one they were taken from.
+`)
+
+	restoreExample = templates.Examples(`
+		# Restore a backup into the data dir of a stopped orchestrator
+		bacalhau admin restore bacalhau-backup-20240101T000000Z.zip
+
+		# Restore a backup, overwriting the existing job store
+		bacalhau admin restore bacalhau-backup-20240101T000000Z.zip --force
+`)
+)
+
+// RestoreOptions is a struct to support the restore command
+type RestoreOptions struct {
+	Force bool // Overwrite an existing job store
+}
+
+func NewRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{}
+}
+
+func NewRestoreCmd() *cobra.Command {
+	o := NewRestoreOptions()
+	restoreCmd := &cobra.Command{
+		Use:           "restore <archive>",
+		Short:         "Restore a backup archive into the data dir of a stopped orchestrator",
+		Long:          restoreLong,
+		Example:       restoreExample,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := util.SetupConfig(cmd)
+			if err != nil {
+				return err
+			}
+			return o.run(cmd, cfg, args[0])
+		},
+	}
+	restoreCmd.Flags().BoolVar(&o.Force, "force", false, "Overwrite the existing job store of the orchestrator")
+	return restoreCmd
+}
+
+func (o *RestoreOptions) run(cmd *cobra.Command, cfg types.Bacalhau, archivePath string) error {
+	// open the repo, migrating it to the version of this client
+	r, err := util.SetupRepo(cfg)
+	if err != nil {
+		return err
+	}
+	repoVersion, err := r.Version()
+	if err != nil {
+		return fmt.Errorf("failed to read repo version: %w", err)
+	}
+
+	archive, err := backup.OpenArchive(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close() //nolint:errcheck
+	manifest := archive.Manifest()
+
+	var jobStorePath string
+	switch manifest.JobStoreType {
+	case types.JobStoreTypeBoltDB:
+		jobStorePath, err = cfg.JobStoreFilePath()
+	case types.JobStoreTypeSQLite:
+		jobStorePath, err = cfg.JobStoreSQLiteFilePath()
+	default:
+		return fmt.Errorf("backup holds an unsupported job store type %q", manifest.JobStoreType)
+	}
+	if err != nil {
+		return err
+	}
+	nodeStatesPath, err := cfg.RestoredNodeStatesFilePath()
+	if err != nil {
+		return err
+	}
+
+	err = archive.Restore(cmd.Context(), backup.RestoreParams{
+		RepoVersion:    repoVersion,
+		JobStorePath:   jobStorePath,
+		NodeStatesPath: nodeStatesPath,
+		Force:          o.Force,
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Restored %s job store taken at %s to %s\n",
+		manifest.JobStoreType, manifest.CreateTime.Format("2006-01-02 15:04:05 MST"), jobStorePath)
+	cmd.Printf("%d node states will be imported when the orchestrator starts\n", manifest.Nodes)
+	configuredType := cfg.Orchestrator.JobStore.Type
+	if configuredType == "" {
+		configuredType = types.JobStoreTypeBoltDB
+	}
+	if configuredType != manifest.JobStoreType {
+		cmd.Printf("Set %s=%s to start the orchestrator with the restored job store.\n",
+			types.OrchestratorJobStoreTypeKey, manifest.JobStoreType)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util/hook"
+)
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                "admin",
+		Short:              "Commands to back up, restore and migrate the state of a Bacalhau orchestrator",
+		PersistentPreRunE:  hook.AfterParentPreRunHook(hook.ClientPreRunHooks),
+		PersistentPostRunE: hook.AfterParentPostRunHook(hook.ClientPostRunHooks),
+	}
+
+	cmd.AddCommand(NewBackupCmd())
+	cmd.AddCommand(NewRestoreCmd())
+	cmd.AddCommand(NewMigrateJobStoreCmd())
+	return cmd
+}
@@ -36,10 +36,14 @@ func BuildJobFromFlags(
 		Engine:    engineSpec,
 		Publisher: taskSettings.Publisher.Value(),
 		ResourcesConfig: &models.ResourcesConfig{
-			CPU:    taskSettings.Resources.CPU,
-			Memory: taskSettings.Resources.Memory,
-			Disk:   taskSettings.Resources.Disk,
-			GPU:    taskSettings.Resources.GPU,
+			CPU:       taskSettings.Resources.CPU,
+			Memory:    taskSettings.Resources.Memory,
+			Disk:      taskSettings.Resources.Disk,
+			GPU:       taskSettings.Resources.GPU,
+			GPUMemory: taskSettings.Resources.GPUMemory,
+			GPUVendor: taskSettings.Resources.GPUVendor,
+			GPUModel:  taskSettings.Resources.GPUModel,
+			Extended:  taskSettings.Resources.Extended,
 		},
 		InputSources: taskSettings.InputSources.Values(),
 		ResultPaths:  taskSettings.ResultPaths,
@@ -48,8 +52,9 @@ func BuildJobFromFlags(
 			Domains: taskSettings.Network.Domains,
 		},
 		Timeouts: &models.TimeoutConfig{
-			TotalTimeout: taskSettings.Timeout,
-			QueueTimeout: taskSettings.QueueTimeout,
+			TotalTimeout:          taskSettings.Timeout,
+			QueueTimeout:          taskSettings.QueueTimeout,
+			DisconnectGracePeriod: taskSettings.DisconnectGrace,
 		},
 	}
 	constraints, err := jobSettings.Constraints()
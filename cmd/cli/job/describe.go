@@ -4,8 +4,12 @@ import (
 	"cmp"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
@@ -119,6 +123,9 @@ func (o *DescribeOptions) run(cmd *cobra.Command, args []string, api client.API)
 
 	o.printHeaderData(cmd, job)
 	o.printExecutionsSummary(cmd, executions)
+	if err = o.printPartitions(cmd, job, executions); err != nil {
+		return fmt.Errorf("failed to write job partitions %s: %w", jobID, err)
+	}
 
 	jobHistory := lo.Filter(history, func(entry *models.JobHistory, _ int) bool {
 		return entry.Type == models.JobHistoryTypeJobLevel
@@ -130,6 +137,9 @@ func (o *DescribeOptions) run(cmd *cobra.Command, args []string, api client.API)
 	if err = o.printExecutions(cmd, executions); err != nil {
 		return fmt.Errorf("failed to write job executions %s: %w", jobID, err)
 	}
+	if err = o.printUsage(cmd, executions); err != nil {
+		return fmt.Errorf("failed to write job resource usage %s: %w", jobID, err)
+	}
 
 	for _, execution := range executions {
 		executionHistory := lo.Filter(history, func(item *models.JobHistory, _ int) bool {
@@ -157,6 +167,9 @@ func (o *DescribeOptions) printHeaderData(cmd *cobra.Command, job *models.Job) {
 	if job.Type == models.JobTypeBatch || job.Type == models.JobTypeService {
 		headerData = append(headerData, collections.NewPair[string, any]("Count", job.Count))
 	}
+	if rerunOf, ok := job.Meta[models.MetaRerunOf]; ok {
+		headerData = append(headerData, collections.NewPair[string, any]("Rerun Of", rerunOf))
+	}
 
 	// Additional data
 	headerData = append(headerData, []collections.Pair[string, any]{
@@ -185,6 +198,96 @@ func (o *DescribeOptions) printExecutionsSummary(cmd *cobra.Command, executions
 	output.KeyValue(cmd, summaryPairs)
 }
 
+// partitionStatus is the status of a single partition of a batch job,
+// represented by its most recent execution.
+type partitionStatus struct {
+	Index      int
+	Scheduled  bool
+	Execution  *models.Execution
+	Parameters map[string]string
+}
+
+var partitionCols = []output.TableColumn[*partitionStatus]{
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Partition"},
+		Value:        func(p *partitionStatus) string { return strconv.Itoa(p.Index) },
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "State"},
+		Value: func(p *partitionStatus) string {
+			switch {
+			case !p.Scheduled:
+				return "Not Scheduled"
+			case p.Execution == nil:
+				return "Pending"
+			default:
+				return p.Execution.ComputeState.StateType.String()
+			}
+		},
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Execution"},
+		Value: func(p *partitionStatus) string {
+			if p.Execution == nil {
+				return ""
+			}
+			return idgen.ShortUUID(p.Execution.ID)
+		},
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Parameters", WidthMax: 60, WidthMaxEnforcer: text.WrapSoft},
+		Value: func(p *partitionStatus) string {
+			params := lo.MapToSlice(p.Parameters, func(k, v string) string { return k + "=" + v })
+			slices.Sort(params)
+			return strings.Join(params, " ")
+		},
+	},
+}
+
+// printPartitions summarizes the status of each partition of batch jobs with
+// multiple partitions, using the most recent execution of each partition.
+// Executions are expected to be sorted by creation time, most recent first.
+func (o *DescribeOptions) printPartitions(cmd *cobra.Command, job *models.Job, executions []*models.Execution) error {
+	if job.Type != models.JobTypeBatch || (job.Count <= 1 && job.Matrix == nil) {
+		return nil
+	}
+
+	partitions := make([]*partitionStatus, job.Count)
+	for i := range partitions {
+		partitions[i] = &partitionStatus{Index: i, Scheduled: job.IsPartitionScheduled(i)}
+		if job.Matrix != nil {
+			// ignore errors as the matrix is validated on submission
+			partitions[i].Parameters, _ = job.Matrix.Values(i)
+		}
+	}
+	for _, e := range executions {
+		if e.PartitionIndex < 0 || e.PartitionIndex >= len(partitions) {
+			continue
+		}
+		if partitions[e.PartitionIndex].Execution == nil {
+			partitions[e.PartitionIndex].Execution = e
+		}
+	}
+
+	tableOptions := output.OutputOptions{
+		Format:  output.TableFormat,
+		NoStyle: true,
+	}
+	output.Bold(cmd, "\nPartitions\n")
+	if err := output.Output(cmd, partitionCols, tableOptions, partitions); err != nil {
+		return err
+	}
+
+	incomplete := lo.CountBy(partitions, func(p *partitionStatus) bool {
+		return p.Scheduled && (p.Execution == nil || p.Execution.ComputeState.StateType != models.ExecutionStateCompleted)
+	})
+	if job.IsTerminal() && incomplete > 0 {
+		cmd.Printf("\n%d partition(s) did not complete. To rerun them, use:\n\tbacalhau job rerun %s --failed-only\n",
+			incomplete, job.ID)
+	}
+	return nil
+}
+
 func (o *DescribeOptions) printExecutions(cmd *cobra.Command, executions []*models.Execution) error {
 	// Executions table
 	tableOptions := output.OutputOptions{
@@ -205,6 +308,31 @@ func (o *DescribeOptions) printExecutions(cmd *cobra.Command, executions []*mode
 	return output.Output(cmd, executionCols, tableOptions, executions)
 }
 
+// printUsage prints the measured resource usage of the executions that reported it
+func (o *DescribeOptions) printUsage(cmd *cobra.Command, executions []*models.Execution) error {
+	measured := lo.Filter(executions, func(e *models.Execution, _ int) bool {
+		return executionUsage(e) != nil
+	})
+	if len(measured) == 0 {
+		return nil
+	}
+	tableOptions := output.OutputOptions{
+		Format:  output.TableFormat,
+		NoStyle: true,
+	}
+	usageCols := []output.TableColumn[*models.Execution]{
+		executionColumnID,
+		executionColumnWallTime,
+		executionColumnCPUTime,
+		executionColumnPeakMemory,
+		executionColumnAvgMemory,
+		executionColumnDiskWritten,
+		executionColumnNetwork,
+	}
+	output.Bold(cmd, "\nResource Usage\n")
+	return output.Output(cmd, usageCols, tableOptions, measured)
+}
+
 func (o *DescribeOptions) printHistory(cmd *cobra.Command, label string, history []*models.JobHistory) error {
 	if len(history) < 1 {
 		return nil
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/spf13/cobra"
@@ -135,8 +136,48 @@ var (
 			Name: "Comment", WidthMax: 40, WidthMaxEnforcer: output.WrapSoftPreserveNewlines},
 		Value: func(e *models.Execution) string { return e.ComputeState.Message },
 	}
+	executionColumnWallTime = executionUsageColumn("Wall Time", func(u *models.ResourceUsage) string {
+		return u.WallTime.Round(time.Millisecond).String()
+	})
+	executionColumnCPUTime = executionUsageColumn("CPU Time", func(u *models.ResourceUsage) string {
+		return u.CPUTime.Round(time.Millisecond).String()
+	})
+	executionColumnPeakMemory = executionUsageColumn("Peak Mem.", func(u *models.ResourceUsage) string {
+		return humanize.IBytes(u.PeakMemory)
+	})
+	executionColumnAvgMemory = executionUsageColumn("Avg Mem.", func(u *models.ResourceUsage) string {
+		return humanize.IBytes(u.AvgMemory)
+	})
+	executionColumnDiskWritten = executionUsageColumn("Disk Written", func(u *models.ResourceUsage) string {
+		return humanize.IBytes(u.DiskWritten)
+	})
+	executionColumnNetwork = executionUsageColumn("Net Rx/Tx", func(u *models.ResourceUsage) string {
+		return humanize.IBytes(u.NetworkRx) + "/" + humanize.IBytes(u.NetworkTx)
+	})
 )
 
+// executionUsage returns the measured resource usage of the execution, or nil if it wasn't measured
+func executionUsage(e *models.Execution) *models.ResourceUsage {
+	if e.RunOutput == nil {
+		return nil
+	}
+	return e.RunOutput.Usage
+}
+
+// executionUsageColumn returns a column of the resource usage of executions,
+// which is blank for executions without a measured usage
+func executionUsageColumn(name string, value func(*models.ResourceUsage) string) output.TableColumn[*models.Execution] {
+	return output.TableColumn[*models.Execution]{
+		ColumnConfig: table.ColumnConfig{Name: name, WidthMax: 14, WidthMaxEnforcer: text.WrapText},
+		Value: func(e *models.Execution) string {
+			if usage := executionUsage(e); usage != nil {
+				return value(usage)
+			}
+			return ""
+		},
+	}
+}
+
 var executionColumns = []output.TableColumn[*models.Execution]{
 	executionColumnCreated,
 	executionColumnModified,
@@ -145,6 +186,9 @@ var executionColumns = []output.TableColumn[*models.Execution]{
 	executionColumnRev,
 	executionColumnState,
 	executionColumnDesired,
+	executionColumnWallTime,
+	executionColumnCPUTime,
+	executionColumnPeakMemory,
 }
 
 func (o *ExecutionOptions) run(cmd *cobra.Command, args []string, api client.API) error {
@@ -0,0 +1,45 @@
+//go:build unit || !integration
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type ExecutionColumnsSuite struct {
+	suite.Suite
+}
+
+func TestExecutionColumnsSuite(t *testing.T) {
+	suite.Run(t, new(ExecutionColumnsSuite))
+}
+
+func (s *ExecutionColumnsSuite) TestUsageColumns() {
+	execution := &models.Execution{RunOutput: &models.RunCommandResult{Usage: &models.ResourceUsage{
+		WallTime:    1500 * time.Millisecond,
+		CPUTime:     750 * time.Millisecond,
+		PeakMemory:  2 * 1024 * 1024,
+		AvgMemory:   1024 * 1024,
+		DiskWritten: 2048,
+		NetworkRx:   1024,
+		NetworkTx:   0,
+	}}}
+	s.Equal("1.5s", executionColumnWallTime.Value(execution))
+	s.Equal("750ms", executionColumnCPUTime.Value(execution))
+	s.Equal("2.0 MiB", executionColumnPeakMemory.Value(execution))
+	s.Equal("1.0 MiB", executionColumnAvgMemory.Value(execution))
+	s.Equal("2.0 KiB", executionColumnDiskWritten.Value(execution))
+	s.Equal("1.0 KiB/0 B", executionColumnNetwork.Value(execution))
+}
+
+func (s *ExecutionColumnsSuite) TestUsageColumnsWithoutUsage() {
+	for _, execution := range []*models.Execution{{}, {RunOutput: &models.RunCommandResult{}}} {
+		s.Empty(executionColumnWallTime.Value(execution))
+		s.Empty(executionColumnPeakMemory.Value(execution))
+	}
+}
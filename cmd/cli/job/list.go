@@ -15,6 +15,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/cmd/util"
 	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
 	"github.com/bacalhau-project/bacalhau/cmd/util/output"
+	"github.com/bacalhau-project/bacalhau/cmd/util/parse"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/math"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
@@ -22,7 +23,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/util/idgen"
 )
 
-var orderByFields = []string{"id", "created_at"}
+var orderByFields = []string{"id", "created_at", "modified_at"}
 
 var (
 	listShort = `List submitted jobs.`
@@ -36,7 +37,10 @@ var (
 		bacalhau job list
 
 		# List jobs and output as json
-		bacalhau job list --output json --pretty`)
+		bacalhau job list --output json --pretty
+
+		# List failed batch jobs created in the last 24 hours
+		bacalhau job list --state failed --type batch --created-after 24h`)
 
 	// defaultLabelFilter is the default label filter for the list command when
 	// no other labels are specified.
@@ -47,7 +51,12 @@ var (
 type ListOptions struct {
 	output.OutputOptions
 	cliflags.ListOptions
-	Labels string
+	Labels        string
+	States        []string
+	Types         []string
+	CreatedAfter  string
+	CreatedBefore string
+	NamePrefix    string
 }
 
 // NewListOptions returns initialized Options
@@ -90,6 +99,16 @@ func NewListCmd() *cobra.Command {
 	listCmd.Flags().StringVar(&o.Labels, "labels", o.Labels,
 		"Filter nodes by labels. See https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/ for more information.")
 
+	listCmd.Flags().StringSliceVar(&o.States, "state", o.States,
+		fmt.Sprintf("Only list jobs in any of the given states. One of %v", models.JobStateTypes()))
+	listCmd.Flags().StringSliceVar(&o.Types, "type", o.Types,
+		"Only list jobs of any of the given types (batch, ops, service, daemon)")
+	listCmd.Flags().StringVar(&o.CreatedAfter, "created-after", o.CreatedAfter,
+		"Only list jobs created after a relative duration (e.g. 24h) or an RFC3339 timestamp.")
+	listCmd.Flags().StringVar(&o.CreatedBefore, "created-before", o.CreatedBefore,
+		"Only list jobs created before a relative duration (e.g. 1h) or an RFC3339 timestamp.")
+	listCmd.Flags().StringVar(&o.NamePrefix, "name-prefix", o.NamePrefix, "Only list jobs with a name starting with the prefix")
+
 	listCmd.Flags().AddFlagSet(cliflags.ListFlags(&o.ListOptions))
 	listCmd.Flags().AddFlagSet(cliflags.OutputFormatFlags(&o.OutputOptions))
 	return listCmd
@@ -140,8 +159,21 @@ func (o *ListOptions) run(cmd *cobra.Command, api client.API) error {
 			return fmt.Errorf("could not parse labels: %w", err)
 		}
 	}
+	createdAfter, err := parse.TimeFilter(o.CreatedAfter)
+	if err != nil {
+		return fmt.Errorf("invalid --created-after value: %w", err)
+	}
+	createdBefore, err := parse.TimeFilter(o.CreatedBefore)
+	if err != nil {
+		return fmt.Errorf("invalid --created-before value: %w", err)
+	}
 	response, err := api.Jobs().List(ctx, &apimodels.ListJobsRequest{
-		Labels: labelRequirements,
+		Labels:        labelRequirements,
+		States:        o.States,
+		Types:         o.Types,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		NamePrefix:    o.NamePrefix,
 		BaseListRequest: apimodels.BaseListRequest{
 			Limit:     o.Limit,
 			NextToken: o.NextToken,
@@ -162,6 +194,23 @@ func (o *ListOptions) run(cmd *cobra.Command, api client.API) error {
 			fmt.Sprintf("--limit %d", o.Limit),
 			fmt.Sprintf("--next-token %s", response.NextToken),
 		}
+		// filters are not part of the token, and must be repeated to fetch the next page.
+		// relative times are resolved so that the same jobs keep matching.
+		for _, state := range o.States {
+			flags = append(flags, fmt.Sprintf("--state %s", state))
+		}
+		for _, jobType := range o.Types {
+			flags = append(flags, fmt.Sprintf("--type %s", jobType))
+		}
+		if createdAfter != 0 {
+			flags = append(flags, fmt.Sprintf("--created-after %s", time.Unix(createdAfter, 0).Format(time.RFC3339)))
+		}
+		if createdBefore != 0 {
+			flags = append(flags, fmt.Sprintf("--created-before %s", time.Unix(createdBefore, 0).Format(time.RFC3339)))
+		}
+		if o.NamePrefix != "" {
+			flags = append(flags, fmt.Sprintf("--name-prefix %s", o.NamePrefix))
+		}
 
 		msg := "To fetch more records use:"
 		msg += fmt.Sprintf("\n\tbacalhau job list %s", strings.Join(flags, " "))
@@ -8,6 +8,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
 
 	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/parse"
 )
 
 var (
@@ -24,6 +25,9 @@ var (
 
 		# Tail logs for a previously submitted job
 		bacalhau job logs j-51225160-807e-48b8-88c9-28311c7899e1 --tail
+
+		# Read stderr lines containing "error" from the last hour of a completed job
+		bacalhau job logs j-51225160-807e-48b8-88c9-28311c7899e1 --stream stderr --since 1h --grep error
 `)
 )
 
@@ -31,6 +35,10 @@ type LogCommandOptions struct {
 	ExecutionID string
 	Follow      bool
 	Tail        bool
+	Stream      string
+	Since       string
+	Until       string
+	Pattern     string
 }
 
 func NewLogCmd() *cobra.Command {
@@ -44,11 +52,23 @@ func NewLogCmd() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, cmdArgs []string) error {
+			since, err := parse.TimeFilter(options.Since)
+			if err != nil {
+				return fmt.Errorf("invalid --since value: %w", err)
+			}
+			until, err := parse.TimeFilter(options.Until)
+			if err != nil {
+				return fmt.Errorf("invalid --until value: %w", err)
+			}
 			opts := util.LogOptions{
 				JobID:       cmdArgs[0],
 				ExecutionID: options.ExecutionID,
 				Follow:      options.Follow,
 				Tail:        options.Tail,
+				Stream:      options.Stream,
+				Since:       since,
+				Until:       until,
+				Pattern:     options.Pattern,
 			}
 			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
 			cfg, err := util.SetupRepoConfig(cmd)
@@ -78,5 +98,25 @@ func NewLogCmd() *cobra.Command {
 		&options.Tail, "tail", "t", false,
 		"Tail the logs from the end of the log stream.",
 	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&options.Stream, "stream", "",
+		"Only show lines from the given stream: stdout or stderr.",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&options.Since, "since", "",
+		"Only show retained logs since a relative duration (e.g. 1h) or an RFC3339 timestamp.",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&options.Until, "until", "",
+		"Only show retained logs until a relative duration (e.g. 30m) or an RFC3339 timestamp.",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&options.Pattern, "grep", "",
+		"Only show lines matching the given regular expression.",
+	)
 	return logsCmd
 }
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	pruneLong = templates.LongDesc(`
+		Purge terminal jobs from the orchestrator.
+
+		Jobs that completed, failed or were stopped, and were last modified before
+		the requested age, are removed along with their executions, history and
+		evaluations. If the orchestrator is configured to archive jobs, they are
+		exported to a compressed archive on the orchestrator before being removed.
+		Jobs that are still in progress are never purged.
+`)
+
+	pruneExample = templates.Examples(`
+		# Purge jobs that terminated more than 30 days ago
+		bacalhau job prune --older-than 720h
+
+		# List the jobs of a namespace that would be purged, without purging them
+		bacalhau job prune --older-than 24h --namespace default --dry-run
+`)
+)
+
+type PruneOptions struct {
+	OlderThan time.Duration // Minimum time since jobs were last modified
+	Namespace string        // Namespace to purge jobs from, all namespaces if empty
+	DryRun    bool          // List the jobs without purging them
+}
+
+func NewPruneOptions() *PruneOptions {
+	return &PruneOptions{}
+}
+
+func NewPruneCmd() *cobra.Command {
+	o := NewPruneOptions()
+
+	pruneCmd := &cobra.Command{
+		Use:           "prune",
+		Short:         "Purge terminal jobs older than a given age",
+		Long:          pruneLong,
+		Example:       pruneExample,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, api)
+		},
+	}
+
+	pruneCmd.Flags().DurationVar(&o.OlderThan, "older-than", 0,
+		"Purge terminal jobs last modified longer ago than this duration (e.g. 720h)")
+	pruneCmd.Flags().StringVar(&o.Namespace, "namespace", "",
+		"Only purge jobs of this namespace. Jobs of all namespaces are purged if empty")
+	pruneCmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "List the jobs that would be purged without purging them")
+	_ = pruneCmd.MarkFlagRequired("older-than")
+	return pruneCmd
+}
+
+func (o *PruneOptions) run(cmd *cobra.Command, api client.API) error {
+	if o.OlderThan <= 0 {
+		return fmt.Errorf("--older-than must be greater than zero")
+	}
+	resp, err := api.Jobs().Prune(cmd.Context(), &apimodels.PruneJobsRequest{
+		OlderThan: o.OlderThan,
+		Namespace: o.Namespace,
+		DryRun:    o.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune jobs: %w", err)
+	}
+
+	if o.DryRun {
+		cmd.Printf("%d jobs would be purged\n", len(resp.JobIDs))
+		for _, jobID := range resp.JobIDs {
+			cmd.Printf("\t%s\n", jobID)
+		}
+		return nil
+	}
+	cmd.Printf("Purged %d jobs\n", len(resp.JobIDs))
+	if resp.ArchivePath != "" {
+		cmd.Printf("Jobs were archived to %s on the orchestrator\n", resp.ArchivePath)
+	}
+	return nil
+}
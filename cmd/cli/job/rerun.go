@@ -0,0 +1,110 @@
+package job
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
+	"github.com/bacalhau-project/bacalhau/cmd/util/printer"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	rerunLong = templates.LongDesc(`
+		Rerun a job that has finished, failed or was stopped.
+
+		A new job is submitted with the same spec as the original job, and is
+		linked to it through its metadata. With --failed-only, the new job only
+		runs the partitions of the original batch job that did not complete.
+`)
+
+	rerunExample = templates.Examples(`
+		# Rerun a job
+		bacalhau job rerun j-51225160-807e-48b8-88c9-28311c7899e1
+
+		# Rerun only the partitions of a batch job that did not complete
+		bacalhau job rerun j-51225160 --failed-only
+`)
+)
+
+type RerunOptions struct {
+	RunTimeSettings *cliflags.RunTimeSettings // Run time settings for execution (e.g. follow, wait after submission)
+	FailedOnly      bool                      // Rerun only the partitions that did not complete
+	ShowWarnings    bool                      // Show warnings when submitting a job
+}
+
+func NewRerunOptions() *RerunOptions {
+	return &RerunOptions{
+		RunTimeSettings: cliflags.DefaultRunTimeSettings(),
+	}
+}
+
+func NewRerunCmd() *cobra.Command {
+	o := NewRerunOptions()
+
+	rerunCmd := &cobra.Command{
+		Use:           "rerun [id]",
+		Short:         "Rerun a previously submitted job",
+		Long:          rerunLong,
+		Example:       rerunExample,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, args, api)
+		},
+	}
+
+	rerunCmd.Flags().AddFlagSet(cliflags.NewRunTimeSettingsFlags(o.RunTimeSettings))
+	rerunCmd.Flags().BoolVar(&o.FailedOnly, "failed-only", false,
+		"Only rerun the partitions of a batch job that did not complete successfully")
+	rerunCmd.Flags().BoolVar(&o.ShowWarnings, "show-warnings", false, "Show warnings when submitting a job")
+	return rerunCmd
+}
+
+func (o *RerunOptions) run(cmd *cobra.Command, args []string, api client.API) error {
+	ctx := cmd.Context()
+	jobID := args[0]
+
+	resp, err := api.Jobs().Rerun(ctx, &apimodels.RerunJobRequest{
+		JobID:      jobID,
+		FailedOnly: o.FailedOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rerun job %s: %w", jobID, err)
+	}
+
+	if o.ShowWarnings && len(resp.Warnings) > 0 {
+		cmd.Println("Warnings:")
+		for _, warning := range resp.Warnings {
+			cmd.Printf("\t* %s\n", warning)
+		}
+	}
+	if len(resp.Partitions) > 0 {
+		cmd.Printf("Rerunning partitions %v of job %s\n\n", resp.Partitions, jobID)
+	}
+
+	jobResp, err := api.Jobs().Get(ctx, &apimodels.GetJobRequest{JobID: resp.JobID})
+	if err != nil {
+		return fmt.Errorf("failed to get job %s: %w", resp.JobID, err)
+	}
+	jobProgressPrinter := printer.NewJobProgressPrinter(api, o.RunTimeSettings)
+	if err = jobProgressPrinter.PrintJobProgress(ctx, jobResp.Job, cmd); err != nil {
+		return fmt.Errorf("failed to print job execution: %w", err)
+	}
+	return nil
+}
@@ -19,8 +19,11 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(NewHistoryCmd())
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewLogCmd())
+	cmd.AddCommand(NewPruneCmd())
+	cmd.AddCommand(NewRerunCmd())
 	cmd.AddCommand(NewRunCmd())
 	cmd.AddCommand(NewStopCmd())
+	cmd.AddCommand(NewTraceCmd())
 	cmd.AddCommand(NewGetCmd())
 	cmd.AddCommand(NewValidateCmd())
 	return cmd
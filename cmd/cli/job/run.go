@@ -8,6 +8,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/cmd/util/output"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/marshaller"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/template"
+	jobtemplates "github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
 
@@ -35,6 +36,9 @@ var (
 		bacalhau job describe 6e51df50 | bacalhau job run
 
 		# Download the 
+
+		# Run the latest version of a job template stored on the orchestrator
+		bacalhau job run --from-template resize-images -V size=512
 		`)
 )
 
@@ -44,6 +48,8 @@ type RunOptions struct {
 	NoTemplate             bool
 	TemplateVars           map[string]string
 	TemplateEnvVarsPattern string
+	FromTemplate           string // Name of a job template stored on the orchestrator to run
+	TemplateVersion        uint64 // Version of the stored job template. Latest if zero
 }
 
 func NewRunOptions() *RunOptions {
@@ -87,6 +93,10 @@ func NewRunCmd() *cobra.Command {
 	runCmd.Flags().StringVarP(&o.TemplateEnvVarsPattern, "template-envs", "E", "",
 		"Specify a regular expression pattern for selecting environment variables to be included as template variables in the job spec."+
 			"\ne.g. --template-envs \".*\" will include all environment variables.")
+	runCmd.Flags().StringVar(&o.FromTemplate, "from-template", "",
+		"Run a job template stored on the orchestrator instead of a job spec. Use --template-vars to set its parameters")
+	runCmd.Flags().Uint64Var(&o.TemplateVersion, "template-version", 0,
+		"Version of the job template to run with --from-template. Defaults to the latest version")
 
 	return runCmd
 }
@@ -95,6 +105,13 @@ func NewRunCmd() *cobra.Command {
 func (o *RunOptions) run(cmd *cobra.Command, args []string, api client.API) error {
 	ctx := cmd.Context()
 
+	if o.FromTemplate != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use --from-template with a job spec file")
+		}
+		return o.runTemplate(cmd, api)
+	}
+
 	// read the job spec from stdin or file
 	jobBytes, err := util.ReadJobFromUser(cmd, args)
 	if err != nil {
@@ -160,6 +177,53 @@ func (o *RunOptions) run(cmd *cobra.Command, args []string, api client.API) erro
 	return nil
 }
 
+// runTemplate runs a job template stored on the orchestrator, which renders the job server side.
+func (o *RunOptions) runTemplate(cmd *cobra.Command, api client.API) error {
+	ctx := cmd.Context()
+
+	if o.RunTimeSettings.DryRun {
+		getResp, err := api.Templates().Get(ctx, &apimodels.GetTemplateRequest{
+			Name:    o.FromTemplate,
+			Version: o.TemplateVersion,
+		})
+		if err != nil {
+			return fmt.Errorf("failed request: %w", err)
+		}
+		j, err := jobtemplates.Render(getResp.Template, o.TemplateVars)
+		if err != nil {
+			return fmt.Errorf("%s: %w", userstrings.JobSpecBad, err)
+		}
+		outputOps := output.NonTabularOutputOptions{Format: output.YAMLFormat}
+		if err = output.OutputOneNonTabular(cmd, outputOps, j); err != nil {
+			return fmt.Errorf("failed to write job: %w", err)
+		}
+		return nil
+	}
+
+	resp, err := api.Templates().Run(ctx, &apimodels.RunTemplateRequest{
+		Name:      o.FromTemplate,
+		Version:   o.TemplateVersion,
+		Variables: o.TemplateVars,
+	})
+	if err != nil {
+		return fmt.Errorf("failed request: %w", err)
+	}
+
+	if o.ShowWarnings && len(resp.Warnings) > 0 {
+		o.printWarnings(cmd, resp.Warnings)
+	}
+
+	jobResp, err := api.Jobs().Get(ctx, &apimodels.GetJobRequest{JobID: resp.JobID})
+	if err != nil {
+		return fmt.Errorf("failed to get job %s: %w", resp.JobID, err)
+	}
+	jobProgressPrinter := printer.NewJobProgressPrinter(api, o.RunTimeSettings)
+	if err := jobProgressPrinter.PrintJobProgress(ctx, jobResp.Job, cmd); err != nil {
+		return fmt.Errorf("failed to print job execution: %w", err)
+	}
+	return nil
+}
+
 func (o *RunOptions) printWarnings(cmd *cobra.Command, warnings []string) {
 	cmd.Println("Warnings:")
 	for _, warning := range warnings {
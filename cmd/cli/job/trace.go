@@ -0,0 +1,193 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/cols"
+	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
+	"github.com/bacalhau-project/bacalhau/cmd/util/output"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	traceShort = `Show the latency timeline of a job by id.`
+
+	traceLong = templates.LongDesc(`
+		Show the latency timeline of a job by id, built from its recorded execution events.
+
+		Each event is shown with the time elapsed since the previous event of the same execution,
+		and the time it took for the event to reach the orchestrator after it occurred on the compute node.
+		The full distributed trace of the messages exchanged between nodes is exported to the configured
+		OpenTelemetry collector, where spans can be looked up by the execution ID.
+`)
+
+	traceExample = templates.Examples(`
+		# Latency timeline of all executions of a job.
+		bacalhau job trace j-e3f8c209-d683-4a41-b840-f09b88d087b9
+
+		# Latency timeline of a single execution.
+		bacalhau job trace j-e3f8c209 --execution-id e-0d8f2b1a
+`)
+)
+
+// TraceOptions is a struct to support trace command
+type TraceOptions struct {
+	output.OutputOptions
+	ExecutionID string
+}
+
+// NewTraceOptions returns initialized Options
+func NewTraceOptions() *TraceOptions {
+	return &TraceOptions{
+		OutputOptions: output.OutputOptions{Format: output.TableFormat},
+	}
+}
+
+func NewTraceCmd() *cobra.Command {
+	o := NewTraceOptions()
+	traceCmd := &cobra.Command{
+		Use:           "trace [id]",
+		Short:         traceShort,
+		Long:          traceLong,
+		Example:       traceExample,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, args, api)
+		},
+	}
+
+	traceCmd.Flags().StringVar(&o.ExecutionID, "execution-id", o.ExecutionID,
+		"The execution id to trace.")
+	traceCmd.Flags().AddFlagSet(cliflags.OutputFormatFlags(&o.OutputOptions))
+	return traceCmd
+}
+
+// traceHop is a single event of the job timeline with its latencies
+type traceHop struct {
+	*models.JobHistory
+	// SinceStart is the time elapsed since the first event of the job
+	SinceStart time.Duration `json:"SinceStart"`
+	// SincePrevious is the time elapsed since the previous event of the same execution,
+	// or of the job for job level events
+	SincePrevious time.Duration `json:"SincePrevious"`
+	// Delivery is the time between the event occurring and it being recorded by the orchestrator
+	Delivery time.Duration `json:"Delivery"`
+}
+
+// buildTrace orders the history events by the time they occurred,
+// and computes the latency of each hop of the job and its executions.
+func buildTrace(history []*models.JobHistory) []*traceHop {
+	sorted := make([]*models.JobHistory, len(history))
+	copy(sorted, history)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Occurred().Before(sorted[j].Occurred())
+	})
+
+	hops := make([]*traceHop, 0, len(sorted))
+	previous := make(map[string]time.Time)
+	var start time.Time
+	for _, h := range sorted {
+		occurred := h.Occurred()
+		if start.IsZero() {
+			start = occurred
+		}
+		hop := &traceHop{JobHistory: h, SinceStart: occurred.Sub(start)}
+		if last, ok := previous[h.ExecutionID]; ok {
+			hop.SincePrevious = occurred.Sub(last)
+		}
+		if !h.Event.Timestamp.IsZero() && h.Time.After(h.Event.Timestamp) {
+			hop.Delivery = h.Time.Sub(h.Event.Timestamp)
+		}
+		previous[h.ExecutionID] = occurred
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// formatLatency formats a latency with millisecond precision,
+// leaving zero latencies blank to keep the timeline readable
+func formatLatency(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return "+" + d.Round(time.Millisecond).String()
+}
+
+func traceColumn(column output.TableColumn[*models.JobHistory]) output.TableColumn[*traceHop] {
+	return output.TableColumn[*traceHop]{
+		ColumnConfig: column.ColumnConfig,
+		Value:        func(h *traceHop) string { return column.Value(h.JobHistory) },
+	}
+}
+
+var traceColumns = []output.TableColumn[*traceHop]{
+	traceColumn(cols.HistoryTimeOnly),
+	traceColumn(cols.HistoryExecID),
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Elapsed", WidthMax: 12, WidthMaxEnforcer: text.WrapText},
+		Value:        func(h *traceHop) string { return formatLatency(h.SinceStart) },
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Hop", WidthMax: 12, WidthMaxEnforcer: text.WrapText},
+		Value:        func(h *traceHop) string { return formatLatency(h.SincePrevious) },
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "Delivery", WidthMax: 12, WidthMaxEnforcer: text.WrapText},
+		Value:        func(h *traceHop) string { return formatLatency(h.Delivery) },
+	},
+	traceColumn(cols.HistoryTopic),
+	traceColumn(cols.HistoryEvent),
+}
+
+func (o *TraceOptions) run(cmd *cobra.Command, args []string, api client.API) error {
+	ctx := cmd.Context()
+	jobID := args[0]
+
+	var history []*models.JobHistory
+	var nextToken string
+	for {
+		response, err := api.Jobs().History(ctx, &apimodels.ListJobHistoryRequest{
+			JobID:       jobID,
+			ExecutionID: o.ExecutionID,
+			BaseListRequest: apimodels.BaseListRequest{
+				NextToken: nextToken,
+			},
+		})
+		if err != nil {
+			return errors.New(err.Error())
+		}
+		history = append(history, response.Items...)
+		if response.NextToken == "" || response.NextToken == nextToken {
+			break
+		}
+		nextToken = response.NextToken
+	}
+
+	if err := output.Output(cmd, traceColumns, o.OutputOptions, buildTrace(history)); err != nil {
+		return fmt.Errorf("failed to output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+//go:build unit || !integration
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type TraceSuite struct {
+	suite.Suite
+}
+
+func TestTraceSuite(t *testing.T) {
+	suite.Run(t, new(TraceSuite))
+}
+
+func (s *TraceSuite) TestBuildTrace() {
+	start := time.Now().UTC()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	history := []*models.JobHistory{
+		{ExecutionID: "e-1", Time: at(300 * time.Millisecond),
+			Event: models.Event{Timestamp: at(250 * time.Millisecond), Message: "completed"}},
+		{Time: at(0), Event: models.Event{Message: "submitted"}},
+		{ExecutionID: "e-1", Time: at(100 * time.Millisecond), Event: models.Event{Message: "created"}},
+		{ExecutionID: "e-2", Time: at(120 * time.Millisecond), Event: models.Event{Message: "created"}},
+	}
+
+	hops := buildTrace(history)
+	s.Require().Len(hops, 4)
+
+	// events are ordered by the time they occurred
+	s.Equal("submitted", hops[0].Event.Message)
+	s.Equal("e-1", hops[1].ExecutionID)
+	s.Equal("e-2", hops[2].ExecutionID)
+	s.Equal("completed", hops[3].Event.Message)
+
+	s.Equal(time.Duration(0), hops[0].SinceStart)
+	s.Equal(250*time.Millisecond, hops[3].SinceStart)
+
+	// hops are measured between events of the same execution
+	s.Equal(time.Duration(0), hops[1].SincePrevious)
+	s.Equal(time.Duration(0), hops[2].SincePrevious)
+	s.Equal(150*time.Millisecond, hops[3].SincePrevious)
+
+	// delivery is the time for an event to be recorded after it occurred
+	s.Equal(time.Duration(0), hops[1].Delivery)
+	s.Equal(50*time.Millisecond, hops[3].Delivery)
+
+	s.Equal("", formatLatency(0))
+	s.Equal("+150ms", formatLatency(hops[3].SincePrevious))
+}
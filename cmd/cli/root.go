@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/bacalhau-project/bacalhau/cmd/cli/admin"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/agent"
 	configcli "github.com/bacalhau-project/bacalhau/cmd/cli/config"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/deprecated"
@@ -19,6 +20,8 @@ import (
 	"github.com/bacalhau-project/bacalhau/cmd/cli/license"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/node"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/serve"
+	"github.com/bacalhau-project/bacalhau/cmd/cli/template"
+	"github.com/bacalhau-project/bacalhau/cmd/cli/usage"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/version"
 	"github.com/bacalhau-project/bacalhau/cmd/cli/wasm"
 	"github.com/bacalhau-project/bacalhau/cmd/util"
@@ -97,6 +100,7 @@ func NewRootCmd() *cobra.Command {
 
 	// register child commands.
 	RootCmd.AddCommand(
+		admin.NewCmd(),
 		agent.NewCmd(),
 		configcli.NewCmd(),
 		devstack.NewCmd(),
@@ -104,6 +108,8 @@ func NewRootCmd() *cobra.Command {
 		job.NewCmd(),
 		node.NewCmd(),
 		serve.NewCmd(),
+		template.NewCmd(),
+		usage.NewCmd(),
 		version.NewCmd(),
 		license.NewCmd(),
 		wasm.NewCmd(),
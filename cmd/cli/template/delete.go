@@ -0,0 +1,44 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+func NewDeleteCmd() *cobra.Command {
+	deleteCmd := &cobra.Command{
+		Use:           "delete [name]",
+		Short:         "Delete all versions of a job template.",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return runDelete(cmd, args, api)
+		},
+	}
+	return deleteCmd
+}
+
+func runDelete(cmd *cobra.Command, args []string, api client.API) error {
+	name := args[0]
+	if _, err := api.Templates().Delete(cmd.Context(), &apimodels.DeleteTemplateRequest{Name: name}); err != nil {
+		return fmt.Errorf("could not delete template %s: %w", name, err)
+	}
+	cmd.Printf("Deleted template %s\n", name)
+	return nil
+}
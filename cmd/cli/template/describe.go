@@ -0,0 +1,72 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
+	"github.com/bacalhau-project/bacalhau/cmd/util/output"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+// DescribeOptions is a struct to support template describe command
+type DescribeOptions struct {
+	OutputOpts output.NonTabularOutputOptions
+	Version    uint64
+}
+
+// NewDescribeOptions returns initialized Options
+func NewDescribeOptions() *DescribeOptions {
+	return &DescribeOptions{
+		OutputOpts: output.NonTabularOutputOptions{Format: output.YAMLFormat},
+	}
+}
+
+func NewDescribeCmd() *cobra.Command {
+	o := NewDescribeOptions()
+
+	describeCmd := &cobra.Command{
+		Use:           "describe [name]",
+		Short:         "Get a job template by name.",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, args, api)
+		},
+	}
+
+	describeCmd.Flags().Uint64Var(&o.Version, "version", o.Version,
+		"Version of the template to describe. Defaults to the latest version")
+	describeCmd.Flags().AddFlagSet(cliflags.OutputNonTabularFormatFlags(&o.OutputOpts))
+	return describeCmd
+}
+
+func (o *DescribeOptions) run(cmd *cobra.Command, args []string, api client.API) error {
+	name := args[0]
+	response, err := api.Templates().Get(cmd.Context(), &apimodels.GetTemplateRequest{
+		Name:    name,
+		Version: o.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("could not get template %s: %w", name, err)
+	}
+
+	if err = output.OutputOneNonTabular(cmd, o.OutputOpts, response.Template); err != nil {
+		return fmt.Errorf("failed to write template %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
+	"github.com/bacalhau-project/bacalhau/cmd/util/output"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var listColumns = []output.TableColumn[*models.JobTemplate]{
+	{
+		ColumnConfig: table.ColumnConfig{Name: "name"},
+		Value:        func(t *models.JobTemplate) string { return t.Name },
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "version"},
+		Value:        func(t *models.JobTemplate) string { return fmt.Sprint(t.Version) },
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "parameters"},
+		Value: func(t *models.JobTemplate) string {
+			names := make([]string, len(t.Parameters))
+			for i, p := range t.Parameters {
+				names[i] = p.Name
+			}
+			return strings.Join(names, ", ")
+		},
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "created"},
+		Value: func(t *models.JobTemplate) string {
+			return time.Unix(0, t.CreateTime).Format(time.DateTime)
+		},
+	},
+	{
+		ColumnConfig: table.ColumnConfig{Name: "description"},
+		Value:        func(t *models.JobTemplate) string { return t.Description },
+	},
+}
+
+// ListOptions is a struct to support template list command
+type ListOptions struct {
+	output.OutputOptions
+	Versions string
+}
+
+// NewListOptions returns initialized Options
+func NewListOptions() *ListOptions {
+	return &ListOptions{
+		OutputOptions: output.OutputOptions{Format: output.TableFormat},
+	}
+}
+
+func NewListCmd() *cobra.Command {
+	o := NewListOptions()
+
+	listCmd := &cobra.Command{
+		Use:           "list",
+		Short:         "List the latest version of job templates.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, api)
+		},
+	}
+
+	listCmd.Flags().StringVar(&o.Versions, "versions", o.Versions,
+		"List all versions of the named template instead of the latest version of every template")
+	listCmd.Flags().AddFlagSet(cliflags.OutputFormatFlags(&o.OutputOptions))
+	return listCmd
+}
+
+func (o *ListOptions) run(cmd *cobra.Command, api client.API) error {
+	ctx := cmd.Context()
+
+	var response *apimodels.ListTemplatesResponse
+	var err error
+	if o.Versions != "" {
+		response, err = api.Templates().Versions(ctx, &apimodels.ListTemplateVersionsRequest{Name: o.Versions})
+	} else {
+		response, err = api.Templates().List(ctx, &apimodels.ListTemplatesRequest{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed request: %w", err)
+	}
+
+	if err = output.Output(cmd, listColumns, o.OutputOptions, response.Items); err != nil {
+		return fmt.Errorf("failed to output: %w", err)
+	}
+	return nil
+}
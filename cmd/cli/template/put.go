@@ -0,0 +1,95 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/marshaller"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	putLong = templates.LongDesc(`
+		Register a job template from a file or from stdin.
+
+		The template is stored on the orchestrator as a new version of the
+		template with the same name. JSON and YAML formats are accepted.
+	`)
+
+	putExample = templates.Examples(`
+		# Register the template defined in template.yaml
+		bacalhau template put ./template.yaml
+
+		# A template file looks like:
+		#   Name: resize-images
+		#   Parameters:
+		#     - Name: size
+		#       Type: int
+		#       Default: "256"
+		#   Spec: |
+		#     Type: batch
+		#     Tasks:
+		#       - Name: main
+		#         Engine:
+		#           Type: docker
+		#           Params:
+		#             Image: resizer
+		#             Parameters: ["--size", "{{.size}}"]
+	`)
+)
+
+func NewPutCmd() *cobra.Command {
+	putCmd := &cobra.Command{
+		Use:           "put [file]",
+		Short:         "Register a new version of a job template.",
+		Long:          putLong,
+		Example:       putExample,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return runPut(cmd, args, api)
+		},
+	}
+	return putCmd
+}
+
+func runPut(cmd *cobra.Command, args []string, api client.API) error {
+	templateBytes, err := util.ReadJobFromUser(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var jobTemplate models.JobTemplate
+	if err = marshaller.YAMLUnmarshalWithMax(templateBytes, &jobTemplate); err != nil {
+		return fmt.Errorf("failed to parse job template: %w", err)
+	}
+	jobTemplate.Normalize()
+	if err = jobTemplate.Validate(); err != nil {
+		return fmt.Errorf("invalid job template: %w", err)
+	}
+
+	response, err := api.Templates().Put(cmd.Context(), &apimodels.PutTemplateRequest{
+		Template: &jobTemplate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed request: %w", err)
+	}
+	cmd.Printf("Registered template %s version %d\n", response.Template.Name, response.Template.Version)
+	return nil
+}
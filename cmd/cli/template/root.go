@@ -0,0 +1,22 @@
+package template
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util/hook"
+)
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                "template",
+		Short:              "Commands to manage job templates stored on the orchestrator.",
+		PersistentPreRunE:  hook.AfterParentPreRunHook(hook.RemoteCmdPreRunHooks),
+		PersistentPostRunE: hook.AfterParentPostRunHook(hook.RemoteCmdPostRunHooks),
+	}
+
+	cmd.AddCommand(NewPutCmd())
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewDescribeCmd())
+	cmd.AddCommand(NewDeleteCmd())
+	return cmd
+}
@@ -0,0 +1,163 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+
+	"github.com/bacalhau-project/bacalhau/cmd/util"
+	"github.com/bacalhau-project/bacalhau/cmd/util/flags/cliflags"
+	"github.com/bacalhau-project/bacalhau/cmd/util/output"
+	"github.com/bacalhau-project/bacalhau/cmd/util/parse"
+	"github.com/bacalhau-project/bacalhau/cmd/util/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/client/v2"
+)
+
+var (
+	reportShortDesc = templates.LongDesc(`
+		Report the resources used by finished executions.
+`)
+
+	reportLongDesc = templates.LongDesc(`
+		Report the resource-seconds and number of executions that finished in a time range,
+		grouped by namespace, job type, day or the value of a job label.
+		Resource-seconds are the resources allocated to executions multiplied by how long they ran.
+
+		Reports require an access token with read access to the reported namespace, or to
+		all namespaces if the report is not limited to one.
+`)
+
+	reportExample = templates.Examples(`
+		# Report the usage of each namespace per day
+		bacalhau usage report
+
+		# Report the usage of each team over the last week as CSV
+		bacalhau usage report --since 168h --group-by label:team --output csv
+
+		# Report the usage of each job type in a namespace
+		bacalhau usage report --namespace ml --group-by type
+`)
+)
+
+var usageColumns = []output.TableColumn[*models.UsageReportRow]{
+	usageColumn("executions", func(r *models.UsageReportRow) string { return fmt.Sprint(r.Executions) }),
+	usageColumn("cpu seconds", func(r *models.UsageReportRow) string { return formatSeconds(r.CPUSeconds) }),
+	usageColumn("memory GiB seconds", func(r *models.UsageReportRow) string { return formatSeconds(r.MemoryGiBSeconds) }),
+	usageColumn("gpu seconds", func(r *models.UsageReportRow) string { return formatSeconds(r.GPUSeconds) }),
+	usageColumn("used cpu seconds", func(r *models.UsageReportRow) string { return formatSeconds(r.UsedCPUSeconds) }),
+}
+
+// ReportOptions is a struct to support usage report command
+type ReportOptions struct {
+	output.OutputOptions
+	Since     string
+	Until     string
+	Namespace string
+	GroupBy   []string
+}
+
+// NewReportOptions returns initialized Options
+func NewReportOptions() *ReportOptions {
+	return &ReportOptions{
+		OutputOptions: output.OutputOptions{Format: output.TableFormat},
+		GroupBy:       models.DefaultUsageGroupBy,
+	}
+}
+
+func NewReportCmd() *cobra.Command {
+	o := NewReportOptions()
+
+	reportCmd := &cobra.Command{
+		Use:           "report",
+		Short:         reportShortDesc,
+		Long:          reportLongDesc,
+		Example:       reportExample,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// initialize a new or open an existing repo merging any config file(s) it contains into cfg.
+			cfg, err := util.SetupRepoConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to setup repo: %w", err)
+			}
+			// create an api client
+			api, err := util.GetAPIClientV2(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create api client: %w", err)
+			}
+			return o.run(cmd, api)
+		},
+	}
+
+	reportCmd.Flags().StringVar(&o.Since, "since", o.Since,
+		"Only report executions that finished since a relative duration (e.g. 24h) or an RFC3339 timestamp.")
+	reportCmd.Flags().StringVar(&o.Until, "until", o.Until,
+		"Only report executions that finished until a relative duration (e.g. 1h) or an RFC3339 timestamp.")
+	reportCmd.Flags().StringVar(&o.Namespace, "namespace", o.Namespace,
+		"Only report executions of the given namespace. All namespaces are reported by default.")
+	reportCmd.Flags().StringSliceVar(&o.GroupBy, "group-by", o.GroupBy,
+		"Keys to group executions by: namespace, type, day or label:<key>.")
+	reportCmd.Flags().AddFlagSet(cliflags.OutputFormatFlags(&o.OutputOptions))
+	return reportCmd
+}
+
+func (o *ReportOptions) run(cmd *cobra.Command, api client.API) error {
+	ctx := cmd.Context()
+
+	if err := models.ValidateUsageGroupBy(o.GroupBy); err != nil {
+		return err
+	}
+	since, err := parse.TimeFilter(o.Since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parse.TimeFilter(o.Until)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	request := &apimodels.GetUsageReportRequest{
+		Since:   since,
+		Until:   until,
+		GroupBy: strings.Join(o.GroupBy, ","),
+	}
+	request.Namespace = o.Namespace
+	response, err := api.Usage().Report(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed request: %w", err)
+	}
+
+	if err = output.Output(cmd, reportColumns(response.GroupBy), o.OutputOptions, response.Rows); err != nil {
+		return fmt.Errorf("failed to output: %w", err)
+	}
+	return nil
+}
+
+// reportColumns returns a column for each group-by key, followed by the usage columns
+func reportColumns(groupBy []string) []output.TableColumn[*models.UsageReportRow] {
+	columns := make([]output.TableColumn[*models.UsageReportRow], 0, len(groupBy)+len(usageColumns))
+	for _, key := range groupBy {
+		columns = append(columns, output.TableColumn[*models.UsageReportRow]{
+			ColumnConfig: table.ColumnConfig{Name: key},
+			Value:        func(r *models.UsageReportRow) string { return r.Group[key] },
+		})
+	}
+	return append(columns, usageColumns...)
+}
+
+func usageColumn(name string, value func(*models.UsageReportRow) string) output.TableColumn[*models.UsageReportRow] {
+	return output.TableColumn[*models.UsageReportRow]{
+		ColumnConfig: table.ColumnConfig{Name: name, Align: text.AlignRight},
+		Value:        value,
+	}
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.1f", seconds)
+}
@@ -0,0 +1,41 @@
+//go:build unit || !integration
+
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type ReportColumnsSuite struct {
+	suite.Suite
+}
+
+func TestReportColumnsSuite(t *testing.T) {
+	suite.Run(t, new(ReportColumnsSuite))
+}
+
+func (s *ReportColumnsSuite) TestColumns() {
+	row := &models.UsageReportRow{
+		Group:            map[string]string{"namespace": "default", "label:team": "ml"},
+		Executions:       3,
+		CPUSeconds:       120,
+		MemoryGiBSeconds: 30.25,
+		GPUSeconds:       0,
+		UsedCPUSeconds:   59.96,
+	}
+	columns := reportColumns([]string{"namespace", "label:team"})
+
+	var names, values []string
+	for _, column := range columns {
+		names = append(names, column.Name)
+		values = append(values, column.Value(row))
+	}
+	s.Equal([]string{
+		"namespace", "label:team", "executions", "cpu seconds", "memory GiB seconds", "gpu seconds", "used cpu seconds",
+	}, names)
+	s.Equal([]string{"default", "ml", "3", "120.0", "30.2", "0.0", "60.0"}, values)
+}
@@ -53,6 +53,7 @@ type WasmRunOptions struct {
 	ImportModules        []*models.InputSource
 	Entrypoint           string
 	EnvironmentVariables []string
+	Fuel                 uint64
 
 	JobSettings     *cliflags.JobSettings
 	TaskSettings    *cliflags.TaskSettings
@@ -144,6 +145,9 @@ func newRunCmd() *cobra.Command {
 	)
 	wasmFlags.StringSliceVarP(&opts.EnvironmentVariables, "env", "e", opts.EnvironmentVariables,
 		"The environment variables to supply to the job (e.g. --env FOO=bar --env BAR=baz)")
+	wasmFlags.Uint64Var(&opts.Fuel, "fuel", opts.Fuel,
+		`The maximum number of function calls the job can make before it is stopped. Defaults to no limit, in which
+		case the job only stops at its timeout.`)
 
 	wasmRunCmd.Flags().AddFlagSet(wasmFlags)
 	return wasmRunCmd
@@ -198,6 +202,7 @@ func build(ctx context.Context, args []string, opts *WasmRunOptions) (*models.Jo
 		WithEntrypoint(opts.Entrypoint).
 		WithImportModules(opts.ImportModules).
 		WithEnvironmentVariables(envVar).
+		WithFuel(opts.Fuel).
 		Build()
 	if err != nil {
 		return nil, err
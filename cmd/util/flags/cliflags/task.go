@@ -31,7 +31,11 @@ Examples:
 	ResourceCPUUsageMsg    = `Job CPU cores (e.g. 500m, 2, 8).`
 	ResourceMemoryUsageMsg = `Job Memory requirement (e.g. 500Mb, 2Gb, 8Gb).`
 	ResourceDiskUsageMsg   = `Job Disk requirement (e.g. 500Gb, 2Tb, 8Tb).`
-	ResourceGPUUsageMsg    = `Job GPU requirement (e.g. 1, 2, 8).`
+	ResourceGPUUsageMsg    = `Job GPU requirement (e.g. 1, 2, 8), or a fraction of a GPU shared with other jobs (e.g. 0.25).`
+	ResourceGPUMemoryMsg   = `Job GPU memory requirement on each GPU (e.g. 4Gb, 16Gb).`
+	ResourceGPUVendorMsg   = `Job GPU vendor requirement (e.g. NVIDIA, AMD, Intel).`
+	ResourceGPUModelMsg    = `Job GPU model requirement, matching the model name of the GPUs (e.g. A100, "Tesla T*").`
+	ResourceExtendedMsg    = `Job extended resource requirement as name=N (e.g. fpga=1, example.com/license=2).`
 
 	NetworkTypeUsageMsg   = `Networking capability required by the job. None, HTTP, or Full`
 	NetworkDomainUsageMsg = `Domain(s) that the job needs to access (for HTTP networking)`
@@ -47,13 +51,18 @@ type TaskSettings struct {
 	Network              NetworkSettings
 	Timeout              int64
 	QueueTimeout         int64
+	DisconnectGrace      int64
 }
 
 type ResourceSettings struct {
-	CPU    string
-	Memory string
-	Disk   string
-	GPU    string
+	CPU       string
+	Memory    string
+	Disk      string
+	GPU       string
+	GPUMemory string
+	GPUVendor string
+	GPUModel  string
+	Extended  map[string]string
 }
 
 type NetworkSettings struct {
@@ -69,17 +78,22 @@ func DefaultTaskSettings() *TaskSettings {
 		EnvironmentVariables: make(map[string]string),
 		Publisher:            opts.NewPublisherSpecConfigOpt(),
 		Resources: ResourceSettings{
-			CPU:    "",
-			Memory: "",
-			Disk:   "",
-			GPU:    "",
+			CPU:       "",
+			Memory:    "",
+			Disk:      "",
+			GPU:       "",
+			GPUMemory: "",
+			GPUVendor: "",
+			GPUModel:  "",
+			Extended:  make(map[string]string),
 		},
 		Network: NetworkSettings{
 			Network: models.NetworkNone,
 			Domains: make([]string, 0),
 		},
-		Timeout:      int64(time.Duration(0)),
-		QueueTimeout: int64(time.Duration(0)),
+		Timeout:         int64(time.Duration(0)),
+		QueueTimeout:    int64(time.Duration(0)),
+		DisconnectGrace: int64(time.Duration(0)),
 	}
 }
 
@@ -94,6 +108,10 @@ func RegisterTaskFlags(cmd *cobra.Command, s *TaskSettings) {
 	fs.StringVar(&s.Resources.Memory, "memory", s.Resources.Memory, ResourceMemoryUsageMsg)
 	fs.StringVar(&s.Resources.Disk, "disk", s.Resources.Disk, ResourceDiskUsageMsg)
 	fs.StringVar(&s.Resources.GPU, "gpu", s.Resources.GPU, ResourceGPUUsageMsg)
+	fs.StringVar(&s.Resources.GPUMemory, "gpu-memory", s.Resources.GPUMemory, ResourceGPUMemoryMsg)
+	fs.StringVar(&s.Resources.GPUVendor, "gpu-vendor", s.Resources.GPUVendor, ResourceGPUVendorMsg)
+	fs.StringVar(&s.Resources.GPUModel, "gpu-model", s.Resources.GPUModel, ResourceGPUModelMsg)
+	fs.StringToStringVar(&s.Resources.Extended, "resource", s.Resources.Extended, ResourceExtendedMsg)
 	fs.Var(flags.NetworkFlag(&s.Network.Network), "network", NetworkTypeUsageMsg)
 	fs.StringArrayVar(&s.Network.Domains, "domain", s.Network.Domains, NetworkDomainUsageMsg)
 	fs.Int64Var(&s.Timeout, "timeout", s.Timeout,
@@ -102,6 +120,10 @@ func RegisterTaskFlags(cmd *cobra.Command, s *TaskSettings) {
 	fs.Int64Var(&s.QueueTimeout, "queue-timeout", s.QueueTimeout,
 		`Job queue timeout in seconds (e.g. 300 for 5 minutes). 
 zero timeout means no queueing is enabled and jobs will fail if they cannot be scheduled immediately`,
+	)
+	fs.Int64Var(&s.DisconnectGrace, "disconnect-grace-period", s.DisconnectGrace,
+		`Time in seconds to wait for a disconnected compute node to reconnect before rescheduling its executions.
+zero uses the orchestrator's default grace period`,
 	)
 	cmd.Flags().AddFlagSet(fs)
 }
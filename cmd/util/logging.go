@@ -24,6 +24,10 @@ type LogOptions struct {
 	ExecutionID string
 	Follow      bool
 	Tail        bool
+	Stream      string
+	Since       int64
+	Until       int64
+	Pattern     string
 }
 
 func Logs(cmd *cobra.Command, api clientv2.API, options LogOptions) error {
@@ -42,6 +46,10 @@ func Logs(cmd *cobra.Command, api clientv2.API, options LogOptions) error {
 		ExecutionID: options.ExecutionID,
 		Follow:      options.Follow,
 		Tail:        options.Tail,
+		Stream:      options.Stream,
+		Since:       options.Since,
+		Until:       options.Until,
+		Pattern:     options.Pattern,
 	})
 	if err != nil {
 		if bacerrors.IsError(err) {
@@ -3,6 +3,7 @@ package parse
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -38,3 +39,19 @@ func StringSliceToMap(slice []string) (map[string]string, error) {
 	}
 	return result, nil
 }
+
+// TimeFilter parses a time filter that is either a duration relative to now,
+// or an RFC3339 timestamp, and returns it in unix seconds.
+func TimeFilter(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a duration or RFC3339 timestamp, got %q", value)
+	}
+	return t.Unix(), nil
+}
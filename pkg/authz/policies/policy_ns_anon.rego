@@ -4,6 +4,12 @@ import rego.v1
 default allow = false
 
 job_endpoint := ["api", "v1", "orchestrator", "jobs"]
+usage_endpoint := ["api", "v1", "orchestrator", "usage"]
+
+# Endpoints exposing the whole orchestrator state, which only admins may use
+admin_endpoints := [
+    ["api", "v1", "orchestrator", "backup"],
+]
 
 # https://developer.mozilla.org/en-US/docs/Glossary/Safe/HTTP
 http_safe_methods := ["GET", "HEAD", "OPTIONS"]
@@ -33,10 +39,28 @@ allow if {
 # Allow reading all other endpoints, including by users who don't have a token
 allow if {
     input.http.path != job_endpoint
+    input.http.path != usage_endpoint
+    not input.http.path in admin_endpoints
     not is_legacy_api
     input.http.method in http_safe_methods
 }
 
+# Allow reading the usage report if the access token has read access to the
+# reported namespace, or to all namespaces if the report is not limited to one
+allow if {
+    input.http.path == usage_endpoint
+    input.http.method in http_safe_methods
+
+    namespace_readable(usage_namespace_perms)
+}
+
+# Allow using admin endpoints if the access token has write access to all namespaces
+allow if {
+    input.http.path in admin_endpoints
+
+    namespace_writable(token_namespaces["*"])
+}
+
 # Allow access to legacy job APIs which will do authz internally
 allow if {
     is_legacy_api
@@ -84,6 +108,22 @@ job_namespace_perms := token_namespaces["*"] if {
     token_namespaces["*"]
 }
 
+# The permissions the access token grants on the namespace of the usage report
+usage_namespace_perms := perms if {
+    perms := bits.or(token_namespaces[usage_namespace], token_namespaces["*"])
+} else := perms if {
+    perms := token_namespaces[usage_namespace]
+} else := perms if {
+    perms := token_namespaces["*"]
+}
+
+# The namespace of the usage report, where all namespaces are reported if not set
+default usage_namespace := "*"
+usage_namespace := ns if {
+    ns := input.http.query["namespace"][0]
+    ns != ""
+}
+
 # The namespace that the submitted job is going into
 default job_namespace := ""
 job_namespace := ns if {
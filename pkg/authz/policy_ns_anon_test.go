@@ -73,6 +73,30 @@ func TestAppliesAnonymousNamespacePolicy(t *testing.T) {
 			"other", "other", "test", NamespaceNoPermission, http.MethodGet, "/api/v1/orchestrator/nodes", sameKey, require.True},
 		{"deny writing other APIs",
 			"other", "other", "test", NamespaceNoPermission, http.MethodDelete, "/api/v1/orchestrator/nodes", sameKey, require.False},
+		{"deny backup without token",
+			"other", "other", "test", NamespaceNoPermission, http.MethodGet, "/api/v1/orchestrator/backup", sameKey, require.False},
+		{"deny backup to namespace user",
+			"test", "test", "test", NamespaceReadable | NamespaceWritable, http.MethodGet, "/api/v1/orchestrator/backup", sameKey, require.False},
+		{"deny backup to read-only user of all namespaces",
+			"test", "test", "*", NamespaceReadable | NamespaceDownloadable, http.MethodGet, "/api/v1/orchestrator/backup", sameKey, require.False},
+		{"allow backup to admin",
+			"test", "test", "*", NamespaceReadable | NamespaceWritable, http.MethodGet, "/api/v1/orchestrator/backup", sameKey, require.True},
+		{"deny backup to admin signed by wrong key",
+			"test", "test", "*", NamespaceReadable | NamespaceWritable, http.MethodGet, "/api/v1/orchestrator/backup", newKey, require.False},
+		{"deny usage report without token",
+			"other", "other", "test", NamespaceNoPermission, http.MethodGet, "/api/v1/orchestrator/usage", sameKey, require.False},
+		{"deny usage report of all namespaces to namespace user",
+			"test", "test", "test", NamespaceReadable, http.MethodGet, "/api/v1/orchestrator/usage", sameKey, require.False},
+		{"allow usage report of all namespaces to reader of all namespaces",
+			"test", "test", "*", NamespaceReadable, http.MethodGet, "/api/v1/orchestrator/usage", sameKey, require.True},
+		{"allow usage report of readable namespace",
+			"test", "test", "test", NamespaceReadable, http.MethodGet, "/api/v1/orchestrator/usage?namespace=test", sameKey, require.True},
+		{"deny usage report of unreadable namespace",
+			"test", "test", "test", NamespaceWritable, http.MethodGet, "/api/v1/orchestrator/usage?namespace=test", sameKey, require.False},
+		{"deny usage report of alternative namespace",
+			"test", "test", "test", NamespaceReadable, http.MethodGet, "/api/v1/orchestrator/usage?namespace=other", sameKey, require.False},
+		{"deny usage report signed by wrong key",
+			"test", "test", "*", NamespaceReadable, http.MethodGet, "/api/v1/orchestrator/usage", newKey, require.False},
 		{"deny signed by wrong key",
 			"test", "test", "test", NamespaceWritable, http.MethodPut, "/api/v1/orchestrator/jobs", newKey, require.False},
 	}
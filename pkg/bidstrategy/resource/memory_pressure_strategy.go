@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bidstrategy"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// MemoryPressureProvider reports how much of the host memory is in use.
+type MemoryPressureProvider interface {
+	// GetMemoryPressure returns the fraction of the host memory in use, between 0 and 1.
+	GetMemoryPressure(ctx context.Context) (float64, error)
+}
+
+type MemoryPressureStrategyParams struct {
+	Provider MemoryPressureProvider
+	// Threshold is the fraction of the host memory in use above which the node stops bidding.
+	Threshold float64
+}
+
+// MemoryPressureStrategy stops bidding on jobs while the memory actually used
+// on the host is above a threshold, regardless of the capacity reserved by
+// executions. It guards nodes that overcommit memory from running out of it.
+type MemoryPressureStrategy struct {
+	provider  MemoryPressureProvider
+	threshold float64
+}
+
+func NewMemoryPressureStrategy(params MemoryPressureStrategyParams) *MemoryPressureStrategy {
+	return &MemoryPressureStrategy{
+		provider:  params.Provider,
+		threshold: params.Threshold,
+	}
+}
+
+func (s *MemoryPressureStrategy) ShouldBidBasedOnUsage(
+	ctx context.Context, request bidstrategy.BidStrategyRequest, usage models.Resources) (bidstrategy.BidStrategyResponse, error) {
+	pressure, err := s.provider.GetMemoryPressure(ctx)
+	if err != nil {
+		// failing to read the memory usage shouldn't stop the node from bidding
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get host memory pressure")
+		return bidstrategy.NewBidResponse(true, "have memory pressure below %.0f%%", s.threshold*100), nil
+	}
+	if pressure > s.threshold {
+		return bidstrategy.NewBidResponse(false, "have memory pressure below %.0f%%, it is at %.0f%%",
+			s.threshold*100, pressure*100), nil
+	}
+	return bidstrategy.NewBidResponse(true, "have memory pressure below %.0f%%", s.threshold*100), nil
+}
+
+// compile-time interface check
+var _ bidstrategy.ResourceBidStrategy = (*MemoryPressureStrategy)(nil)
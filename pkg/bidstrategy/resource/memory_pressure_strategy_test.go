@@ -0,0 +1,48 @@
+//go:build unit || !integration
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bidstrategy"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type fixedMemoryPressure struct {
+	pressure float64
+	err      error
+}
+
+func (f fixedMemoryPressure) GetMemoryPressure(context.Context) (float64, error) {
+	return f.pressure, f.err
+}
+
+func TestMemoryPressureStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  fixedMemoryPressure
+		shouldBid bool
+	}{
+		{name: "below threshold", provider: fixedMemoryPressure{pressure: 0.5}, shouldBid: true},
+		{name: "at threshold", provider: fixedMemoryPressure{pressure: 0.9}, shouldBid: true},
+		{name: "above threshold", provider: fixedMemoryPressure{pressure: 0.97}, shouldBid: false},
+		{name: "unknown pressure", provider: fixedMemoryPressure{err: errors.New("no meminfo")}, shouldBid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewMemoryPressureStrategy(MemoryPressureStrategyParams{
+				Provider:  tt.provider,
+				Threshold: 0.9,
+			})
+			response, err := strategy.ShouldBidBasedOnUsage(
+				context.Background(), bidstrategy.BidStrategyRequest{}, models.Resources{Memory: 1024})
+			require.NoError(t, err)
+			require.Equal(t, tt.shouldBid, response.ShouldBid, response.Reason)
+		})
+	}
+}
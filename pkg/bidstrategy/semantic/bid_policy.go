@@ -0,0 +1,243 @@
+package semantic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bidstrategy"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/policy"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// The rules queried from the bid policy. The policy is typically a package
+// named `bacalhau.bid` defining a boolean rule `accept`, and optionally a
+// string rule `reason` explaining the decision.
+const (
+	BidPolicyAcceptRule = "bacalhau.bid.accept"
+	BidPolicyReasonRule = "bacalhau.bid.reason"
+)
+
+const bidPolicyNotConfiguredReason = "have a bid policy unconfigured"
+
+// DefaultBidPolicyReloadInterval is how often the policy files are checked for changes
+const DefaultBidPolicyReloadInterval = 10 * time.Second
+
+// BidPolicyInput is the input document the bid policy is evaluated against.
+type BidPolicyInput struct {
+	Job   models.Job      `json:"job"`
+	Node  models.NodeInfo `json:"node"`
+	Usage BidPolicyUsage  `json:"usage"`
+}
+
+// BidPolicyUsage is the current usage of the compute node.
+type BidPolicyUsage struct {
+	// Running are the resources used by running executions
+	Running models.Resources `json:"running"`
+	// Queued are the resources requested by executions waiting for capacity
+	Queued models.Resources `json:"queued"`
+	// Available are the resources not used by running executions, which may
+	// exceed Max when the node overcommits CPU or memory
+	Available          models.Resources `json:"available"`
+	Max                models.Resources `json:"max"`
+	RunningExecutions  int              `json:"running_executions"`
+	EnqueuedExecutions int              `json:"enqueued_executions"`
+}
+
+type BidPolicyStrategyParams struct {
+	// Path is a Rego policy file, or a directory of policy files
+	Path             string
+	NodeInfoProvider models.NodeInfoProvider
+	// ReloadInterval is how often Start checks the policy files for changes.
+	// Defaults to DefaultBidPolicyReloadInterval.
+	ReloadInterval time.Duration
+}
+
+// Compile-time check of interface implementation
+var _ bidstrategy.SemanticBidStrategy = (*BidPolicyStrategy)(nil)
+
+// BidPolicyStrategy decides whether to bid on jobs by evaluating a Rego policy
+// provided by the node operator. The policy is loaded and compiled once, and
+// reloaded by Start when its files change, so it can be updated without
+// restarting the node.
+type BidPolicyStrategy struct {
+	path             string
+	nodeInfoProvider models.NodeInfoProvider
+	reloadInterval   time.Duration
+
+	mu          sync.Mutex
+	version     policyVersion
+	acceptQuery policy.Query[BidPolicyInput, bool]
+	reasonQuery policy.Query[BidPolicyInput, string]
+}
+
+// policyVersion identifies the state of the policy files to detect changes
+type policyVersion struct {
+	files   int
+	size    int64
+	modTime time.Time
+}
+
+// NewBidPolicyStrategy creates a strategy evaluating the policy at the given path,
+// returning an error if the policy cannot be loaded.
+func NewBidPolicyStrategy(params BidPolicyStrategyParams) (*BidPolicyStrategy, error) {
+	s := &BidPolicyStrategy{
+		path:             params.Path,
+		nodeInfoProvider: params.NodeInfoProvider,
+		reloadInterval:   params.ReloadInterval,
+	}
+	if s.reloadInterval <= 0 {
+		s.reloadInterval = DefaultBidPolicyReloadInterval
+	}
+	if s.path == "" {
+		return s, nil
+	}
+	path, err := filepath.Abs(s.path)
+	if err != nil {
+		return nil, err
+	}
+	s.path = path
+	if _, err = s.reload(); err != nil {
+		return nil, fmt.Errorf("failed to load bid policy %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// Start periodically reloads the policy when its files change, until the
+// context is done. An invalid policy is ignored, and the last valid policy
+// keeps being evaluated.
+func (s *BidPolicyStrategy) Start(ctx context.Context) {
+	if s.path == "" {
+		return
+	}
+	ticker := time.NewTicker(s.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			reloaded, err := s.reload()
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("Path", s.path).Msg("failed to reload bid policy, using the previous policy")
+			} else if reloaded {
+				log.Ctx(ctx).Info().Str("Path", s.path).Msg("reloaded bid policy")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *BidPolicyStrategy) ShouldBid(
+	ctx context.Context,
+	request bidstrategy.BidStrategyRequest,
+) (bidstrategy.BidStrategyResponse, error) {
+	if s.path == "" {
+		return bidstrategy.NewBidResponse(true, bidPolicyNotConfiguredReason), nil
+	}
+
+	acceptQuery, reasonQuery := s.queries()
+
+	input := BidPolicyInput{Job: request.Job}
+	if s.nodeInfoProvider != nil {
+		input.Node = s.nodeInfoProvider.GetNodeInfo(ctx)
+		input.Usage = usageFromNodeInfo(input.Node)
+	}
+
+	accept, err := acceptQuery(ctx, input)
+	if errors.Is(err, policy.ErrNoResult) {
+		return bidstrategy.BidStrategyResponse{
+			ShouldBid: false,
+			Reason:    fmt.Sprintf("bid policy %s did not define %s", s.path, BidPolicyAcceptRule),
+		}, nil
+	} else if err != nil {
+		return bidstrategy.BidStrategyResponse{}, fmt.Errorf("BidPolicyStrategy: failed to evaluate bid policy: %w", err)
+	}
+
+	reason, err := reasonQuery(ctx, input)
+	if err != nil && !errors.Is(err, policy.ErrNoResult) {
+		return bidstrategy.BidStrategyResponse{}, fmt.Errorf("BidPolicyStrategy: failed to evaluate bid policy: %w", err)
+	}
+	if reason == "" {
+		reason = bidstrategy.FormatReason(accept, "accept jobs based on bid policy %s", s.path)
+	}
+	return bidstrategy.BidStrategyResponse{ShouldBid: accept, Reason: reason}, nil
+}
+
+func (s *BidPolicyStrategy) queries() (policy.Query[BidPolicyInput, bool], policy.Query[BidPolicyInput, string]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acceptQuery, s.reasonQuery
+}
+
+// reload loads the policy again if its files changed since it was last loaded,
+// and returns true if it was reloaded.
+func (s *BidPolicyStrategy) reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version, err := readPolicyVersion(s.path)
+	if err != nil {
+		return false, err
+	}
+	if s.acceptQuery != nil && version == s.version {
+		return false, nil
+	}
+	// only try loading an invalid policy again once its files change
+	s.version = version
+
+	loaded, err := policy.FromPath(s.path)
+	if err != nil {
+		return false, err
+	}
+	acceptQuery, err := policy.PrepareQuery[BidPolicyInput, bool](loaded, BidPolicyAcceptRule)
+	if err != nil {
+		return false, err
+	}
+	reasonQuery, err := policy.PrepareQuery[BidPolicyInput, string](loaded, BidPolicyReasonRule)
+	if err != nil {
+		return false, err
+	}
+	// a previous policy is being replaced, rather than loaded for the first time
+	reloaded := s.acceptQuery != nil
+	s.acceptQuery, s.reasonQuery = acceptQuery, reasonQuery
+	return reloaded, nil
+}
+
+func readPolicyVersion(path string) (policyVersion, error) {
+	var version policyVersion
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		version.files++
+		version.size += info.Size()
+		if info.ModTime().After(version.modTime) {
+			version.modTime = info.ModTime()
+		}
+		return nil
+	})
+	return version, err
+}
+
+func usageFromNodeInfo(node models.NodeInfo) BidPolicyUsage {
+	info := node.ComputeNodeInfo
+	return BidPolicyUsage{
+		Running:            *info.MaxCapacity.Max(info.AvailableCapacity).Sub(info.AvailableCapacity),
+		Queued:             info.QueueUsedCapacity,
+		Available:          info.AvailableCapacity,
+		Max:                info.MaxCapacity,
+		RunningExecutions:  info.RunningExecutions,
+		EnqueuedExecutions: info.EnqueuedExecutions,
+	}
+}
@@ -0,0 +1,205 @@
+//go:build unit || !integration
+
+package semantic_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bidstrategy/semantic"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+const namespacePolicy = `
+package bacalhau.bid
+import rego.v1
+
+default accept := false
+
+accept if {
+	input.job.Namespace == "trusted"
+	input.usage.running_executions < 2
+	input.node.Labels.zone == "a"
+}
+
+reason := "only trusted jobs are accepted" if not accept
+`
+
+const acceptAllPolicy = `
+package bacalhau.bid
+import rego.v1
+
+accept := true
+`
+
+type staticNodeInfoProvider struct {
+	info models.NodeInfo
+}
+
+func (p staticNodeInfoProvider) GetNodeInfo(context.Context) models.NodeInfo {
+	return p.info
+}
+
+type BidPolicyStrategySuite struct {
+	suite.Suite
+	path     string
+	node     models.NodeInfo
+	strategy *semantic.BidPolicyStrategy
+}
+
+func TestBidPolicyStrategySuite(t *testing.T) {
+	suite.Run(t, new(BidPolicyStrategySuite))
+}
+
+func (s *BidPolicyStrategySuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "bid.rego")
+	s.node = models.NodeInfo{
+		Labels: map[string]string{"zone": "a"},
+		ComputeNodeInfo: models.ComputeNodeInfo{
+			MaxCapacity:       models.Resources{CPU: 4},
+			AvailableCapacity: models.Resources{CPU: 3},
+			RunningExecutions: 1,
+		},
+	}
+}
+
+func (s *BidPolicyStrategySuite) writePolicy(policy string, modTime time.Time) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(policy), 0600))
+	s.Require().NoError(os.Chtimes(s.path, modTime, modTime))
+}
+
+func (s *BidPolicyStrategySuite) newStrategy() {
+	var err error
+	s.strategy, err = semantic.NewBidPolicyStrategy(semantic.BidPolicyStrategyParams{
+		Path:             s.path,
+		NodeInfoProvider: staticNodeInfoProvider{info: s.node},
+		ReloadInterval:   10 * time.Millisecond,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *BidPolicyStrategySuite) shouldBid(namespace string) (bool, string) {
+	request := getBidStrategyRequest(s.T())
+	request.Job.Namespace = namespace
+	response, err := s.strategy.ShouldBid(context.Background(), request)
+	s.Require().NoError(err)
+	return response.ShouldBid, response.Reason
+}
+
+func (s *BidPolicyStrategySuite) TestNotConfigured() {
+	strategy, err := semantic.NewBidPolicyStrategy(semantic.BidPolicyStrategyParams{})
+	s.Require().NoError(err)
+	response, err := strategy.ShouldBid(context.Background(), getBidStrategyRequest(s.T()))
+	s.Require().NoError(err)
+	s.True(response.ShouldBid)
+}
+
+func (s *BidPolicyStrategySuite) TestDecision() {
+	s.writePolicy(namespacePolicy, time.Now())
+	s.newStrategy()
+
+	accept, reason := s.shouldBid("trusted")
+	s.True(accept)
+	s.Contains(reason, "this node does accept jobs based on bid policy")
+
+	accept, reason = s.shouldBid("default")
+	s.False(accept)
+	s.Equal("only trusted jobs are accepted", reason)
+}
+
+func (s *BidPolicyStrategySuite) TestUsageInput() {
+	s.node.ComputeNodeInfo.RunningExecutions = 2
+	s.writePolicy(namespacePolicy, time.Now())
+	s.newStrategy()
+
+	accept, _ := s.shouldBid("trusted")
+	s.False(accept)
+}
+
+func (s *BidPolicyStrategySuite) TestOvercommittedUsageInput() {
+	// the available capacity of an overcommitted node may exceed its max capacity
+	s.node.ComputeNodeInfo.MaxCapacity = models.Resources{CPU: 4, Memory: 4000}
+	s.node.ComputeNodeInfo.AvailableCapacity = models.Resources{CPU: 3, Memory: 6000}
+	s.writePolicy(`
+package bacalhau.bid
+import rego.v1
+
+default accept := false
+
+accept if {
+	input.usage.running.CPU == 1
+	not input.usage.running.Memory
+}
+`, time.Now())
+	s.newStrategy()
+
+	accept, _ := s.shouldBid("default")
+	s.True(accept)
+}
+
+func (s *BidPolicyStrategySuite) TestReload() {
+	modTime := time.Now().Add(-time.Hour)
+	s.writePolicy(namespacePolicy, modTime)
+	s.newStrategy()
+	accept, _ := s.shouldBid("default")
+	s.False(accept)
+
+	// the policy is only reloaded by Start, not when bidding
+	s.writePolicy(acceptAllPolicy, modTime.Add(time.Minute))
+	accept, _ = s.shouldBid("default")
+	s.False(accept)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.strategy.Start(ctx)
+	s.Eventually(func() bool {
+		accept, _ = s.shouldBid("default")
+		return accept
+	}, time.Second, 10*time.Millisecond)
+
+	// an invalid policy is ignored, and the last valid policy keeps being evaluated
+	s.writePolicy("package bacalhau.bid\naccept := {", modTime.Add(2*time.Minute))
+	s.Never(func() bool {
+		accept, _ = s.shouldBid("default")
+		return !accept
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *BidPolicyStrategySuite) TestReasonError() {
+	s.writePolicy(`
+package bacalhau.bid
+import rego.v1
+
+accept := true
+
+reason = "first" if true
+reason = "second" if true
+`, time.Now())
+	s.newStrategy()
+
+	_, err := s.strategy.ShouldBid(context.Background(), getBidStrategyRequest(s.T()))
+	s.ErrorContains(err, "failed to evaluate bid policy")
+}
+
+func (s *BidPolicyStrategySuite) TestUndefinedDecision() {
+	s.writePolicy("package bacalhau.other\nallow := true\n", time.Now())
+	s.newStrategy()
+
+	accept, reason := s.shouldBid("default")
+	s.False(accept)
+	s.Contains(reason, "did not define bacalhau.bid.accept")
+}
+
+func (s *BidPolicyStrategySuite) TestInvalidPolicy() {
+	s.writePolicy("package bacalhau.bid\naccept := {", time.Now())
+	_, err := semantic.NewBidPolicyStrategy(semantic.BidPolicyStrategyParams{Path: s.path})
+	s.Error(err)
+
+	_, err = semantic.NewBidPolicyStrategy(semantic.BidPolicyStrategyParams{Path: filepath.Join(s.T().TempDir(), "missing")})
+	s.Error(err)
+}
@@ -0,0 +1,49 @@
+package capacity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// NewExtendedResourcesProvider returns a provider of the extended resources
+// printed by a shell command, one name=N line per resource.
+func NewExtendedResourcesProvider(command string) *ToolBasedProvider {
+	return &ToolBasedProvider{
+		Command:  "bash",
+		Provides: "Extended resources",
+		Args:     []string{"-c", command},
+		Parser:   parseExtendedResources,
+	}
+}
+
+// parseExtendedResources reads extended resources from name=N lines,
+// ignoring empty lines and comments starting with #.
+func parseExtendedResources(output io.Reader) (models.Resources, error) {
+	resources := models.Resources{Extended: make(models.ExtendedResources)}
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			return models.Resources{}, fmt.Errorf("invalid extended resource %q: expected name=N", line)
+		}
+		name = strings.TrimSpace(name)
+		if err := models.ValidateExtendedResourceName(name); err != nil {
+			return models.Resources{}, err
+		}
+		units, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return models.Resources{}, fmt.Errorf("invalid %s value: %s", name, value)
+		}
+		resources.Extended[name] = units
+	}
+	return resources, scanner.Err()
+}
@@ -0,0 +1,58 @@
+//go:build unit || !integration
+
+package capacity
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+func TestParseExtendedResources(t *testing.T) {
+	output := strings.Join([]string{
+		"# discovered devices",
+		"fpga=2",
+		"",
+		"example.com/license = 10",
+	}, "\n")
+	resources, err := parseExtendedResources(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Equal(t, models.ExtendedResources{"fpga": 2, "example.com/license": 10}, resources.Extended)
+
+	for _, invalid := range []string{"fpga", "fpga=two", "-fpga=1"} {
+		_, err = parseExtendedResources(strings.NewReader(invalid))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestExtendedResourcesProvider(t *testing.T) {
+	provider := NewExtendedResourcesProvider("echo dongle=1; echo fpga=4")
+	resources, err := provider.GetAvailableCapacity(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.ExtendedResources{"dongle": 1, "fpga": 4}, resources.Extended)
+}
+
+func TestTracksExtendedResources(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewLocalTracker(LocalTrackerParams{MaxCapacity: models.Resources{
+		CPU:      4,
+		Extended: models.ExtendedResources{"fpga": 2},
+	}})
+
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{Extended: models.ExtendedResources{"fpga": 3}}))
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{Extended: models.ExtendedResources{"dongle": 1}}))
+
+	first := tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 1, Extended: models.ExtendedResources{"fpga": 1}})
+	require.NotNil(t, first)
+	second := tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 1, Extended: models.ExtendedResources{"fpga": 1}})
+	require.NotNil(t, second)
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 1, Extended: models.ExtendedResources{"fpga": 1}}))
+	require.Empty(t, tracker.GetAvailableCapacity(ctx).Extended)
+
+	tracker.Remove(ctx, *first)
+	require.Equal(t, models.ExtendedResources{"fpga": 1}, tracker.GetAvailableCapacity(ctx).Extended)
+}
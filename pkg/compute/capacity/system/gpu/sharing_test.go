@@ -0,0 +1,74 @@
+//go:build unit || !integration
+
+package gpu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+func TestSharingNvidiaGPUs(t *testing.T) {
+	ctx := context.Background()
+	output := strings.Join([]string{
+		"0, Tesla T4, 15360",
+		"1, Tesla T1, 12345",
+	}, "\n")
+	resources, err := parseNvidiaCliOutput(strings.NewReader(output))
+	require.NoError(t, err)
+	tracker := capacity.NewLocalTracker(capacity.LocalTrackerParams{MaxCapacity: resources})
+
+	// only the Tesla T4 has enough memory
+	large := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.5, GPUMemory: 14000})
+	require.NotNil(t, large)
+	require.Equal(t, "Tesla T4", large.GPUs[0].Name)
+
+	// the remaining memory of the Tesla T4 is too small, so the Tesla T1 is shared
+	small := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.25, GPUMemory: 2000})
+	require.NotNil(t, small)
+	require.Equal(t, "Tesla T1", small.GPUs[0].Name)
+
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1}))
+	require.Equal(t, uint64(0), tracker.GetAvailableCapacity(ctx).GPU)
+}
+
+func TestSharingIntelGPUs(t *testing.T) {
+	ctx := context.Background()
+	resources, err := getTestProvider(oneListOutput, infoOutput).GetAvailableCapacity(ctx)
+	require.NoError(t, err)
+	tracker := capacity.NewLocalTracker(capacity.LocalTrackerParams{MaxCapacity: resources})
+
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{GPUMemory: 6000}))
+
+	for i := 0; i < 4; i++ {
+		usage := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.25, GPUMemory: 1024})
+		require.NotNil(t, usage)
+		require.Equal(t, "0000:e9:00.0", usage.GPUs[0].PCIAddress)
+	}
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.25}))
+}
+
+func TestMatchingDiscoveredGPUs(t *testing.T) {
+	ctx := context.Background()
+	nvidia, err := parseNvidiaCliOutput(strings.NewReader("1, Tesla T4, 15360"))
+	require.NoError(t, err)
+	intel, err := getTestProvider(oneListOutput, infoOutput).GetAvailableCapacity(ctx)
+	require.NoError(t, err)
+	tracker := capacity.NewLocalTracker(capacity.LocalTrackerParams{MaxCapacity: *nvidia.Add(intel)})
+
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{GPU: 1, GPUModel: "A100"}))
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{GPU: 1, GPUVendor: models.GPUVendorIntel, GPUMemory: 8192}))
+
+	usage := tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUVendor: models.GPUVendorIntel})
+	require.NotNil(t, usage)
+	require.Equal(t, "0000:e9:00.0", usage.GPUs[0].PCIAddress)
+
+	usage = tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUModel: "t4", GPUMemory: 8192})
+	require.NotNil(t, usage)
+	require.Equal(t, "Tesla T4", usage.GPUs[0].Name)
+}
@@ -1,9 +1,15 @@
 package system
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/pbnjay/memory"
@@ -15,9 +21,13 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 )
 
+// meminfoPath reports the memory usage of the host on Linux
+const meminfoPath = "/proc/meminfo"
+
 type PhysicalCapacityProvider struct {
 	gpuCapacityProviders []capacity.Provider
 	path                 string
+	meminfoPath          string
 }
 
 func NewPhysicalCapacityProvider(path string) *PhysicalCapacityProvider {
@@ -27,7 +37,8 @@ func NewPhysicalCapacityProvider(path string) *PhysicalCapacityProvider {
 			gpu.NewAMDGPUProvider(),
 			gpu.NewIntelGPUProvider(),
 		},
-		path: path,
+		path:        path,
+		meminfoPath: meminfoPath,
 	}
 }
 
@@ -81,6 +92,56 @@ func (p *PhysicalCapacityProvider) GetTotalCapacity(ctx context.Context) (models
 	return resources, nil
 }
 
+// GetMemoryPressure returns the fraction of the host memory in use, between 0 and 1.
+// Memory used by caches the kernel can reclaim is not in use, when the host reports it.
+func (p *PhysicalCapacityProvider) GetMemoryPressure(ctx context.Context) (float64, error) {
+	total := memory.TotalMemory()
+	if total == 0 {
+		return 0, fmt.Errorf("GetMemoryPressure: unable to get total memory")
+	}
+	available, err := p.availableMemory()
+	if err != nil {
+		return 0, err
+	}
+	return 1 - float64(min(available, total))/float64(total), nil
+}
+
+// availableMemory returns the memory available to start new processes,
+// falling back to the free memory on hosts without /proc/meminfo.
+func (p *PhysicalCapacityProvider) availableMemory() (uint64, error) {
+	file, err := os.Open(p.meminfoPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return memory.FreeMemory(), nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer func() { _ = file.Close() }()
+
+	available, found, err := parseMemAvailable(file)
+	if err != nil || !found {
+		return memory.FreeMemory(), err
+	}
+	return available, nil
+}
+
+// parseMemAvailable returns the MemAvailable entry of /proc/meminfo in bytes,
+// and whether the entry was found, as it is missing from older kernels.
+func parseMemAvailable(meminfo io.Reader) (uint64, bool, error) {
+	scanner := bufio.NewScanner(meminfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemAvailable:" {
+			continue
+		}
+		kibibytes, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("parseMemAvailable: invalid value %q: %w", fields[1], err)
+		}
+		return kibibytes * 1024, true, nil
+	}
+	return 0, false, scanner.Err()
+}
+
 // ResourceTypes implements capacity.Provider.
 func (p *PhysicalCapacityProvider) ResourceTypes() []string {
 	ownTypes := []string{"CPU", "Memory", "Disk"}
@@ -0,0 +1,51 @@
+//go:build unit || !integration
+
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemAvailable(t *testing.T) {
+	meminfo := strings.Join([]string{
+		"MemTotal:       16318428 kB",
+		"MemFree:         1161632 kB",
+		"MemAvailable:    9405284 kB",
+		"Buffers:          548484 kB",
+	}, "\n")
+	available, found, err := parseMemAvailable(strings.NewReader(meminfo))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(9405284*1024), available)
+
+	// older kernels don't report the available memory
+	_, found, err = parseMemAvailable(strings.NewReader("MemTotal:       16318428 kB"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, _, err = parseMemAvailable(strings.NewReader("MemAvailable: lots kB"))
+	require.Error(t, err)
+}
+
+func TestGetMemoryPressure(t *testing.T) {
+	provider := NewPhysicalCapacityProvider(t.TempDir())
+
+	provider.meminfoPath = filepath.Join(t.TempDir(), "meminfo")
+	require.NoError(t, os.WriteFile(provider.meminfoPath, []byte("MemAvailable: 0 kB\n"), 0600))
+	pressure, err := provider.GetMemoryPressure(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1.0, pressure)
+
+	// falls back to the free memory without meminfo
+	provider.meminfoPath = filepath.Join(t.TempDir(), "missing")
+	pressure, err = provider.GetMemoryPressure(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, pressure, 0.0)
+	require.Less(t, pressure, 1.0)
+}
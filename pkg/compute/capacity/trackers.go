@@ -2,30 +2,75 @@ package capacity
 
 import (
 	"context"
+	"sort"
 	"sync"
 
+	"github.com/samber/lo"
+
 	"github.com/bacalhau-project/bacalhau/pkg/lib/math"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 )
 
 type LocalTrackerParams struct {
 	MaxCapacity models.Resources
+	// CPUOvercommit and MemoryOvercommit are the ratios of CPU and memory that
+	// executions can reserve to the max capacity. Ratios below 1 mean the
+	// resource is not overcommitted.
+	CPUOvercommit    float64
+	MemoryOvercommit float64
 }
 
 // LocalTracker keeps track of the current resource usage of the local node in-memory.
+//
+// GPUs are either allocated whole to a single execution, or shared between
+// executions requesting a fraction of a GPU or only GPU memory. A shared GPU is
+// counted as used as long as any execution shares it, so it is never allocated
+// whole at the same time.
+//
+// Executions can reserve more CPU and memory than the max capacity when they
+// are overcommitted, but each execution must fit the max capacity on its own.
 type LocalTracker struct {
-	maxCapacity  models.Resources
-	usedCapacity models.Resources
-	mu           sync.Mutex
+	maxCapacity        models.Resources
+	reservableCapacity models.Resources
+	usedCapacity       models.Resources
+	sharedGPUs         map[uint64]*sharedGPU
+	mu                 sync.Mutex
+}
+
+// sharedGPU tracks the executions sharing a GPU
+type sharedGPU struct {
+	gpu        models.GPU
+	share      float64
+	memory     uint64
+	executions int
+}
+
+// shareTolerance absorbs the rounding errors of summing GPU shares
+const shareTolerance = 1e-9
+
+// fits returns true if the GPU matches the usage, and has enough share and memory left for it
+func (s *sharedGPU) fits(usage models.Resources) bool {
+	if !usage.MatchesGPU(s.gpu) || s.share+usage.GPUShare > 1+shareTolerance {
+		return false
+	}
+	return usage.GPUMemory == 0 || s.memory+usage.GPUMemory <= s.gpu.Memory
 }
 
 func NewLocalTracker(params LocalTrackerParams) *LocalTracker {
+	reservableCapacity := *params.MaxCapacity.Copy()
+	reservableCapacity.CPU *= math.Max(params.CPUOvercommit, 1)
+	reservableCapacity.Memory = uint64(float64(reservableCapacity.Memory) * math.Max(params.MemoryOvercommit, 1))
 	return &LocalTracker{
-		maxCapacity: params.MaxCapacity,
+		maxCapacity:        params.MaxCapacity,
+		reservableCapacity: reservableCapacity,
+		sharedGPUs:         make(map[uint64]*sharedGPU),
 	}
 }
 
 func (t *LocalTracker) IsWithinLimits(ctx context.Context, usage models.Resources) bool {
+	if usage.HasGPURequirements() && uint64(lo.CountBy(t.maxCapacity.GPUs, usage.MatchesGPU)) < usage.GPUCount() {
+		return false
+	}
 	return usage.LessThanEq(t.maxCapacity)
 }
 
@@ -33,14 +78,20 @@ func (t *LocalTracker) AddIfHasCapacity(ctx context.Context, usage models.Resour
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if usage.IsSharedGPU() {
+		return t.addSharedIfHasCapacity(usage)
+	}
+
 	newUsedCapacity := t.usedCapacity.Add(usage)
-	if !newUsedCapacity.LessThanEq(t.maxCapacity) {
+	if !newUsedCapacity.LessThanEq(t.reservableCapacity) {
 		return nil
 	}
 
 	// Allocate any GPUs that have been asked for but not chosen
 	unspecifiedGPUs := math.Max(usage.GPU-uint64(len(usage.GPUs)), 0)
-	availableGPUs := t.maxCapacity.Sub(t.usedCapacity).GPUs
+	availableGPUs := lo.Filter(t.maxCapacity.Sub(t.usedCapacity).GPUs, func(gpu models.GPU, _ int) bool {
+		return usage.MatchesGPU(gpu)
+	})
 	if unspecifiedGPUs > uint64(len(availableGPUs)) {
 		return nil
 	}
@@ -50,10 +101,50 @@ func (t *LocalTracker) AddIfHasCapacity(ctx context.Context, usage models.Resour
 	return &usage
 }
 
+// addSharedIfHasCapacity allocates a GPU shared with other executions to the
+// usage, preferring GPUs that are already shared so that whole GPUs remain
+// available to executions that need them.
+func (t *LocalTracker) addSharedIfHasCapacity(usage models.Resources) *models.Resources {
+	// the usage of the node excluding the GPU, which is only counted once however many executions share it
+	nonGPUUsage := usage
+	nonGPUUsage.GPUShare, nonGPUUsage.GPUMemory, nonGPUUsage.GPUs = 0, 0, nil
+	if !t.usedCapacity.Add(nonGPUUsage).LessThanEq(t.reservableCapacity) {
+		return nil
+	}
+
+	shared := lo.Values(t.sharedGPUs)
+	sort.Slice(shared, func(i, j int) bool { return shared[i].gpu.Index < shared[j].gpu.Index })
+	for _, s := range shared {
+		if s.fits(usage) && (len(usage.GPUs) == 0 || usage.GPUs[0].Index == s.gpu.Index) {
+			return t.share(s, usage, nonGPUUsage)
+		}
+	}
+
+	// share a GPU that is not in use yet
+	for _, gpu := range t.maxCapacity.Sub(t.usedCapacity).GPUs {
+		s := &sharedGPU{gpu: gpu}
+		if s.fits(usage) && (len(usage.GPUs) == 0 || usage.GPUs[0].Index == gpu.Index) {
+			t.sharedGPUs[gpu.Index] = s
+			nonGPUUsage.GPU, nonGPUUsage.GPUs = 1, []models.GPU{gpu}
+			return t.share(s, usage, nonGPUUsage)
+		}
+	}
+	return nil
+}
+
+func (t *LocalTracker) share(s *sharedGPU, usage, nodeUsage models.Resources) *models.Resources {
+	s.share += usage.GPUShare
+	s.memory += usage.GPUMemory
+	s.executions++
+	t.usedCapacity = *t.usedCapacity.Add(nodeUsage)
+	usage.GPUs = []models.GPU{s.gpu}
+	return &usage
+}
+
 func (t *LocalTracker) GetAvailableCapacity(ctx context.Context) models.Resources {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	return *t.maxCapacity.Sub(t.usedCapacity)
+	return *t.reservableCapacity.Sub(t.usedCapacity)
 }
 
 func (t *LocalTracker) GetMaxCapacity(ctx context.Context) models.Resources {
@@ -63,7 +154,26 @@ func (t *LocalTracker) GetMaxCapacity(ctx context.Context) models.Resources {
 func (t *LocalTracker) Remove(ctx context.Context, usage models.Resources) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	t.usedCapacity = *t.usedCapacity.Sub(usage)
+	if !usage.IsSharedGPU() {
+		t.usedCapacity = *t.usedCapacity.Sub(usage)
+		return
+	}
+
+	nodeUsage := usage
+	nodeUsage.GPUShare, nodeUsage.GPUMemory, nodeUsage.GPUs = 0, 0, nil
+	if len(usage.GPUs) > 0 {
+		if s, ok := t.sharedGPUs[usage.GPUs[0].Index]; ok {
+			s.share = math.Max(s.share-usage.GPUShare, 0)
+			s.memory -= math.Min(usage.GPUMemory, s.memory)
+			s.executions--
+			// release the GPU once the last execution sharing it is done
+			if s.executions <= 0 {
+				delete(t.sharedGPUs, s.gpu.Index)
+				nodeUsage.GPU, nodeUsage.GPUs = 1, []models.GPU{s.gpu}
+			}
+		}
+	}
+	t.usedCapacity = *t.usedCapacity.Sub(nodeUsage)
 }
 
 // compile-time check that LocalTracker implements Tracker
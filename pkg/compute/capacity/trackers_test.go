@@ -46,3 +46,129 @@ func TestDoesntAllocateGPUs(t *testing.T) {
 	require.Len(t, avail.GPUs, 2)
 	require.Equal(t, avail, tracker.maxCapacity)
 }
+
+func TestSharesGPUs(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewLocalTracker(LocalTrackerParams{MaxCapacity: models.Resources{
+		GPU: 2,
+		GPUs: []models.GPU{
+			{Index: 0, Name: "Lancer 2X", Vendor: models.GPUVendorNvidia, Memory: 100},
+			{Index: 1, Name: "Berdly 1.0", Vendor: models.GPUVendorNvidia, Memory: 100},
+		},
+	}})
+
+	first := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.5})
+	require.NotNil(t, first)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[0]}, first.GPUs)
+	require.Equal(t, 0.5, first.GPUShare)
+
+	// fractions are packed on the GPU already shared
+	second := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.25})
+	require.NotNil(t, second)
+	require.Equal(t, first.GPUs, second.GPUs)
+
+	// a shared GPU is not available for whole allocations
+	avail := tracker.GetAvailableCapacity(ctx)
+	require.Equal(t, uint64(1), avail.GPU)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, avail.GPUs)
+
+	// the next fraction doesn't fit the shared GPU anymore
+	third := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.5})
+	require.NotNil(t, third)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, third.GPUs)
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1}))
+
+	// the GPU is released once all executions sharing it are done
+	tracker.Remove(ctx, *first)
+	require.Equal(t, uint64(0), tracker.GetAvailableCapacity(ctx).GPU)
+	tracker.Remove(ctx, *second)
+	require.Equal(t, uint64(1), tracker.GetAvailableCapacity(ctx).GPU)
+	tracker.Remove(ctx, *third)
+	require.Equal(t, tracker.maxCapacity, tracker.GetAvailableCapacity(ctx))
+}
+
+func TestAllocatesGPUsByMemory(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewLocalTracker(LocalTrackerParams{MaxCapacity: models.Resources{
+		GPU: 2,
+		GPUs: []models.GPU{
+			{Index: 0, Name: "Lancer 2X", Vendor: models.GPUVendorNvidia, Memory: 100},
+			{Index: 1, Name: "Berdly 1.0", Vendor: models.GPUVendorNvidia, Memory: 200},
+		},
+	}})
+
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{GPU: 1, GPUMemory: 300}))
+	require.True(t, tracker.IsWithinLimits(ctx, models.Resources{GPUMemory: 150}))
+
+	// whole GPUs are only allocated if they have enough memory
+	whole := tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUMemory: 150})
+	require.NotNil(t, whole)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, whole.GPUs)
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUMemory: 150}))
+	tracker.Remove(ctx, *whole)
+
+	// shared GPUs are allocated until their memory is exhausted
+	first := tracker.AddIfHasCapacity(ctx, models.Resources{GPUMemory: 60})
+	require.NotNil(t, first)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[0]}, first.GPUs)
+	second := tracker.AddIfHasCapacity(ctx, models.Resources{GPUMemory: 60})
+	require.NotNil(t, second)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, second.GPUs)
+	third := tracker.AddIfHasCapacity(ctx, models.Resources{GPUMemory: 140})
+	require.NotNil(t, third)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, third.GPUs)
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPUMemory: 60}))
+}
+
+func TestAllocatesGPUsByModelAndVendor(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewLocalTracker(LocalTrackerParams{MaxCapacity: models.Resources{
+		GPU: 3,
+		GPUs: []models.GPU{
+			{Index: 0, Name: "Lancer 2X", Vendor: models.GPUVendorNvidia, Memory: 100},
+			{Index: 1, Name: "Berdly 1.0", Vendor: models.GPUVendorAMDATI, Memory: 100},
+			{Index: 2, Name: "Lancer 3X", Vendor: models.GPUVendorNvidia, Memory: 100},
+		},
+	}})
+
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{GPU: 2, GPUVendor: models.GPUVendorAMDATI}))
+	require.True(t, tracker.IsWithinLimits(ctx, models.Resources{GPU: 2, GPUModel: "lancer"}))
+
+	amd := tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUVendor: models.GPUVendorAMDATI})
+	require.NotNil(t, amd)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[1]}, amd.GPUs)
+
+	lancer := tracker.AddIfHasCapacity(ctx, models.Resources{GPU: 1, GPUModel: "Lancer 3*"})
+	require.NotNil(t, lancer)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[2]}, lancer.GPUs)
+
+	// the only GPU left is not from the vendor required
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.5, GPUVendor: models.GPUVendorAMDATI}))
+	shared := tracker.AddIfHasCapacity(ctx, models.Resources{GPUShare: 0.5, GPUVendor: models.GPUVendorNvidia})
+	require.NotNil(t, shared)
+	require.Equal(t, []models.GPU{tracker.maxCapacity.GPUs[0]}, shared.GPUs)
+}
+
+func TestOvercommitsResources(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewLocalTracker(LocalTrackerParams{
+		MaxCapacity:      models.Resources{CPU: 4, Memory: 1000, Disk: 1000},
+		CPUOvercommit:    2,
+		MemoryOvercommit: 1.5,
+	})
+
+	// each execution must fit the max capacity on its own
+	require.False(t, tracker.IsWithinLimits(ctx, models.Resources{CPU: 6}))
+	require.True(t, tracker.IsWithinLimits(ctx, models.Resources{CPU: 4, Memory: 1000}))
+
+	require.NotNil(t, tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 4, Memory: 1000, Disk: 500}))
+	require.NotNil(t, tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 3, Memory: 500, Disk: 500}))
+	avail := tracker.GetAvailableCapacity(ctx)
+	require.Equal(t, 1.0, avail.CPU)
+	require.Zero(t, avail.Memory)
+	require.Zero(t, avail.Disk)
+	require.Equal(t, models.Resources{CPU: 4, Memory: 1000, Disk: 1000}, tracker.GetMaxCapacity(ctx))
+
+	// disk is never overcommitted
+	require.Nil(t, tracker.AddIfHasCapacity(ctx, models.Resources{CPU: 1, Disk: 1}))
+}
@@ -18,6 +18,7 @@ type Tracker interface {
 	// that was added including any allocations that were made, or nil if the usage could not be added.
 	AddIfHasCapacity(ctx context.Context, usage models.Resources) *models.Resources
 	// GetAvailableCapacity returns the available capacity of the compute node.
+	// It may exceed the max capacity when CPU or memory are overcommitted.
 	GetAvailableCapacity(ctx context.Context) models.Resources
 	// GetMaxCapacity returns the total capacity of the compute node.
 	GetMaxCapacity(ctx context.Context) models.Resources
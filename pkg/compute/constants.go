@@ -9,4 +9,5 @@ const (
 const (
 	EventObjectExecutionUpsert = "ExecutionUpsert"
 	EventObjectExecutionEvent  = "ExecutionEvent"
+	EventObjectExecutionLogs   = "ExecutionLogs"
 )
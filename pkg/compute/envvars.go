@@ -67,6 +67,16 @@ func GetExecutionEnvVars(execution *models.Execution, resolver EnvVarResolver) (
 		}
 	}
 
-	// Merge task and system variables, with system taking precedence
-	return envvar.Merge(taskEnv, sysEnv), nil
+	// Add the parameter values of the partition for jobs with a matrix
+	matrixEnv := make(map[string]string)
+	if execution.Job != nil && execution.Job.Matrix != nil {
+		values, err := execution.Job.Matrix.Values(execution.PartitionIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve job matrix parameters: %w", err)
+		}
+		matrixEnv = values
+	}
+
+	// Merge task, matrix and system variables, with system taking precedence
+	return envvar.Merge(envvar.Merge(taskEnv, matrixEnv), sysEnv), nil
 }
@@ -146,6 +146,60 @@ func TestGetExecutionEnvVars(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "execution with job matrix",
+			execution: &models.Execution{
+				ID:             "exec-1",
+				NodeID:         "node-1",
+				JobID:          "job-1",
+				PartitionIndex: 3,
+				Job: &models.Job{
+					Type:  "batch",
+					Count: 4,
+					Matrix: &models.JobMatrix{
+						Parameters: []models.MatrixParameter{
+							{Name: "MODEL", Values: []string{"small", "large"}},
+							{Name: "SEED", Range: &models.MatrixRange{Start: 10, End: 20, Step: 10}},
+						},
+					},
+					Tasks: []*models.Task{
+						{
+							Name: "task-1",
+							Env: map[string]models.EnvVarValue{
+								"LITERAL_VAR": "literal-value",
+							},
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"BACALHAU_EXECUTION_ID":    "exec-1",
+				"BACALHAU_JOB_ID":          "job-1",
+				"BACALHAU_JOB_TYPE":        "batch",
+				"BACALHAU_PARTITION_INDEX": "3",
+				"BACALHAU_PARTITION_COUNT": "4",
+				"LITERAL_VAR":              "literal-value",
+				"MODEL":                    "large",
+				"SEED":                     "20",
+			},
+		},
+		{
+			name: "execution with partition outside of job matrix",
+			execution: &models.Execution{
+				ID:             "exec-1",
+				JobID:          "job-1",
+				PartitionIndex: 2,
+				Job: &models.Job{
+					Type:  "batch",
+					Count: 3,
+					Matrix: &models.JobMatrix{
+						Parameters: []models.MatrixParameter{{Name: "MODEL", Values: []string{"small", "large"}}},
+					},
+					Tasks: []*models.Task{{Name: "task-1"}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "execution with network ports",
 			execution: &models.Execution{
@@ -34,6 +34,8 @@ type BaseExecutorParams struct {
 	FailureInjectionConfig models.FailureInjectionConfig
 	EnvResolver            EnvVarResolver
 	PortAllocator          PortAllocator
+	// LogForwarder forwards the output of executions to the orchestrator. Optional.
+	LogForwarder *LogForwarder
 }
 
 // BaseExecutor is the base implementation for backend service.
@@ -49,6 +51,7 @@ type BaseExecutor struct {
 	failureInjection models.FailureInjectionConfig
 	envResolver      EnvVarResolver
 	portAllocator    PortAllocator
+	logForwarder     *LogForwarder
 }
 
 func NewBaseExecutor(params BaseExecutorParams) *BaseExecutor {
@@ -63,6 +66,7 @@ func NewBaseExecutor(params BaseExecutorParams) *BaseExecutor {
 		resultsPath:      params.ResultsPath,
 		envResolver:      params.EnvResolver,
 		portAllocator:    params.PortAllocator,
+		logForwarder:     params.LogForwarder,
 	}
 }
 
@@ -323,10 +327,12 @@ func (e *BaseExecutor) Run(ctx context.Context, execution *models.Execution) (er
 
 	stopwatch := telemetry.Timer(ctx, jobDurationMilliseconds, execution.Job.MetricAttributes()...)
 	topic := EventTopicExecutionRunning
+	// output of a run that failed, which is kept with the failure to report its resource usage
+	var failedOutput *models.RunCommandResult
 	defer func() {
 		if err != nil {
 			if !bacerrors.IsErrorWithCode(err, executor.ExecutionAlreadyCancelled) {
-				e.handleFailure(ctx, execution, err, topic)
+				e.handleFailure(ctx, execution, err, topic, failedOutput)
 			}
 		}
 		dur := stopwatch()
@@ -359,7 +365,14 @@ func (e *BaseExecutor) Run(ctx context.Context, execution *models.Execution) (er
 		}
 	}
 
+	// forward the output of the execution while it runs, and until its remaining
+	// output is drained before reporting its result
+	waitForLogs := func() {}
+	if e.logForwarder != nil {
+		waitForLogs = e.logForwarder.Forward(ctx, execution)
+	}
 	result, err := e.Wait(ctx, execution)
+	waitForLogs()
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			// TODO(forrest) [correctness]:
@@ -378,6 +391,7 @@ func (e *BaseExecutor) Run(ctx context.Context, execution *models.Execution) (er
 		return err
 	}
 	if result.ErrorMsg != "" {
+		failedOutput = result
 		return fmt.Errorf("%s", result.ErrorMsg)
 	}
 	jobsCompleted.Add(ctx, 1)
@@ -473,13 +487,15 @@ func (e *BaseExecutor) Cancel(ctx context.Context, execution *models.Execution)
 	return exe.Cancel(ctx, execution.ID)
 }
 
-func (e *BaseExecutor) handleFailure(ctx context.Context, execution *models.Execution, err error, topic models.EventTopic) {
+func (e *BaseExecutor) handleFailure(
+	ctx context.Context, execution *models.Execution, err error, topic models.EventTopic, runOutput *models.RunCommandResult) {
 	log.Ctx(ctx).Warn().Err(err).Msgf("%s failed", topic)
 
 	updateError := e.store.UpdateExecutionState(ctx, store.UpdateExecutionRequest{
 		ExecutionID: execution.ID,
 		NewValues: models.Execution{
 			ComputeState: models.NewExecutionState(models.ExecutionStateFailed).WithMessage(err.Error()),
+			RunOutput:    runOutput,
 		},
 		Events: []*models.Event{models.NewEvent(topic).WithError(err)},
 	})
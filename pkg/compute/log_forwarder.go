@@ -0,0 +1,228 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/executor"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/logger"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+)
+
+const (
+	// defaultLogBatchSize is the maximum number of lines forwarded in a single batch.
+	defaultLogBatchSize = 100
+	// defaultLogFlushInterval is how often pending lines are forwarded when
+	// the batch is not full.
+	defaultLogFlushInterval = time.Second
+	// defaultLogDrainTimeout is how long to wait for the remaining output of an
+	// execution once it is done.
+	defaultLogDrainTimeout = 5 * time.Second
+)
+
+type LogForwarderParams struct {
+	Executors  executor.ExecProvider
+	EventStore watcher.EventStore
+	// BatchSize is the maximum number of lines forwarded in a single batch.
+	// If not set (0), defaultLogBatchSize will be used.
+	BatchSize int
+	// FlushInterval is how often pending lines are forwarded.
+	// If not set (0), defaultLogFlushInterval will be used.
+	FlushInterval time.Duration
+	// DrainTimeout is how long to wait for the remaining output of an execution once it is done.
+	// If not set (0), defaultLogDrainTimeout will be used.
+	DrainTimeout time.Duration
+}
+
+// LogForwarder follows the log stream of running executions and stores their
+// output lines, timestamped as they are read, as events to be forwarded to the
+// orchestrator by the data plane.
+type LogForwarder struct {
+	executors     executor.ExecProvider
+	eventStore    watcher.EventStore
+	batchSize     int
+	flushInterval time.Duration
+	drainTimeout  time.Duration
+}
+
+func NewLogForwarder(params LogForwarderParams) *LogForwarder {
+	if params.BatchSize <= 0 {
+		params.BatchSize = defaultLogBatchSize
+	}
+	if params.FlushInterval <= 0 {
+		params.FlushInterval = defaultLogFlushInterval
+	}
+	if params.DrainTimeout <= 0 {
+		params.DrainTimeout = defaultLogDrainTimeout
+	}
+	return &LogForwarder{
+		executors:     params.Executors,
+		eventStore:    params.EventStore,
+		batchSize:     params.BatchSize,
+		flushInterval: params.FlushInterval,
+		drainTimeout:  params.DrainTimeout,
+	}
+}
+
+// Forward starts forwarding the output of a running execution. The returned
+// function must be called once the execution is done, and waits for the
+// remaining output to be forwarded until the drain timeout.
+// Only executions orchestrated over NCL are forwarded.
+func (f *LogForwarder) Forward(ctx context.Context, execution *models.Execution) (wait func()) {
+	if execution.OrchestrationProtocol() != models.ProtocolNCLV1 {
+		return func() {}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f.forward(streamCtx, context.WithoutCancel(ctx), execution)
+	}()
+
+	return func() {
+		select {
+		case <-done:
+		case <-time.After(f.drainTimeout):
+			log.Ctx(ctx).Debug().Msgf("timed out waiting for the remaining logs of execution %s", execution.ID)
+		}
+		cancel()
+		<-done
+	}
+}
+
+// forward reads the log stream until it ends or the stream context is done,
+// and stores the lines with the store context, which outlives the stream.
+func (f *LogForwarder) forward(streamCtx, storeCtx context.Context, execution *models.Execution) {
+	exec, err := f.executors.Get(streamCtx, execution.Job.Task().Engine.Type)
+	if err != nil {
+		log.Ctx(streamCtx).Debug().Err(err).Msgf("not forwarding logs of execution %s", execution.ID)
+		return
+	}
+	reader, err := exec.GetLogStream(streamCtx, messages.ExecutionLogsRequest{
+		ExecutionID: execution.ID,
+		Follow:      true,
+	})
+	if err != nil {
+		log.Ctx(streamCtx).Debug().Err(err).Msgf("not forwarding logs of execution %s", execution.ID)
+		return
+	}
+	// unblock pending reads once the stream is no longer needed
+	go func() {
+		<-streamCtx.Done()
+		_ = reader.Close()
+	}()
+
+	frames := make(chan *logger.DataFrame)
+	go func() {
+		defer close(frames)
+		for {
+			df, readErr := logger.NewDataFrameFromReader(reader)
+			if readErr != nil {
+				if !errors.Is(readErr, io.EOF) && streamCtx.Err() == nil {
+					log.Ctx(streamCtx).Debug().Err(readErr).Msgf("failed to read logs of execution %s", execution.ID)
+				}
+				return
+			}
+			select {
+			case frames <- df:
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+
+	batch := newLogBatch(execution)
+	flush := func() {
+		if len(batch.Lines) == 0 {
+			return
+		}
+		if storeErr := f.eventStore.StoreEvent(storeCtx, watcher.StoreEventRequest{
+			Operation:  watcher.OperationCreate,
+			ObjectType: EventObjectExecutionLogs,
+			Object:     *batch.ExecutionLogBatch,
+		}); storeErr != nil {
+			log.Ctx(storeCtx).Warn().Err(storeErr).Msgf("failed to forward logs of execution %s", execution.ID)
+		}
+		batch.reset()
+	}
+
+	ticker := time.NewTicker(f.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case df, ok := <-frames:
+			if !ok {
+				batch.flushPartial(time.Now().UTC())
+				flush()
+				return
+			}
+			batch.add(df, time.Now().UTC())
+			if len(batch.Lines) >= f.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-streamCtx.Done():
+			// the stream may not end on its own, so stop reading once it is no longer needed
+			batch.flushPartial(time.Now().UTC())
+			flush()
+			return
+		}
+	}
+}
+
+// logBatch splits data frames into lines, holding partial lines of each
+// stream until they are completed.
+type logBatch struct {
+	*models.ExecutionLogBatch
+	partial map[models.ExecutionLogType]string
+}
+
+func newLogBatch(execution *models.Execution) *logBatch {
+	return &logBatch{
+		ExecutionLogBatch: &models.ExecutionLogBatch{
+			JobID:       execution.JobID,
+			ExecutionID: execution.ID,
+		},
+		partial: make(map[models.ExecutionLogType]string),
+	}
+}
+
+func (b *logBatch) add(df *logger.DataFrame, timestamp time.Time) {
+	logType := models.ExecutionLogTypeSTDERR
+	if df.Tag == logger.StdoutStreamTag {
+		logType = models.ExecutionLogTypeSTDOUT
+	}
+	data := b.partial[logType] + string(df.Data)
+	for {
+		i := strings.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		b.Lines = append(b.Lines, models.ExecutionLogLine{Type: logType, Line: data[:i+1], Timestamp: timestamp})
+		data = data[i+1:]
+	}
+	b.partial[logType] = data
+}
+
+// flushPartial adds the partial lines left at the end of the streams
+func (b *logBatch) flushPartial(timestamp time.Time) {
+	for _, logType := range []models.ExecutionLogType{models.ExecutionLogTypeSTDOUT, models.ExecutionLogTypeSTDERR} {
+		if line := b.partial[logType]; line != "" {
+			b.Lines = append(b.Lines, models.ExecutionLogLine{Type: logType, Line: line, Timestamp: timestamp})
+		}
+	}
+	clear(b.partial)
+}
+
+func (b *logBatch) reset() {
+	b.Lines = nil
+}
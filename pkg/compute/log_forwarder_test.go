@@ -0,0 +1,162 @@
+//go:build unit || !integration
+
+package compute_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/compute"
+	"github.com/bacalhau-project/bacalhau/pkg/executor"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/provider"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/logger"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+	testutils "github.com/bacalhau-project/bacalhau/pkg/test/utils"
+)
+
+// logStreamExecutor is an executor only serving a log stream
+type logStreamExecutor struct {
+	executor.Executor
+	reader io.ReadCloser
+}
+
+func (e *logStreamExecutor) IsInstalled(context.Context) (bool, error) {
+	return true, nil
+}
+
+func (e *logStreamExecutor) GetLogStream(context.Context, messages.ExecutionLogsRequest) (io.ReadCloser, error) {
+	return e.reader, nil
+}
+
+// unclosableReader is a log stream whose reads are not unblocked by Close
+type unclosableReader struct {
+	io.Reader
+}
+
+func (r unclosableReader) Close() error {
+	return nil
+}
+
+type LogForwarderTestSuite struct {
+	suite.Suite
+	eventStore watcher.EventStore
+	reader     *io.PipeReader
+	writer     *io.PipeWriter
+	forwarder  *compute.LogForwarder
+	execution  *models.Execution
+}
+
+func TestLogForwarderTestSuite(t *testing.T) {
+	suite.Run(t, new(LogForwarderTestSuite))
+}
+
+func (s *LogForwarderTestSuite) SetupTest() {
+	s.eventStore = testutils.CreateComputeEventStore(s.T())
+	s.reader, s.writer = io.Pipe()
+	s.execution = mock.Execution()
+	s.execution.Job.Meta[models.MetaOrchestratorProtocol] = models.ProtocolNCLV1.String()
+	s.forwarder = compute.NewLogForwarder(compute.LogForwarderParams{
+		Executors: provider.NewMappedProvider(map[string]executor.Executor{
+			s.execution.Job.Task().Engine.Type: &logStreamExecutor{reader: s.reader},
+		}),
+		EventStore:    s.eventStore,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		DrainTimeout:  100 * time.Millisecond,
+	})
+}
+
+func (s *LogForwarderTestSuite) write(tag logger.StreamTag, data string) {
+	_, err := s.writer.Write(logger.NewDataFrameFromData(tag, []byte(data)).ToBytes())
+	s.Require().NoError(err)
+}
+
+// forwardedLines returns the lines of all forwarded batches
+func (s *LogForwarderTestSuite) forwardedLines() []models.ExecutionLogLine {
+	response, err := s.eventStore.GetEvents(context.Background(), watcher.GetEventsRequest{
+		EventIterator: watcher.TrimHorizonIterator(),
+		Limit:         100,
+	})
+	s.Require().NoError(err)
+
+	var lines []models.ExecutionLogLine
+	for _, event := range response.Events {
+		s.Equal(compute.EventObjectExecutionLogs, event.ObjectType)
+		batch, ok := event.Object.(models.ExecutionLogBatch)
+		s.Require().True(ok)
+		s.Equal(s.execution.ID, batch.ExecutionID)
+		s.Equal(s.execution.JobID, batch.JobID)
+		lines = append(lines, batch.Lines...)
+	}
+	return lines
+}
+
+func (s *LogForwarderTestSuite) TestForwardsTimestampedLines() {
+	start := time.Now().UTC()
+	wait := s.forwarder.Forward(context.Background(), s.execution)
+
+	s.write(logger.StdoutStreamTag, "first\nsec")
+	s.write(logger.StderrStreamTag, "oops\n")
+	s.write(logger.StdoutStreamTag, "ond\nlast")
+	s.Require().NoError(s.writer.Close())
+	wait()
+
+	lines := s.forwardedLines()
+	s.Require().Len(lines, 4)
+	s.Equal(models.ExecutionLogLine{Type: models.ExecutionLogTypeSTDOUT, Line: "first\n", Timestamp: lines[0].Timestamp}, lines[0])
+	s.Equal(models.ExecutionLogLine{Type: models.ExecutionLogTypeSTDERR, Line: "oops\n", Timestamp: lines[1].Timestamp}, lines[1])
+	s.Equal(models.ExecutionLogLine{Type: models.ExecutionLogTypeSTDOUT, Line: "second\n", Timestamp: lines[2].Timestamp}, lines[2])
+	// partial lines are forwarded once the stream ends
+	s.Equal(models.ExecutionLogLine{Type: models.ExecutionLogTypeSTDOUT, Line: "last", Timestamp: lines[3].Timestamp}, lines[3])
+
+	for i, line := range lines {
+		s.False(line.Timestamp.Before(start), "line %d timestamped before the stream started", i)
+		if i > 0 {
+			s.False(line.Timestamp.Before(lines[i-1].Timestamp), "line %d timestamped before the previous line", i)
+		}
+	}
+}
+
+func (s *LogForwarderTestSuite) TestStopsAfterDrainTimeout() {
+	wait := s.forwarder.Forward(context.Background(), s.execution)
+	s.write(logger.StdoutStreamTag, "still running\n")
+
+	// the stream never ends, so forwarding stops after the drain timeout
+	// with the lines read so far
+	start := time.Now()
+	wait()
+	s.Less(time.Since(start), time.Second)
+	s.Require().Len(s.forwardedLines(), 1)
+}
+
+func (s *LogForwarderTestSuite) TestStopsWhenStreamIgnoresClose() {
+	s.forwarder = compute.NewLogForwarder(compute.LogForwarderParams{
+		Executors: provider.NewMappedProvider(map[string]executor.Executor{
+			s.execution.Job.Task().Engine.Type: &logStreamExecutor{reader: unclosableReader{Reader: s.reader}},
+		}),
+		EventStore:    s.eventStore,
+		FlushInterval: time.Hour,
+		DrainTimeout:  100 * time.Millisecond,
+	})
+	wait := s.forwarder.Forward(context.Background(), s.execution)
+	s.write(logger.StdoutStreamTag, "still running\n")
+
+	start := time.Now()
+	wait()
+	s.Less(time.Since(start), time.Second)
+	s.Require().Len(s.forwardedLines(), 1)
+}
+
+func (s *LogForwarderTestSuite) TestSkipsLegacyProtocol() {
+	s.execution.Job.Meta[models.MetaOrchestratorProtocol] = models.ProtocolBProtocolV2.String()
+	wait := s.forwarder.Forward(context.Background(), s.execution)
+	wait()
+	s.Empty(s.forwardedLines())
+}
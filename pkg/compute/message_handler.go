@@ -12,6 +12,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
 )
 
 type MessageHandler struct {
@@ -34,6 +35,8 @@ func (m *MessageHandler) ShouldProcess(ctx context.Context, message *envelope.Me
 // HandleMessage handles incoming messages
 func (m *MessageHandler) HandleMessage(ctx context.Context, message *envelope.Message) error {
 	var err error
+	ctx, span := ncl.StartMessageSpan(ctx, "compute.MessageHandler.HandleMessage", message)
+	defer span.End()
 
 	switch message.Metadata.Get(envelope.KeyMessageType) {
 	case messages.AskForBidMessageType:
@@ -46,7 +49,7 @@ func (m *MessageHandler) HandleMessage(ctx context.Context, message *envelope.Me
 		err = m.handleCancel(ctx, message)
 	}
 
-	return m.handleError(ctx, message, err)
+	return m.handleError(ctx, message, telemetry.RecordErrorOnSpan(span)(err))
 }
 
 // handleError logs the error with context and returns nil.
@@ -114,6 +114,7 @@ func NewStore(ctx context.Context, dbPath string) (*Store, error) {
 	err = errors.Join(
 		eventObjectSerializer.RegisterType(compute.EventObjectExecutionUpsert, reflect.TypeOf(models.ExecutionUpsert{})),
 		eventObjectSerializer.RegisterType(compute.EventObjectExecutionEvent, reflect.TypeOf(models.Event{})),
+		eventObjectSerializer.RegisterType(compute.EventObjectExecutionLogs, reflect.TypeOf(models.ExecutionLogBatch{})),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register event object types: %w", err)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
@@ -19,6 +20,10 @@ func NewNCLMessageCreator() *NCLMessageCreator {
 }
 
 func (d *NCLMessageCreator) CreateMessage(event watcher.Event) (*envelope.Message, error) {
+	if batch, ok := event.Object.(models.ExecutionLogBatch); ok {
+		return d.createLogsMessage(batch), nil
+	}
+
 	upsert, ok := event.Object.(models.ExecutionUpsert)
 	if !ok {
 		return nil, bacerrors.New("failed to process event: expected models.ExecutionUpsert, got %T", event.Object).
@@ -57,14 +62,33 @@ func (d *NCLMessageCreator) CreateMessage(event watcher.Event) (*envelope.Messag
 		}).WithMetadataValue(envelope.KeyMessageType, messages.RunResultMessageType)
 	case models.ExecutionStateFailed:
 		log.Debug().Msgf("Execution %s failed", execution.ID)
-		message = envelope.NewMessage(messages.ComputeError{BaseResponse: baseResponse}).
+		message = envelope.NewMessage(messages.ComputeError{
+			BaseResponse:     baseResponse,
+			RunCommandResult: execution.RunOutput,
+		}).
 			WithMetadataValue(envelope.KeyMessageType, messages.ComputeErrorMessageType)
 	default:
 		// No message created for other states
 	}
 
+	if message != nil {
+		message.WithMetadataValue(ncl.KeyExecutionID, execution.ID)
+	}
 	return message, nil
 }
 
+// createLogsMessage creates a message forwarding the output lines of an execution.
+func (d *NCLMessageCreator) createLogsMessage(batch models.ExecutionLogBatch) *envelope.Message {
+	return envelope.NewMessage(messages.ExecutionLogs{
+		BaseResponse: messages.BaseResponse{
+			ExecutionID: batch.ExecutionID,
+			JobID:       batch.JobID,
+		},
+		Lines: batch.Lines,
+	}).
+		WithMetadataValue(envelope.KeyMessageType, messages.ExecutionLogsMessageType).
+		WithMetadataValue(ncl.KeyExecutionID, batch.ExecutionID)
+}
+
 // compile-time check that NCLMessageCreator implements dispatcher.MessageCreator
 var _ nclprotocol.MessageCreator = &NCLMessageCreator{}
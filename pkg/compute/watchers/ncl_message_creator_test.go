@@ -4,6 +4,7 @@ package watchers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -141,12 +142,40 @@ func (s *NCLMessageCreatorTestSuite) TestCreateMessage_ExecutionCompleted() {
 	s.Equal(0, result.RunCommandResult.ExitCode)
 }
 
+func (s *NCLMessageCreatorTestSuite) TestCreateMessage_ExecutionLogs() {
+	batch := models.ExecutionLogBatch{
+		JobID:       "job-1",
+		ExecutionID: "exec-1",
+		Lines: []models.ExecutionLogLine{
+			{Type: models.ExecutionLogTypeSTDOUT, Line: "hello\n", Timestamp: time.Now().UTC()},
+		},
+	}
+
+	msg, err := s.creator.CreateMessage(watcher.Event{Object: batch})
+	s.Require().NoError(err)
+	s.Require().NotNil(msg)
+
+	s.Equal(messages.ExecutionLogsMessageType, msg.Metadata.Get(envelope.KeyMessageType))
+
+	payload, ok := msg.GetPayload(messages.ExecutionLogs{})
+	s.Require().True(ok)
+	logs := payload.(messages.ExecutionLogs)
+
+	s.Equal(batch.ExecutionID, logs.ExecutionID)
+	s.Equal(batch.JobID, logs.JobID)
+	s.Equal(batch.Lines, logs.Lines)
+}
+
 func (s *NCLMessageCreatorTestSuite) TestCreateMessage_ExecutionFailed() {
 	execution := mock.Execution()
 	execution.Job.Meta[models.MetaOrchestratorProtocol] = models.ProtocolNCLV1.String()
 	execution.ComputeState = models.State[models.ExecutionStateType]{
 		StateType: models.ExecutionStateFailed,
 	}
+	execution.RunOutput = &models.RunCommandResult{
+		ExitCode: 1,
+		Usage:    &models.ResourceUsage{CPUTime: time.Second},
+	}
 
 	msg, err := s.creator.CreateMessage(watcher.Event{
 		Object: models.ExecutionUpsert{
@@ -167,6 +196,8 @@ func (s *NCLMessageCreatorTestSuite) TestCreateMessage_ExecutionFailed() {
 	s.Equal(execution.ID, result.ExecutionID)
 	s.Equal(execution.JobID, result.JobID)
 	s.Equal(execution.Job.Type, result.JobType)
+	s.Require().NotNil(result.RunCommandResult)
+	s.Equal(time.Second, result.RunCommandResult.Usage.CPUTime)
 }
 
 func (s *NCLMessageCreatorTestSuite) TestCreateMessage_UnhandledState() {
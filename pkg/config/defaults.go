@@ -37,11 +37,29 @@ var Default = types.Bacalhau{
 			QueueBackoff:         types.Minute,
 			HousekeepingInterval: 30 * types.Second,
 			HousekeepingTimeout:  2 * types.Minute,
+			BidTimeout:           30 * types.Second,
 		},
 		EvaluationBroker: types.EvaluationBroker{
 			VisibilityTimeout: types.Minute,
 			MaxRetryCount:     10,
 		},
+		ExecutionLogs: types.ExecutionLogs{
+			Enabled:              true,
+			Retention:            7 * types.Day,
+			MaxLinesPerExecution: 10000,
+		},
+		JobRetention: types.JobRetention{
+			Enabled:  false,
+			Interval: types.Duration(time.Hour),
+			MaxAge:   30 * types.Day,
+		},
+		JobStore: types.JobStore{
+			Type: types.JobStoreTypeBoltDB,
+		},
+		Compression: types.MessageCompression{
+			Algorithm: "zstd",
+			Threshold: 1024,
+		},
 	},
 	Compute: types.Compute{
 		Enabled:       false,
@@ -62,6 +80,14 @@ var Default = types.Bacalhau{
 			Disk:   "80%",
 			GPU:    "100%",
 		},
+		Overcommit: types.Overcommit{
+			CPU:    1,
+			Memory: 1,
+		},
+		Compression: types.MessageCompression{
+			Algorithm: "zstd",
+			Threshold: 1024,
+		},
 	},
 	JobDefaults: types.JobDefaults{
 		Batch: types.BatchJobDefaultsConfig{
@@ -113,6 +139,15 @@ var Default = types.Bacalhau{
 					TTL:     types.Duration(1 * time.Hour),
 					Refresh: types.Duration(1 * time.Hour),
 				}},
+			WASM: types.WASM{
+				CompilationCache: types.WASMCompilationCache{
+					MaxSize: "1GB",
+				},
+				HTTP: types.WASMHTTP{
+					MaxRequestSize:  "1MB",
+					MaxResponseSize: "10MB",
+				},
+			},
 		},
 	},
 	Publishers: types.PublishersConfig{
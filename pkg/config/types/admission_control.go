@@ -18,4 +18,7 @@ type JobAdmissionControl struct {
 	ProbeHTTP string `yaml:"ProbeHTTP,omitempty" json:"ProbeHTTP,omitempty"`
 	// ProbeExec specifies the command to execute for probing job submission.
 	ProbeExec string `yaml:"ProbeExec,omitempty" json:"ProbeExec,omitempty"`
+	// BidPolicyPath specifies a Rego policy file or directory deciding whether to bid on jobs.
+	// The policy is reloaded when its files change.
+	BidPolicyPath string `yaml:"BidPolicyPath,omitempty" json:"BidPolicyPath,omitempty"`
 }
@@ -22,6 +22,8 @@ type Bacalhau struct {
 	StrictVersionMatch bool         `yaml:"StrictVersionMatch,omitempty" json:"StrictVersionMatch,omitempty"`
 	Orchestrator       Orchestrator `yaml:"Orchestrator,omitempty" json:"Orchestrator,omitempty"`
 	Compute            Compute      `yaml:"Compute,omitempty" json:"Compute,omitempty"`
+	// NATS specifies how nodes connect to an external NATS cluster instead of the one embedded in orchestrators.
+	NATS NATS `yaml:"NATS,omitempty" json:"NATS,omitempty"`
 	// Labels are key-value pairs used to describe and categorize the nodes.
 	Labels              map[string]string   `yaml:"Labels,omitempty" json:"Labels,omitempty"`
 	WebUI               WebUI               `yaml:"WebUI,omitempty" json:"WebUI,omitempty"`
@@ -0,0 +1,10 @@
+package types
+
+// MessageCompression specifies how messages sent between orchestrator and compute nodes are compressed.
+type MessageCompression struct {
+	// Algorithm specifies the compression algorithm of messages sent to nodes that support it,
+	// either zstd, gzip or none. Messages sent to older nodes are never compressed.
+	Algorithm string `yaml:"Algorithm,omitempty" json:"Algorithm,omitempty"`
+	// Threshold specifies the minimum size in bytes of a message before it is compressed.
+	Threshold int `yaml:"Threshold,omitempty" json:"Threshold,omitempty"`
+}
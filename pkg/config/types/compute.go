@@ -11,12 +11,57 @@ type Compute struct {
 	Auth              ComputeAuth    `yaml:"Auth,omitempty" json:"Auth,omitempty"`
 	Heartbeat         Heartbeat      `yaml:"Heartbeat,omitempty" json:"Heartbeat,omitempty"`
 	AllocatedCapacity ResourceScaler `yaml:"AllocatedCapacity,omitempty" json:"AllocatedCapacity,omitempty"`
+	// Overcommit specifies how much more than the allocated capacity executions can reserve.
+	Overcommit Overcommit `yaml:"Overcommit,omitempty" json:"Overcommit,omitempty"`
+	// ExtendedResources specifies the resources other than CPU, memory, disk and GPUs
+	// that the compute node provides, such as FPGA cards or software licenses.
+	ExtendedResources ExtendedResources `yaml:"ExtendedResources,omitempty" json:"ExtendedResources,omitempty"`
 	// AllowListedLocalPaths specifies a list of local file system paths that the compute node is allowed to access.
 	AllowListedLocalPaths []string `yaml:"AllowListedLocalPaths" json:"AllowListedLocalPaths,omitempty"`
 	// TLS specifies the TLS related configuration on the compute node when connecting with the orchestrator.
 	TLS ComputeTLS `yaml:"TLS,omitempty" json:"TLS,omitempty"`
 	// Env specifies environment variable configuration for the compute node
 	Env EnvConfig `yaml:"Env,omitempty" json:"Env,omitempty"`
+	// Compression specifies how messages sent to the orchestrator are compressed.
+	Compression MessageCompression `yaml:"Compression,omitempty" json:"Compression,omitempty"`
+	// RequireSignedMessages rejects orchestrators that don't sign their messages, such as older versions.
+	RequireSignedMessages bool `yaml:"RequireSignedMessages,omitempty" json:"RequireSignedMessages,omitempty"`
+}
+
+// Overcommit specifies the ratios of resources that executions can reserve to
+// the allocated capacity, for workloads that use less than they request.
+// Executions are still limited to the resources they request, but are only
+// guaranteed their share of the allocated capacity. The available capacity
+// reported by an overcommitted node may exceed its max capacity.
+type Overcommit struct {
+	// CPU is the ratio of CPU that executions can reserve to the allocated CPU, e.g. 2.0.
+	// Ratios below 1 mean CPU is not overcommitted.
+	CPU float64 `yaml:"CPU,omitempty" json:"CPU,omitempty"`
+	// Memory is the ratio of memory that executions can reserve to the allocated memory, e.g. 1.2.
+	// Ratios below 1 mean memory is not overcommitted.
+	Memory float64 `yaml:"Memory,omitempty" json:"Memory,omitempty"`
+	// MemoryPressureThreshold is the fraction of the host memory in use, between 0 and 1,
+	// above which the compute node stops bidding on new jobs. Zero disables the threshold.
+	MemoryPressureThreshold float64 `yaml:"MemoryPressureThreshold,omitempty" json:"MemoryPressureThreshold,omitempty"`
+}
+
+// CPURatio returns the CPU overcommit ratio, which is at least 1.
+func (o Overcommit) CPURatio() float64 {
+	return max(o.CPU, 1)
+}
+
+// MemoryRatio returns the memory overcommit ratio, which is at least 1.
+func (o Overcommit) MemoryRatio() float64 {
+	return max(o.Memory, 1)
+}
+
+// ExtendedResources specifies the extended resources provided by the compute node.
+type ExtendedResources struct {
+	// Resources is the number of units of each extended resource, by name.
+	Resources map[string]uint64 `yaml:"Resources,omitempty" json:"Resources,omitempty"`
+	// DiscoveryCommand is a shell command printing the number of units of extended
+	// resources as name=N lines when the node starts, overriding the configured Resources.
+	DiscoveryCommand string `yaml:"DiscoveryCommand,omitempty" json:"DiscoveryCommand,omitempty"`
 }
 
 type ComputeAuth struct {
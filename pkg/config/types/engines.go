@@ -40,5 +40,29 @@ type DockerManifestCache struct {
 	Refresh Duration `yaml:"Refresh,omitempty" json:"Refresh,omitempty"`
 }
 
+// WASM represents the configuration settings for the WASM runtime provider.
 type WASM struct {
+	// CompilationCache specifies the settings for the cache of compiled WASM modules.
+	CompilationCache WASMCompilationCache `yaml:"CompilationCache,omitempty" json:"CompilationCache,omitempty"`
+	// HTTP specifies the settings for the HTTP requests made by WASM jobs.
+	HTTP WASMHTTP `yaml:"HTTP,omitempty" json:"HTTP,omitempty"`
+}
+
+// WASMCompilationCache represents the configuration settings for the cache of compiled WASM modules,
+// which is shared by all executions of the compute node and persisted in its data directory.
+type WASMCompilationCache struct {
+	// Disabled specifies whether modules are compiled from scratch for every execution.
+	Disabled bool `yaml:"Disabled,omitempty" json:"Disabled,omitempty"`
+	// MaxSize specifies the maximum size of the cache on disk (e.g. 1GB). The least recently used
+	// modules are evicted when it is exceeded. Empty means the cache is not limited.
+	MaxSize string `yaml:"MaxSize,omitempty" json:"MaxSize,omitempty"`
+}
+
+// WASMHTTP represents the configuration settings for the HTTP requests made by WASM jobs
+// to the domains allowed by their network configuration.
+type WASMHTTP struct {
+	// MaxRequestSize specifies the maximum size of the headers and body of a request (e.g. 1MB).
+	MaxRequestSize string `yaml:"MaxRequestSize,omitempty" json:"MaxRequestSize,omitempty"`
+	// MaxResponseSize specifies the maximum size of the body of a response (e.g. 10MB).
+	MaxResponseSize string `yaml:"MaxResponseSize,omitempty" json:"MaxResponseSize,omitempty"`
 }
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -107,6 +108,37 @@ func initUserIDKey(path string) error {
 	return nil
 }
 
+func initNodeKey(path string) error {
+	exists, err := fileExists(path)
+	if err != nil {
+		return fmt.Errorf("failed to check node key file at path: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("node key file already exists at path: %s", path)
+	}
+
+	log.Debug().Msgf("initializing node key file at '%s'", path)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	keyBlock := pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: keyBytes,
+	}
+
+	if err = os.WriteFile(path, pem.EncodeToMemory(&keyBlock), util.OS_USER_RW); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return nil
+}
+
 func fileExists(path string) (bool, error) {
 	// Check if the file exists
 	_, err := os.Stat(path)
@@ -20,12 +20,20 @@ const ComputeAllocatedCapacityGPUKey = "Compute.AllocatedCapacity.GPU"
 const ComputeAllocatedCapacityMemoryKey = "Compute.AllocatedCapacity.Memory"
 const ComputeAllowListedLocalPathsKey = "Compute.AllowListedLocalPaths"
 const ComputeAuthTokenKey = "Compute.Auth.Token"
+const ComputeCompressionAlgorithmKey = "Compute.Compression.Algorithm"
+const ComputeCompressionThresholdKey = "Compute.Compression.Threshold"
 const ComputeEnabledKey = "Compute.Enabled"
 const ComputeEnvAllowListKey = "Compute.Env.AllowList"
+const ComputeExtendedResourcesDiscoveryCommandKey = "Compute.ExtendedResources.DiscoveryCommand"
+const ComputeExtendedResourcesResourcesKey = "Compute.ExtendedResources.Resources"
 const ComputeHeartbeatInfoUpdateIntervalKey = "Compute.Heartbeat.InfoUpdateInterval"
 const ComputeHeartbeatIntervalKey = "Compute.Heartbeat.Interval"
 const ComputeHeartbeatResourceUpdateIntervalKey = "Compute.Heartbeat.ResourceUpdateInterval"
 const ComputeOrchestratorsKey = "Compute.Orchestrators"
+const ComputeOvercommitCPUKey = "Compute.Overcommit.CPU"
+const ComputeOvercommitMemoryKey = "Compute.Overcommit.Memory"
+const ComputeOvercommitMemoryPressureThresholdKey = "Compute.Overcommit.MemoryPressureThreshold"
+const ComputeRequireSignedMessagesKey = "Compute.RequireSignedMessages"
 const ComputeTLSCACertKey = "Compute.TLS.CACert"
 const ComputeTLSRequireTLSKey = "Compute.TLS.RequireTLS"
 const DataDirKey = "DataDir"
@@ -34,11 +42,16 @@ const EnginesDisabledKey = "Engines.Disabled"
 const EnginesTypesDockerManifestCacheRefreshKey = "Engines.Types.Docker.ManifestCache.Refresh"
 const EnginesTypesDockerManifestCacheSizeKey = "Engines.Types.Docker.ManifestCache.Size"
 const EnginesTypesDockerManifestCacheTTLKey = "Engines.Types.Docker.ManifestCache.TTL"
+const EnginesTypesWASMCompilationCacheDisabledKey = "Engines.Types.WASM.CompilationCache.Disabled"
+const EnginesTypesWASMCompilationCacheMaxSizeKey = "Engines.Types.WASM.CompilationCache.MaxSize"
+const EnginesTypesWASMHTTPMaxRequestSizeKey = "Engines.Types.WASM.HTTP.MaxRequestSize"
+const EnginesTypesWASMHTTPMaxResponseSizeKey = "Engines.Types.WASM.HTTP.MaxResponseSize"
 const InputSourcesDisabledKey = "InputSources.Disabled"
 const InputSourcesMaxRetryCountKey = "InputSources.MaxRetryCount"
 const InputSourcesReadTimeoutKey = "InputSources.ReadTimeout"
 const InputSourcesTypesIPFSEndpointKey = "InputSources.Types.IPFS.Endpoint"
 const JobAdmissionControlAcceptNetworkedJobsKey = "JobAdmissionControl.AcceptNetworkedJobs"
+const JobAdmissionControlBidPolicyPathKey = "JobAdmissionControl.BidPolicyPath"
 const JobAdmissionControlLocalityKey = "JobAdmissionControl.Locality"
 const JobAdmissionControlProbeExecKey = "JobAdmissionControl.ProbeExec"
 const JobAdmissionControlProbeHTTPKey = "JobAdmissionControl.ProbeHTTP"
@@ -50,6 +63,7 @@ const JobDefaultsBatchTaskResourcesCPUKey = "JobDefaults.Batch.Task.Resources.CP
 const JobDefaultsBatchTaskResourcesDiskKey = "JobDefaults.Batch.Task.Resources.Disk"
 const JobDefaultsBatchTaskResourcesGPUKey = "JobDefaults.Batch.Task.Resources.GPU"
 const JobDefaultsBatchTaskResourcesMemoryKey = "JobDefaults.Batch.Task.Resources.Memory"
+const JobDefaultsBatchTaskTimeoutsDisconnectGracePeriodKey = "JobDefaults.Batch.Task.Timeouts.DisconnectGracePeriod"
 const JobDefaultsBatchTaskTimeoutsExecutionTimeoutKey = "JobDefaults.Batch.Task.Timeouts.ExecutionTimeout"
 const JobDefaultsBatchTaskTimeoutsTotalTimeoutKey = "JobDefaults.Batch.Task.Timeouts.TotalTimeout"
 const JobDefaultsDaemonPriorityKey = "JobDefaults.Daemon.Priority"
@@ -57,6 +71,7 @@ const JobDefaultsDaemonTaskResourcesCPUKey = "JobDefaults.Daemon.Task.Resources.
 const JobDefaultsDaemonTaskResourcesDiskKey = "JobDefaults.Daemon.Task.Resources.Disk"
 const JobDefaultsDaemonTaskResourcesGPUKey = "JobDefaults.Daemon.Task.Resources.GPU"
 const JobDefaultsDaemonTaskResourcesMemoryKey = "JobDefaults.Daemon.Task.Resources.Memory"
+const JobDefaultsDaemonTaskTimeoutsDisconnectGracePeriodKey = "JobDefaults.Daemon.Task.Timeouts.DisconnectGracePeriod"
 const JobDefaultsOpsPriorityKey = "JobDefaults.Ops.Priority"
 const JobDefaultsOpsTaskPublisherParamsKey = "JobDefaults.Ops.Task.Publisher.Params"
 const JobDefaultsOpsTaskPublisherTypeKey = "JobDefaults.Ops.Task.Publisher.Type"
@@ -64,6 +79,7 @@ const JobDefaultsOpsTaskResourcesCPUKey = "JobDefaults.Ops.Task.Resources.CPU"
 const JobDefaultsOpsTaskResourcesDiskKey = "JobDefaults.Ops.Task.Resources.Disk"
 const JobDefaultsOpsTaskResourcesGPUKey = "JobDefaults.Ops.Task.Resources.GPU"
 const JobDefaultsOpsTaskResourcesMemoryKey = "JobDefaults.Ops.Task.Resources.Memory"
+const JobDefaultsOpsTaskTimeoutsDisconnectGracePeriodKey = "JobDefaults.Ops.Task.Timeouts.DisconnectGracePeriod"
 const JobDefaultsOpsTaskTimeoutsExecutionTimeoutKey = "JobDefaults.Ops.Task.Timeouts.ExecutionTimeout"
 const JobDefaultsOpsTaskTimeoutsTotalTimeoutKey = "JobDefaults.Ops.Task.Timeouts.TotalTimeout"
 const JobDefaultsServicePriorityKey = "JobDefaults.Service.Priority"
@@ -71,10 +87,16 @@ const JobDefaultsServiceTaskResourcesCPUKey = "JobDefaults.Service.Task.Resource
 const JobDefaultsServiceTaskResourcesDiskKey = "JobDefaults.Service.Task.Resources.Disk"
 const JobDefaultsServiceTaskResourcesGPUKey = "JobDefaults.Service.Task.Resources.GPU"
 const JobDefaultsServiceTaskResourcesMemoryKey = "JobDefaults.Service.Task.Resources.Memory"
+const JobDefaultsServiceTaskTimeoutsDisconnectGracePeriodKey = "JobDefaults.Service.Task.Timeouts.DisconnectGracePeriod"
 const LabelsKey = "Labels"
 const LoggingLevelKey = "Logging.Level"
 const LoggingLogDebugInfoIntervalKey = "Logging.LogDebugInfoInterval"
 const LoggingModeKey = "Logging.Mode"
+const NATSCredentialsFileKey = "NATS.CredentialsFile"
+const NATSJWTFileKey = "NATS.JWTFile"
+const NATSNKeySeedFileKey = "NATS.NKeySeedFile"
+const NATSServersKey = "NATS.Servers"
+const NATSSubjectPrefixKey = "NATS.SubjectPrefix"
 const NameProviderKey = "NameProvider"
 const OrchestratorAdvertiseKey = "Orchestrator.Advertise"
 const OrchestratorAuthTokenKey = "Orchestrator.Auth.Token"
@@ -83,14 +105,29 @@ const OrchestratorClusterHostKey = "Orchestrator.Cluster.Host"
 const OrchestratorClusterNameKey = "Orchestrator.Cluster.Name"
 const OrchestratorClusterPeersKey = "Orchestrator.Cluster.Peers"
 const OrchestratorClusterPortKey = "Orchestrator.Cluster.Port"
+const OrchestratorCompressionAlgorithmKey = "Orchestrator.Compression.Algorithm"
+const OrchestratorCompressionThresholdKey = "Orchestrator.Compression.Threshold"
 const OrchestratorEnabledKey = "Orchestrator.Enabled"
 const OrchestratorEvaluationBrokerMaxRetryCountKey = "Orchestrator.EvaluationBroker.MaxRetryCount"
 const OrchestratorEvaluationBrokerVisibilityTimeoutKey = "Orchestrator.EvaluationBroker.VisibilityTimeout"
+const OrchestratorExecutionLogsEnabledKey = "Orchestrator.ExecutionLogs.Enabled"
+const OrchestratorExecutionLogsMaxLinesPerExecutionKey = "Orchestrator.ExecutionLogs.MaxLinesPerExecution"
+const OrchestratorExecutionLogsRetentionKey = "Orchestrator.ExecutionLogs.Retention"
 const OrchestratorHostKey = "Orchestrator.Host"
+const OrchestratorJobRetentionArchiveDirKey = "Orchestrator.JobRetention.ArchiveDir"
+const OrchestratorJobRetentionArchiveKey = "Orchestrator.JobRetention.Archive"
+const OrchestratorJobRetentionEnabledKey = "Orchestrator.JobRetention.Enabled"
+const OrchestratorJobRetentionIntervalKey = "Orchestrator.JobRetention.Interval"
+const OrchestratorJobRetentionMaxAgeKey = "Orchestrator.JobRetention.MaxAge"
+const OrchestratorJobRetentionMaxJobsPerNamespaceKey = "Orchestrator.JobRetention.MaxJobsPerNamespace"
+const OrchestratorJobStoreTypeKey = "Orchestrator.JobStore.Type"
 const OrchestratorLicenseLocalPathKey = "Orchestrator.License.LocalPath"
 const OrchestratorNodeManagerDisconnectTimeoutKey = "Orchestrator.NodeManager.DisconnectTimeout"
 const OrchestratorNodeManagerManualApprovalKey = "Orchestrator.NodeManager.ManualApproval"
 const OrchestratorPortKey = "Orchestrator.Port"
+const OrchestratorRequireSignedMessagesKey = "Orchestrator.RequireSignedMessages"
+const OrchestratorSchedulerBidOverAskKey = "Orchestrator.Scheduler.BidOverAsk"
+const OrchestratorSchedulerBidTimeoutKey = "Orchestrator.Scheduler.BidTimeout"
 const OrchestratorSchedulerHousekeepingIntervalKey = "Orchestrator.Scheduler.HousekeepingInterval"
 const OrchestratorSchedulerHousekeepingTimeoutKey = "Orchestrator.Scheduler.HousekeepingTimeout"
 const OrchestratorSchedulerQueueBackoffKey = "Orchestrator.Scheduler.QueueBackoff"
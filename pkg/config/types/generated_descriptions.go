@@ -4,79 +4,101 @@ package types
 
 // ConfigDescriptions maps configuration paths to their descriptions
 var ConfigDescriptions = map[string]string{
-	APIAuthAccessPolicyPathKey:                       "AccessPolicyPath is the path to a file or directory that will be loaded as the policy to apply to all inbound API requests. If unspecified, a policy that permits access to all API endpoints to both authenticated and unauthenticated users (the default as of v1.2.0) will be used.",
-	APIAuthMethodsKey:                                "Methods maps \"method names\" to authenticator implementations. A method name is a human-readable string chosen by the person configuring the system that is shown to users to help them pick the authentication method they want to use. There can be multiple usages of the same Authenticator *type* but with different configs and parameters, each identified with a unique method name.  For example, if an implementation wants to allow users to log in with Github or Bitbucket, they might both use an authenticator implementation of type \"oidc\", and each would appear once on this provider with key / method name \"github\" and \"bitbucket\".  By default, only a single authentication method that accepts authentication via client keys will be enabled.",
-	APIHostKey:                                       "Host specifies the hostname or IP address on which the API server listens or the client connects.",
-	APIPortKey:                                       "Port specifies the port number on which the API server listens or the client connects.",
-	APITLSAutoCertKey:                                "AutoCert specifies the domain for automatic certificate generation.",
-	APITLSAutoCertCachePathKey:                       "AutoCertCachePath specifies the directory to cache auto-generated certificates.",
-	APITLSCAFileKey:                                  "CAFile specifies the path to the Certificate Authority file.",
-	APITLSCertFileKey:                                "CertFile specifies the path to the TLS certificate file.",
-	APITLSInsecureKey:                                "Insecure allows insecure TLS connections (e.g., self-signed certificates).",
-	APITLSKeyFileKey:                                 "KeyFile specifies the path to the TLS private key file.",
-	APITLSSelfSignedKey:                              "SelfSigned indicates whether to use a self-signed certificate.",
-	APITLSUseTLSKey:                                  "UseTLS indicates whether to use TLS for client connections.",
-	ComputeAllocatedCapacityCPUKey:                   "CPU specifies the amount of CPU a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"100m\").",
-	ComputeAllocatedCapacityDiskKey:                  "Disk specifies the amount of Disk space a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"10Gi\").",
-	ComputeAllocatedCapacityGPUKey:                   "GPU specifies the amount of GPU a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"1\"). Note: When using percentages, the result is always rounded up to the nearest whole GPU.",
-	ComputeAllocatedCapacityMemoryKey:                "Memory specifies the amount of Memory a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"1Gi\").",
-	ComputeAllowListedLocalPathsKey:                  "AllowListedLocalPaths specifies a list of local file system paths that the compute node is allowed to access.",
-	ComputeAuthTokenKey:                              "Token specifies the key for compute nodes to be able to access the orchestrator.",
-	ComputeEnabledKey:                                "Enabled indicates whether the compute node is active and available for job execution.",
-	ComputeEnvAllowListKey:                           "AllowList specifies which host environment variables can be forwarded to jobs. Supports glob patterns (e.g., \"AWS_*\", \"API_*\")",
-	ComputeHeartbeatInfoUpdateIntervalKey:            "InfoUpdateInterval specifies the time between updates of non-resource information to the orchestrator.",
-	ComputeHeartbeatIntervalKey:                      "Interval specifies the time between heartbeat signals sent to the orchestrator.",
-	ComputeHeartbeatResourceUpdateIntervalKey:        "Deprecated: use Interval instead",
-	ComputeOrchestratorsKey:                          "Orchestrators specifies a list of orchestrator endpoints that this compute node connects to.",
-	ComputeTLSCACertKey:                              "CACert specifies the CA file path that the compute node trusts when connecting to orchestrator.",
-	ComputeTLSRequireTLSKey:                          "RequireTLS specifies if the compute node enforces encrypted communication with orchestrator.",
-	DataDirKey:                                       "DataDir specifies a location on disk where the bacalhau node will maintain state.",
-	DisableAnalyticsKey:                              "DisableAnalytics, when true, disables sharing anonymous analytics data with the Bacalhau development team",
-	EnginesDisabledKey:                               "Disabled specifies a list of engines that are disabled.",
-	EnginesTypesDockerManifestCacheRefreshKey:        "Refresh specifies the refresh interval for cache entries.",
-	EnginesTypesDockerManifestCacheSizeKey:           "Size specifies the size of the Docker manifest cache.",
-	EnginesTypesDockerManifestCacheTTLKey:            "TTL specifies the time-to-live duration for cache entries.",
-	InputSourcesDisabledKey:                          "Disabled specifies a list of storages that are disabled.",
-	InputSourcesMaxRetryCountKey:                     "ReadTimeout specifies the maximum number of attempts for reading from a storage.",
-	InputSourcesReadTimeoutKey:                       "ReadTimeout specifies the maximum time allowed for reading from a storage.",
-	InputSourcesTypesIPFSEndpointKey:                 "Endpoint specifies the multi-address to connect to for IPFS. e.g /ip4/127.0.0.1/tcp/5001",
-	JobAdmissionControlAcceptNetworkedJobsKey:        "AcceptNetworkedJobs indicates whether to accept jobs that require network access.",
-	JobAdmissionControlLocalityKey:                   "Locality specifies the locality of the job input data.",
-	JobAdmissionControlProbeExecKey:                  "ProbeExec specifies the command to execute for probing job submission.",
-	JobAdmissionControlProbeHTTPKey:                  "ProbeHTTP specifies the HTTP endpoint for probing job submission.",
-	JobAdmissionControlRejectStatelessJobsKey:        "RejectStatelessJobs indicates whether to reject stateless jobs, i.e. jobs without inputs.",
-	JobDefaultsBatchPriorityKey:                      "Priority specifies the default priority allocated to a batch or ops job. This value is used when the job hasn't explicitly set its priority requirement.",
-	JobDefaultsBatchTaskPublisherParamsKey:           "Params specifies the publisher configuration data.",
-	JobDefaultsBatchTaskPublisherTypeKey:             "Type specifies the publisher type. e.g. \"s3\", \"local\", \"ipfs\", etc.",
-	JobDefaultsBatchTaskResourcesCPUKey:              "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
-	JobDefaultsBatchTaskResourcesDiskKey:             "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
-	JobDefaultsBatchTaskResourcesGPUKey:              "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
-	JobDefaultsBatchTaskResourcesMemoryKey:           "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
-	JobDefaultsBatchTaskTimeoutsExecutionTimeoutKey:  "ExecutionTimeout is the maximum time allowed for task execution",
-	JobDefaultsBatchTaskTimeoutsTotalTimeoutKey:      "TotalTimeout is the maximum total time allowed for a task",
-	JobDefaultsDaemonPriorityKey:                     "Priority specifies the default priority allocated to a service or daemon job. This value is used when the job hasn't explicitly set its priority requirement.",
-	JobDefaultsDaemonTaskResourcesCPUKey:             "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
-	JobDefaultsDaemonTaskResourcesDiskKey:            "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
-	JobDefaultsDaemonTaskResourcesGPUKey:             "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
-	JobDefaultsDaemonTaskResourcesMemoryKey:          "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
-	JobDefaultsOpsPriorityKey:                        "Priority specifies the default priority allocated to a batch or ops job. This value is used when the job hasn't explicitly set its priority requirement.",
-	JobDefaultsOpsTaskPublisherParamsKey:             "Params specifies the publisher configuration data.",
-	JobDefaultsOpsTaskPublisherTypeKey:               "Type specifies the publisher type. e.g. \"s3\", \"local\", \"ipfs\", etc.",
-	JobDefaultsOpsTaskResourcesCPUKey:                "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
-	JobDefaultsOpsTaskResourcesDiskKey:               "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
-	JobDefaultsOpsTaskResourcesGPUKey:                "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
-	JobDefaultsOpsTaskResourcesMemoryKey:             "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
-	JobDefaultsOpsTaskTimeoutsExecutionTimeoutKey:    "ExecutionTimeout is the maximum time allowed for task execution",
-	JobDefaultsOpsTaskTimeoutsTotalTimeoutKey:        "TotalTimeout is the maximum total time allowed for a task",
-	JobDefaultsServicePriorityKey:                    "Priority specifies the default priority allocated to a service or daemon job. This value is used when the job hasn't explicitly set its priority requirement.",
-	JobDefaultsServiceTaskResourcesCPUKey:            "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
-	JobDefaultsServiceTaskResourcesDiskKey:           "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
-	JobDefaultsServiceTaskResourcesGPUKey:            "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
-	JobDefaultsServiceTaskResourcesMemoryKey:         "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
+	APIAuthAccessPolicyPathKey:                             "AccessPolicyPath is the path to a file or directory that will be loaded as the policy to apply to all inbound API requests. If unspecified, a policy that permits access to all API endpoints to both authenticated and unauthenticated users (the default as of v1.2.0) will be used.",
+	APIAuthMethodsKey:                                      "Methods maps \"method names\" to authenticator implementations. A method name is a human-readable string chosen by the person configuring the system that is shown to users to help them pick the authentication method they want to use. There can be multiple usages of the same Authenticator *type* but with different configs and parameters, each identified with a unique method name.  For example, if an implementation wants to allow users to log in with Github or Bitbucket, they might both use an authenticator implementation of type \"oidc\", and each would appear once on this provider with key / method name \"github\" and \"bitbucket\".  By default, only a single authentication method that accepts authentication via client keys will be enabled.",
+	APIHostKey:                                             "Host specifies the hostname or IP address on which the API server listens or the client connects.",
+	APIPortKey:                                             "Port specifies the port number on which the API server listens or the client connects.",
+	APITLSAutoCertKey:                                      "AutoCert specifies the domain for automatic certificate generation.",
+	APITLSAutoCertCachePathKey:                             "AutoCertCachePath specifies the directory to cache auto-generated certificates.",
+	APITLSCAFileKey:                                        "CAFile specifies the path to the Certificate Authority file.",
+	APITLSCertFileKey:                                      "CertFile specifies the path to the TLS certificate file.",
+	APITLSInsecureKey:                                      "Insecure allows insecure TLS connections (e.g., self-signed certificates).",
+	APITLSKeyFileKey:                                       "KeyFile specifies the path to the TLS private key file.",
+	APITLSSelfSignedKey:                                    "SelfSigned indicates whether to use a self-signed certificate.",
+	APITLSUseTLSKey:                                        "UseTLS indicates whether to use TLS for client connections.",
+	ComputeAllocatedCapacityCPUKey:                         "CPU specifies the amount of CPU a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"100m\").",
+	ComputeAllocatedCapacityDiskKey:                        "Disk specifies the amount of Disk space a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"10Gi\").",
+	ComputeAllocatedCapacityGPUKey:                         "GPU specifies the amount of GPU a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"1\"). Note: When using percentages, the result is always rounded up to the nearest whole GPU.",
+	ComputeAllocatedCapacityMemoryKey:                      "Memory specifies the amount of Memory a compute node allocates for running jobs. It can be expressed as a percentage (e.g., \"85%\") or a Kubernetes resource string (e.g., \"1Gi\").",
+	ComputeAllowListedLocalPathsKey:                        "AllowListedLocalPaths specifies a list of local file system paths that the compute node is allowed to access.",
+	ComputeAuthTokenKey:                                    "Token specifies the key for compute nodes to be able to access the orchestrator.",
+	ComputeCompressionAlgorithmKey:                         "Algorithm specifies the compression algorithm of messages sent to nodes that support it, either zstd, gzip or none. Messages sent to older nodes are never compressed.",
+	ComputeCompressionThresholdKey:                         "Threshold specifies the minimum size in bytes of a message before it is compressed.",
+	ComputeEnabledKey:                                      "Enabled indicates whether the compute node is active and available for job execution.",
+	ComputeEnvAllowListKey:                                 "AllowList specifies which host environment variables can be forwarded to jobs. Supports glob patterns (e.g., \"AWS_*\", \"API_*\")",
+	ComputeExtendedResourcesDiscoveryCommandKey:            "DiscoveryCommand is a shell command printing the number of units of extended resources as name=N lines when the node starts, overriding the configured Resources.",
+	ComputeExtendedResourcesResourcesKey:                   "Resources is the number of units of each extended resource, by name.",
+	ComputeHeartbeatInfoUpdateIntervalKey:                  "InfoUpdateInterval specifies the time between updates of non-resource information to the orchestrator.",
+	ComputeHeartbeatIntervalKey:                            "Interval specifies the time between heartbeat signals sent to the orchestrator.",
+	ComputeHeartbeatResourceUpdateIntervalKey:              "Deprecated: use Interval instead",
+	ComputeOrchestratorsKey:                                "Orchestrators specifies a list of orchestrator endpoints that this compute node connects to.",
+	ComputeOvercommitCPUKey:                                "CPU is the ratio of CPU that executions can reserve to the allocated CPU, e.g. 2.0. Ratios below 1 mean CPU is not overcommitted.",
+	ComputeOvercommitMemoryKey:                             "Memory is the ratio of memory that executions can reserve to the allocated memory, e.g. 1.2. Ratios below 1 mean memory is not overcommitted.",
+	ComputeOvercommitMemoryPressureThresholdKey:            "MemoryPressureThreshold is the fraction of the host memory in use, between 0 and 1, above which the compute node stops bidding on new jobs. Zero disables the threshold.",
+	ComputeRequireSignedMessagesKey:                        "RequireSignedMessages rejects orchestrators that don't sign their messages, such as older versions.",
+	ComputeTLSCACertKey:                                    "CACert specifies the CA file path that the compute node trusts when connecting to orchestrator.",
+	ComputeTLSRequireTLSKey:                                "RequireTLS specifies if the compute node enforces encrypted communication with orchestrator.",
+	DataDirKey:                                             "DataDir specifies a location on disk where the bacalhau node will maintain state.",
+	DisableAnalyticsKey:                                    "DisableAnalytics, when true, disables sharing anonymous analytics data with the Bacalhau development team",
+	EnginesDisabledKey:                                     "Disabled specifies a list of engines that are disabled.",
+	EnginesTypesDockerManifestCacheRefreshKey:              "Refresh specifies the refresh interval for cache entries.",
+	EnginesTypesDockerManifestCacheSizeKey:                 "Size specifies the size of the Docker manifest cache.",
+	EnginesTypesDockerManifestCacheTTLKey:                  "TTL specifies the time-to-live duration for cache entries.",
+	EnginesTypesWASMCompilationCacheDisabledKey:            "Disabled specifies whether modules are compiled from scratch for every execution.",
+	EnginesTypesWASMCompilationCacheMaxSizeKey:             "MaxSize specifies the maximum size of the cache on disk (e.g. 1GB). The least recently used modules are evicted when it is exceeded. Empty means the cache is not limited.",
+	EnginesTypesWASMHTTPMaxRequestSizeKey:                  "MaxRequestSize specifies the maximum size of the headers and body of a request (e.g. 1MB).",
+	EnginesTypesWASMHTTPMaxResponseSizeKey:                 "MaxResponseSize specifies the maximum size of the body of a response (e.g. 10MB).",
+	InputSourcesDisabledKey:                                "Disabled specifies a list of storages that are disabled.",
+	InputSourcesMaxRetryCountKey:                           "ReadTimeout specifies the maximum number of attempts for reading from a storage.",
+	InputSourcesReadTimeoutKey:                             "ReadTimeout specifies the maximum time allowed for reading from a storage.",
+	InputSourcesTypesIPFSEndpointKey:                       "Endpoint specifies the multi-address to connect to for IPFS. e.g /ip4/127.0.0.1/tcp/5001",
+	JobAdmissionControlAcceptNetworkedJobsKey:              "AcceptNetworkedJobs indicates whether to accept jobs that require network access.",
+	JobAdmissionControlBidPolicyPathKey:                    "BidPolicyPath specifies a Rego policy file or directory deciding whether to bid on jobs. The policy is reloaded when its files change.",
+	JobAdmissionControlLocalityKey:                         "Locality specifies the locality of the job input data.",
+	JobAdmissionControlProbeExecKey:                        "ProbeExec specifies the command to execute for probing job submission.",
+	JobAdmissionControlProbeHTTPKey:                        "ProbeHTTP specifies the HTTP endpoint for probing job submission.",
+	JobAdmissionControlRejectStatelessJobsKey:              "RejectStatelessJobs indicates whether to reject stateless jobs, i.e. jobs without inputs.",
+	JobDefaultsBatchPriorityKey:                            "Priority specifies the default priority allocated to a batch or ops job. This value is used when the job hasn't explicitly set its priority requirement.",
+	JobDefaultsBatchTaskPublisherParamsKey:                 "Params specifies the publisher configuration data.",
+	JobDefaultsBatchTaskPublisherTypeKey:                   "Type specifies the publisher type. e.g. \"s3\", \"local\", \"ipfs\", etc.",
+	JobDefaultsBatchTaskResourcesCPUKey:                    "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
+	JobDefaultsBatchTaskResourcesDiskKey:                   "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
+	JobDefaultsBatchTaskResourcesGPUKey:                    "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
+	JobDefaultsBatchTaskResourcesMemoryKey:                 "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
+	JobDefaultsBatchTaskTimeoutsDisconnectGracePeriodKey:   "DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect before its executions are considered lost and rescheduled. Zero means executions are not rescheduled while their node is disconnected.",
+	JobDefaultsBatchTaskTimeoutsExecutionTimeoutKey:        "ExecutionTimeout is the maximum time allowed for task execution",
+	JobDefaultsBatchTaskTimeoutsTotalTimeoutKey:            "TotalTimeout is the maximum total time allowed for a task",
+	JobDefaultsDaemonPriorityKey:                           "Priority specifies the default priority allocated to a service or daemon job. This value is used when the job hasn't explicitly set its priority requirement.",
+	JobDefaultsDaemonTaskResourcesCPUKey:                   "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
+	JobDefaultsDaemonTaskResourcesDiskKey:                  "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
+	JobDefaultsDaemonTaskResourcesGPUKey:                   "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
+	JobDefaultsDaemonTaskResourcesMemoryKey:                "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
+	JobDefaultsDaemonTaskTimeoutsDisconnectGracePeriodKey:  "DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect before its executions are considered lost and rescheduled. Zero means executions are not rescheduled while their node is disconnected.",
+	JobDefaultsOpsPriorityKey:                              "Priority specifies the default priority allocated to a batch or ops job. This value is used when the job hasn't explicitly set its priority requirement.",
+	JobDefaultsOpsTaskPublisherParamsKey:                   "Params specifies the publisher configuration data.",
+	JobDefaultsOpsTaskPublisherTypeKey:                     "Type specifies the publisher type. e.g. \"s3\", \"local\", \"ipfs\", etc.",
+	JobDefaultsOpsTaskResourcesCPUKey:                      "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
+	JobDefaultsOpsTaskResourcesDiskKey:                     "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
+	JobDefaultsOpsTaskResourcesGPUKey:                      "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
+	JobDefaultsOpsTaskResourcesMemoryKey:                   "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
+	JobDefaultsOpsTaskTimeoutsDisconnectGracePeriodKey:     "DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect before its executions are considered lost and rescheduled. Zero means executions are not rescheduled while their node is disconnected.",
+	JobDefaultsOpsTaskTimeoutsExecutionTimeoutKey:          "ExecutionTimeout is the maximum time allowed for task execution",
+	JobDefaultsOpsTaskTimeoutsTotalTimeoutKey:              "TotalTimeout is the maximum total time allowed for a task",
+	JobDefaultsServicePriorityKey:                          "Priority specifies the default priority allocated to a service or daemon job. This value is used when the job hasn't explicitly set its priority requirement.",
+	JobDefaultsServiceTaskResourcesCPUKey:                  "CPU specifies the default amount of CPU allocated to a task. It uses Kubernetes resource string format (e.g., \"100m\" for 0.1 CPU cores). This value is used when the task hasn't explicitly set its CPU requirement.",
+	JobDefaultsServiceTaskResourcesDiskKey:                 "Disk specifies the default amount of disk space allocated to a task. It uses Kubernetes resource string format (e.g., \"1Gi\" for 1 gibibyte). This value is used when the task hasn't explicitly set its disk space requirement.",
+	JobDefaultsServiceTaskResourcesGPUKey:                  "GPU specifies the default number of GPUs allocated to a task. It uses Kubernetes resource string format (e.g., \"1\" for 1 GPU). This value is used when the task hasn't explicitly set its GPU requirement.",
+	JobDefaultsServiceTaskResourcesMemoryKey:               "Memory specifies the default amount of memory allocated to a task. It uses Kubernetes resource string format (e.g., \"256Mi\" for 256 mebibytes). This value is used when the task hasn't explicitly set its memory requirement.",
+	JobDefaultsServiceTaskTimeoutsDisconnectGracePeriodKey: "DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect before its executions are considered lost and rescheduled. Zero means executions are not rescheduled while their node is disconnected.",
 	LabelsKey:                                        "Labels are key-value pairs used to describe and categorize the nodes.",
 	LoggingLevelKey:                                  "Level sets the logging level. One of: trace, debug, info, warn, error, fatal, panic.",
 	LoggingLogDebugInfoIntervalKey:                   "LogDebugInfoInterval specifies the interval for logging debug information.",
 	LoggingModeKey:                                   "Mode specifies the logging mode. One of: default, json.",
+	NATSCredentialsFileKey:                           "CredentialsFile specifies a NATS credentials file containing a user JWT and nkey seed.",
+	NATSJWTFileKey:                                   "JWTFile specifies a file containing a user JWT, used with the nkey seed in NKeySeedFile.",
+	NATSNKeySeedFileKey:                              "NKeySeedFile specifies a file containing the nkey seed used to authenticate to NATS.",
+	NATSServersKey:                                   "Servers specifies the URLs of an external NATS cluster that orchestrator and compute nodes connect to. When set, orchestrators don't embed a NATS server and compute nodes ignore Compute.Orchestrators.",
+	NATSSubjectPrefixKey:                             "SubjectPrefix specifies the prefix of the NATS subjects and key-value buckets used by the cluster, allowing multiple clusters to share a NATS cluster. Defaults to bacalhau.global.",
 	NameProviderKey:                                  "NameProvider specifies the method used to generate names for the node. One of: hostname, aws, gcp, uuid, puuid.",
 	OrchestratorAdvertiseKey:                         "Advertise specifies URL to advertise to other servers.",
 	OrchestratorAuthTokenKey:                         "Token specifies the key for compute nodes to be able to access the orchestrator",
@@ -85,14 +107,29 @@ var ConfigDescriptions = map[string]string{
 	OrchestratorClusterNameKey:                       "Name specifies the unique identifier for this orchestrator cluster.",
 	OrchestratorClusterPeersKey:                      "Peers is a list of other cluster members to connect to on startup.",
 	OrchestratorClusterPortKey:                       "Port specifies the port number for cluster communication.",
+	OrchestratorCompressionAlgorithmKey:              "Algorithm specifies the compression algorithm of messages sent to nodes that support it, either zstd, gzip or none. Messages sent to older nodes are never compressed.",
+	OrchestratorCompressionThresholdKey:              "Threshold specifies the minimum size in bytes of a message before it is compressed.",
 	OrchestratorEnabledKey:                           "Enabled indicates whether the orchestrator node is active and available for job submission.",
 	OrchestratorEvaluationBrokerMaxRetryCountKey:     "MaxRetryCount specifies the maximum number of times an evaluation can be retried before being marked as failed.",
 	OrchestratorEvaluationBrokerVisibilityTimeoutKey: "VisibilityTimeout specifies how long an evaluation can be claimed before it's returned to the queue.",
+	OrchestratorExecutionLogsEnabledKey:              "Enabled indicates whether the orchestrator retains the execution logs forwarded by compute nodes.",
+	OrchestratorExecutionLogsMaxLinesPerExecutionKey: "MaxLinesPerExecution specifies the maximum number of lines retained per execution.",
+	OrchestratorExecutionLogsRetentionKey:            "Retention specifies how long logs are retained after they were last written.",
 	OrchestratorHostKey:                              "Host specifies the hostname or IP address on which the Orchestrator server listens for compute node connections.",
+	OrchestratorJobRetentionArchiveDirKey:            "ArchiveDir specifies the directory jobs are archived to. Defaults to a directory within the orchestrator data directory.",
+	OrchestratorJobRetentionArchiveKey:               "Archive indicates whether purged jobs are first exported to compressed JSON lines files.",
+	OrchestratorJobRetentionEnabledKey:               "Enabled indicates whether terminal jobs are periodically purged from the job store.",
+	OrchestratorJobRetentionIntervalKey:              "Interval specifies how often the retention policy is applied.",
+	OrchestratorJobRetentionMaxAgeKey:                "MaxAge specifies how long terminal jobs are retained after they were last modified. Zero disables age-based purging.",
+	OrchestratorJobRetentionMaxJobsPerNamespaceKey:   "MaxJobsPerNamespace specifies the number of most recent terminal jobs retained per namespace. Zero disables count-based purging.",
+	OrchestratorJobStoreTypeKey:                      "Type specifies the database backing the job store, either BoltDB or SQLite.",
 	OrchestratorLicenseLocalPathKey:                  "LocalPath specifies the local license file path",
 	OrchestratorNodeManagerDisconnectTimeoutKey:      "DisconnectTimeout specifies how long to wait before considering a node disconnected.",
 	OrchestratorNodeManagerManualApprovalKey:         "ManualApproval, if true, requires manual approval for new compute nodes joining the cluster.",
 	OrchestratorPortKey:                              "Host specifies the port number on which the Orchestrator server listens for compute node connections.",
+	OrchestratorRequireSignedMessagesKey:             "RequireSignedMessages rejects compute nodes that don't sign their messages, such as older versions.",
+	OrchestratorSchedulerBidOverAskKey:               "BidOverAsk specifies how many additional nodes are asked to bid in parallel for each partition of a job. The first accepted bid is approved and the other nodes are sent a bid rejection.",
+	OrchestratorSchedulerBidTimeoutKey:               "BidTimeout specifies how long to wait for a compute node to respond to a bid request before offering the execution to the next ranked node. Zero disables bid timeouts.",
 	OrchestratorSchedulerHousekeepingIntervalKey:     "HousekeepingInterval specifies how often to run housekeeping tasks.",
 	OrchestratorSchedulerHousekeepingTimeoutKey:      "HousekeepingTimeout specifies the maximum time allowed for a single housekeeping run.",
 	OrchestratorSchedulerQueueBackoffKey:             "QueueBackoff specifies the time to wait before retrying a failed job.",
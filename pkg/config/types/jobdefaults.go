@@ -83,6 +83,10 @@ type TaskTimeoutConfig struct {
 	TotalTimeout Duration `yaml:"TotalTimeout,omitempty" json:"TotalTimeout,omitempty"`
 	// ExecutionTimeout is the maximum time allowed for task execution
 	ExecutionTimeout Duration `yaml:"ExecutionTimeout,omitempty" json:"ExecutionTimeout,omitempty"`
+	// DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect
+	// before its executions are considered lost and rescheduled. Zero means executions are not
+	// rescheduled while their node is disconnected.
+	DisconnectGracePeriod Duration `yaml:"DisconnectGracePeriod,omitempty" json:"DisconnectGracePeriod,omitempty"`
 }
 
 type LongRunningJobDefaultsConfig struct {
@@ -93,5 +97,13 @@ type LongRunningJobDefaultsConfig struct {
 }
 
 type LongRunningTaskDefaultConfig struct {
-	Resources ResourcesConfig `yaml:"Resources,omitempty" json:"Resources,omitempty"`
+	Resources ResourcesConfig              `yaml:"Resources,omitempty" json:"Resources,omitempty"`
+	Timeouts  LongRunningTaskTimeoutConfig `yaml:"Timeouts,omitempty" json:"Timeouts,omitempty"`
+}
+
+type LongRunningTaskTimeoutConfig struct {
+	// DisconnectGracePeriod is the time to wait for a disconnected compute node to reconnect
+	// before its executions are considered lost and rescheduled. Zero means executions are not
+	// rescheduled while their node is disconnected.
+	DisconnectGracePeriod Duration `yaml:"DisconnectGracePeriod,omitempty" json:"DisconnectGracePeriod,omitempty"`
 }
@@ -0,0 +1,18 @@
+package types
+
+// NATS configures orchestrator and compute nodes to connect to an external NATS cluster
+// instead of the NATS server embedded in orchestrators.
+type NATS struct {
+	// Servers specifies the URLs of an external NATS cluster that orchestrator and compute nodes connect to.
+	// When set, orchestrators don't embed a NATS server and compute nodes ignore Compute.Orchestrators.
+	Servers []string `yaml:"Servers,omitempty" json:"Servers,omitempty"`
+	// CredentialsFile specifies a NATS credentials file containing a user JWT and nkey seed.
+	CredentialsFile string `yaml:"CredentialsFile,omitempty" json:"CredentialsFile,omitempty"`
+	// JWTFile specifies a file containing a user JWT, used with the nkey seed in NKeySeedFile.
+	JWTFile string `yaml:"JWTFile,omitempty" json:"JWTFile,omitempty"`
+	// NKeySeedFile specifies a file containing the nkey seed used to authenticate to NATS.
+	NKeySeedFile string `yaml:"NKeySeedFile,omitempty" json:"NKeySeedFile,omitempty"`
+	// SubjectPrefix specifies the prefix of the NATS subjects and key-value buckets used by the cluster,
+	// allowing multiple clusters to share a NATS cluster. Defaults to bacalhau.global.
+	SubjectPrefix string `yaml:"SubjectPrefix,omitempty" json:"SubjectPrefix,omitempty"`
+}
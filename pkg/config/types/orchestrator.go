@@ -21,6 +21,16 @@ type Orchestrator struct {
 	SupportReverseProxy bool `yaml:"SupportReverseProxy,omitempty" json:"SupportReverseProxy,omitempty"`
 	// License specifies license configuration for orchestrator node
 	License License `yaml:"License,omitempty" json:"License,omitempty"`
+	// ExecutionLogs specifies how execution logs shipped to the orchestrator are retained.
+	ExecutionLogs ExecutionLogs `yaml:"ExecutionLogs,omitempty" json:"ExecutionLogs,omitempty"`
+	// JobRetention specifies how terminal jobs are purged from the job store.
+	JobRetention JobRetention `yaml:"JobRetention,omitempty" json:"JobRetention,omitempty"`
+	// JobStore specifies the database jobs, executions and their history are stored in.
+	JobStore JobStore `yaml:"JobStore,omitempty" json:"JobStore,omitempty"`
+	// Compression specifies how messages sent to compute nodes are compressed.
+	Compression MessageCompression `yaml:"Compression,omitempty" json:"Compression,omitempty"`
+	// RequireSignedMessages rejects compute nodes that don't sign their messages, such as older versions.
+	RequireSignedMessages bool `yaml:"RequireSignedMessages,omitempty" json:"RequireSignedMessages,omitempty"`
 }
 
 type OrchestratorAuth struct {
@@ -70,6 +80,12 @@ type Scheduler struct {
 	HousekeepingInterval Duration `yaml:"HousekeepingInterval,omitempty" json:"HousekeepingInterval,omitempty"`
 	// HousekeepingTimeout specifies the maximum time allowed for a single housekeeping run.
 	HousekeepingTimeout Duration `yaml:"HousekeepingTimeout,omitempty" json:"HousekeepingTimeout,omitempty"`
+	// BidTimeout specifies how long to wait for a compute node to respond to a bid request
+	// before offering the execution to the next ranked node. Zero disables bid timeouts.
+	BidTimeout Duration `yaml:"BidTimeout,omitempty" json:"BidTimeout,omitempty"`
+	// BidOverAsk specifies how many additional nodes are asked to bid in parallel for each partition of a job.
+	// The first accepted bid is approved and the other nodes are sent a bid rejection.
+	BidOverAsk int `yaml:"BidOverAsk,omitempty" json:"BidOverAsk,omitempty"`
 }
 
 type EvaluationBroker struct {
@@ -83,3 +99,37 @@ type License struct {
 	// LocalPath specifies the local license file path
 	LocalPath string `yaml:"LocalPath,omitempty" json:"LocalPath,omitempty"`
 }
+
+type ExecutionLogs struct {
+	// Enabled indicates whether the orchestrator retains the execution logs forwarded by compute nodes.
+	Enabled bool `yaml:"Enabled,omitempty" json:"Enabled,omitempty"`
+	// Retention specifies how long logs are retained after they were last written.
+	Retention Duration `yaml:"Retention,omitempty" json:"Retention,omitempty"`
+	// MaxLinesPerExecution specifies the maximum number of lines retained per execution.
+	MaxLinesPerExecution int `yaml:"MaxLinesPerExecution,omitempty" json:"MaxLinesPerExecution,omitempty"`
+}
+
+type JobRetention struct {
+	// Enabled indicates whether terminal jobs are periodically purged from the job store.
+	Enabled bool `yaml:"Enabled,omitempty" json:"Enabled,omitempty"`
+	// Interval specifies how often the retention policy is applied.
+	Interval Duration `yaml:"Interval,omitempty" json:"Interval,omitempty"`
+	// MaxAge specifies how long terminal jobs are retained after they were last modified. Zero disables age-based purging.
+	MaxAge Duration `yaml:"MaxAge,omitempty" json:"MaxAge,omitempty"`
+	// MaxJobsPerNamespace specifies the number of most recent terminal jobs retained per namespace. Zero disables count-based purging.
+	MaxJobsPerNamespace int `yaml:"MaxJobsPerNamespace,omitempty" json:"MaxJobsPerNamespace,omitempty"`
+	// Archive indicates whether purged jobs are first exported to compressed JSON lines files.
+	Archive bool `yaml:"Archive,omitempty" json:"Archive,omitempty"`
+	// ArchiveDir specifies the directory jobs are archived to. Defaults to a directory within the orchestrator data directory.
+	ArchiveDir string `yaml:"ArchiveDir,omitempty" json:"ArchiveDir,omitempty"`
+}
+
+const (
+	JobStoreTypeBoltDB = "BoltDB"
+	JobStoreTypeSQLite = "SQLite"
+)
+
+type JobStore struct {
+	// Type specifies the database backing the job store, either BoltDB or SQLite.
+	Type string `yaml:"Type,omitempty" json:"Type,omitempty"`
+}
@@ -23,6 +23,26 @@ func (b Bacalhau) UserKeyPath() (string, error) {
 	return path, nil
 }
 
+const NodeKeyFileName = "node_key.pem"
+
+// NodeKeyPath returns the path of the ed25519 key signing messages exchanged
+// between orchestrator and compute nodes, creating the key if it doesn't exist.
+func (b Bacalhau) NodeKeyPath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	path := filepath.Join(b.DataDir, NodeKeyFileName)
+	if exists, err := fileExists(path); err != nil {
+		return "", fmt.Errorf("checking if node key exists: %w", err)
+	} else if exists {
+		return path, nil
+	}
+	if err := initNodeKey(path); err != nil {
+		return "", fmt.Errorf("creating node private key: %w", err)
+	}
+	return path, nil
+}
+
 const AuthTokensFileName = "tokens.json"
 
 func (b Bacalhau) AuthTokensPath() (string, error) {
@@ -58,6 +78,87 @@ func (b Bacalhau) JobStoreFilePath() (string, error) {
 	return filepath.Join(b.DataDir, OrchestratorDirName, JobStoreFileName), nil
 }
 
+const JobStoreSQLiteFileName = "state_sqlite.db"
+
+func (b Bacalhau) JobStoreSQLiteFilePath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	// make sure the parent dir exists first
+	if _, err := b.OrchestratorDir(); err != nil {
+		return "", fmt.Errorf("getting job store path: %w", err)
+	}
+	return filepath.Join(b.DataDir, OrchestratorDirName, JobStoreSQLiteFileName), nil
+}
+
+const RestoredNodeStatesFileName = "restored_nodes.json"
+
+// RestoredNodeStatesFilePath returns the path node states are restored to from a backup,
+// to be imported into the node store when the orchestrator starts.
+func (b Bacalhau) RestoredNodeStatesFilePath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	// make sure the parent dir exists first
+	if _, err := b.OrchestratorDir(); err != nil {
+		return "", fmt.Errorf("getting restored node states path: %w", err)
+	}
+	return filepath.Join(b.DataDir, OrchestratorDirName, RestoredNodeStatesFileName), nil
+}
+
+const ExecutionLogsFileName = "execution_logs.db"
+
+func (b Bacalhau) ExecutionLogsFilePath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	// make sure the parent dir exists first
+	if _, err := b.OrchestratorDir(); err != nil {
+		return "", fmt.Errorf("getting execution logs path: %w", err)
+	}
+	return filepath.Join(b.DataDir, OrchestratorDirName, ExecutionLogsFileName), nil
+}
+
+const UsageFileName = "usage.db"
+
+// UsageFilePath returns the path of the database accounting the resource usage of executions.
+func (b Bacalhau) UsageFilePath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	// make sure the parent dir exists first
+	if _, err := b.OrchestratorDir(); err != nil {
+		return "", fmt.Errorf("getting usage path: %w", err)
+	}
+	return filepath.Join(b.DataDir, OrchestratorDirName, UsageFileName), nil
+}
+
+const JobTemplatesFileName = "job_templates.db"
+
+func (b Bacalhau) JobTemplatesFilePath() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	// make sure the parent dir exists first
+	if _, err := b.OrchestratorDir(); err != nil {
+		return "", fmt.Errorf("getting job templates path: %w", err)
+	}
+	return filepath.Join(b.DataDir, OrchestratorDirName, JobTemplatesFileName), nil
+}
+
+const JobArchiveDirName = "job-archive"
+
+func (b Bacalhau) JobArchiveDir() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	path := filepath.Join(b.DataDir, OrchestratorDirName, JobArchiveDirName)
+	if err := ensureDir(path); err != nil {
+		return "", fmt.Errorf("getting job archive path: %w", err)
+	}
+	return path, nil
+}
+
 const NetworkTransportDirName = "nats-store"
 
 func (b Bacalhau) NetworkTransportDir() (string, error) {
@@ -110,6 +211,19 @@ func (b Bacalhau) ResultsStorageDir() (string, error) {
 	return path, nil
 }
 
+const WASMCompilationCacheDirName = "wasm-cache"
+
+func (b Bacalhau) WASMCompilationCacheDir() (string, error) {
+	if b.DataDir == "" {
+		return "", fmt.Errorf("data dir not set")
+	}
+	path := filepath.Join(b.DataDir, ComputeDirName, WASMCompilationCacheDirName)
+	if err := ensureDir(path); err != nil {
+		return "", fmt.Errorf("getting wasm compilation cache path: %w", err)
+	}
+	return path, nil
+}
+
 const ExecutionStoreFileName = "state_boltdb.db"
 
 func (b Bacalhau) ExecutionStoreFilePath() (string, error) {
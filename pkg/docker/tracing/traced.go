@@ -101,6 +101,15 @@ func (c TracedClient) ContainerStop(ctx context.Context, containerID string, tim
 	}))
 }
 
+func (c TracedClient) ContainerStats(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error) {
+	ctx, span := c.span(ctx, "container.stats")
+	// span ends when the stats body is closed
+
+	stats, err := c.client.ContainerStats(ctx, containerID, stream)
+	stats.Body, err = telemetry.RecordErrorOnSpanReadCloserAndClose(span)(stats.Body, err)
+	return stats, err
+}
+
 func (c TracedClient) ContainerWait(
 	ctx context.Context,
 	containerID string,
@@ -0,0 +1,51 @@
+//go:build unit || !integration
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+func TestConfigureDevices(t *testing.T) {
+	gpu := models.GPU{Index: 1, Name: "Tesla T4", Vendor: models.GPUVendorNvidia, Memory: 15360}
+	resources := &models.Resources{GPUShare: 0.25, GPUMemory: 4096, GPUs: []models.GPU{gpu}}
+
+	requests, mappings, err := configureDevices(context.Background(), resources)
+	require.NoError(t, err)
+	require.Empty(t, mappings)
+	require.Len(t, requests, 1)
+	require.Equal(t, []string{"1"}, requests[0].DeviceIDs)
+	require.Equal(t, []string{
+		"CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=25",
+		"CUDA_MPS_PINNED_DEVICE_MEM_LIMIT=0=4096M",
+	}, sharedGPUEnv(resources))
+
+	// devices are picked by the index of the GPUs allocated to the execution
+	requests, _, err = configureDevices(context.Background(), &models.Resources{
+		GPU: 2, GPUVendor: models.GPUVendorNvidia, GPUModel: "Tesla*",
+		GPUs: []models.GPU{gpu, {Index: 3, Name: "Tesla T1", Vendor: models.GPUVendorNvidia}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, requests[0].DeviceIDs)
+
+	// whole GPUs are not limited
+	require.Empty(t, sharedGPUEnv(&models.Resources{GPU: 1, GPUs: []models.GPU{gpu}}))
+}
+
+func TestConfigureDevicesRejectsUnfitGPUs(t *testing.T) {
+	gpu := models.GPU{Index: 0, Name: "Tesla T1", Vendor: models.GPUVendorNvidia, Memory: 12345}
+
+	_, _, err := configureDevices(context.Background(), &models.Resources{GPU: 1, GPUMemory: 16384, GPUs: []models.GPU{gpu}})
+	require.ErrorContains(t, err, "requires 16384 MiB")
+
+	_, _, err = configureDevices(context.Background(), &models.Resources{GPU: 1, GPUModel: "A100", GPUs: []models.GPU{gpu}})
+	require.ErrorContains(t, err, "doesn't match the GPU model and vendor")
+
+	_, _, err = configureDevices(context.Background(), &models.Resources{GPUShare: 0.5})
+	require.ErrorContains(t, err, "single GPU")
+}
@@ -37,6 +37,13 @@ import (
 
 const NanoCPUCoefficient = 1000000000
 
+const (
+	// cpuSharesPerCore is the relative CPU weight docker gives a container by default
+	cpuSharesPerCore = 1024
+	// minCPUShares is the lowest CPU weight accepted by the kernel
+	minCPUShares = 2
+)
+
 const (
 	labelExecutorName = "bacalhau-executor"
 	labelJobName      = "bacalhau-jobID"
@@ -57,11 +64,13 @@ type Executor struct {
 	complete          map[string]chan struct{}
 	client            *docker.Client
 	dockerCacheConfig types.DockerManifestCache
+	overcommit        types.Overcommit
 }
 
 func NewExecutor(
 	id string,
 	dockerCacheCfg types.Docker,
+	overcommit types.Overcommit,
 ) (*Executor, error) {
 	dockerClient, err := docker.NewDockerClient()
 	if err != nil {
@@ -74,6 +83,7 @@ func NewExecutor(
 		activeFlags:       make(map[string]chan struct{}),
 		complete:          make(map[string]chan struct{}),
 		dockerCacheConfig: dockerCacheCfg.ManifestCache,
+		overcommit:        overcommit,
 	}
 
 	return de, nil
@@ -318,6 +328,7 @@ func (e *Executor) newDockerJobContainer(ctx context.Context, params *executor.R
 		envvar.ToSlice(params.Env),
 		dockerArgs.EnvironmentVariables,
 	)
+	envVars = append(envVars, sharedGPUEnv(params.Resources)...)
 
 	containerConfig := &container.Config{
 		Image:      dockerArgs.Image,
@@ -358,6 +369,7 @@ func (e *Executor) newDockerJobContainer(ctx context.Context, params *executor.R
 			Devices:        deviceMappings,
 		},
 	}
+	reserveOvercommittedResources(&hostConfig.Resources, e.overcommit)
 
 	if _, set := os.LookupEnv("SKIP_IMAGE_PULL"); !set {
 		dockerCreds := config_legacy.GetDockerCredentials()
@@ -390,6 +402,19 @@ func (e *Executor) newDockerJobContainer(ctx context.Context, params *executor.R
 func configureDevices(ctx context.Context, resources *models.Resources) ([]container.DeviceRequest, []container.DeviceMapping, error) {
 	requests := []container.DeviceRequest{}
 	mappings := []container.DeviceMapping{}
+	if resources.IsSharedGPU() && len(resources.GPUs) != 1 {
+		return nil, nil, fmt.Errorf("shared GPU request must be allocated a single GPU, got %d", len(resources.GPUs))
+	}
+	for _, gpu := range resources.GPUs {
+		if gpu.Memory < resources.GPUMemory {
+			return nil, nil, fmt.Errorf("GPU %d has %d MiB of memory but the job requires %d MiB",
+				gpu.Index, gpu.Memory, resources.GPUMemory)
+		}
+		if !resources.MatchesGPU(gpu) {
+			return nil, nil, fmt.Errorf("GPU %d %q from %s doesn't match the GPU model and vendor required by the job",
+				gpu.Index, gpu.Name, gpu.Vendor)
+		}
+	}
 	vendorGroups := lo.GroupBy(resources.GPUs, func(gpu models.GPU) models.GPUVendor { return gpu.Vendor })
 
 	for vendor, gpus := range vendorGroups {
@@ -436,6 +461,39 @@ func configureDevices(ctx context.Context, resources *models.Resources) ([]conta
 	return requests, mappings, nil
 }
 
+// reserveOvercommittedResources turns the CPU and memory limits of the container
+// into soft reservations when they are overcommitted. The container can still
+// use the resources requested by the execution, but is only guaranteed its
+// share of the allocated capacity when the host is under contention.
+func reserveOvercommittedResources(resources *container.Resources, overcommit types.Overcommit) {
+	if ratio := overcommit.CPURatio(); ratio > 1 && resources.NanoCPUs > 0 {
+		resources.CPUShares = max(int64(float64(resources.NanoCPUs)/NanoCPUCoefficient*cpuSharesPerCore/ratio), minCPUShares)
+	}
+	if ratio := overcommit.MemoryRatio(); ratio > 1 && resources.Memory > 0 {
+		resources.MemoryReservation = int64(float64(resources.Memory) / ratio)
+	}
+}
+
+// sharedGPUEnv returns the environment variables limiting the share of an
+// NVIDIA GPU used by an execution when it shares the GPU with other executions.
+// The limits are enforced by the CUDA Multi-Process Service when it runs on the
+// host, and otherwise executions are time-sliced by the driver.
+func sharedGPUEnv(resources *models.Resources) []string {
+	if resources == nil || !resources.IsSharedGPU() || len(resources.GPUs) != 1 ||
+		resources.GPUs[0].Vendor != models.GPUVendorNvidia {
+		return nil
+	}
+	var env []string
+	if resources.GPUShare > 0 {
+		env = append(env, fmt.Sprintf("CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=%d", int(math.Ceil(resources.GPUShare*100))))
+	}
+	if resources.GPUMemory > 0 {
+		// the GPU is the only device visible to the container, so its index is 0
+		env = append(env, fmt.Sprintf("CUDA_MPS_PINNED_DEVICE_MEM_LIMIT=0=%dM", resources.GPUMemory))
+	}
+	return env
+}
+
 func makeContainerMounts(
 	ctx context.Context, inputs []storage.PreparedStorage, outputs []*models.ResultPath, resultsDir string,
 ) ([]mount.Mount, error) {
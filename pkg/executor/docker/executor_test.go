@@ -63,7 +63,7 @@ func (s *ExecutorTestSuite) SetupTest() {
 	cfg, err := config.NewTestConfig()
 	require.NoError(s.T(), err)
 
-	s.executor, err = NewExecutor("bacalhau-executor-unit-test", cfg.Engines.Types.Docker)
+	s.executor, err = NewExecutor("bacalhau-executor-unit-test", cfg.Engines.Types.Docker, cfg.Compute.Overcommit)
 	require.NoError(s.T(), err)
 	s.T().Cleanup(func() {
 		if err := s.executor.Shutdown(context.Background()); err != nil {
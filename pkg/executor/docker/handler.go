@@ -84,6 +84,26 @@ func (h *executionHandler) run(ctx context.Context) {
 	// The container is now active
 	close(h.activeCh)
 
+	// sample the resource usage of the container until it stops, and attach it to the result
+	usage := executor.NewUsageRecorder(time.Now())
+	var exitedAt time.Time
+	usageCtx, stopUsage := context.WithCancel(ctx)
+	usageDone := make(chan struct{})
+	go func() {
+		defer close(usageDone)
+		h.sampleUsage(usageCtx, usage)
+	}()
+	defer func() {
+		stopUsage()
+		<-usageDone
+		if exitedAt.IsZero() {
+			exitedAt = time.Now()
+		}
+		if h.result != nil {
+			h.result.Usage = usage.Summary(exitedAt)
+		}
+	}()
+
 	// the idea here is even if the container errors
 	// we want to capture stdout, stderr and feed it back to the user
 	var containerError error
@@ -108,6 +128,7 @@ func (h *executionHandler) run(ctx context.Context) {
 		// the docker client was unable to wait on the container, bail.
 		return
 	case exitStatus := <-statusCh:
+		exitedAt = time.Now()
 		// success case, the container completed its execution, but may have experienced an error, we will attempt to collect logs.
 		containerExitStatusCode = exitStatus.StatusCode
 		containerJSON, err := h.client.ContainerInspect(ctx, h.containerID)
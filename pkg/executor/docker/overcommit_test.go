@@ -0,0 +1,33 @@
+//go:build unit || !integration
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+)
+
+func TestReserveOvercommittedResources(t *testing.T) {
+	limits := container.Resources{NanoCPUs: 2 * NanoCPUCoefficient, Memory: 1200}
+
+	// resources that are not overcommitted are only limited
+	resources := limits
+	reserveOvercommittedResources(&resources, types.Overcommit{CPU: 1, Memory: 0.5})
+	require.Equal(t, limits, resources)
+
+	resources = limits
+	reserveOvercommittedResources(&resources, types.Overcommit{CPU: 2, Memory: 1.2})
+	require.Equal(t, int64(2*NanoCPUCoefficient), resources.NanoCPUs)
+	require.Equal(t, int64(1024), resources.CPUShares)
+	require.Equal(t, int64(1200), resources.Memory)
+	require.Equal(t, int64(1000), resources.MemoryReservation)
+
+	// executions without limits are not reserved anything
+	resources = container.Resources{}
+	reserveOvercommittedResources(&resources, types.Overcommit{CPU: 2, Memory: 1.2})
+	require.Equal(t, container.Resources{}, resources)
+}
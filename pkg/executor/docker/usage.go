@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/bacalhau-project/bacalhau/pkg/executor"
+	"github.com/bacalhau-project/bacalhau/pkg/util/closer"
+)
+
+// sampleUsage streams the stats of the container into the recorder
+// until the container stops or the context is canceled.
+func (h *executionHandler) sampleUsage(ctx context.Context, recorder *executor.UsageRecorder) {
+	stats, err := h.client.ContainerStats(ctx, h.containerID, true)
+	if err != nil {
+		h.logger.Debug().Err(err).Msg("failed to stream container stats")
+		return
+	}
+	defer closer.CloseWithLogOnError("container stats", stats.Body)
+
+	decoder := json.NewDecoder(stats.Body)
+	for {
+		var sample container.StatsResponse
+		if err = decoder.Decode(&sample); err != nil {
+			return
+		}
+		recordStats(recorder, sample)
+	}
+}
+
+// recordStats records a sample of the docker stats API
+func recordStats(recorder *executor.UsageRecorder, sample container.StatsResponse) {
+	// stats of a stopped container are empty
+	if sample.Read.IsZero() {
+		return
+	}
+	recorder.RecordCPUTime(time.Duration(sample.CPUStats.CPUUsage.TotalUsage))
+	recorder.RecordMemory(memoryUsage(sample.MemoryStats))
+
+	diskWritten := sample.StorageStats.WriteSizeBytes
+	for _, entry := range sample.BlkioStats.IoServiceBytesRecursive {
+		if strings.EqualFold(entry.Op, "write") {
+			diskWritten += entry.Value
+		}
+	}
+	recorder.RecordDiskWritten(diskWritten)
+
+	var rx, tx uint64
+	for _, network := range sample.Networks {
+		rx += network.RxBytes
+		tx += network.TxBytes
+	}
+	recorder.RecordNetwork(rx, tx)
+}
+
+// memoryUsage returns the memory used by the container without the page cache,
+// the same way as the docker CLI does for cgroup v1 and v2.
+func memoryUsage(stats container.MemoryStats) uint64 {
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if inactive, ok := stats.Stats[key]; ok && inactive < stats.Usage {
+			return stats.Usage - inactive
+		}
+	}
+	return stats.Usage
+}
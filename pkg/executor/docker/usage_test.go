@@ -0,0 +1,60 @@
+//go:build unit || !integration
+
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/executor"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+func TestRecordStats(t *testing.T) {
+	start := time.Now()
+	recorder := executor.NewUsageRecorder(start)
+
+	sample := func(cpu, memory, cache, written, rx, tx uint64) container.StatsResponse {
+		return container.StatsResponse{
+			Stats: container.Stats{
+				Read:        start,
+				CPUStats:    container.CPUStats{CPUUsage: container.CPUUsage{TotalUsage: cpu}},
+				MemoryStats: container.MemoryStats{Usage: memory, Stats: map[string]uint64{"inactive_file": cache}},
+				BlkioStats: container.BlkioStats{IoServiceBytesRecursive: []container.BlkioStatEntry{
+					{Op: "read", Value: 1000},
+					{Op: "write", Value: written},
+				}},
+			},
+			Networks: map[string]container.NetworkStats{
+				"eth0": {RxBytes: rx, TxBytes: tx},
+				"eth1": {RxBytes: rx, TxBytes: tx},
+			},
+		}
+	}
+	recordStats(recorder, sample(uint64(time.Second), 150, 50, 10, 1, 2))
+	recordStats(recorder, sample(uint64(3*time.Second), 350, 50, 30, 3, 4))
+	// stats of the stopped container
+	recordStats(recorder, container.StatsResponse{})
+
+	require.Equal(t, &models.ResourceUsage{
+		WallTime:    10 * time.Second,
+		CPUTime:     3 * time.Second,
+		PeakMemory:  300,
+		AvgMemory:   200,
+		DiskWritten: 30,
+		NetworkRx:   6,
+		NetworkTx:   8,
+	}, recorder.Summary(start.Add(10*time.Second)))
+}
+
+func TestMemoryUsage(t *testing.T) {
+	// cgroup v1
+	require.Equal(t, uint64(70), memoryUsage(container.MemoryStats{Usage: 100, Stats: map[string]uint64{"total_inactive_file": 30}}))
+	// cgroup v2
+	require.Equal(t, uint64(80), memoryUsage(container.MemoryStats{Usage: 100, Stats: map[string]uint64{"inactive_file": 20}}))
+	// no page cache stats
+	require.Equal(t, uint64(100), memoryUsage(container.MemoryStats{Usage: 100}))
+}
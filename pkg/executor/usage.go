@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// UsageRecorder aggregates samples of the resource usage of an execution into a models.ResourceUsage summary.
+// CPU time, disk and network counters are cumulative, and the highest sample is kept, as counters can be reset
+// when the execution stops. It is safe for concurrent use.
+type UsageRecorder struct {
+	mu            sync.Mutex
+	start         time.Time
+	usage         models.ResourceUsage
+	memoryTotal   uint64
+	memorySamples uint64
+}
+
+// NewUsageRecorder creates a recorder for an execution that started at the given time
+func NewUsageRecorder(start time.Time) *UsageRecorder {
+	return &UsageRecorder{start: start}
+}
+
+// RecordMemory records a sample of the memory used by the execution, in bytes
+func (r *UsageRecorder) RecordMemory(bytes uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.PeakMemory = max(r.usage.PeakMemory, bytes)
+	r.memoryTotal += bytes
+	r.memorySamples++
+}
+
+// RecordPeakMemory records the peak memory used by the execution, in bytes, for executors
+// that can't sample the memory while the execution runs. It isn't part of the average memory usage.
+func (r *UsageRecorder) RecordPeakMemory(bytes uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.PeakMemory = max(r.usage.PeakMemory, bytes)
+}
+
+// RecordCPUTime records the total CPU time consumed by the execution so far
+func (r *UsageRecorder) RecordCPUTime(cpuTime time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.CPUTime = max(r.usage.CPUTime, cpuTime)
+}
+
+// RecordDiskWritten records the total number of bytes written to disk by the execution so far
+func (r *UsageRecorder) RecordDiskWritten(bytes uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.DiskWritten = max(r.usage.DiskWritten, bytes)
+}
+
+// RecordNetwork records the total number of bytes received and sent by the execution so far
+func (r *UsageRecorder) RecordNetwork(rx, tx uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.NetworkRx = max(r.usage.NetworkRx, rx)
+	r.usage.NetworkTx = max(r.usage.NetworkTx, tx)
+}
+
+// Summary returns the resource usage of the execution, which ended at the given time
+func (r *UsageRecorder) Summary(end time.Time) *models.ResourceUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	usage := r.usage
+	if end.After(r.start) {
+		usage.WallTime = end.Sub(r.start)
+	}
+	if r.memorySamples > 0 {
+		usage.AvgMemory = r.memoryTotal / r.memorySamples
+	}
+	return &usage
+}
+
+// DirSize returns the total size of the regular files under the directory, in bytes,
+// which executors use to measure the data written to the outputs of an execution.
+func DirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
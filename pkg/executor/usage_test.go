@@ -0,0 +1,73 @@
+//go:build unit || !integration
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+func TestUsageRecorder(t *testing.T) {
+	start := time.Now()
+	recorder := NewUsageRecorder(start)
+
+	recorder.RecordMemory(100)
+	recorder.RecordMemory(300)
+	recorder.RecordMemory(200)
+	recorder.RecordCPUTime(2 * time.Second)
+	recorder.RecordDiskWritten(1024)
+	recorder.RecordNetwork(10, 20)
+
+	// counters reset when the execution stops are ignored
+	recorder.RecordCPUTime(0)
+	recorder.RecordDiskWritten(0)
+	recorder.RecordNetwork(0, 0)
+
+	require.Equal(t, &models.ResourceUsage{
+		WallTime:    4 * time.Second,
+		CPUTime:     2 * time.Second,
+		PeakMemory:  300,
+		AvgMemory:   200,
+		DiskWritten: 1024,
+		NetworkRx:   10,
+		NetworkTx:   20,
+	}, recorder.Summary(start.Add(4*time.Second)))
+}
+
+func TestUsageRecorderWithoutSamples(t *testing.T) {
+	start := time.Now()
+	usage := NewUsageRecorder(start).Summary(start.Add(time.Second))
+	require.Equal(t, &models.ResourceUsage{WallTime: time.Second}, usage)
+	require.InDelta(t, 0, usage.CPUUtilization(), 0.001)
+}
+
+func TestUsageRecorderPeakMemory(t *testing.T) {
+	start := time.Now()
+	recorder := NewUsageRecorder(start)
+	recorder.RecordPeakMemory(300)
+	recorder.RecordMemory(100)
+
+	// the peak memory isn't a sample of the average memory usage
+	require.Equal(t, &models.ResourceUsage{
+		WallTime:   time.Second,
+		PeakMemory: 300,
+		AvgMemory:  100,
+	}, recorder.Summary(start.Add(time.Second)))
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 5), 0600))
+
+	size, err := DirSize(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), size)
+}
@@ -2,8 +2,11 @@ package util
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/bacalhau-project/bacalhau/pkg/config/types"
 	"github.com/bacalhau-project/bacalhau/pkg/executor"
 	"github.com/bacalhau-project/bacalhau/pkg/executor/docker"
@@ -32,6 +35,11 @@ type StandardStorageProviderOptions struct {
 
 type StandardExecutorOptions struct {
 	DockerID string
+	// Overcommit is how much CPU and memory executions can reserve beyond the allocated capacity.
+	Overcommit types.Overcommit
+	// WASMCompilationCacheDir is where compiled WASM modules are cached.
+	// The cache is disabled if empty.
+	WASMCompilationCacheDir string
 }
 
 func NewStandardStorageProvider(cfg types.Bacalhau) (storage.StorageProvider, error) {
@@ -107,14 +115,26 @@ func NewStandardExecutorProvider(
 
 	if cfg.IsNotDisabled(models.EngineDocker) {
 		var err error
-		providers[models.EngineDocker], err = docker.NewExecutor(executorOptions.DockerID, cfg.Types.Docker)
+		providers[models.EngineDocker], err = docker.NewExecutor(
+			executorOptions.DockerID, cfg.Types.Docker, executorOptions.Overcommit)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if cfg.IsNotDisabled(models.EngineWasm) {
-		wasmExecutor, err := wasm.NewExecutor()
+		wasmCache, err := newWASMCompilationCache(cfg.Types.WASM.CompilationCache, executorOptions.WASMCompilationCacheDir)
+		if err != nil {
+			return nil, err
+		}
+		httpLimits, err := newWASMHTTPLimits(cfg.Types.WASM.HTTP)
+		if err != nil {
+			return nil, err
+		}
+		wasmExecutor, err := wasm.NewExecutor(wasm.ExecutorParams{
+			Cache:      wasmCache,
+			HTTPLimits: httpLimits,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -124,6 +144,41 @@ func NewStandardExecutorProvider(
 	return provider.NewMappedProvider(providers), nil
 }
 
+func newWASMCompilationCache(cfg types.WASMCompilationCache, dir string) (*wasm.ModuleCache, error) {
+	if cfg.Disabled || dir == "" {
+		return nil, nil
+	}
+	maxSize, err := parseOptionalBytes("wasm compilation cache max size", cfg.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+	return wasm.NewModuleCache(context.Background(), dir, maxSize)
+}
+
+func newWASMHTTPLimits(cfg types.WASMHTTP) (wasm.HTTPLimits, error) {
+	maxRequestSize, err := parseOptionalBytes("wasm http max request size", cfg.MaxRequestSize)
+	if err != nil {
+		return wasm.HTTPLimits{}, err
+	}
+	maxResponseSize, err := parseOptionalBytes("wasm http max response size", cfg.MaxResponseSize)
+	if err != nil {
+		return wasm.HTTPLimits{}, err
+	}
+	return wasm.HTTPLimits{MaxRequestSize: maxRequestSize, MaxResponseSize: maxResponseSize}, nil
+}
+
+// parseOptionalBytes parses a size such as 1GB, where an empty size is zero
+func parseOptionalBytes(name string, size string) (uint64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	bytes, err := humanize.ParseBytes(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, size, err)
+	}
+	return bytes, nil
+}
+
 // return noop executors for all engines
 func NewNoopExecutors(config noop_executor.ExecutorConfig) executor.ExecProvider {
 	noopExecutor := noop_executor.NewNoopExecutorWithConfig(config)
@@ -0,0 +1,352 @@
+package wasm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tetratelabs/wazero"
+)
+
+// moduleCacheIndexFileName is the file tracking the modules in the cache, stored
+// alongside the files written by wazero.
+const moduleCacheIndexFileName = "index.json"
+
+// ModuleCacheStats are the statistics of a ModuleCache since it was created.
+type ModuleCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Size is the size of the cache on disk, in bytes
+	Size uint64
+}
+
+// HitRate returns the ratio of modules loaded from the cache, or zero if no module was loaded.
+func (s ModuleCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// ModuleCache shares compiled modules across executions, so a module is
+// compiled once per node instead of once per execution. Compiled modules are
+// persisted on disk by wazero's compilation cache, and survive restarts.
+//
+// Each runtime gets its own wazero compilation cache backed by the shared
+// directory. Sharing wazero's cache itself would also share the modules it
+// keeps in memory, along with the function listeners of the execution that
+// compiled them first, and never release them.
+//
+// wazero doesn't bound the size of its cache, so ModuleCache tracks the files
+// written when compiling each module, keyed by the hash of the module content,
+// and evicts the least recently used modules when the cache exceeds its maximum
+// size. After compiling a module, the files in the cache directory that are not
+// tracked yet are attributed to it. Compilations of the same module are serialized,
+// while different modules compile concurrently, so a file can be attributed to
+// another module compiled at the same time. It is still tracked and evicted, and
+// the module it belongs to is recompiled on its next use.
+type ModuleCache struct {
+	dir     string
+	maxSize uint64
+
+	mu        sync.Mutex
+	entries   map[string]*moduleCacheEntry
+	compiling map[string]*moduleLock
+	stats     ModuleCacheStats
+}
+
+// moduleLock serializes the compilations of a module, and is released once
+// no compilation of the module is in progress.
+type moduleLock struct {
+	sync.Mutex
+	refs int
+}
+
+// moduleCacheEntry tracks the files compiled from a module. A module can have
+// several compiled variants, e.g. with and without function listeners.
+type moduleCacheEntry struct {
+	Hash     string    `json:"Hash"`
+	Files    []string  `json:"Files"`
+	Size     uint64    `json:"Size"`
+	LastUsed time.Time `json:"LastUsed"`
+}
+
+// NewModuleCache creates a cache of compiled modules in the given directory,
+// limited to maxSize bytes. A maxSize of zero means the cache is not limited.
+func NewModuleCache(ctx context.Context, dir string, maxSize uint64) (*ModuleCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create wasm compilation cache in %s: %w", dir, err)
+	}
+	c := &ModuleCache{
+		dir:       dir,
+		maxSize:   maxSize,
+		entries:   make(map[string]*moduleCacheEntry),
+		compiling: make(map[string]*moduleLock),
+	}
+	if err := c.load(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load wasm compilation cache from %s: %w", dir, err)
+	}
+	return c, nil
+}
+
+// NewRuntime creates a runtime compiling modules through the cache.
+// Closing the runtime releases the modules it compiled from memory.
+func (c *ModuleCache) NewRuntime(ctx context.Context, config wazero.RuntimeConfig) (wazero.Runtime, error) {
+	cache, err := wazero.NewCompilationCacheWithDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wasm compilation cache in %s: %w", c.dir, err)
+	}
+	return cachedRuntime{
+		Runtime: wazero.NewRuntimeWithConfig(ctx, config.WithCompilationCache(cache)),
+		cache:   cache,
+	}, nil
+}
+
+// cachedRuntime closes its compilation cache along with the runtime
+type cachedRuntime struct {
+	wazero.Runtime
+	cache wazero.CompilationCache
+}
+
+func (r cachedRuntime) Close(ctx context.Context) error {
+	return errors.Join(r.Runtime.Close(ctx), r.cache.Close(ctx))
+}
+
+// Compile compiles the module with a runtime created by NewRuntime,
+// loading it from the cache if it was compiled before.
+func (c *ModuleCache) Compile(ctx context.Context, runtime wazero.Runtime, source []byte) (wazero.CompiledModule, error) {
+	sum := sha256.Sum256(source)
+	hash := hex.EncodeToString(sum[:])
+	unlock := c.lockModule(hash)
+	defer unlock()
+
+	c.mu.Lock()
+	_, cached := c.entries[hash]
+	c.mu.Unlock()
+
+	module, err := runtime.CompileModule(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	// wazero writes to the cache when the module, or the variant being compiled, wasn't found in it
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[hash]
+	if !ok {
+		entry = &moduleCacheEntry{Hash: hash}
+	}
+	entry.LastUsed = time.Now()
+	added := c.claim(entry, files)
+	// the interpreter doesn't write compiled modules, which are then never tracked
+	if len(entry.Files) > 0 {
+		c.entries[hash] = entry
+	}
+
+	hit := cached && added == 0
+	if hit {
+		c.stats.Hits++
+		ModuleCacheHits.Inc(ctx)
+	} else {
+		c.stats.Misses++
+		ModuleCacheMisses.Inc(ctx)
+	}
+	if added > 0 {
+		c.stats.Size += added
+		ModuleCacheSize.Add(ctx, int64(added))
+	}
+	log.Ctx(ctx).Debug().
+		Str("Hash", hash).
+		Bool("Hit", hit).
+		Float64("HitRate", c.stats.HitRate()).
+		Msg("Compiled WASM module")
+
+	c.evict(ctx)
+	if err = c.save(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("Dir", c.dir).Msg("failed to save wasm compilation cache index")
+	}
+	return module, nil
+}
+
+// lockModule waits for other compilations of the module to complete,
+// and returns a function releasing the module for the next compilation.
+func (c *ModuleCache) lockModule(hash string) func() {
+	c.mu.Lock()
+	lock, ok := c.compiling[hash]
+	if !ok {
+		lock = &moduleLock{}
+		c.compiling[hash] = lock
+	}
+	lock.refs++
+	c.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(c.compiling, hash)
+		}
+	}
+}
+
+// claim attributes the files of the cache directory that are not tracked by any module
+// to the entry, and returns their total size. Files listed before being evicted are skipped.
+func (c *ModuleCache) claim(entry *moduleCacheEntry, files map[string]uint64) uint64 {
+	for _, tracked := range c.entries {
+		for _, file := range tracked.Files {
+			delete(files, file)
+		}
+	}
+	var added uint64
+	for file, size := range files {
+		if _, err := os.Stat(filepath.Join(c.dir, file)); err != nil {
+			continue
+		}
+		entry.Files = append(entry.Files, file)
+		entry.Size += size
+		added += size
+	}
+	return added
+}
+
+// Stats returns the statistics of the cache.
+func (c *ModuleCache) Stats() ModuleCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// evict removes the least recently used modules until the cache fits its maximum size.
+func (c *ModuleCache) evict(ctx context.Context) {
+	if c.maxSize == 0 || c.stats.Size <= c.maxSize {
+		return
+	}
+	entries := make([]*moduleCacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+	for _, entry := range entries {
+		if c.stats.Size <= c.maxSize {
+			return
+		}
+		c.remove(ctx, entry)
+		c.stats.Evictions++
+		ModuleCacheEvictions.Inc(ctx)
+		log.Ctx(ctx).Debug().Str("Hash", entry.Hash).Uint64("Size", entry.Size).Msg("Evicted WASM module from compilation cache")
+	}
+}
+
+func (c *ModuleCache) remove(ctx context.Context, entry *moduleCacheEntry) {
+	for _, file := range entry.Files {
+		if err := os.Remove(filepath.Join(c.dir, file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Ctx(ctx).Warn().Err(err).Str("File", file).Msg("failed to remove compiled WASM module")
+		}
+	}
+	delete(c.entries, entry.Hash)
+	c.stats.Size -= entry.Size
+	ModuleCacheSize.Add(ctx, -int64(entry.Size))
+}
+
+// load reads the index of the cache, reconciling it with the files on disk.
+// Files that are not tracked by the index, such as the files compiled by a
+// previous version of wazero, are removed.
+func (c *ModuleCache) load(ctx context.Context) error {
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	var entries []*moduleCacheEntry
+	content, err := os.ReadFile(filepath.Join(c.dir, moduleCacheIndexFileName))
+	if err == nil {
+		if err = json.Unmarshal(content, &entries); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("Dir", c.dir).Msg("ignoring invalid wasm compilation cache index")
+			entries = nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for _, entry := range entries {
+		tracked := &moduleCacheEntry{Hash: entry.Hash, LastUsed: entry.LastUsed}
+		for _, file := range entry.Files {
+			if size, found := files[file]; found {
+				tracked.Files = append(tracked.Files, file)
+				tracked.Size += size
+				delete(files, file)
+			}
+		}
+		if len(tracked.Files) > 0 {
+			c.entries[tracked.Hash] = tracked
+			c.stats.Size += tracked.Size
+		}
+	}
+	for file := range files {
+		if err = os.Remove(filepath.Join(c.dir, file)); err != nil {
+			return err
+		}
+	}
+
+	ModuleCacheSize.Add(ctx, int64(c.stats.Size))
+	c.evict(ctx)
+	return c.save()
+}
+
+func (c *ModuleCache) save() error {
+	entries := make([]*moduleCacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	content, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, moduleCacheIndexFileName), content, 0600)
+}
+
+// files returns the size of the files written by wazero, by their path relative to the cache directory.
+func (c *ModuleCache) files() (map[string]uint64, error) {
+	files := make(map[string]uint64)
+	err := filepath.WalkDir(c.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(c.dir, path)
+		if err != nil {
+			return err
+		}
+		// skip the index and files that are still being written
+		if rel == moduleCacheIndexFileName || strings.HasSuffix(rel, ".tmp") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = uint64(info.Size())
+		return nil
+	})
+	return files, err
+}
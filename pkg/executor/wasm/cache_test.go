@@ -0,0 +1,148 @@
+//go:build unit || !integration
+
+package wasm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tetratelabs/wazero"
+
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/cat"
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/noop"
+)
+
+type ModuleCacheTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	dir string
+}
+
+func TestModuleCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(ModuleCacheTestSuite))
+}
+
+func (s *ModuleCacheTestSuite) SetupTest() {
+	// wazero only caches modules compiled to native code, which is not supported on all platforms
+	if runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64" {
+		s.T().Skip("wazero compiler is not supported on " + runtime.GOARCH)
+	}
+	s.ctx = context.Background()
+	s.dir = s.T().TempDir()
+}
+
+func (s *ModuleCacheTestSuite) newCache(maxSize uint64) *ModuleCache {
+	cache, err := NewModuleCache(s.ctx, s.dir, maxSize)
+	s.Require().NoError(err)
+	return cache
+}
+
+// compile compiles the program with a new runtime, as each execution does
+func (s *ModuleCacheTestSuite) compile(cache *ModuleCache, program []byte) {
+	r, err := cache.NewRuntime(s.ctx, wazero.NewRuntimeConfig())
+	s.Require().NoError(err)
+	defer func() { s.NoError(r.Close(s.ctx)) }()
+	_, err = cache.Compile(s.ctx, r, program)
+	s.Require().NoError(err)
+}
+
+func (s *ModuleCacheTestSuite) TestHitAfterFirstCompilation() {
+	cache := s.newCache(0)
+	s.compile(cache, noop.Program())
+	s.compile(cache, noop.Program())
+
+	stats := cache.Stats()
+	s.Equal(uint64(1), stats.Misses)
+	s.Equal(uint64(1), stats.Hits)
+	s.Equal(0.5, stats.HitRate())
+	s.Positive(stats.Size)
+}
+
+func (s *ModuleCacheTestSuite) TestInterpreterNeverHits() {
+	cache := s.newCache(0)
+	for i := 0; i < 2; i++ {
+		r, err := cache.NewRuntime(s.ctx, wazero.NewRuntimeConfigInterpreter())
+		s.Require().NoError(err)
+		_, err = cache.Compile(s.ctx, r, noop.Program())
+		s.Require().NoError(err)
+		s.Require().NoError(r.Close(s.ctx))
+	}
+
+	stats := cache.Stats()
+	s.Equal(uint64(0), stats.Hits)
+	s.Equal(uint64(2), stats.Misses)
+	s.Zero(stats.Size)
+}
+
+func (s *ModuleCacheTestSuite) TestConcurrentCompilations() {
+	cache := s.newCache(0)
+	programs := [][]byte{noop.Program(), cat.Program()}
+	const compilations = 4
+
+	var wg sync.WaitGroup
+	for i := 0; i < compilations; i++ {
+		for _, program := range programs {
+			wg.Add(1)
+			go func(program []byte) {
+				defer wg.Done()
+				s.compile(cache, program)
+			}(program)
+		}
+	}
+	wg.Wait()
+
+	// each module is compiled once, and every file written is tracked
+	stats := cache.Stats()
+	s.Equal(uint64(len(programs)), stats.Misses)
+	s.Equal(uint64(len(programs)*(compilations-1)), stats.Hits)
+	files, err := cache.files()
+	s.Require().NoError(err)
+	var size uint64
+	for _, fileSize := range files {
+		size += fileSize
+	}
+	s.Equal(size, stats.Size)
+}
+
+func (s *ModuleCacheTestSuite) TestPersistsAcrossRestarts() {
+	cache := s.newCache(0)
+	s.compile(cache, noop.Program())
+	size := cache.Stats().Size
+
+	restarted := s.newCache(0)
+	s.Equal(size, restarted.Stats().Size)
+	s.compile(restarted, noop.Program())
+	s.Equal(uint64(1), restarted.Stats().Hits)
+	s.Equal(uint64(0), restarted.Stats().Misses)
+}
+
+func (s *ModuleCacheTestSuite) TestEvictsLeastRecentlyUsed() {
+	unlimited := s.newCache(0)
+	s.compile(unlimited, noop.Program())
+	s.compile(unlimited, cat.Program())
+
+	// only the most recently used module fits the cache
+	cache := s.newCache(unlimited.Stats().Size - 1)
+	s.Equal(uint64(1), cache.Stats().Evictions)
+
+	s.compile(cache, cat.Program())
+	s.Equal(uint64(1), cache.Stats().Hits)
+	s.compile(cache, noop.Program())
+	s.Equal(uint64(1), cache.Stats().Misses)
+	s.Equal(uint64(2), cache.Stats().Evictions)
+}
+
+func (s *ModuleCacheTestSuite) TestRemovesUntrackedFiles() {
+	stale := filepath.Join(s.dir, "wazero-stale", "module")
+	s.Require().NoError(os.MkdirAll(filepath.Dir(stale), 0700))
+	s.Require().NoError(os.WriteFile(stale, []byte("compiled"), 0600))
+
+	cache := s.newCache(0)
+	s.NoFileExists(stale)
+	s.Zero(cache.Stats().Size)
+}
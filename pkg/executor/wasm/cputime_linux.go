@@ -0,0 +1,19 @@
+//go:build linux
+
+package wasm
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// threadCPUTime returns the CPU time consumed by the calling thread, which must be
+// locked to the goroutine for the difference between two calls to be meaningful.
+func threadCPUTime() (time.Duration, bool) {
+	var usage unix.Rusage
+	if err := unix.Getrusage(unix.RUSAGE_THREAD, &usage); err != nil {
+		return 0, false
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), true
+}
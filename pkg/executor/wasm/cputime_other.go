@@ -0,0 +1,13 @@
+//go:build !linux
+
+package wasm
+
+import (
+	"time"
+)
+
+// threadCPUTime returns false as the CPU time of a thread can't be measured
+// on this platform, in which case the CPU time of executions is not reported.
+func threadCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -32,10 +32,22 @@ import (
 type Executor struct {
 	// handlers is a map of executionID to its handler.
 	handlers generic.SyncMap[string, *executionHandler]
+	// cache of compiled modules shared by all executions, or nil if disabled
+	cache *ModuleCache
+	// limits of the http requests made by executions
+	httpLimits HTTPLimits
 }
 
-func NewExecutor() (*Executor, error) {
-	return &Executor{}, nil
+type ExecutorParams struct {
+	// Cache of compiled modules shared by all executions.
+	// Modules are compiled from scratch for every execution if nil.
+	Cache *ModuleCache
+	// HTTPLimits are the limits of the http requests made by executions
+	HTTPLimits HTTPLimits
+}
+
+func NewExecutor(params ExecutorParams) (*Executor, error) {
+	return &Executor{cache: params.Cache, httpLimits: params.HTTPLimits}, nil
 }
 
 func (e *Executor) IsInstalled(context.Context) (bool, error) {
@@ -81,6 +93,7 @@ func (e *Executor) Start(ctx context.Context, request *executor.RunCommandReques
 	// the WASM page size of 64kb, so round up to the nearest page size if the
 	// limit is not specified as a multiple of that.
 	engineConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	var memoryLimit uint64
 	if request.Resources.Memory > 0 {
 		requestedPages := request.Resources.Memory/WasmPageSize + math.Min(request.Resources.Memory%WasmPageSize, 1)
 		if requestedPages > WasmMaxPagesLimit {
@@ -89,6 +102,11 @@ func (e *Executor) Start(ctx context.Context, request *executor.RunCommandReques
 			return err
 		}
 		engineConfig = engineConfig.WithMemoryLimitPages(uint32(requestedPages))
+		memoryLimit = requestedPages * WasmPageSize
+	}
+	runtime, err := e.newRuntime(ctx, engineConfig)
+	if err != nil {
+		return err
 	}
 
 	engineParams, err := wasmmodels.DecodeArguments(request.EngineParams)
@@ -109,13 +127,17 @@ func (e *Executor) Start(ctx context.Context, request *executor.RunCommandReques
 	}
 
 	handler := &executionHandler{
-		runtime:     wazero.NewRuntimeWithConfig(ctx, engineConfig),
+		runtime:     runtime,
 		arguments:   engineParams,
 		fs:          rootFs,
 		inputs:      request.Inputs,
 		executionID: request.ExecutionID,
 		resultsDir:  request.ResultsDir,
 		limits:      request.OutputLimits,
+		memoryLimit: memoryLimit,
+		cache:       e.cache,
+		network:     request.Network,
+		httpLimits:  e.httpLimits,
 		logger: log.With().
 			Str("execution", request.ExecutionID).
 			Str("job", request.JobID).
@@ -133,6 +155,14 @@ func (e *Executor) Start(ctx context.Context, request *executor.RunCommandReques
 	return nil
 }
 
+// newRuntime creates the runtime of an execution, compiling modules through the cache if enabled.
+func (e *Executor) newRuntime(ctx context.Context, config wazero.RuntimeConfig) (wazero.Runtime, error) {
+	if e.cache == nil {
+		return wazero.NewRuntimeWithConfig(ctx, config), nil
+	}
+	return e.cache.NewRuntime(ctx, config)
+}
+
 // Wait initiates a wait for the completion of a specific execution using its
 // executionID. The function returns two channels: one for the result and another
 // for any potential error. If the executionID is not found, an error is immediately
@@ -5,24 +5,36 @@ package wasm
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/bacalhau-project/bacalhau/pkg/executor"
+	wasmmodels "github.com/bacalhau-project/bacalhau/pkg/executor/wasm/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/storage"
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/fuel_burner"
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/memory_hog"
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/noop"
 )
 
 type ExecutorTestSuite struct {
 	suite.Suite
+	cache *ModuleCache
 }
 
 func TestExecutorTestSuite(t *testing.T) {
 	suite.Run(t, new(ExecutorTestSuite))
 }
 
+func (s *ExecutorTestSuite) SetupTest() {
+	s.cache = nil
+}
+
 func (s *ExecutorTestSuite) TestFailingRequestedMemGreaterThan4GB() {
-	e, err := NewExecutor()
+	e, err := NewExecutor(ExecutorParams{})
 	s.Require().NoError(err)
 
 	r := &executor.RunCommandRequest{
@@ -41,3 +53,67 @@ func (s *ExecutorTestSuite) TestFailingRequestedMemGreaterThan4GB() {
 
 	assert.Contains(s.T(), err.Error(), "requested memory exceeds the wasm limit")
 }
+
+func (s *ExecutorTestSuite) run(program []byte, memory uint64, fuel uint64) *models.RunCommandResult {
+	e, err := NewExecutor(ExecutorParams{Cache: s.cache})
+	s.Require().NoError(err)
+
+	entryModule := prepareModule(s.T(), "", program)
+	r := &executor.RunCommandRequest{
+		JobID:       "job",
+		ExecutionID: uuid.NewString(),
+		Resources:   &models.Resources{Memory: memory},
+		Inputs:      []storage.PreparedStorage{entryModule},
+		ResultsDir:  s.T().TempDir(),
+		EngineParams: &models.SpecConfig{
+			Type: models.EngineWasm,
+			Params: wasmmodels.EngineArguments{
+				EntryModule: entryModule,
+				EntryPoint:  "_start",
+				Fuel:        fuel,
+			}.ToMap(),
+		},
+		OutputLimits: executor.OutputLimits{MaxStdoutFileLength: 1024, MaxStderrFileLength: 1024},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := e.Run(ctx, r)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *ExecutorTestSuite) TestMemoryLimitExceeded() {
+	result := s.run(memory_hog.Program(), 4*WasmPageSize, 0)
+	s.Equal(1, result.ExitCode)
+	s.Contains(result.ErrorMsg, "memory limit exceeded")
+	s.Equal(uint64(4*WasmPageSize), result.Usage.PeakMemory)
+	// the memory isn't sampled while the module runs
+	s.Zero(result.Usage.AvgMemory)
+}
+
+func (s *ExecutorTestSuite) TestFuelBudgetExceeded() {
+	result := s.run(fuel_burner.Program(), 0, 1000)
+	s.Equal(1, result.ExitCode)
+	s.Contains(result.ErrorMsg, "fuel budget exceeded")
+	s.NotContains(result.ErrorMsg, "memory limit exceeded")
+}
+
+func (s *ExecutorTestSuite) TestWithinLimits() {
+	result := s.run(noop.Program(), 0, 1_000_000)
+	s.Equal(0, result.ExitCode)
+	s.Empty(result.ErrorMsg)
+}
+
+func (s *ExecutorTestSuite) TestCompilationCache() {
+	var err error
+	s.cache, err = NewModuleCache(context.Background(), s.T().TempDir(), 0)
+	s.Require().NoError(err)
+
+	// the fuel budget is still enforced by modules loaded from the cache
+	for i := 0; i < 2; i++ {
+		result := s.run(fuel_burner.Program(), 0, 1000)
+		s.Contains(result.ErrorMsg, "fuel budget exceeded")
+	}
+	s.Equal(uint64(1), s.cache.Stats().Hits)
+}
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"runtime"
 	"sort"
 	"time"
 
 	"github.com/dylibso/observe-sdk/go/adapter/opentelemetry"
 	"github.com/rs/zerolog"
 	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
 	"github.com/tetratelabs/wazero/sys"
 	"go.uber.org/atomic"
 	"golang.org/x/exp/maps"
@@ -39,6 +41,13 @@ type executionHandler struct {
 	executionID string
 	resultsDir  string
 	limits      executor.OutputLimits
+	// memory limit of the runtime in bytes, or zero if unlimited
+	memoryLimit uint64
+	// cache of compiled modules, or nil if disabled
+	cache *ModuleCache
+	// network config and limits of the http requests made by the modules
+	network    *models.NetworkConfig
+	httpLimits HTTPLimits
 
 	// cancellation
 	cancel func()
@@ -89,6 +98,14 @@ func (h *executionHandler) run(ctx context.Context) {
 		h.cancel()
 	}()
 
+	// meter the function calls of the modules if the job has a fuel budget.
+	// The listener has to be set on the context the modules are compiled with.
+	var fuel *fuelMeter
+	if h.arguments.Fuel > 0 {
+		fuel = newFuelMeter(h.arguments.Fuel, h.cancel)
+		ctx = withFuelMeter(ctx, fuel)
+	}
+
 	var adapter *opentelemetry.OTelAdapter
 	conf := opentelemetry.OTelConfig{
 		ServiceName:        "bacalhau",
@@ -132,7 +149,15 @@ func (h *executionHandler) run(ctx context.Context) {
 	}
 
 	h.logger.Info().Msg("instantiating wasm modules")
+	httpHost := newHTTPHost(h.network, h.httpLimits, h.logger)
+	if err = httpHost.instantiate(ctx, tracingEngine); err != nil {
+		h.result = executor.NewFailedResult(fmt.Errorf("failed to instantiate http host module: %w", err).Error())
+		return
+	}
 	loader := NewModuleLoader(tracingEngine, config, h.inputs...)
+	if h.cache != nil {
+		loader = loader.WithCache(h.cache)
+	}
 
 	// TODO we have been ignoring errors from this method for ages. Now that we actually check them tests fail! nice..
 	// v1.0.3: https://github.com/bacalhau-project/bacalhau/blob/v1.0.3/pkg/executor/wasm/executor.go#L243
@@ -168,9 +193,11 @@ func (h *executionHandler) run(ctx context.Context) {
 			Str("volume_source", entryModule.Volume.Source).
 			Str("volume_target", entryModule.Volume.Target).
 			Msg("failed to instantiate entry module")
-		h.result = executor.NewFailedResult(
-			fmt.Errorf("failed to instantiate entry module module (%s): %w",
-				entryModule.InputSource.Source.Type, err).Error())
+		err = fmt.Errorf("failed to instantiate entry module module (%s): %w", entryModule.InputSource.Source.Type, err)
+		if isMemoryLimitErr(err) {
+			err = memoryLimitErr(h.memoryLimit, err)
+		}
+		h.result = executor.NewFailedResult(err.Error())
 		return
 	}
 
@@ -202,7 +229,22 @@ func (h *executionHandler) run(ctx context.Context) {
 	// the exit code for inclusion in the job output, and ignore the return code
 	// from the function (most WASI compilers will not give one). Some compilers
 	// though do not set an exit code, so we use a default of -1.
+	//
+	// The goroutine is locked to its thread while the module runs, so that the CPU
+	// time consumed by the thread is the CPU time consumed by the module.
+	runtime.LockOSThread()
+	cpuStart, cpuStartOK := threadCPUTime()
+	startedAt := time.Now()
 	_, wasmErr := entryFunc.Call(wasmCtx)
+	endedAt := time.Now()
+	cpuEnd, cpuEndOK := threadCPUTime()
+	runtime.UnlockOSThread()
+
+	usage := h.measureUsage(instance, startedAt)
+	if cpuStartOK && cpuEndOK {
+		usage.RecordCPUTime(cpuEnd - cpuStart)
+	}
+	usage.RecordNetwork(httpHost.received, httpHost.sent)
 	exitCode := int64(-1)
 	var errExit *sys.ExitError
 	if errors.As(wasmErr, &errExit) {
@@ -216,6 +258,11 @@ func (h *executionHandler) run(ctx context.Context) {
 		exitCode = 1
 		h.logger.Warn().Int64("exit_code", exitCode).Err(wasmErr).Msg("execution ended")
 	}
+	// report which limit stopped the module, so it can be told apart from a timeout or a bug
+	if limitErr := h.limitExceeded(instance, fuel, exitCode); limitErr != nil {
+		wasmErr, exitCode = limitErr, 1
+		h.logger.Warn().Err(limitErr).Msg("execution exceeded its limits")
+	}
 	// execution has finished and there's nothing else to read from so inform
 	// the logs that it is time to drain any remaining items.
 	h.logManager.Drain()
@@ -223,6 +270,38 @@ func (h *executionHandler) run(ctx context.Context) {
 	stdoutReader, stderrReader := h.logManager.GetDefaultReaders(false)
 
 	h.result = executor.WriteJobResults(h.resultsDir, stdoutReader, stderrReader, int(exitCode), wasmErr, h.limits)
+
+	// everything written by the module ends up in the results directory, along with its logs
+	if written, err := executor.DirSize(h.resultsDir); err == nil {
+		usage.RecordDiskWritten(written)
+	} else {
+		h.logger.Debug().Err(err).Msg("failed to measure the size of the results directory")
+	}
+	h.result.Usage = usage.Summary(endedAt)
+}
+
+// limitExceeded returns an error describing the resource limit that stopped
+// the module, or nil if it didn't fail because of one.
+func (h *executionHandler) limitExceeded(instance api.Module, fuel *fuelMeter, exitCode int64) error {
+	if fuel.Exhausted() {
+		return fuel.err()
+	}
+	if exitCode != 0 && memoryLimitExceeded(moduleMemory(instance), h.memoryLimit) {
+		return memoryLimitErr(h.memoryLimit, nil)
+	}
+	return nil
+}
+
+// measureUsage records the memory usage of the entry module once it has run.
+// The linear memory of a module can only grow, so its final size is the peak memory usage.
+// The memory can't be safely read while the module runs, so the average memory usage
+// is not measured.
+func (h *executionHandler) measureUsage(instance api.Module, startedAt time.Time) *executor.UsageRecorder {
+	usage := executor.NewUsageRecorder(startedAt)
+	if memory := moduleMemory(instance); memory != nil {
+		usage.RecordPeakMemory(uint64(memory.Size()))
+	}
+	return usage
 }
 
 func (h *executionHandler) active() bool {
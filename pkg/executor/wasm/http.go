@@ -0,0 +1,221 @@
+package wasm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// HTTPModuleName is the name of the host module WASM jobs import to make HTTP
+// requests. It exports the following functions:
+//
+//	request(method_ptr, method_len, url_ptr, url_len, headers_ptr, headers_len, body_ptr, body_len i32) -> i32
+//	response_body_len() -> i32
+//	read_response_body(ptr, len i32) -> i32
+//
+// request sends an HTTP request and returns the status code of the response,
+// or one of the negative HTTPErr codes. Headers are passed as "Name: value"
+// lines. The body of the response is kept until the next request, and is read
+// in chunks by read_response_body, which returns the number of bytes copied to
+// the module memory, or zero once the body has been read entirely.
+//
+// Requests are only allowed to the domains allowed by the network config of the
+// job, and redirects are only followed to allowed domains.
+const HTTPModuleName = "bacalhau_http"
+
+// Error codes returned by the HTTP host functions.
+const (
+	// HTTPErrDenied is returned when the network config of the job doesn't allow the domain
+	HTTPErrDenied int32 = -(iota + 1)
+	// HTTPErrInvalid is returned when the request can't be read from memory or is malformed
+	HTTPErrInvalid
+	// HTTPErrRequestTooLarge is returned when the headers and body of the request exceed the limit
+	HTTPErrRequestTooLarge
+	// HTTPErrFailed is returned when the request could not be sent or its response received
+	HTTPErrFailed
+	// HTTPErrResponseTooLarge is returned when the body of the response exceeds the limit
+	HTTPErrResponseTooLarge
+)
+
+// maxHTTPRedirects is the number of redirects followed, as by the default http client
+const maxHTTPRedirects = 10
+
+// HTTPLimits are the limits of the HTTP requests made by WASM jobs, in bytes.
+// A zero limit means the size is not limited.
+type HTTPLimits struct {
+	MaxRequestSize  uint64
+	MaxResponseSize uint64
+}
+
+// httpHost implements the HTTP host functions for an execution.
+type httpHost struct {
+	network *models.NetworkConfig
+	limits  HTTPLimits
+	client  *http.Client
+	logger  zerolog.Logger
+
+	// body of the last response and how much of it was read by the module
+	body []byte
+	read int
+
+	// bytes sent and received by the execution
+	sent     uint64
+	received uint64
+}
+
+func newHTTPHost(network *models.NetworkConfig, limits HTTPLimits, logger zerolog.Logger) *httpHost {
+	h := &httpHost{
+		network: network,
+		limits:  limits,
+		logger:  logger,
+	}
+	h.client = &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !h.network.AllowsDomain(req.URL.Hostname()) {
+				return fmt.Errorf("redirect to %s is not allowed by the network config", req.URL.Hostname())
+			}
+			if len(via) >= maxHTTPRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxHTTPRedirects)
+			}
+			return nil
+		},
+	}
+	return h
+}
+
+// instantiate makes the host functions available to the modules of the runtime.
+func (h *httpHost) instantiate(ctx context.Context, runtime wazero.Runtime) error {
+	_, err := runtime.NewHostModuleBuilder(HTTPModuleName).
+		NewFunctionBuilder().WithFunc(h.request).Export("request").
+		NewFunctionBuilder().WithFunc(h.responseBodyLen).Export("response_body_len").
+		NewFunctionBuilder().WithFunc(h.readResponseBody).Export("read_response_body").
+		Instantiate(ctx)
+	return err
+}
+
+//nolint:gocyclo // each step of the request fails with its own error code
+func (h *httpHost) request(
+	ctx context.Context, module api.Module,
+	methodPtr, methodLen, urlPtr, urlLen, headersPtr, headersLen, bodyPtr, bodyLen uint32,
+) int32 {
+	h.body, h.read = nil, 0
+
+	if h.limits.MaxRequestSize > 0 && uint64(headersLen)+uint64(bodyLen) > h.limits.MaxRequestSize {
+		h.logger.Warn().Uint32("size", headersLen+bodyLen).Msg("http request exceeds the size limit")
+		return HTTPErrRequestTooLarge
+	}
+
+	memory := moduleMemory(module)
+	if memory == nil {
+		return HTTPErrInvalid
+	}
+	method, ok1 := memory.Read(methodPtr, methodLen)
+	rawURL, ok2 := memory.Read(urlPtr, urlLen)
+	headers, ok3 := memory.Read(headersPtr, headersLen)
+	body, ok4 := memory.Read(bodyPtr, bodyLen)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		h.logger.Warn().Msg("http request is out of the module memory")
+		return HTTPErrInvalid
+	}
+
+	target, err := url.Parse(string(rawURL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		h.logger.Warn().Str("url", string(rawURL)).Msg("http request has an invalid url")
+		return HTTPErrInvalid
+	}
+	logger := h.logger.With().Str("method", string(method)).Str("host", target.Host).Logger()
+	if !h.network.AllowsDomain(target.Hostname()) {
+		logger.Warn().Msg("http request denied by the network config")
+		return HTTPErrDenied
+	}
+
+	// the body is copied, as the module memory can change once the module runs again
+	req, err := http.NewRequestWithContext(ctx, string(method), target.String(), bytes.NewReader(bytes.Clone(body)))
+	if err != nil {
+		logger.Warn().Err(err).Msg("http request is invalid")
+		return HTTPErrInvalid
+	}
+	if err = parseHeaders(headers, req.Header); err != nil {
+		logger.Warn().Err(err).Msg("http request has invalid headers")
+		return HTTPErrInvalid
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		logger.Warn().Err(err).Msg("http request failed")
+		return HTTPErrFailed
+	}
+	defer func() { _ = resp.Body.Close() }()
+	h.sent += uint64(headersLen) + uint64(bodyLen)
+
+	reader := io.Reader(resp.Body)
+	if h.limits.MaxResponseSize > 0 {
+		reader = io.LimitReader(resp.Body, int64(h.limits.MaxResponseSize)+1)
+	}
+	content, err := io.ReadAll(reader)
+	h.received += uint64(len(content))
+	if err != nil {
+		logger.Warn().Err(err).Int("status", resp.StatusCode).Msg("failed to read http response")
+		return HTTPErrFailed
+	}
+	if h.limits.MaxResponseSize > 0 && uint64(len(content)) > h.limits.MaxResponseSize {
+		logger.Warn().Int("status", resp.StatusCode).Msg("http response exceeds the size limit")
+		return HTTPErrResponseTooLarge
+	}
+
+	h.body = content
+	logger.Info().
+		Int("status", resp.StatusCode).
+		Uint32("request_size", headersLen+bodyLen).
+		Int("response_size", len(content)).
+		Msg("http request completed")
+	return int32(resp.StatusCode)
+}
+
+func (h *httpHost) responseBodyLen() int32 {
+	return int32(len(h.body))
+}
+
+func (h *httpHost) readResponseBody(_ context.Context, module api.Module, ptr, size uint32) int32 {
+	memory := moduleMemory(module)
+	if memory == nil {
+		return HTTPErrInvalid
+	}
+	chunk := h.body[h.read:]
+	if uint32(len(chunk)) > size {
+		chunk = chunk[:size]
+	}
+	if !memory.Write(ptr, chunk) {
+		return HTTPErrInvalid
+	}
+	h.read += len(chunk)
+	return int32(len(chunk))
+}
+
+// parseHeaders adds the headers passed as "Name: value" lines to the request headers.
+func parseHeaders(raw []byte, headers http.Header) error {
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid header %q", line)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return scanner.Err()
+}
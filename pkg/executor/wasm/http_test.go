@@ -0,0 +1,173 @@
+//go:build unit || !integration
+
+package wasm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/testdata/wasm/http_client"
+)
+
+// offsets in the module memory of the request and response passed to fetch
+const (
+	fetchMethodPtr  = 0
+	fetchURLPtr     = 1 << 10
+	fetchHeadersPtr = 4 << 10
+	fetchBodyPtr    = 8 << 10
+	fetchOutPtr     = 16 << 10
+	fetchOutCap     = 16 << 10
+)
+
+type HTTPHostTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	server   *httptest.Server
+	requests int
+}
+
+func TestHTTPHostTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPHostTestSuite))
+}
+
+func (s *HTTPHostTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.requests = 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Method + " " + r.Header.Get("X-Test") + " " + string(body)))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		// localhost resolves to the same server, but is not an allowed domain
+		http.Redirect(w, r, strings.Replace(s.server.URL, "127.0.0.1", "localhost", 1)+"/echo", http.StatusFound)
+	})
+	s.server = httptest.NewServer(mux)
+	s.T().Cleanup(s.server.Close)
+}
+
+func (s *HTTPHostTestSuite) allowServer() *models.NetworkConfig {
+	return &models.NetworkConfig{Type: models.NetworkHTTP, Domains: []string{"127.0.0.1"}}
+}
+
+// instantiate runs the http_client module with the host functions
+func (s *HTTPHostTestSuite) instantiate(network *models.NetworkConfig, limits HTTPLimits) (*httpHost, api.Module) {
+	runtime := wazero.NewRuntime(s.ctx)
+	s.T().Cleanup(func() { s.NoError(runtime.Close(s.ctx)) })
+
+	host := newHTTPHost(network, limits, zerolog.Nop())
+	s.Require().NoError(host.instantiate(s.ctx, runtime))
+	module, err := runtime.Instantiate(s.ctx, http_client.Program())
+	s.Require().NoError(err)
+	return host, module
+}
+
+// fetch makes a request from the module, returning the result of the request and the response body
+func (s *HTTPHostTestSuite) fetch(module api.Module, method, url, headers, body string) (int32, string) {
+	memory := module.Memory()
+	s.Require().True(memory.WriteString(fetchMethodPtr, method))
+	s.Require().True(memory.WriteString(fetchURLPtr, url))
+	s.Require().True(memory.WriteString(fetchHeadersPtr, headers))
+	s.Require().True(memory.WriteString(fetchBodyPtr, body))
+
+	results, err := module.ExportedFunction("fetch").Call(s.ctx,
+		fetchMethodPtr, uint64(len(method)),
+		fetchURLPtr, uint64(len(url)),
+		fetchHeadersPtr, uint64(len(headers)),
+		fetchBodyPtr, uint64(len(body)),
+		fetchOutPtr, fetchOutCap,
+	)
+	s.Require().NoError(err)
+	status := int32(uint32(results[0]))
+
+	out, ok := memory.Read(fetchOutPtr, fetchOutCap)
+	s.Require().True(ok)
+	return status, strings.TrimRight(string(out), "\x00")
+}
+
+func (s *HTTPHostTestSuite) TestRequest() {
+	host, module := s.instantiate(s.allowServer(), HTTPLimits{})
+	status, body := s.fetch(module, http.MethodPost, s.server.URL+"/echo", "X-Test: value\n", "payload")
+	s.Equal(int32(http.StatusOK), status)
+	s.Equal("POST value payload", body)
+	s.Equal(int32(len(body)), host.responseBodyLen())
+	s.Equal(uint64(len("X-Test: value\n")+len("payload")), host.sent)
+	s.Equal(uint64(len(body)), host.received)
+}
+
+func (s *HTTPHostTestSuite) TestErrorStatus() {
+	_, module := s.instantiate(s.allowServer(), HTTPLimits{})
+	status, _ := s.fetch(module, http.MethodGet, s.server.URL+"/missing", "", "")
+	s.Equal(int32(http.StatusNotFound), status)
+}
+
+func (s *HTTPHostTestSuite) TestDenied() {
+	tests := []struct {
+		name    string
+		network *models.NetworkConfig
+	}{
+		{name: "no-network", network: nil},
+		{name: "network-none", network: &models.NetworkConfig{Type: models.NetworkNone}},
+		{name: "other-domain", network: &models.NetworkConfig{Type: models.NetworkHTTP, Domains: []string{"example.com"}}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, module := s.instantiate(tt.network, HTTPLimits{})
+			status, _ := s.fetch(module, http.MethodGet, s.server.URL+"/echo", "", "")
+			s.Equal(HTTPErrDenied, status)
+		})
+	}
+	s.Zero(s.requests)
+}
+
+func (s *HTTPHostTestSuite) TestHostNetworkAllowsAnyDomain() {
+	_, module := s.instantiate(&models.NetworkConfig{Type: models.NetworkHost}, HTTPLimits{})
+	status, _ := s.fetch(module, http.MethodGet, s.server.URL+"/echo", "", "")
+	s.Equal(int32(http.StatusOK), status)
+}
+
+func (s *HTTPHostTestSuite) TestRedirectToDeniedDomain() {
+	_, module := s.instantiate(s.allowServer(), HTTPLimits{})
+	status, _ := s.fetch(module, http.MethodGet, s.server.URL+"/redirect", "", "")
+	s.Equal(HTTPErrFailed, status)
+	s.Equal(1, s.requests)
+}
+
+func (s *HTTPHostTestSuite) TestSizeLimits() {
+	_, module := s.instantiate(s.allowServer(), HTTPLimits{MaxRequestSize: 8, MaxResponseSize: 8})
+
+	status, _ := s.fetch(module, http.MethodPost, s.server.URL+"/echo", "", "too large payload")
+	s.Equal(HTTPErrRequestTooLarge, status)
+	s.Zero(s.requests)
+
+	status, body := s.fetch(module, http.MethodPost, s.server.URL+"/echo", "", "payload")
+	s.Equal(HTTPErrResponseTooLarge, status)
+	s.Empty(body)
+}
+
+func (s *HTTPHostTestSuite) TestInvalidRequest() {
+	_, module := s.instantiate(s.allowServer(), HTTPLimits{})
+
+	status, _ := s.fetch(module, http.MethodGet, "ftp://127.0.0.1/file", "", "")
+	s.Equal(HTTPErrInvalid, status)
+
+	status, _ = s.fetch(module, http.MethodGet, s.server.URL+"/echo", "not a header", "")
+	s.Equal(HTTPErrInvalid, status)
+	s.Zero(s.requests)
+}
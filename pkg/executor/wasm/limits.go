@@ -0,0 +1,101 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"go.uber.org/atomic"
+)
+
+// wazeroOverLimit is part of the error returned by wazero when a module
+// declares more memory than the runtime memory limit.
+const wazeroOverLimit = "over limit of"
+
+// fuelMeter burns a unit of fuel on every function call made by the modules
+// of an execution, and cancels the execution once its budget is exhausted.
+//
+// Function calls are the finest grained hook wazero offers without
+// instrumenting the module, so a loop that never calls a function is only
+// bounded by the execution timeout.
+type fuelMeter struct {
+	budget    uint64
+	burnt     *atomic.Uint64
+	exhausted *atomic.Bool
+	cancel    func()
+}
+
+func newFuelMeter(budget uint64, cancel func()) *fuelMeter {
+	return &fuelMeter{
+		budget:    budget,
+		burnt:     atomic.NewUint64(0),
+		exhausted: atomic.NewBool(false),
+		cancel:    cancel,
+	}
+}
+
+// withFuelMeter returns a context that meters the function calls of modules
+// compiled with it, alongside any function listener already in the context.
+func withFuelMeter(ctx context.Context, meter *fuelMeter) context.Context {
+	factory := experimental.FunctionListenerFactory(meter)
+	if existing, ok := ctx.Value(experimental.FunctionListenerFactoryKey{}).(experimental.FunctionListenerFactory); ok {
+		factory = experimental.MultiFunctionListenerFactory(existing, meter)
+	}
+	return context.WithValue(ctx, experimental.FunctionListenerFactoryKey{}, factory)
+}
+
+func (m *fuelMeter) NewFunctionListener(api.FunctionDefinition) experimental.FunctionListener {
+	return m
+}
+
+func (m *fuelMeter) Before(context.Context, api.Module, api.FunctionDefinition, []uint64, experimental.StackIterator) {
+	if m.burnt.Inc() > m.budget && m.exhausted.CompareAndSwap(false, true) {
+		m.cancel()
+	}
+}
+
+func (m *fuelMeter) After(context.Context, api.Module, api.FunctionDefinition, []uint64) {}
+
+func (m *fuelMeter) Abort(context.Context, api.Module, api.FunctionDefinition, error) {}
+
+// Exhausted returns true if the execution was stopped because it ran out of fuel.
+func (m *fuelMeter) Exhausted() bool {
+	return m != nil && m.exhausted.Load()
+}
+
+func (m *fuelMeter) err() error {
+	return fmt.Errorf("fuel budget exceeded: the module made more than %d function calls", m.budget)
+}
+
+// moduleMemory returns the memory of the module, or nil if it doesn't have one.
+// wazero returns a typed nil for modules without memory, which can't be compared to nil.
+func moduleMemory(module api.Module) api.Memory {
+	memory := module.Memory()
+	if memory == nil || reflect.ValueOf(memory).IsNil() {
+		return nil
+	}
+	return memory
+}
+
+// memoryLimitExceeded returns true if a module that failed had grown its
+// memory up to the limit, in which case it most likely failed to grow further.
+func memoryLimitExceeded(memory api.Memory, limit uint64) bool {
+	return limit > 0 && memory != nil && uint64(memory.Size())+WasmPageSize > limit
+}
+
+func memoryLimitErr(limit uint64, err error) error {
+	if err != nil {
+		return fmt.Errorf("memory limit exceeded: the module needs more than %s: %w", humanize.IBytes(limit), err)
+	}
+	return fmt.Errorf("memory limit exceeded: the module needs more than %s", humanize.IBytes(limit))
+}
+
+// isMemoryLimitErr returns true if wazero refused to instantiate a module
+// because it declares more memory than the limit.
+func isMemoryLimitErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), wazeroOverLimit)
+}
@@ -34,6 +34,8 @@ type ModuleLoader struct {
 	runtime  wazero.Runtime
 	config   wazero.ModuleConfig
 	storages []storage.PreparedStorage
+	// cache of compiled modules shared across executions, if enabled
+	cache *ModuleCache
 
 	// Runtime will throw an error if the same module is instantiated more than
 	// once. So we use this mutex around checking for modules and instantiating
@@ -44,6 +46,13 @@ func NewModuleLoader(runtime wazero.Runtime, config wazero.ModuleConfig, storage
 	return &ModuleLoader{runtime: runtime, config: config, storages: storages}
 }
 
+// WithCache compiles modules through the cache. The runtime must have been
+// created by ModuleCache.NewRuntime.
+func (loader *ModuleLoader) WithCache(cache *ModuleCache) *ModuleLoader {
+	loader.cache = cache
+	return loader
+}
+
 // Load compiles and returns a module located at the passed path.
 func (loader *ModuleLoader) Load(ctx context.Context, path string) (wazero.CompiledModule, error) {
 	ctx, span := telemetry.NewSpan(ctx, telemetry.GetTracer(), "pkg/executor/wasm.ModuleLoader.Load")
@@ -56,7 +65,12 @@ func (loader *ModuleLoader) Load(ctx context.Context, path string) (wazero.Compi
 		return nil, err
 	}
 
-	module, err := loader.runtime.CompileModule(ctx, bytes)
+	var module wazero.CompiledModule
+	if loader.cache != nil {
+		module, err = loader.cache.Compile(ctx, loader.runtime, bytes)
+	} else {
+		module, err = loader.runtime.CompileModule(ctx, bytes)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package wasm
 import (
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
 )
@@ -17,4 +18,29 @@ var (
 		"wasm_active_executions",
 		"Number of active WASM executions",
 	))
+
+	// The hit rate of the compilation cache is hits / (hits + misses)
+	ModuleCacheHits = lo.Must(telemetry.NewCounter(
+		wasmExecutorMeter,
+		"wasm_module_cache_hits",
+		"Number of WASM modules loaded from the compilation cache",
+	))
+
+	ModuleCacheMisses = lo.Must(telemetry.NewCounter(
+		wasmExecutorMeter,
+		"wasm_module_cache_misses",
+		"Number of WASM modules compiled because they were not in the compilation cache",
+	))
+
+	ModuleCacheEvictions = lo.Must(telemetry.NewCounter(
+		wasmExecutorMeter,
+		"wasm_module_cache_evictions",
+		"Number of compiled WASM modules evicted from the compilation cache",
+	))
+
+	ModuleCacheSize = lo.Must(wasmExecutorMeter.Int64UpDownCounter(
+		"wasm_module_cache_size",
+		metric.WithDescription("Size of the compiled WASM modules in the compilation cache"),
+		metric.WithUnit("By"),
+	))
 )
@@ -30,6 +30,10 @@ type EngineSpec struct {
 	// ImportModules is a slice of StorageSpec's containing WASM modules whose exports will be available as imports
 	// to the EntryModule.
 	ImportModules []*models.InputSource `json:"ImportModules,omitempty"`
+
+	// Fuel is the maximum number of function calls the job is allowed to make before it is stopped.
+	// Zero means the job is only bounded by its timeout.
+	Fuel uint64 `json:"Fuel,omitempty"`
 }
 
 func (c EngineSpec) Validate() error {
@@ -50,6 +54,7 @@ func (c EngineSpec) ToArguments(entryModule storage.PreparedStorage, importModul
 		Parameters:           c.Parameters,
 		EnvironmentVariables: c.EnvironmentVariables,
 		ImportModules:        importModules,
+		Fuel:                 c.Fuel,
 	}
 }
 
@@ -93,6 +98,7 @@ type EngineArguments struct {
 	EnvironmentVariables map[string]string
 	EntryModule          storage.PreparedStorage
 	ImportModules        []storage.PreparedStorage
+	Fuel                 uint64
 }
 
 func (c EngineArguments) Validate() error {
@@ -163,6 +169,11 @@ func (b *WasmEngineBuilder) WithImportModules(e []*models.InputSource) *WasmEngi
 	return b
 }
 
+func (b *WasmEngineBuilder) WithFuel(e uint64) *WasmEngineBuilder {
+	b.spec.Fuel = e
+	return b
+}
+
 func (b *WasmEngineBuilder) Build() (*models.SpecConfig, error) {
 	if err := b.spec.Validate(); err != nil {
 		return nil, err
@@ -132,7 +132,15 @@ func (b *Broadcaster[T]) safeChannelSend(ch chan T, value T) (isClosed bool) {
 	}
 }
 
+// Close closes the channels of all subscribed clients, which is a no-op if
+// the broadcaster is already closed.
 func (b *Broadcaster[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
 	b.closed = true
 	for ch := range b.clients {
 		close(ch)
@@ -148,6 +148,9 @@ func (lm *LogManager) Drain() {
 		lm.processItem(m)
 	}
 
+	// Nothing else will be written, so end the streams of following readers
+	lm.broadcaster.Close()
+
 	// Ask the file to sync to disk
 	_ = lm.file.Sync()
 
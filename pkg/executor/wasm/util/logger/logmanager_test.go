@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 	"time"
@@ -72,3 +73,26 @@ func (s *LogManagerTestSuite) TestLogManagerWrittenToDisk() {
 
 	lm.Close()
 }
+
+func (s *LogManagerTestSuite) TestFollowingReaderEndsAfterDrain() {
+	lm, _ := NewLogManager(s.ctx, s.id)
+	stdout, _ := lm.GetWriters()
+	stdout.Write([]byte("hello"))
+
+	read := make(chan []byte, 1)
+	go func() {
+		data, _ := io.ReadAll(lm.GetMuxedReader(true))
+		read <- data
+	}()
+
+	time.Sleep(time.Duration(100) * time.Millisecond)
+	lm.Drain()
+
+	select {
+	case data := <-read:
+		require.Contains(s.T(), string(data), "hello")
+	case <-time.After(time.Second):
+		s.Fail("following reader did not end after the logs were drained")
+	}
+	lm.Close()
+}
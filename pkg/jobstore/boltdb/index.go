@@ -75,3 +75,19 @@ func (i *Index) Remove(tx *bolt.Tx, identifier []byte, subpath ...[]byte) error
 
 	return bkt.Delete(identifier)
 }
+
+// RemoveSubpath removes the subpath and all the identifiers indexed under it
+func (i *Index) RemoveSubpath(tx *bolt.Tx, subpath ...[]byte) error {
+	if len(subpath) == 0 {
+		return errors.New("cannot remove the root of an index")
+	}
+	bkt, err := i.rootBucketPath.Sub(subpath[:len(subpath)-1]...).Get(tx, false)
+	if err != nil {
+		return err
+	}
+	err = bkt.DeleteBucket(subpath[len(subpath)-1])
+	if errors.Is(err, bolt.ErrBucketNotFound) {
+		return nil
+	}
+	return err
+}
@@ -3,27 +3,29 @@ package boltjobstore
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"slices"
-	"sort"
 	"strings"
 
 	"github.com/benbjohnson/clock"
 	"github.com/imdario/mergo"
 	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
 	bolt "go.etcd.io/bbolt"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/bacalhau-project/bacalhau/pkg/analytics"
 	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/boltdblib"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/marshaller"
-	"github.com/bacalhau-project/bacalhau/pkg/lib/math"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
 	boltdb_watcher "github.com/bacalhau-project/bacalhau/pkg/lib/watcher/boltdb"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
@@ -43,14 +45,21 @@ const (
 	BucketNamespacesIndex  = "idx_namespaces"  // namespace -> Job id
 	BucketExecutionsIndex  = "idx_executions"  // execution-id -> Job id
 	BucketEvaluationsIndex = "idx_evaluations" // evaluation-id -> Job id
+	BucketStatesIndex      = "idx_states"      // state -> Job id
+	BucketTypesIndex       = "idx_types"       // type -> Job id
+	BucketLabelsIndex      = "idx_labels"      // label key -> label value -> Job id
+	BucketCreateTimeIndex  = "idx_create_time" // create time + Job id -> {}
 
 	// Event-related buckets
-	eventsBucket      = "v1_events"
-	checkpointsBucket = "v1_checkpoints"
+	BucketEvents      = "v1_events"
+	BucketCheckpoints = "v1_checkpoints"
 )
 
 var SpecKey = []byte("spec")
 
+// timestampKeyLength is the length of the big endian timestamp prefixing time ordered keys
+const timestampKeyLength = 8
+
 type BoltJobStore struct {
 	database   *bolt.DB
 	eventStore *boltdb_watcher.EventStore
@@ -62,6 +71,10 @@ type BoltJobStore struct {
 	tagsIndex        *Index
 	executionsIndex  *Index
 	evaluationsIndex *Index
+	statesIndex      *Index
+	typesIndex       *Index
+	labelsIndex      *Index
+	createTimeIndex  *Index
 }
 
 type Option func(store *BoltJobStore)
@@ -92,6 +105,12 @@ func WithClock(clock clock.Clock) Option {
 //	NamespacesIndex  = namespace -> Job id
 //	ExecutionsIndex  = execution-id -> Job id
 //	EvaluationsIndex = evaluation-id -> Job id
+//	StatesIndex      = state -> Job id
+//	TypesIndex       = type -> Job id
+//	LabelsIndex      = label key -> label value -> Job id
+//	CreateTimeIndex  = create time + Job id -> {}
+//
+// Indexes added after jobs were stored are populated from the stored jobs when the store is opened.
 func NewBoltJobStore(dbPath string, options ...Option) (*BoltJobStore, error) {
 	db, err := boltdblib.Open(dbPath)
 	if err != nil {
@@ -108,6 +127,16 @@ func NewBoltJobStore(dbPath string, options ...Option) (*BoltJobStore, error) {
 		opt(store)
 	}
 
+	store.inProgressIndex = NewIndex(BucketProgressIndex)
+	store.namespacesIndex = NewIndex(BucketNamespacesIndex)
+	store.tagsIndex = NewIndex(BucketTagsIndex)
+	store.executionsIndex = NewIndex(BucketExecutionsIndex)
+	store.evaluationsIndex = NewIndex(BucketEvaluationsIndex)
+	store.statesIndex = NewIndex(BucketStatesIndex)
+	store.typesIndex = NewIndex(BucketTypesIndex)
+	store.labelsIndex = NewIndex(BucketLabelsIndex)
+	store.createTimeIndex = NewIndex(BucketCreateTimeIndex)
+
 	// Create the top level buckets ready for use as they
 	// will definitely be required
 	if err = db.Update(func(tx *bolt.Tx) error {
@@ -131,17 +160,30 @@ func NewBoltJobStore(dbPath string, options ...Option) (*BoltJobStore, error) {
 			}
 		}
 
+		// Query indexes that don't exist yet are populated from the existing jobs
+		queryIndexBuckets := []string{
+			BucketStatesIndex,
+			BucketTypesIndex,
+			BucketLabelsIndex,
+			BucketCreateTimeIndex,
+		}
+		reindex := false
+		for _, ib := range queryIndexBuckets {
+			if tx.Bucket([]byte(ib)) == nil {
+				reindex = true
+			}
+			if _, err := tx.CreateBucketIfNotExists([]byte(ib)); err != nil {
+				return err
+			}
+		}
+		if reindex {
+			return store.reindexJobs(tx)
+		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	store.inProgressIndex = NewIndex(BucketProgressIndex)
-	store.namespacesIndex = NewIndex(BucketNamespacesIndex)
-	store.tagsIndex = NewIndex(BucketTagsIndex)
-	store.executionsIndex = NewIndex(BucketExecutionsIndex)
-	store.evaluationsIndex = NewIndex(BucketEvaluationsIndex)
-
 	eventObjectSerializer := watcher.NewJSONSerializer()
 	err = errors.Join(
 		eventObjectSerializer.RegisterType(jobstore.EventObjectExecutionUpsert, reflect.TypeOf(models.ExecutionUpsert{})),
@@ -152,8 +194,8 @@ func NewBoltJobStore(dbPath string, options ...Option) (*BoltJobStore, error) {
 	}
 
 	eventStore, err := boltdb_watcher.NewEventStore(store.database,
-		boltdb_watcher.WithEventsBucket(eventsBucket),
-		boltdb_watcher.WithCheckpointBucket(checkpointsBucket),
+		boltdb_watcher.WithEventsBucket(BucketEvents),
+		boltdb_watcher.WithCheckpointBucket(BucketCheckpoints),
 		boltdb_watcher.WithEventSerializer(eventObjectSerializer),
 	)
 	store.eventStore = eventStore
@@ -434,51 +476,29 @@ func (b *BoltJobStore) getJobs(ctx context.Context, tx *bolt.Tx, recorder *telem
 		return nil, err
 	}
 
-	result, err := b.getJobsBuildList(ctx, tx, recorder, jobSet, query)
+	jobSet, err = b.getJobsFilterIndexes(ctx, tx, recorder, jobSet, query)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodeJobsCursor(query.Cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sort the jobs according to the query.SortBy and query.SortOrder
-	var sortFunc func(a, b models.Job) int
+	var jobs []models.Job
+	var more bool
 	switch query.SortBy {
 	case "created_at", "":
-		sortFunc = func(a, b models.Job) int { return util.Compare[int64]{}.Cmp(a.CreateTime, b.CreateTime) }
-	case "modified_at":
-		sortFunc = func(a, b models.Job) int { return util.Compare[int64]{}.Cmp(a.ModifyTime, b.ModifyTime) }
+		jobs, more, err = b.getJobsByCreateTime(ctx, tx, recorder, jobSet, query, cursor)
+	case "modified_at", "id":
+		jobs, more, err = b.getJobsSorted(ctx, tx, recorder, jobSet, query, cursor)
 	default:
 		return nil, fmt.Errorf("OrderBy %s not supported for listJobs", query.SortBy)
 	}
-	if query.SortReverse {
-		baseSortFnc := sortFunc
-		sortFunc = func(a, b models.Job) int {
-			r := baseSortFnc(a, b)
-			if r == -1 {
-				return 1
-			}
-			if r == 1 {
-				return -1
-			}
-			return 0
-		}
-	}
-
-	slices.SortFunc(result, sortFunc)
-	recorder.Latency(ctx, jobstore.OperationPartDuration, "sort")
-
-	// If we have a selector, filter the results to only those that match
-	if query.Selector != nil {
-		var filtered []models.Job
-		for _, job := range result {
-			if query.Selector.Matches(labels.Set(job.Labels)) {
-				filtered = append(filtered, job)
-			}
-		}
-		result = filtered
-		recorder.Latency(ctx, jobstore.OperationPartDuration, "filter_selector")
+	if err != nil {
+		return nil, err
 	}
-
-	jobs, more := b.getJobsWithinLimit(result, query)
 	recorder.Latency(ctx, jobstore.OperationPartDuration, "filter_limit")
 
 	response := &jobstore.JobQueryResponse{
@@ -489,6 +509,7 @@ func (b *BoltJobStore) getJobs(ctx context.Context, tx *bolt.Tx, recorder *telem
 
 	if more {
 		response.NextOffset = query.Offset + uint64(query.Limit)
+		response.NextCursor = encodeJobsCursor(jobSortKey(&jobs[len(jobs)-1], query.SortBy))
 	}
 
 	return response, nil
@@ -580,75 +601,270 @@ func (b *BoltJobStore) getJobsExcludeTags(ctx context.Context, tx *bolt.Tx, reco
 	return jobSet, nil
 }
 
-func (b *BoltJobStore) getJobsBuildList(ctx context.Context, tx *bolt.Tx, recorder *telemetry.MetricRecorder,
-	jobSet map[string]struct{}, query jobstore.JobQuery) ([]models.Job, error) {
-	var result []models.Job
+// getJobsFilterIndexes filters out jobs that don't match the states, types and label
+// equality requirements of the query, using the corresponding indexes.
+func (b *BoltJobStore) getJobsFilterIndexes(ctx context.Context, tx *bolt.Tx, recorder *telemetry.MetricRecorder,
+	jobSet map[string]struct{}, query jobstore.JobQuery) (map[string]struct{}, error) {
+	defer recorder.Latency(ctx, jobstore.OperationPartDuration, "filter_indexes")
 
-	defer recorder.Latency(ctx, jobstore.OperationPartDuration, "build_list")
+	if len(query.States) > 0 {
+		states := lo.Map(query.States, func(state models.JobStateType, _ int) []byte {
+			return []byte(state.String())
+		})
+		if err := intersectIndex(tx, jobSet, b.statesIndex, states); err != nil {
+			return nil, err
+		}
+	}
 
-	for key := range jobSet {
-		var job models.Job
+	if len(query.Types) > 0 {
+		types := lo.Map(query.Types, func(jobType string, _ int) []byte {
+			return []byte(jobType)
+		})
+		if err := intersectIndex(tx, jobSet, b.typesIndex, types); err != nil {
+			return nil, err
+		}
+	}
 
-		path := NewBucketPath(BucketJobs, key)
-		data := GetBucketData(tx, path, SpecKey)
+	if query.Selector != nil {
+		requirements, _ := query.Selector.Requirements()
+		for _, requirement := range requirements {
+			switch requirement.Operator() {
+			case selection.Equals, selection.DoubleEquals, selection.In:
+			default:
+				// other operators can't be answered by the index, and are evaluated
+				// when the selector is matched against the jobs
+				continue
+			}
+			values := requirement.Values().List()
+			if slices.Contains(values, "") {
+				continue
+			}
+			subpaths := lo.Map(values, func(value string, _ int) []byte {
+				return []byte(value)
+			})
+			key := []byte(requirement.Key())
+			if err := intersectIndex(tx, jobSet, b.labelsIndex, subpaths, key); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
-		recorder.Count(ctx, jobstore.RowsRead)
+	return jobSet, nil
+}
 
-		err := b.marshaller.Unmarshal(data, &job)
+// intersectIndex removes from the job set the jobs not indexed under any of the subpaths.
+// The prefix is prepended to each subpath.
+func intersectIndex(tx *bolt.Tx, jobSet map[string]struct{}, index *Index, subpaths [][]byte, prefix ...[]byte) error {
+	matching := make(map[string]struct{})
+	for _, subpath := range subpaths {
+		ids, err := index.List(tx, append(slices.Clone(prefix), subpath)...)
 		if err != nil {
-			return nil, err
+			return NewBoltDBError(err)
+		}
+		for _, id := range ids {
+			matching[string(id)] = struct{}{}
+		}
+	}
+	for k := range jobSet {
+		if _, ok := matching[k]; !ok {
+			delete(jobSet, k)
 		}
-		result = append(result, job)
 	}
+	return nil
+}
+
+// getJobsByCreateTime walks the create time index in the requested order, starting after
+// the cursor if set, and returns up to query.Limit jobs of the set that match the query.
+// The returned bool is true if more jobs match the query.
+func (b *BoltJobStore) getJobsByCreateTime(ctx context.Context, tx *bolt.Tx, recorder *telemetry.MetricRecorder,
+	jobSet map[string]struct{}, query jobstore.JobQuery, cursor []byte) ([]models.Job, bool, error) {
+	defer recorder.Latency(ctx, jobstore.OperationPartDuration, "build_list")
 
-	listSorter := b.getListSorter(result, query)
-	sort.Slice(result, listSorter)
+	bkt, err := NewBucketPath(BucketCreateTimeIndex).Get(tx, false)
+	if err != nil {
+		return nil, false, NewBoltDBError(err)
+	}
+
+	// position the cursor on the first candidate key, and pick the direction to move it
+	c := bkt.Cursor()
+	var k []byte
+	next := c.Next
+	if !query.SortReverse {
+		switch {
+		case cursor != nil:
+			if k, _ = c.Seek(cursor); bytes.Equal(k, cursor) {
+				k, _ = c.Next()
+			}
+		case !query.CreatedAfter.IsZero():
+			k, _ = c.Seek(uint64ToBytes(uint64(query.CreatedAfter.UnixNano()))) //nolint:gosec // G115: times are positive
+		default:
+			k, _ = c.First()
+		}
+	} else {
+		next = c.Prev
+		seek := cursor
+		if seek == nil && !query.CreatedBefore.IsZero() {
+			seek = uint64ToBytes(uint64(query.CreatedBefore.UnixNano())) //nolint:gosec // G115: times are positive
+		}
+		if seek == nil {
+			k, _ = c.Last()
+		} else if k, _ = c.Seek(seek); k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+	}
 
-	return result, nil
+	offset := query.Offset
+	if cursor != nil {
+		offset = 0
+	}
+	var jobs []models.Job
+	for ; k != nil; k, _ = next() {
+		createTime, jobID := decodeCreateTimeIndexKey(k)
+		if !query.SortReverse && !query.CreatedBefore.IsZero() && createTime >= query.CreatedBefore.UnixNano() {
+			break
+		}
+		if query.SortReverse && !query.CreatedAfter.IsZero() && createTime < query.CreatedAfter.UnixNano() {
+			break
+		}
+		if _, ok := jobSet[jobID]; !ok {
+			continue
+		}
+		job, err := b.readJob(ctx, recorder, tx, jobID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !jobMatchesQuery(&job, query) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if query.Limit > 0 && len(jobs) == int(query.Limit) {
+			return jobs, true, nil
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, false, nil
 }
 
-// TODO: Ignoring linting for now. Fixing this is a task by itself
-//
-//nolint:gosec // G115: slice length is always non-negative in Go
-func (b *BoltJobStore) getJobsWithinLimit(jobs []models.Job, query jobstore.JobQuery) ([]models.Job, bool) {
-	if query.Offset >= uint64(len(jobs)) {
-		return []models.Job{}, false
+// getJobsSorted loads all the jobs of the set that match the query, sorts them in memory
+// and returns up to query.Limit jobs after the cursor if set.
+// The returned bool is true if more jobs match the query.
+func (b *BoltJobStore) getJobsSorted(ctx context.Context, tx *bolt.Tx, recorder *telemetry.MetricRecorder,
+	jobSet map[string]struct{}, query jobstore.JobQuery, cursor []byte) ([]models.Job, bool, error) {
+	type sortableJob struct {
+		key []byte
+		job models.Job
+	}
+	var result []sortableJob
+	for jobID := range jobSet {
+		job, err := b.readJob(ctx, recorder, tx, jobID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !jobMatchesQuery(&job, query) {
+			continue
+		}
+		key := jobSortKey(&job, query.SortBy)
+		// skip the jobs up to and including the cursor
+		if cursor != nil {
+			cmp := bytes.Compare(key, cursor)
+			if (!query.SortReverse && cmp <= 0) || (query.SortReverse && cmp >= 0) {
+				continue
+			}
+		}
+		result = append(result, sortableJob{key: key, job: job})
 	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, "build_list")
+
+	slices.SortFunc(result, func(a, b sortableJob) int {
+		if query.SortReverse {
+			return bytes.Compare(b.key, a.key)
+		}
+		return bytes.Compare(a.key, b.key)
+	})
+	recorder.Latency(ctx, jobstore.OperationPartDuration, "sort")
 
-	jobsFiltered := jobs[query.Offset:]
-	if query.Limit == 0 {
-		return jobsFiltered, false
+	if cursor == nil {
+		result = result[min(query.Offset, uint64(len(result))):]
+	}
+	more := false
+	if query.Limit > 0 && len(result) > int(query.Limit) {
+		result = result[:query.Limit]
+		more = true
 	}
+	return lo.Map(result, func(item sortableJob, _ int) models.Job { return item.job }), more, nil
+}
 
-	limit := math.Min(uint32(len(jobsFiltered)), query.Limit)
-	filteredLength := uint32(len(jobsFiltered))
+func (b *BoltJobStore) readJob(
+	ctx context.Context, recorder *telemetry.MetricRecorder, tx *bolt.Tx, jobID string) (models.Job, error) {
+	var job models.Job
+	data := GetBucketData(tx, NewBucketPath(BucketJobs, jobID), SpecKey)
+	recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+	recorder.Count(ctx, jobstore.RowsRead)
+	err := b.marshaller.Unmarshal(data, &job)
+	return job, err
+}
 
-	jobsFiltered = jobsFiltered[:limit]
+// jobMatchesQuery checks the filters of the query that are not answered by an index
+func jobMatchesQuery(job *models.Job, query jobstore.JobQuery) bool {
+	if query.NamePrefix != "" && !strings.HasPrefix(job.Name, query.NamePrefix) {
+		return false
+	}
+	if !query.CreatedAfter.IsZero() && job.CreateTime < query.CreatedAfter.UnixNano() {
+		return false
+	}
+	if !query.CreatedBefore.IsZero() && job.CreateTime >= query.CreatedBefore.UnixNano() {
+		return false
+	}
+	if query.Selector != nil && !query.Selector.Matches(labels.Set(job.Labels)) {
+		return false
+	}
+	return true
+}
 
-	return jobsFiltered, filteredLength > query.Limit
+// jobSortKey returns the key jobs are ordered by when sorted by the given field.
+// Keys are unique, as they all end with the job ID, and are used as pagination cursors.
+func jobSortKey(job *models.Job, sortBy string) []byte {
+	switch sortBy {
+	case "id":
+		return []byte(job.ID)
+	case "modified_at":
+		return append(uint64ToBytes(uint64(job.ModifyTime)), job.ID...) //nolint:gosec // G115: times are positive
+	default:
+		return createTimeIndexKey(job)
+	}
 }
 
-func (b *BoltJobStore) getListSorter(jobs []models.Job, query jobstore.JobQuery) func(i, j int) bool {
-	return func(i, j int) bool {
-		switch query.SortBy {
-		case "id":
-			if query.SortReverse {
-				// what does it mean to sort by ID?
-				return jobs[i].ID > jobs[j].ID
-			} else {
-				return jobs[i].ID < jobs[j].ID
-			}
-		case "created_at":
-			if query.SortReverse {
-				return jobs[i].CreateTime > jobs[j].CreateTime
-			} else {
-				return jobs[i].CreateTime < jobs[j].CreateTime
-			}
-		default:
-			return false
-		}
+// createTimeIndexKey returns the key of the job in the create time index, which is
+// the big endian create time followed by the job ID, so that keys are ordered by time.
+func createTimeIndexKey(job *models.Job) []byte {
+	return append(uint64ToBytes(uint64(job.CreateTime)), job.ID...) //nolint:gosec // G115: times are positive
+}
+
+func decodeCreateTimeIndexKey(key []byte) (int64, string) {
+	if len(key) < timestampKeyLength {
+		return 0, string(key)
 	}
+	return int64(bytesToUint64(key[:timestampKeyLength])), string(key[timestampKeyLength:]) //nolint:gosec // G115
+}
+
+func encodeJobsCursor(key []byte) string {
+	return hex.EncodeToString(key)
+}
+
+func decodeJobsCursor(cursor string) ([]byte, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(cursor)
+	if err != nil {
+		return nil, jobstore.NewErrInvalidCursor(cursor)
+	}
+	return key, nil
 }
 
 // GetExecutions returns the current job state for the provided job id
@@ -992,11 +1208,93 @@ func (b *BoltJobStore) createJob(
 			return err
 		}
 	}
+
+	if err = b.addQueryIndexes(tx, &job); err != nil {
+		return NewBoltDBError(err)
+	}
 	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartIndexWrite)
 
 	return nil
 }
 
+// addQueryIndexes adds the job to the indexes used to filter and order job queries
+func (b *BoltJobStore) addQueryIndexes(tx *bolt.Tx, job *models.Job) error {
+	jobIDKey := []byte(job.ID)
+	if err := b.statesIndex.Add(tx, jobIDKey, []byte(job.State.StateType.String())); err != nil {
+		return err
+	}
+	if job.Type != "" {
+		if err := b.typesIndex.Add(tx, jobIDKey, []byte(job.Type)); err != nil {
+			return err
+		}
+	}
+	for key, value := range job.Labels {
+		// bucket names can't be empty, so empty labels are only matched when
+		// evaluating the selector against the jobs
+		if key == "" || value == "" {
+			continue
+		}
+		if err := b.labelsIndex.Add(tx, jobIDKey, []byte(key), []byte(value)); err != nil {
+			return err
+		}
+	}
+	return b.createTimeIndex.Add(tx, createTimeIndexKey(job))
+}
+
+// removeQueryIndexes removes the job from the indexes used to filter and order job queries
+func (b *BoltJobStore) removeQueryIndexes(tx *bolt.Tx, job *models.Job) error {
+	jobIDKey := []byte(job.ID)
+	if err := b.statesIndex.Remove(tx, jobIDKey, []byte(job.State.StateType.String())); err != nil &&
+		!errors.Is(err, bolt.ErrBucketNotFound) {
+		return err
+	}
+	if job.Type != "" {
+		if err := b.typesIndex.Remove(tx, jobIDKey, []byte(job.Type)); err != nil &&
+			!errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+	}
+	for key, value := range job.Labels {
+		if key == "" || value == "" {
+			continue
+		}
+		if err := b.labelsIndex.Remove(tx, jobIDKey, []byte(key), []byte(value)); err != nil &&
+			!errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+	}
+	return b.createTimeIndex.Remove(tx, createTimeIndexKey(job))
+}
+
+// reindexJobs populates the query indexes from the stored jobs
+func (b *BoltJobStore) reindexJobs(tx *bolt.Tx) error {
+	bkt, err := NewBucketPath(BucketJobs).Get(tx, false)
+	if err != nil {
+		return err
+	}
+	var jobIDs []string
+	if err = bkt.ForEachBucket(func(k []byte) error {
+		jobIDs = append(jobIDs, string(k))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, jobID := range jobIDs {
+		var job models.Job
+		data := GetBucketData(tx, NewBucketPath(BucketJobs, jobID), SpecKey)
+		if err = b.marshaller.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("failed to index job %s: %w", jobID, err)
+		}
+		if err = b.addQueryIndexes(tx, &job); err != nil {
+			return fmt.Errorf("failed to index job %s: %w", jobID, err)
+		}
+	}
+	if len(jobIDs) > 0 {
+		log.Info().Msgf("indexed %d existing jobs in the job store", len(jobIDs))
+	}
+	return nil
+}
+
 // DeleteJob removes the specified job from the system entirely
 func (b *BoltJobStore) DeleteJob(ctx context.Context, jobID string) (err error) {
 	recorder := b.metricRecorder(ctx, BucketJobs, jobstore.AttrOperationDelete)
@@ -1020,6 +1318,20 @@ func (b *BoltJobStore) deleteJob(
 		return NewBoltDBError(err)
 	}
 
+	// Remove the executions and evaluations of the job from their indexes before
+	// their buckets are deleted along with the job bucket
+	for _, sub := range []struct {
+		bucket string
+		index  *Index
+	}{
+		{bucket: BucketJobExecutions, index: b.executionsIndex},
+		{bucket: BucketJobEvaluations, index: b.evaluationsIndex},
+	} {
+		if err = b.removeJobIndexEntries(tx, jobID, sub.bucket, sub.index); err != nil {
+			return err
+		}
+	}
+
 	// Delete the Job bucket (and everything within it)
 	if bkt, err := NewBucketPath(BucketJobs).Get(tx, false); err != nil {
 		return NewBoltDBError(err)
@@ -1050,11 +1362,39 @@ func (b *BoltJobStore) deleteJob(
 			return err
 		}
 	}
+
+	if err = b.removeQueryIndexes(tx, &job); err != nil {
+		return NewBoltDBError(err)
+	}
 	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartIndexDelete)
 
 	return nil
 }
 
+// removeJobIndexEntries removes the index entries of every object held in the bucket of the job
+func (b *BoltJobStore) removeJobIndexEntries(tx *bolt.Tx, jobID string, bucket string, index *Index) error {
+	bkt, err := NewBucketPath(BucketJobs, jobID, bucket).Get(tx, false)
+	if err != nil {
+		if errors.Is(err, bolt.ErrBucketNotFound) {
+			return nil
+		}
+		return NewBoltDBError(err)
+	}
+	var ids [][]byte
+	if err = bkt.ForEach(func(k []byte, _ []byte) error {
+		ids = append(ids, bytes.Clone(k))
+		return nil
+	}); err != nil {
+		return NewBoltDBError(err)
+	}
+	for _, id := range ids {
+		if err = index.RemoveSubpath(tx, id); err != nil {
+			return NewBoltDBError(err)
+		}
+	}
+	return nil
+}
+
 // UpdateJobState updates the current state for a single Job, appending an entry to
 // the history at the same time
 func (b *BoltJobStore) UpdateJobState(ctx context.Context, request jobstore.UpdateJobStateRequest) (err error) {
@@ -1088,6 +1428,14 @@ func (b *BoltJobStore) updateJobState(ctx context.Context, tx *bolt.Tx, recorder
 		return jobstore.NewErrJobAlreadyTerminal(request.JobID, job.State.StateType, request.NewState)
 	}
 
+	// move the job to its new state in the states index
+	if err = b.statesIndex.Remove(tx, []byte(job.ID), []byte(job.State.StateType.String())); err != nil {
+		return NewBoltDBError(err)
+	}
+	if err = b.statesIndex.Add(tx, []byte(job.ID), []byte(request.NewState.String())); err != nil {
+		return NewBoltDBError(err)
+	}
+
 	// update the job state
 	job.State.StateType = request.NewState
 	job.State.Message = request.Message
@@ -1514,6 +1862,52 @@ func (b *BoltJobStore) getEvaluationJobID(ctx context.Context, tx *bolt.Tx, reco
 	return string(keys[0]), nil
 }
 
+// GetEvaluations retrieves all evaluations of the specified job, ordered by creation time
+func (b *BoltJobStore) GetEvaluations(ctx context.Context, jobID string) (evals []models.Evaluation, err error) {
+	recorder := b.metricRecorder(ctx, BucketJobEvaluations, jobstore.AttrOperationList)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = boltdblib.View(ctx, b.database, func(tx *bolt.Tx) (err error) {
+		evals, err = b.getEvaluations(ctx, tx, recorder, jobID)
+		return
+	})
+	return evals, err
+}
+
+func (b *BoltJobStore) getEvaluations(
+	ctx context.Context, tx *bolt.Tx, recorder *telemetry.MetricRecorder, jobID string) ([]models.Evaluation, error) {
+	if _, err := b.getJob(ctx, tx, recorder, jobID); err != nil {
+		return nil, err
+	}
+
+	bkt, err := NewBucketPath(BucketJobs, jobID, BucketJobEvaluations).Get(tx, false)
+	if err != nil {
+		return nil, NewBoltDBError(err)
+	}
+
+	var evals []models.Evaluation
+	err = bkt.ForEach(func(_ []byte, v []byte) error {
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(v)))
+		recorder.Count(ctx, jobstore.RowsRead)
+
+		var eval models.Evaluation
+		if err := b.marshaller.Unmarshal(v, &eval); err != nil {
+			return err
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+		evals = append(evals, eval)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(evals, func(a, b models.Evaluation) int { return util.Compare[int64]{}.Cmp(a.CreateTime, b.CreateTime) })
+	return evals, nil
+}
+
 // DeleteEvaluation deletes the specified evaluation
 func (b *BoltJobStore) DeleteEvaluation(ctx context.Context, id string) (err error) {
 	recorder := b.metricRecorder(ctx, BucketJobEvaluations, jobstore.AttrOperationDelete)
@@ -1567,6 +1961,25 @@ func (b *BoltJobStore) GetEventStore() watcher.EventStore {
 	return b.eventStore
 }
 
+// Snapshot writes a copy of the database to w from a read transaction, so that
+// it is consistent without blocking writers. As the event store shares the
+// database, the copy also holds the event log and watcher checkpoints.
+func (b *BoltJobStore) Snapshot(ctx context.Context, w io.Writer) (n int64, err error) {
+	recorder := b.metricRecorder(ctx, "", jobstore.AttrOperationSnapshot)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = boltdblib.View(ctx, b.database, func(tx *bolt.Tx) error {
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		return n, bacerrors.Wrap(err, "failed to snapshot job store").
+			WithComponent(BoltDBComponent)
+	}
+	return n, nil
+}
+
 func (b *BoltJobStore) Close(ctx context.Context) error {
 	log.Ctx(ctx).Debug().Msg("closing bolt-backed job store")
 	var mErr error
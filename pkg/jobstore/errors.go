@@ -148,3 +148,9 @@ func NewBadRequestError(message string) bacerrors.Error {
 		WithCode(bacerrors.BadRequestError).
 		WithComponent(JobStoreComponent)
 }
+
+func NewErrInvalidCursor(cursor string) bacerrors.Error {
+	return bacerrors.New("invalid job list cursor: %s", cursor).
+		WithCode(bacerrors.ValidationError).
+		WithComponent(JobStoreComponent)
+}
@@ -75,11 +75,12 @@ var (
 
 // Common attribute keys for jobstore
 const (
-	AttrOperationCreate = "create"
-	AttrOperationGet    = "get"
-	AttrOperationList   = "list"
-	AttrOperationUpdate = "update"
-	AttrOperationDelete = "delete"
+	AttrOperationCreate   = "create"
+	AttrOperationGet      = "get"
+	AttrOperationList     = "list"
+	AttrOperationUpdate   = "update"
+	AttrOperationDelete   = "delete"
+	AttrOperationSnapshot = "snapshot"
 
 	// Data operations
 	AttrOperationPartRead   = "read"
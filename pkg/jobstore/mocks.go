@@ -6,6 +6,7 @@ package jobstore
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -297,6 +298,21 @@ func (mr *MockStoreMockRecorder) GetEvaluation(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvaluation", reflect.TypeOf((*MockStore)(nil).GetEvaluation), ctx, id)
 }
 
+// GetEvaluations mocks base method.
+func (m *MockStore) GetEvaluations(ctx context.Context, jobID string) ([]models.Evaluation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvaluations", ctx, jobID)
+	ret0, _ := ret[0].([]models.Evaluation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvaluations indicates an expected call of GetEvaluations.
+func (mr *MockStoreMockRecorder) GetEvaluations(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvaluations", reflect.TypeOf((*MockStore)(nil).GetEvaluations), ctx, jobID)
+}
+
 // GetEventStore mocks base method.
 func (m *MockStore) GetEventStore() watcher.EventStore {
 	m.ctrl.T.Helper()
@@ -386,6 +402,21 @@ func (mr *MockStoreMockRecorder) GetJobs(ctx, query interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockStore)(nil).GetJobs), ctx, query)
 }
 
+// Snapshot mocks base method.
+func (m *MockStore) Snapshot(ctx context.Context, w io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockStoreMockRecorder) Snapshot(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStore)(nil).Snapshot), ctx, w)
+}
+
 // UpdateExecution mocks base method.
 func (m *MockStore) UpdateExecution(ctx context.Context, request UpdateExecutionRequest) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,14 @@
+package sqlitejobstore
+
+import (
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+)
+
+const SQLiteComponent = "SQLite"
+
+// NewSQLiteError wraps an error returned by the database
+func NewSQLiteError(err error) bacerrors.Error {
+	return bacerrors.Wrap(err, "sqlite job store operation failed").
+		WithCode(bacerrors.DatastoreFailure).
+		WithComponent(SQLiteComponent)
+}
@@ -0,0 +1,192 @@
+package sqlitejobstore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	boltjobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/boltdb"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/sqlitedblib"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
+)
+
+// boltOpenTimeout is how long to wait for the lock of the BoltDB database,
+// which is held by the orchestrator while it is running
+const boltOpenTimeout = 1 * time.Second
+
+// MigrationSummary holds the number of records copied by MigrateFromBoltDB
+type MigrationSummary struct {
+	Jobs           int
+	Executions     int
+	Evaluations    int
+	HistoryEntries int
+	Events         int
+	Checkpoints    int
+}
+
+// MigrateFromBoltDB copies the jobs, executions, evaluations, job history, events and
+// watcher checkpoints of the BoltDB job store at boltPath into the store, preserving
+// their states, revisions, timestamps and sequence numbers.
+// The store must be empty, and the records are copied in a single transaction
+// so that a failed migration leaves the store empty.
+func (s *SQLiteJobStore) MigrateFromBoltDB(ctx context.Context, boltPath string) (summary MigrationSummary, err error) {
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationCreate, attribute.Bool("migration", true))
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	if _, err = os.Stat(boltPath); err != nil {
+		return summary, bacerrors.Wrap(err, "failed to read BoltDB job store at %s", boltPath).
+			WithCode(bacerrors.ConfigurationError).
+			WithComponent(SQLiteComponent)
+	}
+	source, err := bolt.Open(boltPath, 0o600, &bolt.Options{ReadOnly: true, Timeout: boltOpenTimeout}) //nolint:mnd
+	if err != nil {
+		return summary, bacerrors.Wrap(err, "failed to open BoltDB job store at %s", boltPath).
+			WithHint("Stop the orchestrator using the job store before migrating it").
+			WithCode(bacerrors.ConfigurationError).
+			WithComponent(SQLiteComponent)
+	}
+	defer source.Close()
+
+	serializer, err := newEventObjectSerializer()
+	if err != nil {
+		return summary, err
+	}
+
+	err = sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) error {
+		empty, err := s.isEmpty(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return bacerrors.New("the SQLite job store already holds data").
+				WithHint("Migrate into a new database file").
+				WithCode(bacerrors.ValidationError).
+				WithComponent(SQLiteComponent)
+		}
+
+		return source.View(func(boltTx *bolt.Tx) error {
+			return s.migrateBoltTx(ctx, tx, boltTx, recorder, serializer, &summary)
+		})
+	})
+	return summary, err
+}
+
+// isEmpty returns true if the store holds no jobs and no events
+func (s *SQLiteJobStore) isEmpty(ctx context.Context, tx *sqlitedblib.Tx) (bool, error) {
+	exists, err := sqlitedblib.Exists(ctx, tx,
+		fmt.Sprintf(`SELECT 1 FROM jobs UNION ALL SELECT 1 FROM %s`, tableEvents))
+	if err != nil {
+		return false, NewSQLiteError(err)
+	}
+	return !exists, nil
+}
+
+func (s *SQLiteJobStore) migrateBoltTx(ctx context.Context, tx *sqlitedblib.Tx, boltTx *bolt.Tx,
+	recorder *telemetry.MetricRecorder, serializer watcher.Serializer, summary *MigrationSummary) error {
+	if jobs := boltTx.Bucket([]byte(boltjobstore.BucketJobs)); jobs != nil {
+		err := jobs.ForEachBucket(func(jobID []byte) error {
+			if err := s.migrateBoltJob(ctx, tx, jobs.Bucket(jobID), recorder, summary); err != nil {
+				return fmt.Errorf("failed to migrate job %s: %w", jobID, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if events := boltTx.Bucket([]byte(boltjobstore.BucketEvents)); events != nil {
+		// event keys are the big endian sequence number followed by the timestamp
+		err := events.ForEach(func(k, v []byte) error {
+			var event watcher.Event
+			if err := serializer.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("failed to read event: %w", err)
+			}
+			event.SeqNum = binary.BigEndian.Uint64(k[:timestampKeyLength])
+			summary.Events++
+			return s.eventStore.ImportEventTx(ctx, tx, event)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if checkpoints := boltTx.Bucket([]byte(boltjobstore.BucketCheckpoints)); checkpoints != nil {
+		err := checkpoints.ForEach(func(k, v []byte) error {
+			summary.Checkpoints++
+			return s.eventStore.StoreCheckpointTx(ctx, tx, string(k), binary.BigEndian.Uint64(v))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteJobStore) migrateBoltJob(ctx context.Context, tx *sqlitedblib.Tx, bkt *bolt.Bucket,
+	recorder *telemetry.MetricRecorder, summary *MigrationSummary) error {
+	var job models.Job
+	if err := s.marshaller.Unmarshal(bkt.Get(boltjobstore.SpecKey), &job); err != nil {
+		return err
+	}
+	if err := s.insertJob(ctx, tx, recorder, job); err != nil {
+		return err
+	}
+	summary.Jobs++
+
+	if executions := bkt.Bucket([]byte(boltjobstore.BucketJobExecutions)); executions != nil {
+		err := executions.ForEach(func(_, v []byte) error {
+			var execution models.Execution
+			if err := s.marshaller.Unmarshal(v, &execution); err != nil {
+				return err
+			}
+			summary.Executions++
+			return s.insertExecution(ctx, tx, recorder, execution)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if evaluations := bkt.Bucket([]byte(boltjobstore.BucketJobEvaluations)); evaluations != nil {
+		err := evaluations.ForEach(func(_, v []byte) error {
+			var eval models.Evaluation
+			if err := s.marshaller.Unmarshal(v, &eval); err != nil {
+				return err
+			}
+			summary.Evaluations++
+			return s.insertEvaluation(ctx, tx, recorder, eval)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// history keys are the big endian sequence numbers of the entries
+	if history := bkt.Bucket([]byte(boltjobstore.BucketJobHistory)); history != nil {
+		err := history.ForEach(func(k, v []byte) error {
+			var item models.JobHistory
+			if err := s.marshaller.Unmarshal(v, &item); err != nil {
+				return err
+			}
+			item.JobID = job.ID
+			item.SeqNum = binary.BigEndian.Uint64(k)
+			summary.HistoryEntries++
+			return s.insertHistory(ctx, tx, recorder, item)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package sqlitejobstore
+
+const (
+	TableJobs        = "jobs"
+	TableJobLabels   = "job_labels"
+	TableExecutions  = "executions"
+	TableEvaluations = "evaluations"
+	TableJobHistory  = "job_history"
+
+	// Event-related tables
+	tableEvents      = "events"
+	tableCheckpoints = "event_checkpoints"
+)
+
+// schemaVersions holds the statements creating each version of the job store schema.
+// New versions are appended, and existing versions must never be modified,
+// as databases record the latest version they were migrated to.
+//
+// Jobs, executions, evaluations and history entries are stored as JSON documents in
+// the data column, alongside the columns they are queried and ordered by, so that the
+// store can be inspected and reported on with plain SQL.
+var schemaVersions = [][]string{
+	// version 1
+	{
+		`CREATE TABLE jobs (
+			id          TEXT PRIMARY KEY,
+			name        TEXT NOT NULL,
+			namespace   TEXT NOT NULL,
+			type        TEXT NOT NULL,
+			state       TEXT NOT NULL,
+			terminal    INTEGER NOT NULL,
+			create_time INTEGER NOT NULL,
+			modify_time INTEGER NOT NULL,
+			revision    INTEGER NOT NULL,
+			data        BLOB NOT NULL
+		)`,
+		`CREATE INDEX idx_jobs_namespace ON jobs (namespace)`,
+		`CREATE INDEX idx_jobs_state ON jobs (state)`,
+		`CREATE INDEX idx_jobs_type ON jobs (type)`,
+		`CREATE INDEX idx_jobs_name ON jobs (name)`,
+		`CREATE INDEX idx_jobs_create_time ON jobs (create_time, id)`,
+		`CREATE INDEX idx_jobs_modify_time ON jobs (modify_time, id)`,
+		`CREATE INDEX idx_jobs_in_progress ON jobs (type, id) WHERE terminal = 0`,
+
+		`CREATE TABLE job_labels (
+			job_id TEXT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			key    TEXT NOT NULL,
+			value  TEXT NOT NULL,
+			PRIMARY KEY (job_id, key)
+		)`,
+		`CREATE INDEX idx_job_labels_key_value ON job_labels (key, value)`,
+		`CREATE INDEX idx_job_labels_tag ON job_labels (lower(key))`,
+
+		`CREATE TABLE executions (
+			id            TEXT PRIMARY KEY,
+			job_id        TEXT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			node_id       TEXT NOT NULL,
+			compute_state TEXT NOT NULL,
+			desired_state TEXT NOT NULL,
+			create_time   INTEGER NOT NULL,
+			modify_time   INTEGER NOT NULL,
+			revision      INTEGER NOT NULL,
+			data          BLOB NOT NULL
+		)`,
+		`CREATE INDEX idx_executions_job ON executions (job_id, create_time)`,
+		`CREATE INDEX idx_executions_node ON executions (node_id)`,
+
+		`CREATE TABLE evaluations (
+			id     TEXT PRIMARY KEY,
+			job_id TEXT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			data   BLOB NOT NULL
+		)`,
+		`CREATE INDEX idx_evaluations_job ON evaluations (job_id)`,
+
+		`CREATE TABLE job_history (
+			job_id       TEXT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			seq_num      INTEGER NOT NULL,
+			type         TEXT NOT NULL,
+			execution_id TEXT NOT NULL,
+			time         INTEGER NOT NULL,
+			data         BLOB NOT NULL,
+			PRIMARY KEY (job_id, seq_num)
+		)`,
+	},
+}
@@ -0,0 +1,1482 @@
+package sqlitejobstore
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	"github.com/imdario/mergo"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/bacalhau-project/bacalhau/pkg/analytics"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/marshaller"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/sqlitedblib"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	sqlite_watcher "github.com/bacalhau-project/bacalhau/pkg/lib/watcher/sqlite"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
+	"github.com/bacalhau-project/bacalhau/pkg/util/idgen"
+)
+
+// timestampKeyLength is the length of the big endian timestamp prefixing time ordered cursors
+const timestampKeyLength = 8
+
+// maxRune is appended to a prefix to get the upper bound of the strings starting with the prefix
+const maxRune = "\U0010FFFF"
+
+type SQLiteJobStore struct {
+	database   *sqlitedblib.DB
+	eventStore *sqlite_watcher.EventStore
+	clock      clock.Clock
+	marshaller marshaller.Marshaller
+}
+
+type Option func(store *SQLiteJobStore)
+
+func WithClock(clock clock.Clock) Option {
+	return func(store *SQLiteJobStore) {
+		store.clock = clock
+	}
+}
+
+// NewSQLiteJobStore creates a new job store backed by the SQLite database at dbPath.
+// Jobs, executions, evaluations and job history are held in their own tables,
+// which are indexed by the fields jobs are queried by. See schemaVersions for the
+// schema, which is migrated to the latest version when the store is opened.
+func NewSQLiteJobStore(dbPath string, options ...Option) (*SQLiteJobStore, error) {
+	db, err := sqlitedblib.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &SQLiteJobStore{
+		database:   db,
+		clock:      clock.New(),
+		marshaller: marshaller.NewJSONMarshaller(),
+	}
+
+	for _, opt := range options {
+		opt(store)
+	}
+
+	if err = sqlitedblib.Migrate(context.Background(), db, schemaVersions); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	eventObjectSerializer, err := newEventObjectSerializer()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	eventStore, err := sqlite_watcher.NewEventStore(store.database,
+		sqlite_watcher.WithEventsTable(tableEvents),
+		sqlite_watcher.WithCheckpointTable(tableCheckpoints),
+		sqlite_watcher.WithEventSerializer(eventObjectSerializer),
+	)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	store.eventStore = eventStore
+
+	return store, nil
+}
+
+// newEventObjectSerializer returns the serializer of the events stored by the job store
+func newEventObjectSerializer() (*watcher.JSONSerializer, error) {
+	eventObjectSerializer := watcher.NewJSONSerializer()
+	err := errors.Join(
+		eventObjectSerializer.RegisterType(jobstore.EventObjectExecutionUpsert, reflect.TypeOf(models.ExecutionUpsert{})),
+		eventObjectSerializer.RegisterType(jobstore.EventObjectEvaluation, reflect.TypeOf(models.Evaluation{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register event object types: %w", err)
+	}
+	return eventObjectSerializer, nil
+}
+
+// metricRecorder returns a new metric recorder with the given attributes
+func (s *SQLiteJobStore) metricRecorder(
+	ctx context.Context, table, operation string, attrs ...attribute.KeyValue) *telemetry.MetricRecorder {
+	recorder := telemetry.NewMetricRecorder(
+		append(attrs,
+			semconv.DBSystemSqlite,
+			semconv.DBNamespaceKey.String("jobstore"),
+			semconv.DBOperationName(operation),
+			semconv.DBCollectionName(table),
+		)...,
+	)
+	recorder.Count(ctx, jobstore.OperationCount)
+	return recorder
+}
+
+// BeginTx starts a new writable transaction for the store
+func (s *SQLiteJobStore) BeginTx(ctx context.Context) (jobstore.TxContext, error) {
+	tx, err := sqlitedblib.Begin(ctx, s.database, true)
+	if err != nil {
+		return nil, err
+	}
+	return sqlitedblib.NewTxContext(ctx, tx), nil
+}
+
+// GetJob retrieves the Job identified by the id string. If the job isn't found it will
+// return an indicating the error.
+func (s *SQLiteJobStore) GetJob(ctx context.Context, id string) (job models.Job, err error) {
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationGet)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		job, err = s.getJob(ctx, tx, recorder, id)
+		return
+	})
+	return job, err
+}
+
+func (s *SQLiteJobStore) getJob(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, jobID string) (models.Job, error) {
+	var job models.Job
+
+	jobID, err := s.reifyJobID(ctx, tx, recorder, jobID)
+	if err != nil {
+		return job, err
+	}
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, `SELECT data FROM jobs WHERE id = ?`, jobID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, jobstore.NewErrJobNotFound(jobID)
+	}
+	if err != nil {
+		return job, NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+
+	err = s.marshaller.Unmarshal(data, &job)
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+	recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+	recorder.Count(ctx, jobstore.RowsRead)
+	return job, err
+}
+
+// reifyJobID ensures the provided job ID is a full-length ID. This is either through
+// returning the ID, or resolving the short ID to a single job id.
+func (s *SQLiteJobStore) reifyJobID(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, jobID string) (string, error) {
+	if idgen.ShortUUID(jobID) != jobID {
+		// Return what we were given
+		return jobID, nil
+	}
+	defer recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartReifyID)
+
+	// at most two matches are needed to tell whether the short ID is ambiguous
+	found, err := queryStrings(ctx, tx,
+		`SELECT id FROM jobs WHERE id >= ? AND id < ? ORDER BY id LIMIT 2`, jobID, jobID+maxRune)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(found) {
+	case 0:
+		return "", jobstore.NewErrJobNotFound(jobID)
+	case 1:
+		return found[0], nil
+	default:
+		return "", jobstore.NewErrMultipleJobsFound(jobID)
+	}
+}
+
+func (s *SQLiteJobStore) jobExists(ctx context.Context, tx *sqlitedblib.Tx, jobID string) (bool, error) {
+	exists, err := sqlitedblib.Exists(ctx, tx, `SELECT 1 FROM jobs WHERE id = ?`, jobID)
+	if err != nil {
+		return false, NewSQLiteError(err)
+	}
+	return exists, nil
+}
+
+func (s *SQLiteJobStore) getExecution(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, id string) (models.Execution, error) {
+	var exec models.Execution
+
+	var data []byte
+	err := tx.QueryRowContext(ctx, `SELECT data FROM executions WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return exec, jobstore.NewErrExecutionNotFound(id)
+	}
+	if err != nil {
+		return exec, NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+	recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+	recorder.Count(ctx, jobstore.RowsRead)
+
+	err = s.marshaller.Unmarshal(data, &exec)
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+	return exec, err
+}
+
+func (s *SQLiteJobStore) getExecutions(ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder,
+	options jobstore.GetExecutionsOptions) ([]models.Execution, error) {
+	jobID, err := s.reifyJobID(ctx, tx, recorder, options.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// load latest job state if requested, or check the job exists otherwise
+	var job *models.Job
+	if options.IncludeJob {
+		j, err := s.getJob(ctx, tx, recorder, jobID)
+		if err != nil {
+			return nil, err
+		}
+		job = &j
+		recorder.Latency(ctx, jobstore.OperationPartDuration, "load_job")
+	} else if exists, err := s.jobExists(ctx, tx, jobID); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, jobstore.NewErrJobNotFound(jobID)
+	}
+
+	var orderBy string
+	switch options.OrderBy {
+	// create_time will eventually be deprectated. It is being used for backward compatibility.
+	case "create_time", "created_at", "": //nolint: goconst
+		orderBy = "create_time"
+	// modify_time will eventually be deprecated. It is being used for backward compatibility.
+	case "modify_time", "modified_at":
+		orderBy = "modify_time"
+	default:
+		return nil, fmt.Errorf("OrderBy %s not supported for getExecutions", options.OrderBy)
+	}
+	direction := "ASC"
+	if options.Reverse {
+		direction = "DESC"
+	}
+
+	limit := -1
+	if options.Limit > 0 {
+		limit = options.Limit
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		fmt.Sprintf(`SELECT data FROM executions WHERE job_id = ? ORDER BY %s %s LIMIT ?`, orderBy, direction),
+		jobID, limit)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+
+	var execs []models.Execution
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+		recorder.Count(ctx, jobstore.RowsRead)
+
+		var exec models.Execution
+		if err = s.marshaller.Unmarshal(data, &exec); err != nil {
+			return nil, err
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+
+		exec.Job = job
+		execs = append(execs, exec)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	return execs, nil
+}
+
+// GetJobs returns all Jobs that match the provided query
+func (s *SQLiteJobStore) GetJobs(
+	ctx context.Context, query jobstore.JobQuery) (response *jobstore.JobQueryResponse, err error) {
+	scope := jobstore.AttrScopeAll
+	if query.Namespace != "" && !query.ReturnAll {
+		scope = jobstore.AttrScopeNamespace
+	}
+	attrs := []attribute.KeyValue{
+		jobstore.AttrScopeKey.String(scope),
+		jobstore.AttrNamespaceKey.String(query.Namespace),
+	}
+	if len(query.IncludeTags) > 0 {
+		attrs = append(attrs, attribute.Bool("query.include_tags", true))
+	}
+	if len(query.ExcludeTags) > 0 {
+		attrs = append(attrs, attribute.Bool("query.exclude_tags", true))
+	}
+	if query.Selector != nil {
+		attrs = append(attrs, attribute.Bool("query.selector", true))
+	}
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationList, attrs...)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		response, err = s.getJobs(ctx, tx, recorder, query)
+		return
+	})
+	return response, err
+}
+
+func (s *SQLiteJobStore) getJobs(ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder,
+	query jobstore.JobQuery) (*jobstore.JobQueryResponse, error) {
+	cursor, err := decodeJobsCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var sortColumns []string
+	switch query.SortBy {
+	case "created_at", "":
+		sortColumns = []string{"create_time", "id"}
+	case "modified_at":
+		sortColumns = []string{"modify_time", "id"}
+	case "id":
+		sortColumns = []string{"id"}
+	default:
+		return nil, fmt.Errorf("OrderBy %s not supported for listJobs", query.SortBy)
+	}
+
+	where, args := jobsQueryConditions(query)
+	if cursor != nil {
+		cursorValues, err := decodeSortKey(cursor, query.SortBy)
+		if err != nil {
+			return nil, jobstore.NewErrInvalidCursor(query.Cursor)
+		}
+		comparison := ">"
+		if query.SortReverse {
+			comparison = "<"
+		}
+		where = append(where, fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(sortColumns, ", "), comparison, placeholders(len(cursorValues))))
+		args = append(args, cursorValues...)
+	}
+
+	direction := " ASC"
+	if query.SortReverse {
+		direction = " DESC"
+	}
+	statement := "SELECT data FROM jobs"
+	if len(where) > 0 {
+		statement += " WHERE " + strings.Join(where, " AND ")
+	}
+	statement += " ORDER BY " + strings.Join(sortColumns, direction+", ") + direction
+
+	rows, err := tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartIndexRead)
+
+	offset := query.Offset
+	if cursor != nil {
+		offset = 0
+	}
+	var jobs []models.Job
+	more := false
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+		recorder.Count(ctx, jobstore.RowsRead)
+
+		var job models.Job
+		if err = s.marshaller.Unmarshal(data, &job); err != nil {
+			return nil, err
+		}
+		// selector requirements that can't be expressed in SQL are evaluated here
+		if query.Selector != nil && !query.Selector.Matches(labels.Set(job.Labels)) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if query.Limit > 0 && len(jobs) == int(query.Limit) {
+			more = true
+			break
+		}
+		jobs = append(jobs, job)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, "filter_limit")
+
+	response := &jobstore.JobQueryResponse{
+		Jobs:   jobs,
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+
+	if more {
+		response.NextOffset = query.Offset + uint64(query.Limit)
+		response.NextCursor = encodeJobsCursor(jobSortKey(&jobs[len(jobs)-1], query.SortBy))
+	}
+
+	return response, nil
+}
+
+// jobsQueryConditions returns the SQL conditions, and their arguments, filtering the jobs
+// table to the jobs matching the query. Label selector requirements are only partially
+// expressed, and the selector must also be matched against the returned jobs.
+func jobsQueryConditions(query jobstore.JobQuery) ([]string, []any) {
+	var where []string
+	var args []any
+
+	if query.Namespace != "" && !query.ReturnAll {
+		where = append(where, "namespace = ?")
+		args = append(args, query.Namespace)
+	}
+
+	// jobs having ANY of the included tags, and none of the excluded tags, as a label key
+	tagCondition := func(tags []string) string {
+		for _, tag := range tags {
+			args = append(args, strings.ToLower(tag))
+		}
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM job_labels WHERE job_labels.job_id = jobs.id AND lower(key) IN (%s))",
+			placeholders(len(tags)))
+	}
+	if len(query.IncludeTags) > 0 {
+		where = append(where, tagCondition(query.IncludeTags))
+	}
+	if len(query.ExcludeTags) > 0 {
+		where = append(where, "NOT "+tagCondition(query.ExcludeTags))
+	}
+
+	if len(query.States) > 0 {
+		where = append(where, fmt.Sprintf("state IN (%s)", placeholders(len(query.States))))
+		for _, state := range query.States {
+			args = append(args, state.String())
+		}
+	}
+	if len(query.Types) > 0 {
+		where = append(where, fmt.Sprintf("type IN (%s)", placeholders(len(query.Types))))
+		for _, jobType := range query.Types {
+			args = append(args, jobType)
+		}
+	}
+
+	if !query.CreatedAfter.IsZero() {
+		where = append(where, "create_time >= ?")
+		args = append(args, query.CreatedAfter.UnixNano())
+	}
+	if !query.CreatedBefore.IsZero() {
+		where = append(where, "create_time < ?")
+		args = append(args, query.CreatedBefore.UnixNano())
+	}
+	if query.NamePrefix != "" {
+		where = append(where, "name >= ? AND name < ?")
+		args = append(args, query.NamePrefix, query.NamePrefix+maxRune)
+	}
+
+	if query.Selector != nil {
+		requirements, _ := query.Selector.Requirements()
+		for _, requirement := range requirements {
+			values := requirement.Values().List()
+			labelCondition := fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM job_labels WHERE job_labels.job_id = jobs.id AND key = ? AND value IN (%s))",
+				placeholders(len(values)))
+			switch requirement.Operator() {
+			case selection.Equals, selection.DoubleEquals, selection.In:
+				where = append(where, labelCondition)
+			case selection.NotEquals, selection.NotIn:
+				where = append(where, "NOT "+labelCondition)
+			default:
+				// other operators are only evaluated when the selector is matched against the jobs
+				continue
+			}
+			args = append(args, requirement.Key())
+			for _, value := range values {
+				args = append(args, value)
+			}
+		}
+	}
+
+	return where, args
+}
+
+// jobSortKey returns the key jobs are ordered by when sorted by the given field.
+// Keys are unique, as they all end with the job ID, and are used as pagination cursors.
+func jobSortKey(job *models.Job, sortBy string) []byte {
+	switch sortBy {
+	case "id":
+		return []byte(job.ID)
+	case "modified_at":
+		return append(binary.BigEndian.AppendUint64(nil, uint64(job.ModifyTime)), job.ID...) //nolint:gosec // G115
+	default:
+		return append(binary.BigEndian.AppendUint64(nil, uint64(job.CreateTime)), job.ID...) //nolint:gosec // G115
+	}
+}
+
+// decodeSortKey returns the values of the sort columns encoded in the sort key
+func decodeSortKey(key []byte, sortBy string) ([]any, error) {
+	if sortBy == "id" {
+		return []any{string(key)}, nil
+	}
+	if len(key) < timestampKeyLength {
+		return nil, fmt.Errorf("sort key too short")
+	}
+	timestamp := int64(binary.BigEndian.Uint64(key[:timestampKeyLength])) //nolint:gosec // G115: times are positive
+	return []any{timestamp, string(key[timestampKeyLength:])}, nil
+}
+
+func encodeJobsCursor(key []byte) string {
+	return hex.EncodeToString(key)
+}
+
+func decodeJobsCursor(cursor string) ([]byte, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(cursor)
+	if err != nil {
+		return nil, jobstore.NewErrInvalidCursor(cursor)
+	}
+	return key, nil
+}
+
+// GetExecutions returns the current job state for the provided job id
+func (s *SQLiteJobStore) GetExecutions(
+	ctx context.Context, options jobstore.GetExecutionsOptions) (state []models.Execution, err error) {
+	var attrs []attribute.KeyValue
+	if options.IncludeJob {
+		attrs = append(attrs, attribute.Bool("query.include_job", true))
+	}
+	recorder := s.metricRecorder(ctx, TableExecutions, jobstore.AttrOperationList, attrs...)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		state, err = s.getExecutions(ctx, tx, recorder, options)
+		return
+	})
+	return state, err
+}
+
+// GetInProgressJobs gets a list of the currently in-progress jobs, if a job type is supplied then
+// only jobs of that type will be retrieved
+func (s *SQLiteJobStore) GetInProgressJobs(ctx context.Context, jobType string) (jobs []models.Job, err error) {
+	attrs := []attribute.KeyValue{
+		jobstore.AttrScopeKey.String(jobstore.AttrScopeInProgress),
+	}
+	if jobType != "" {
+		attrs = append(attrs, attribute.String("query.job_type", jobType))
+	}
+
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationList, attrs...)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		jobs, err = s.getInProgressJobs(ctx, tx, recorder, jobType)
+		return
+	})
+	return jobs, err
+}
+
+func (s *SQLiteJobStore) getInProgressJobs(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, jobType string) ([]models.Job, error) {
+	statement := `SELECT data FROM jobs WHERE terminal = 0`
+	var args []any
+	if jobType != "" {
+		statement += ` AND type = ?`
+		args = append(args, jobType)
+	}
+	statement += ` ORDER BY type, id`
+
+	rows, err := tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartIndexRead)
+
+	var jobs []models.Job
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+		recorder.Count(ctx, jobstore.RowsRead)
+
+		var job models.Job
+		if err = s.marshaller.Unmarshal(data, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	return jobs, nil
+}
+
+// GetJobHistory retrieves the paginated job history for a given job ID based on the specified query.
+// Pagination follows the same rules as the BoltDB job store: a NextToken is returned as long as
+// more history entries exist, or may be added because the job or execution is not terminal yet.
+func (s *SQLiteJobStore) GetJobHistory(ctx context.Context,
+	jobID string,
+	query jobstore.JobHistoryQuery,
+) (response *jobstore.JobHistoryQueryResponse, err error) {
+	recorder := s.metricRecorder(ctx, TableJobHistory, jobstore.AttrOperationList,
+		jobstore.AttrScopeKey.String(jobstore.AttrScopeJob))
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		response, err = s.getJobHistory(ctx, tx, recorder, jobID, query)
+		return
+	})
+	return response, err
+}
+
+func (s *SQLiteJobStore) getJobHistory(ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder,
+	jobID string, query jobstore.JobHistoryQuery) (*jobstore.JobHistoryQueryResponse, error) {
+	jobID, err := s.reifyJobID(ctx, tx, recorder, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, limit, err := parseHistoryPaginationParams(query)
+	if err != nil {
+		return nil, err
+	}
+
+	where := []string{"job_id = ?", "seq_num >= ?"}
+	args := []any{jobID, offset}
+	if query.ExecutionID != "" {
+		where = append(where, "execution_id >= ? AND execution_id < ?")
+		args = append(args, query.ExecutionID, query.ExecutionID+maxRune)
+	}
+	if query.Since != 0 {
+		where = append(where, "time >= ?")
+		args = append(args, query.Since*1e9)
+	}
+	if query.ExcludeJobLevel {
+		where = append(where, "type != ?")
+		args = append(args, models.JobHistoryTypeJobLevel.String())
+	}
+	if query.ExcludeExecutionLevel {
+		where = append(where, "type != ?")
+		args = append(args, models.JobHistoryTypeExecutionLevel.String())
+	}
+	args = append(args, limit)
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT seq_num, data FROM job_history WHERE `+strings.Join(where, " AND ")+` ORDER BY seq_num LIMIT ?`,
+		args...)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+
+	var history []models.JobHistory
+	var lastSeq uint64
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&lastSeq, &data); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+
+		var item models.JobHistory
+		if err = s.marshaller.Unmarshal(data, &item); err != nil {
+			return nil, err
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+		recorder.Count(ctx, jobstore.RowsRead)
+		history = append(history, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+
+	response := &jobstore.JobHistoryQueryResponse{
+		JobHistory: history,
+	}
+
+	// Determine if we should continue pagination
+	//nolint:gosec // G115: history within reasonable bounds
+	shouldContinue, err := s.shouldContinueHistoryPagination(
+		ctx, tx, recorder, jobID, uint32(len(history)) == limit, lastSeq, query)
+	if err != nil {
+		return nil, err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, "determine_pagination")
+
+	if shouldContinue {
+		newOffset := lastSeq + 1
+		if len(history) == 0 {
+			// If we didn't find any items, then we need to continue from the last offset
+			newOffset = offset
+		}
+		response.NextToken = models.NewPagingToken(&models.PagingTokenParams{
+			Offset: newOffset,
+			Limit:  query.Limit,
+		}).String()
+	}
+
+	return response, nil
+}
+
+func parseHistoryPaginationParams(query jobstore.JobHistoryQuery) (uint64, uint32, error) {
+	const defaultTokenLimit = 100
+	offset := uint64(0)
+	limit := uint32(defaultTokenLimit)
+
+	if query.NextToken != "" {
+		token, err := models.NewPagingTokenFromString(query.NextToken)
+		if err != nil {
+			return 0, 0, jobstore.NewBadRequestError(fmt.Sprintf("invalid next token: %s", err))
+		}
+		offset = token.Offset
+		if token.Limit != 0 {
+			limit = token.Limit
+		}
+	}
+
+	if query.Limit != 0 {
+		limit = query.Limit
+	}
+
+	return offset, limit, nil
+}
+
+func (s *SQLiteJobStore) shouldContinueHistoryPagination(
+	ctx context.Context,
+	tx *sqlitedblib.Tx,
+	recorder *telemetry.MetricRecorder,
+	jobID string,
+	limitReached bool,
+	lastSeq uint64,
+	query jobstore.JobHistoryQuery,
+) (bool, error) {
+	// If the page is full and more items follow it, then we should continue
+	if limitReached {
+		more, err := sqlitedblib.Exists(ctx, tx,
+			`SELECT 1 FROM job_history WHERE job_id = ? AND seq_num > ?`, jobID, lastSeq)
+		if err != nil {
+			return false, NewSQLiteError(err)
+		}
+		if more {
+			return true, nil
+		}
+	}
+
+	// Otherwise, we need to check if the job or execution are in a terminal state
+	// For execution level events, stop if the execution in terminal state
+	if query.ExecutionID != "" {
+		execution, err := s.getExecution(ctx, tx, recorder, query.ExecutionID)
+		if err != nil {
+			return false, err
+		}
+		return !execution.IsTerminalState(), nil
+	}
+
+	// If querying all executions or job level events, stop if the job is in terminal state
+	job, err := s.getJob(ctx, tx, recorder, jobID)
+	if err != nil {
+		return false, err
+	}
+	return !job.IsTerminal(), nil
+}
+
+// CreateJob creates a new record of a job in the data store
+func (s *SQLiteJobStore) CreateJob(ctx context.Context, job models.Job) (err error) {
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationCreate)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	job.State = models.NewJobState(models.JobStateTypePending)
+	job.Revision = 1
+	job.CreateTime = s.clock.Now().UTC().UnixNano()
+	job.ModifyTime = s.clock.Now().UTC().UnixNano()
+	job.Normalize()
+	err = job.Validate()
+	if err != nil {
+		return jobstore.NewJobStoreError(err.Error())
+	}
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.createJob(ctx, tx, recorder, job)
+	})
+}
+
+func (s *SQLiteJobStore) createJob(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, job models.Job) error {
+	if exists, err := s.jobExists(ctx, tx, job.ID); err != nil {
+		return err
+	} else if exists {
+		return jobstore.NewErrJobAlreadyExists(job.ID)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartValidate)
+
+	return s.insertJob(ctx, tx, recorder, job)
+}
+
+// insertJob writes the job and its labels as they are
+func (s *SQLiteJobStore) insertJob(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, job models.Job) error {
+	data, err := s.marshaller.Marshal(job)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO jobs
+		(id, name, namespace, type, state, terminal, create_time, modify_time, revision, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.ID, job.Name, job.Namespace, job.Type, job.State.StateType.String(), job.IsTerminal(),
+		job.CreateTime, job.ModifyTime, job.Revision, data)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+
+	for key, value := range job.Labels {
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO job_labels (job_id, key, value) VALUES (?, ?, ?)`, job.ID, key, value); err != nil {
+			return NewSQLiteError(err)
+		}
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartIndexWrite)
+	return nil
+}
+
+// DeleteJob removes the specified job from the system entirely
+func (s *SQLiteJobStore) DeleteJob(ctx context.Context, jobID string) (err error) {
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationDelete)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.deleteJob(ctx, tx, recorder, jobID)
+	})
+}
+
+func (s *SQLiteJobStore) deleteJob(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, jobID string) error {
+	jobID, err := s.reifyJobID(ctx, tx, recorder, jobID)
+	if err != nil {
+		return err
+	}
+
+	// labels, executions, evaluations and history are deleted along with the job
+	result, err := tx.ExecContext(ctx, `DELETE FROM jobs WHERE id = ?`, jobID)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return jobstore.NewErrJobNotFound(jobID)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartDelete)
+	return nil
+}
+
+// UpdateJobState updates the current state for a single Job, appending an entry to
+// the history at the same time
+func (s *SQLiteJobStore) UpdateJobState(ctx context.Context, request jobstore.UpdateJobStateRequest) (err error) {
+	recorder := s.metricRecorder(ctx, TableJobs, jobstore.AttrOperationUpdate,
+		jobstore.AttrToStateKey.String(request.NewState.String()))
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.updateJobState(ctx, tx, recorder, request)
+	})
+}
+
+func (s *SQLiteJobStore) updateJobState(ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder,
+	request jobstore.UpdateJobStateRequest) error {
+	job, err := s.getJob(ctx, tx, recorder, request.JobID)
+	if err != nil {
+		return err
+	}
+
+	// Add current state to metrics
+	recorder.AddAttributes(jobstore.AttrFromStateKey.String(job.State.StateType.String()))
+
+	// check the expected state
+	if err = request.Condition.Validate(job); err != nil {
+		return err
+	}
+
+	if job.IsTerminal() {
+		return jobstore.NewErrJobAlreadyTerminal(request.JobID, job.State.StateType, request.NewState)
+	}
+
+	// update the job state
+	job.State.StateType = request.NewState
+	job.State.Message = request.Message
+	job.Revision++
+	job.ModifyTime = s.clock.Now().UTC().UnixNano()
+
+	data, err := s.marshaller.Marshal(job)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE jobs SET state = ?, terminal = ?, modify_time = ?, revision = ?, data = ? WHERE id = ?`,
+		job.State.StateType.String(), job.IsTerminal(), job.ModifyTime, job.Revision, data, job.ID)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+
+	if job.IsTerminal() {
+		tx.OnCommit(func() {
+			// TODO to include execution telemetry
+			analytics.EmitEvent(context.TODO(), analytics.NewJobTerminalEvent(job))
+		})
+	}
+
+	return nil
+}
+
+// AddJobHistory appends a new history entry to the job history
+func (s *SQLiteJobStore) AddJobHistory(ctx context.Context, jobID string, events ...models.Event) (err error) {
+	recorder := s.metricRecorder(ctx, TableJobHistory, jobstore.AttrOperationCreate,
+		jobstore.AttrScopeKey.String(jobstore.AttrScopeJob))
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		for _, event := range events {
+			if err = s.addHistory(ctx, tx, recorder, models.JobHistory{
+				Type:  models.JobHistoryTypeJobLevel,
+				JobID: jobID,
+				Event: event,
+				Time:  s.clock.Now().UTC(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteJobStore) addExecutionHistory(ctx context.Context, tx *sqlitedblib.Tx,
+	recorder *telemetry.MetricRecorder, jobID, executionID string, events ...*models.Event) error {
+	now := s.clock.Now().UTC()
+	for _, event := range events {
+		if err := s.addHistory(ctx, tx, recorder, models.JobHistory{
+			Type:        models.JobHistoryTypeExecutionLevel,
+			JobID:       jobID,
+			ExecutionID: executionID,
+			Event:       *event,
+			Time:        now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addHistory appends the entry to the history of its job, with the next sequence number of the job
+func (s *SQLiteJobStore) addHistory(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, historyEntry models.JobHistory) error {
+	err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(seq_num), 0) + 1 FROM job_history WHERE job_id = ?`, historyEntry.JobID).
+		Scan(&historyEntry.SeqNum)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartSequence)
+
+	return s.insertHistory(ctx, tx, recorder, historyEntry)
+}
+
+// insertHistory writes the history entry as it is
+func (s *SQLiteJobStore) insertHistory(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, historyEntry models.JobHistory) error {
+	data, err := s.marshaller.Marshal(historyEntry)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO job_history (job_id, seq_num, type, execution_id, time, data) VALUES (?, ?, ?, ?, ?, ?)`,
+		historyEntry.JobID, historyEntry.SeqNum, historyEntry.Type.String(), historyEntry.ExecutionID,
+		historyEntry.Time.UnixNano(), data)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+	return nil
+}
+
+// CreateExecution creates a record of a new execution
+func (s *SQLiteJobStore) CreateExecution(ctx context.Context, execution models.Execution) (err error) {
+	recorder := s.metricRecorder(ctx, TableExecutions, jobstore.AttrOperationCreate)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	if execution.CreateTime == 0 {
+		execution.CreateTime = s.clock.Now().UTC().UnixNano()
+	}
+	if execution.ModifyTime == 0 {
+		execution.ModifyTime = execution.CreateTime
+	}
+	if execution.Revision == 0 {
+		execution.Revision = 1
+	}
+	execution.Normalize()
+	err = execution.Validate()
+	if err != nil {
+		return err
+	}
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.createExecution(ctx, tx, recorder, execution)
+	})
+}
+
+func (s *SQLiteJobStore) createExecution(ctx context.Context, tx *sqlitedblib.Tx,
+	recorder *telemetry.MetricRecorder, execution models.Execution) error {
+	if exists, err := s.jobExists(ctx, tx, execution.JobID); err != nil {
+		return err
+	} else if !exists {
+		return jobstore.NewErrJobNotFound(execution.JobID)
+	}
+
+	// Verify no duplicate execution
+	if exists, err := sqlitedblib.Exists(ctx, tx, `SELECT 1 FROM executions WHERE id = ?`, execution.ID); err != nil {
+		return NewSQLiteError(err)
+	} else if exists {
+		return jobstore.NewErrExecutionAlreadyExists(execution.ID)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartValidate)
+
+	if err := s.insertExecution(ctx, tx, recorder, execution); err != nil {
+		return err
+	}
+
+	// Record event
+	if err := s.eventStore.StoreEventTx(ctx, tx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationCreate,
+		ObjectType: jobstore.EventObjectExecutionUpsert,
+		Object:     models.ExecutionUpsert{Current: &execution},
+	}); err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartEventWrite)
+
+	tx.OnCommit(func() {
+		analytics.EmitEvent(context.TODO(), analytics.NewCreatedExecutionEvent(execution))
+	})
+	return nil
+}
+
+// insertExecution writes the execution as it is
+func (s *SQLiteJobStore) insertExecution(ctx context.Context, tx *sqlitedblib.Tx,
+	recorder *telemetry.MetricRecorder, execution models.Execution) error {
+	data, err := s.marshaller.Marshal(execution)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO executions
+		(id, job_id, node_id, compute_state, desired_state, create_time, modify_time, revision, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		execution.ID, execution.JobID, execution.NodeID, execution.ComputeState.StateType.String(),
+		execution.DesiredState.StateType.String(), execution.CreateTime, execution.ModifyTime, execution.Revision, data)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+	return nil
+}
+
+// UpdateExecution updates the state of a single execution by loading from storage,
+// updating and then writing back in a single transaction
+func (s *SQLiteJobStore) UpdateExecution(ctx context.Context, request jobstore.UpdateExecutionRequest) (err error) {
+	recorder := s.metricRecorder(ctx, TableExecutions, jobstore.AttrOperationUpdate)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.updateExecution(ctx, tx, recorder, request)
+	})
+}
+
+func (s *SQLiteJobStore) updateExecution(ctx context.Context, tx *sqlitedblib.Tx,
+	recorder *telemetry.MetricRecorder, request jobstore.UpdateExecutionRequest) error {
+	// Get current execution
+	existingExecution, err := s.getExecution(ctx, tx, recorder, request.ExecutionID)
+	if err != nil {
+		return jobstore.NewErrExecutionNotFound(request.ExecutionID)
+	}
+
+	// Record state transitions in metrics
+	recorder.AddAttributes(
+		jobstore.FromDesiredStateKey.String(existingExecution.DesiredState.StateType.String()),
+		jobstore.ToDesiredStateKey.String(request.NewValues.DesiredState.StateType.String()),
+		jobstore.AttrFromStateKey.String(existingExecution.ComputeState.StateType.String()),
+		jobstore.AttrToStateKey.String(request.NewValues.ComputeState.StateType.String()),
+	)
+
+	// Validate state transition
+	if err = request.Condition.Validate(existingExecution); err != nil {
+		return err
+	}
+	if existingExecution.IsTerminalComputeState() {
+		return jobstore.NewErrExecutionAlreadyTerminal(
+			request.ExecutionID, existingExecution.ComputeState.StateType, request.NewValues.ComputeState.StateType)
+	}
+
+	// populate default values, maintain existing execution createTime
+	newExecution := request.NewValues
+	newExecution.CreateTime = existingExecution.CreateTime
+	if newExecution.ModifyTime == 0 {
+		newExecution.ModifyTime = s.clock.Now().UTC().UnixNano()
+	}
+	if newExecution.Revision == 0 {
+		newExecution.Revision = existingExecution.Revision + 1
+	}
+	newExecution.Normalize()
+
+	if err = mergo.Merge(&newExecution, existingExecution); err != nil {
+		return err
+	}
+
+	data, err := s.marshaller.Marshal(newExecution)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx, `UPDATE executions
+		SET node_id = ?, compute_state = ?, desired_state = ?, modify_time = ?, revision = ?, data = ?
+		WHERE id = ?`,
+		newExecution.NodeID, newExecution.ComputeState.StateType.String(),
+		newExecution.DesiredState.StateType.String(), newExecution.ModifyTime, newExecution.Revision, data,
+		newExecution.ID)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+
+	// Add execution history
+	if err = s.addExecutionHistory(ctx, tx, recorder, newExecution.JobID, newExecution.ID, request.Events...); err != nil {
+		return err
+	}
+
+	// Store event
+	if err = s.eventStore.StoreEventTx(ctx, tx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationUpdate,
+		ObjectType: jobstore.EventObjectExecutionUpsert,
+		Object: models.ExecutionUpsert{
+			Current: &newExecution, Previous: &existingExecution, Events: request.Events,
+		},
+	}); err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartEventWrite)
+
+	tx.OnCommit(func() {
+		if newExecution.IsTerminalState() {
+			analytics.EmitEvent(context.TODO(), analytics.NewTerminalExecutionEvent(newExecution))
+		}
+		if newExecution.IsDiscarded() {
+			analytics.EmitEvent(context.TODO(), analytics.NewComputeMessageExecutionEvent(newExecution))
+		}
+	})
+
+	return nil
+}
+
+// AddExecutionHistory appends a new history entry to the execution history
+func (s *SQLiteJobStore) AddExecutionHistory(
+	ctx context.Context, jobID, executionID string, events ...models.Event) (err error) {
+	recorder := s.metricRecorder(ctx, TableJobHistory, jobstore.AttrOperationCreate,
+		jobstore.AttrScopeKey.String(jobstore.AttrScopeExecution))
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		eventsValues := lo.ToSlicePtr(events)
+		return s.addExecutionHistory(ctx, tx, recorder, jobID, executionID, eventsValues...)
+	})
+}
+
+// CreateEvaluation creates a new evaluation
+func (s *SQLiteJobStore) CreateEvaluation(ctx context.Context, eval models.Evaluation) (err error) {
+	recorder := s.metricRecorder(ctx, TableEvaluations, jobstore.AttrOperationCreate)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.createEvaluation(ctx, tx, recorder, eval)
+	})
+}
+
+func (s *SQLiteJobStore) createEvaluation(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, eval models.Evaluation) error {
+	if _, err := s.getJob(ctx, tx, recorder, eval.JobID); err != nil {
+		return err
+	}
+
+	if exists, err := sqlitedblib.Exists(ctx, tx, `SELECT 1 FROM evaluations WHERE id = ?`, eval.ID); err != nil {
+		return NewSQLiteError(err)
+	} else if exists {
+		return jobstore.NewErrEvaluationAlreadyExists(eval.ID)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartValidate)
+
+	if err := s.insertEvaluation(ctx, tx, recorder, eval); err != nil {
+		return err
+	}
+
+	err := s.eventStore.StoreEventTx(ctx, tx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationCreate,
+		ObjectType: jobstore.EventObjectEvaluation,
+		Object:     eval,
+	})
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartEventWrite)
+	return err
+}
+
+// insertEvaluation writes the evaluation as it is
+func (s *SQLiteJobStore) insertEvaluation(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, eval models.Evaluation) error {
+	data, err := s.marshaller.Marshal(eval)
+	if err != nil {
+		return err
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartMarshal)
+	recorder.CountN(ctx, jobstore.DataWritten, int64(len(data)))
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO evaluations (id, job_id, data) VALUES (?, ?, ?)`, eval.ID, eval.JobID, data)
+	if err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartWrite)
+	return nil
+}
+
+// GetEvaluation retrieves the specified evaluation
+func (s *SQLiteJobStore) GetEvaluation(ctx context.Context, id string) (eval models.Evaluation, err error) {
+	recorder := s.metricRecorder(ctx, TableEvaluations, jobstore.AttrOperationGet)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		eval, err = s.getEvaluation(ctx, tx, recorder, id)
+		return
+	})
+	return eval, err
+}
+
+func (s *SQLiteJobStore) getEvaluation(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, id string) (models.Evaluation, error) {
+	var eval models.Evaluation
+
+	var data []byte
+	err := tx.QueryRowContext(ctx, `SELECT data FROM evaluations WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return eval, jobstore.NewErrEvaluationNotFound(id)
+	}
+	if err != nil {
+		return eval, NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+
+	err = s.marshaller.Unmarshal(data, &eval)
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+	recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+	recorder.Count(ctx, jobstore.RowsRead)
+	return eval, err
+}
+
+// GetEvaluations retrieves all evaluations of the specified job, ordered by creation time
+func (s *SQLiteJobStore) GetEvaluations(ctx context.Context, jobID string) (evals []models.Evaluation, err error) {
+	recorder := s.metricRecorder(ctx, TableEvaluations, jobstore.AttrOperationList)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	err = sqlitedblib.View(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		evals, err = s.getEvaluations(ctx, tx, recorder, jobID)
+		return
+	})
+	return evals, err
+}
+
+func (s *SQLiteJobStore) getEvaluations(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, jobID string) ([]models.Evaluation, error) {
+	if _, err := s.getJob(ctx, tx, recorder, jobID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT data FROM evaluations WHERE job_id = ?`, jobID)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+
+	var evals []models.Evaluation
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartRead)
+		recorder.CountN(ctx, jobstore.DataRead, int64(len(data)))
+		recorder.Count(ctx, jobstore.RowsRead)
+
+		var eval models.Evaluation
+		if err = s.marshaller.Unmarshal(data, &eval); err != nil {
+			return nil, err
+		}
+		recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartUnmarshal)
+		evals = append(evals, eval)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+
+	slices.SortFunc(evals, func(a, b models.Evaluation) int { return cmp.Compare(a.CreateTime, b.CreateTime) })
+	return evals, nil
+}
+
+// DeleteEvaluation deletes the specified evaluation
+func (s *SQLiteJobStore) DeleteEvaluation(ctx context.Context, id string) (err error) {
+	recorder := s.metricRecorder(ctx, TableEvaluations, jobstore.AttrOperationDelete)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	return sqlitedblib.Update(ctx, s.database, func(tx *sqlitedblib.Tx) (err error) {
+		return s.deleteEvaluation(ctx, tx, recorder, id)
+	})
+}
+
+func (s *SQLiteJobStore) deleteEvaluation(
+	ctx context.Context, tx *sqlitedblib.Tx, recorder *telemetry.MetricRecorder, id string) error {
+	eval, err := s.getEvaluation(ctx, tx, recorder, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM evaluations WHERE id = ?`, id); err != nil {
+		return NewSQLiteError(err)
+	}
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartDelete)
+
+	err = s.eventStore.StoreEventTx(ctx, tx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationDelete,
+		ObjectType: jobstore.EventObjectEvaluation,
+		Object:     eval,
+	})
+	recorder.Latency(ctx, jobstore.OperationPartDuration, jobstore.AttrOperationPartEventWrite)
+	return err
+}
+
+// GetEventStore returns the event store
+func (s *SQLiteJobStore) GetEventStore() watcher.EventStore {
+	return s.eventStore
+}
+
+// Snapshot writes a copy of the database to w. The copy is made with VACUUM INTO,
+// which reads the database in a single transaction so that it is consistent
+// without blocking writers, and holds the event log and watcher checkpoints
+// stored alongside the jobs.
+func (s *SQLiteJobStore) Snapshot(ctx context.Context, w io.Writer) (n int64, err error) {
+	recorder := s.metricRecorder(ctx, "", jobstore.AttrOperationSnapshot)
+	defer recorder.Done(ctx, jobstore.OperationDuration)
+	defer recorder.Error(err)
+
+	dir, err := os.MkdirTemp("", "bacalhau-jobstore-snapshot")
+	if err != nil {
+		return 0, NewSQLiteError(err)
+	}
+	defer os.RemoveAll(dir)
+
+	snapshotPath := filepath.Join(dir, "snapshot.db")
+	if _, err = s.database.ExecContext(ctx, `VACUUM INTO ?`, snapshotPath); err != nil {
+		return 0, NewSQLiteError(err)
+	}
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		return 0, NewSQLiteError(err)
+	}
+	defer f.Close()
+
+	if n, err = io.Copy(w, f); err != nil {
+		return n, NewSQLiteError(err)
+	}
+	return n, nil
+}
+
+func (s *SQLiteJobStore) Close(ctx context.Context) error {
+	log.Ctx(ctx).Debug().Msg("closing sqlite-backed job store")
+	var mErr error
+	mErr = errors.Join(mErr, s.eventStore.Close(ctx))
+	mErr = errors.Join(mErr, s.database.Close())
+	return mErr
+}
+
+// queryStrings returns the single string column of the rows returned by the query
+func queryStrings(ctx context.Context, tx *sqlitedblib.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, NewSQLiteError(err)
+		}
+		values = append(values, value)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, NewSQLiteError(err)
+	}
+	return values, nil
+}
+
+// placeholders returns n comma separated bind parameter placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// Static check to ensure that SQLiteJobStore implements jobstore.Store
+var _ jobstore.Store = (*SQLiteJobStore)(nil)
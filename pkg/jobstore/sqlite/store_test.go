@@ -0,0 +1,209 @@
+//go:build unit || !integration
+
+package sqlitejobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	boltjobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/boltdb"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore/test"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+)
+
+func TestStore(t *testing.T) {
+	test.RunStoreSuite(t, func(dbPath string, clock clock.Clock) (jobstore.Store, error) {
+		return NewSQLiteJobStore(filepath.Join(dbPath, "test.sqlite"), WithClock(clock))
+	})
+}
+
+type MigrateFromBoltDBTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	dir       string
+	boltStore *boltjobstore.BoltJobStore
+	store     *SQLiteJobStore
+}
+
+func TestMigrateFromBoltDBTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateFromBoltDBTestSuite))
+}
+
+func (s *MigrateFromBoltDBTestSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	s.dir = s.T().TempDir()
+	s.boltStore, err = boltjobstore.NewBoltJobStore(filepath.Join(s.dir, "jobs.boltdb"))
+	s.Require().NoError(err)
+	s.store, err = NewSQLiteJobStore(filepath.Join(s.dir, "jobs.sqlite"))
+	s.Require().NoError(err)
+}
+
+func (s *MigrateFromBoltDBTestSuite) TearDownTest() {
+	s.NoError(s.store.Close(s.ctx))
+}
+
+func (s *MigrateFromBoltDBTestSuite) TestMigrate() {
+	job := mock.Job()
+	job.Labels = map[string]string{"team": "data"}
+	execution := mock.ExecutionForJob(job)
+	execution.ComputeState = models.NewExecutionState(models.ExecutionStateNew)
+	eval := mock.EvalForJob(job)
+
+	s.Require().NoError(s.boltStore.CreateJob(s.ctx, *job))
+	s.Require().NoError(s.boltStore.AddJobHistory(s.ctx, job.ID, *models.NewEvent("test").WithMessage("created")))
+	s.Require().NoError(s.boltStore.UpdateJobState(s.ctx, jobstore.UpdateJobStateRequest{
+		JobID:    job.ID,
+		NewState: models.JobStateTypeRunning,
+	}))
+	s.Require().NoError(s.boltStore.CreateExecution(s.ctx, *execution))
+	execution.ComputeState = models.NewExecutionState(models.ExecutionStateBidAccepted)
+	s.Require().NoError(s.boltStore.UpdateExecution(s.ctx, jobstore.UpdateExecutionRequest{
+		ExecutionID: execution.ID,
+		NewValues:   *execution,
+		Events:      []*models.Event{models.NewEvent("test").WithMessage("accepted")},
+	}))
+	s.Require().NoError(s.boltStore.CreateEvaluation(s.ctx, *eval))
+	s.Require().NoError(s.boltStore.GetEventStore().StoreCheckpoint(s.ctx, "watcher", 2))
+
+	// capture the state of the BoltDB store before closing it to release its lock
+	expectedJob, err := s.boltStore.GetJob(s.ctx, job.ID)
+	s.Require().NoError(err)
+	expectedExecutions, err := s.boltStore.GetExecutions(s.ctx, jobstore.GetExecutionsOptions{JobID: job.ID})
+	s.Require().NoError(err)
+	expectedHistory, err := s.boltStore.GetJobHistory(s.ctx, job.ID, jobstore.JobHistoryQuery{})
+	s.Require().NoError(err)
+	expectedEvents, err := s.boltStore.GetEventStore().GetEvents(s.ctx, watcher.GetEventsRequest{
+		EventIterator: watcher.TrimHorizonIterator(),
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.boltStore.Close(s.ctx))
+
+	summary, err := s.store.MigrateFromBoltDB(s.ctx, filepath.Join(s.dir, "jobs.boltdb"))
+	s.Require().NoError(err)
+	s.Equal(MigrationSummary{
+		Jobs:           1,
+		Executions:     1,
+		Evaluations:    1,
+		HistoryEntries: 2,
+		Events:         3,
+		Checkpoints:    1,
+	}, summary)
+
+	migratedJob, err := s.store.GetJob(s.ctx, job.ID)
+	s.Require().NoError(err)
+	s.Equal(expectedJob, migratedJob)
+
+	migratedExecutions, err := s.store.GetExecutions(s.ctx, jobstore.GetExecutionsOptions{JobID: job.ID})
+	s.Require().NoError(err)
+	s.Equal(expectedExecutions, migratedExecutions)
+
+	migratedEval, err := s.store.GetEvaluation(s.ctx, eval.ID)
+	s.Require().NoError(err)
+	s.Equal(eval.ID, migratedEval.ID)
+
+	migratedHistory, err := s.store.GetJobHistory(s.ctx, job.ID, jobstore.JobHistoryQuery{})
+	s.Require().NoError(err)
+	s.Equal(len(expectedHistory.JobHistory), len(migratedHistory.JobHistory))
+	for i := range expectedHistory.JobHistory {
+		s.Equal(expectedHistory.JobHistory[i].SeqNum, migratedHistory.JobHistory[i].SeqNum)
+		s.Equal(expectedHistory.JobHistory[i].Event.Message, migratedHistory.JobHistory[i].Event.Message)
+		s.True(expectedHistory.JobHistory[i].Time.Equal(migratedHistory.JobHistory[i].Time))
+	}
+
+	// labels are queryable after the migration
+	response, err := s.store.GetJobs(s.ctx, jobstore.JobQuery{ReturnAll: true, IncludeTags: []string{"team"}})
+	s.Require().NoError(err)
+	s.Len(response.Jobs, 1)
+
+	migratedEvents, err := s.store.GetEventStore().GetEvents(s.ctx, watcher.GetEventsRequest{
+		EventIterator: watcher.TrimHorizonIterator(),
+	})
+	s.Require().NoError(err)
+	s.Require().Len(migratedEvents.Events, len(expectedEvents.Events))
+	for i := range expectedEvents.Events {
+		s.Equal(expectedEvents.Events[i].SeqNum, migratedEvents.Events[i].SeqNum)
+		s.Equal(expectedEvents.Events[i].ObjectType, migratedEvents.Events[i].ObjectType)
+		s.Equal(expectedEvents.Events[i].Timestamp.UnixNano(), migratedEvents.Events[i].Timestamp.UnixNano())
+	}
+
+	checkpoint, err := s.store.GetEventStore().GetCheckpoint(s.ctx, "watcher")
+	s.Require().NoError(err)
+	s.Equal(uint64(2), checkpoint)
+
+	// new history entries and events follow the migrated ones
+	s.Require().NoError(s.store.AddJobHistory(s.ctx, job.ID, *models.NewEvent("test").WithMessage("migrated")))
+	migratedHistory, err = s.store.GetJobHistory(s.ctx, job.ID, jobstore.JobHistoryQuery{})
+	s.Require().NoError(err)
+	s.Equal(uint64(3), migratedHistory.JobHistory[2].SeqNum)
+
+	latest, err := s.store.GetEventStore().GetLatestEventNum(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(uint64(3), latest)
+}
+
+func (s *MigrateFromBoltDBTestSuite) TestMigrateIntoNonEmptyStore() {
+	s.Require().NoError(s.boltStore.Close(s.ctx))
+	s.Require().NoError(s.store.CreateJob(s.ctx, *mock.Job()))
+
+	_, err := s.store.MigrateFromBoltDB(s.ctx, filepath.Join(s.dir, "jobs.boltdb"))
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ValidationError))
+}
+
+func (s *MigrateFromBoltDBTestSuite) TestMigrateMissingSource() {
+	s.Require().NoError(s.boltStore.Close(s.ctx))
+
+	_, err := s.store.MigrateFromBoltDB(s.ctx, filepath.Join(s.dir, "missing.boltdb"))
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ConfigurationError))
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteJobStore(filepath.Join(t.TempDir(), "jobs.sqlite"))
+	require.NoError(t, err)
+	defer store.Close(ctx)
+
+	job := mock.Job()
+	require.NoError(t, store.CreateJob(ctx, *job))
+	require.NoError(t, store.CreateEvaluation(ctx, *mock.EvalForJob(job)))
+	require.NoError(t, store.GetEventStore().StoreCheckpoint(ctx, "watcher", 1))
+
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.sqlite")
+	f, err := os.Create(snapshotFile)
+	require.NoError(t, err)
+	n, err := store.Snapshot(ctx, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Positive(t, n)
+
+	// changes made after the snapshot are not part of it
+	require.NoError(t, store.DeleteJob(ctx, job.ID))
+
+	restored, err := NewSQLiteJobStore(snapshotFile)
+	require.NoError(t, err)
+	defer restored.Close(ctx)
+
+	restoredJob, err := restored.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, restoredJob.ID)
+
+	checkpoint, err := restored.GetEventStore().GetCheckpoint(ctx, "watcher")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), checkpoint)
+
+	latest, err := restored.GetEventStore().GetLatestEventNum(ctx)
+	require.NoError(t, err)
+	require.Positive(t, latest)
+}
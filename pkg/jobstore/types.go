@@ -3,6 +3,8 @@ package jobstore
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -23,6 +25,18 @@ type JobQuery struct {
 	SortBy      string
 	SortReverse bool
 	Selector    labels.Selector
+
+	// States and Types filter jobs to those in any of the given states, or of any of the given types
+	States []models.JobStateType
+	Types  []string
+	// CreatedAfter and CreatedBefore filter jobs to those created at or after, and before, the given times
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// NamePrefix filters jobs to those with a name starting with the prefix
+	NamePrefix string
+	// Cursor is the NextCursor of a previous response, and returns the jobs following
+	// the last job of that response. Offset is ignored when a cursor is provided.
+	Cursor string
 }
 
 type JobQueryResponse struct {
@@ -30,6 +44,7 @@ type JobQueryResponse struct {
 	Offset     uint64 // Offset into the filtered results of the first returned record
 	Limit      uint32 // The number of records to return, 0 means all
 	NextOffset uint64 // Offset + Limit of the next page of results, 0 means no more results
+	NextCursor string // Cursor of the next page of results, empty means no more results
 }
 
 type JobHistoryQuery struct {
@@ -110,12 +125,21 @@ type Store interface {
 	// GetEvaluation retrieves the specified evaluation
 	GetEvaluation(ctx context.Context, id string) (models.Evaluation, error)
 
+	// GetEvaluations retrieves all evaluations of the specified job,
+	// ordered by creation time
+	GetEvaluations(ctx context.Context, jobID string) ([]models.Evaluation, error)
+
 	// DeleteEvaluation deletes the specified evaluation
 	DeleteEvaluation(ctx context.Context, id string) error
 
 	// GetEventStore returns the event store for the execution store
 	GetEventStore() watcher.EventStore
 
+	// Snapshot writes a consistent copy of the database of the store, including the
+	// event log of its event store, to w while the store keeps serving requests.
+	// The copy can be opened in place of the database to restore the store.
+	Snapshot(ctx context.Context, w io.Writer) (int64, error)
+
 	// Close provides an interface to cleanup any resources in use when the
 	// store is no longer required
 	Close(ctx context.Context) error
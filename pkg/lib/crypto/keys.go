@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -46,6 +47,30 @@ func LoadUserKey(path string) (*UserKey, error) {
 	}, nil
 }
 
+// LoadEd25519KeyFile loads a PKCS8 encoded ed25519 private key from a PEM file
+func LoadEd25519KeyFile(keyFile string) (ed25519.PrivateKey, error) {
+	keyBytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read key file %q", keyFile)
+	}
+
+	keyBlock, _ := pem.Decode(keyBytes)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("failed to decode key file %q", keyFile)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse key")
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key file %q is not an ed25519 private key", keyFile)
+	}
+	return edKey, nil
+}
+
 func LoadPKCS1KeyFile(keyFile string) (*rsa.PrivateKey, error) {
 	file, err := os.Open(keyFile)
 	if err != nil {
@@ -0,0 +1,191 @@
+package envelope
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression represents the algorithm used to compress the serialized message
+// of an envelope. It is encoded in the upper bits of the envelope version byte.
+type Compression byte
+
+const (
+	CompressionNone Compression = 0
+	CompressionGzip Compression = 1
+	CompressionZstd Compression = 2
+)
+
+const (
+	// compressionShift is the position of the compression bits in the version byte
+	compressionShift = 6
+	// schemaVersionMask extracts the schema version from the version byte
+	schemaVersionMask = 1<<compressionShift - 1
+
+	// DefaultCompressionThreshold is the minimum size in bytes of a serialized message
+	// before it is compressed. Smaller messages gain little from compression.
+	DefaultCompressionThreshold = 1024
+
+	// MaxDecompressedSize limits the size of a decompressed message to protect
+	// against malicious payloads that expand to exhaust memory.
+	MaxDecompressedSize = 64 << 20
+)
+
+// String returns a string representation of the compression algorithm
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("0x%02x", byte(c))
+	}
+}
+
+// ParseCompression returns the compression algorithm with the given name.
+// An empty name is parsed as CompressionNone.
+func ParseCompression(name string) (Compression, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return CompressionNone, nil
+	case "gzip":
+		return CompressionGzip, nil
+	case "zstd":
+		return CompressionZstd, nil
+	default:
+		return CompressionNone, NewErrUnsupportedEncoding(name)
+	}
+}
+
+// SupportedCompressions returns the compression algorithms that the serializer
+// can decompress, in order of preference.
+func SupportedCompressions() []Compression {
+	return []Compression{CompressionZstd, CompressionGzip}
+}
+
+// FormatAcceptCompression formats a list of compression algorithms as the value
+// of the KeyAcceptCompression metadata key.
+func FormatAcceptCompression(algorithms []Compression) string {
+	names := make([]string, 0, len(algorithms))
+	for _, c := range algorithms {
+		names = append(names, c.String())
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseAcceptCompression parses the value of the KeyAcceptCompression metadata key.
+// Unknown algorithms are ignored, as they may be supported by newer peers.
+func ParseAcceptCompression(value string) []Compression {
+	var algorithms []Compression
+	for _, name := range strings.Split(value, ",") {
+		c, err := ParseCompression(name)
+		if err != nil || c == CompressionNone {
+			continue
+		}
+		algorithms = append(algorithms, c)
+	}
+	return algorithms
+}
+
+// NegotiateCompression returns the preferred compression algorithm if the peer
+// advertised it in its KeyAcceptCompression metadata, and CompressionNone otherwise.
+// Peers that don't advertise any algorithm, such as older versions, are sent
+// uncompressed messages.
+func NegotiateCompression(preferred Compression, peer *Metadata) Compression {
+	if preferred == CompressionNone || peer == nil {
+		return CompressionNone
+	}
+	for _, c := range ParseAcceptCompression(peer.Get(KeyAcceptCompression)) {
+		if c == preferred {
+			return preferred
+		}
+	}
+	return CompressionNone
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// zstdCodecs returns the zstd encoder and decoder shared by all serializers.
+// They are safe for concurrent use with EncodeAll and DecodeAll.
+func zstdCodecs() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxDecompressedSize))
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// compress compresses data with the given algorithm
+func compress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		encoder, _, err := zstdCodecs()
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, make([]byte, 0, len(data)/2)), nil //nolint:mnd
+	default:
+		return nil, NewErrUnsupportedEncoding(c.String())
+	}
+}
+
+// decompress decompresses data with the given algorithm,
+// failing if the result exceeds MaxDecompressedSize
+func decompress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		out, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > MaxDecompressedSize {
+			return nil, errors.New("decompressed message exceeds maximum size")
+		}
+		return out, nil
+	case CompressionZstd:
+		_, decoder, err := zstdCodecs()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(data, nil)
+	default:
+		return nil, NewErrUnsupportedEncoding(c.String())
+	}
+}
@@ -0,0 +1,56 @@
+//go:build unit || !integration
+
+package envelope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CompressionTestSuite struct {
+	suite.Suite
+}
+
+func TestCompressionTestSuite(t *testing.T) {
+	suite.Run(t, new(CompressionTestSuite))
+}
+
+func (suite *CompressionTestSuite) TestParseCompression() {
+	for name, expected := range map[string]Compression{
+		"":      CompressionNone,
+		"none":  CompressionNone,
+		"gzip":  CompressionGzip,
+		"ZSTD":  CompressionZstd,
+		" zstd": CompressionZstd,
+	} {
+		c, err := ParseCompression(name)
+		suite.Require().NoError(err, name)
+		suite.Equal(expected, c, name)
+	}
+
+	_, err := ParseCompression("lz4")
+	suite.Error(err)
+}
+
+func (suite *CompressionTestSuite) TestAcceptCompression() {
+	value := FormatAcceptCompression(SupportedCompressions())
+	suite.Equal("zstd,gzip", value)
+	suite.Equal(SupportedCompressions(), ParseAcceptCompression(value))
+
+	// unknown algorithms advertised by newer peers are ignored
+	suite.Equal([]Compression{CompressionGzip}, ParseAcceptCompression("lz4, gzip"))
+	suite.Empty(ParseAcceptCompression(""))
+}
+
+func (suite *CompressionTestSuite) TestNegotiateCompression() {
+	peer := &Metadata{KeyAcceptCompression: "zstd,gzip"}
+	suite.Equal(CompressionZstd, NegotiateCompression(CompressionZstd, peer))
+	suite.Equal(CompressionGzip, NegotiateCompression(CompressionGzip, peer))
+	suite.Equal(CompressionNone, NegotiateCompression(CompressionNone, peer))
+
+	// older peers don't advertise compression support
+	suite.Equal(CompressionNone, NegotiateCompression(CompressionZstd, &Metadata{}))
+	suite.Equal(CompressionNone, NegotiateCompression(CompressionZstd, nil))
+	suite.Equal(CompressionNone, NegotiateCompression(CompressionZstd, &Metadata{KeyAcceptCompression: "gzip"}))
+}
@@ -17,3 +17,37 @@ type PayloadSerializer interface {
 	Serialize(message *Message) (*EncodedMessage, error)
 	Deserialize(rawMessage *EncodedMessage, payloadType reflect.Type) (*Message, error)
 }
+
+// Signer signs encoded messages, adding the signature to their metadata.
+type Signer interface {
+	Sign(message *EncodedMessage) error
+}
+
+// Verifier verifies the signature of encoded messages before their payload is deserialized.
+// It returns an error if the message must be rejected.
+type Verifier interface {
+	Verify(message *EncodedMessage) error
+}
+
+// VerifierFunc is a function type that implements Verifier
+type VerifierFunc func(message *EncodedMessage) error
+
+// Verify calls the function to verify the message
+func (f VerifierFunc) Verify(message *EncodedMessage) error {
+	return f(message)
+}
+
+// PayloadVerifier verifies encoded messages whose verification depends on their payload,
+// such as the key of their sender. It is called once the payload is deserialized, with
+// both the encoded and the deserialized message. It returns an error if the message must be rejected.
+type PayloadVerifier interface {
+	VerifyPayload(encoded *EncodedMessage, message *Message) error
+}
+
+// PayloadVerifierFunc is a function type that implements PayloadVerifier
+type PayloadVerifierFunc func(encoded *EncodedMessage, message *Message) error
+
+// VerifyPayload calls the function to verify the message
+func (f PayloadVerifierFunc) VerifyPayload(encoded *EncodedMessage, message *Message) error {
+	return f(encoded, message)
+}
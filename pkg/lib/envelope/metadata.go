@@ -9,8 +9,13 @@ import (
 const (
 	KeyMessageType     = "Bacalhau-Type"
 	KeyPayloadEncoding = "Bacalhau-PayloadEncoding"
-	LegacyMessageType  = "Type"
-	LegacyEncoding     = "PayloadEncoding"
+	// KeyAcceptCompression lists the compression algorithms the sender
+	// can decompress, such as "zstd,gzip"
+	KeyAcceptCompression = "Bacalhau-AcceptCompression"
+	// KeySignature holds the signature of the message's metadata and payload
+	KeySignature      = "Bacalhau-Signature"
+	LegacyMessageType = "Type"
+	LegacyEncoding    = "PayloadEncoding"
 )
 
 // Metadata contains metadata about the message
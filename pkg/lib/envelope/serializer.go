@@ -26,10 +26,15 @@ const (
 // | Version (1 byte)| CRC (4 bytes) | Serialized envelope.Message |
 // +----------------+----------------+--------------------+
 //
-// - Version: Indicates the schema version used for serialization (1 byte)
-// - CRC: A 32-bit CRC checksum of the serialized message (4 bytes)
+// - Version: Indicates the schema version and compression used for serialization (1 byte)
+// - CRC: A 32-bit CRC checksum of the serialized, and possibly compressed, message (4 bytes)
 // - Serialized envelope.Message: The actual message content, serialized by a version-specific serializer
 //
+// The lower 6 bits of the version byte hold the schema version, and the upper 2 bits the
+// Compression of the serialized message, which is zero for uncompressed messages.
+// Compressed messages are rejected by older peers as an unsupported version, so compression
+// should only be enabled once the peer advertised support for it, see NegotiateCompression.
+//
 // The Serializer adds a version byte and a CRC checksum to each serialized message,
 // allowing for future extensibility, backward compatibility, and data integrity verification.
 type Serializer struct {
@@ -48,12 +53,17 @@ type Serializer struct {
 	//    SchemaVersionProtobufV1: &ProtoMessageSerializer{},
 	// }
 	serializers map[SchemaVersion]MessageSerializer
+	// compression is the algorithm used to compress serialized messages
+	compression Compression
+	// compressionThreshold is the minimum size of a serialized message to be compressed
+	compressionThreshold int
 }
 
 // NewSerializer creates a new Serializer with default serializers
 func NewSerializer() *Serializer {
 	return &Serializer{
 		serializationVersion: DefaultSchemaVersion,
+		compressionThreshold: DefaultCompressionThreshold,
 		serializers: map[SchemaVersion]MessageSerializer{
 			SchemaVersionJSONV1:     &JSONMessageSerializer{},
 			SchemaVersionProtobufV1: &ProtoMessageSerializer{},
@@ -69,8 +79,24 @@ func (v *Serializer) WithSerializationVersion(version SchemaVersion) *Serializer
 	return v
 }
 
+// WithCompression sets the algorithm used to compress serialized messages that are at
+// least threshold bytes long. Messages are left uncompressed if compression doesn't
+// reduce their size. It does not affect the deserialization of messages, which
+// supports all algorithms.
+func (v *Serializer) WithCompression(compression Compression, threshold int) *Serializer {
+	v.compression = compression
+	v.compressionThreshold = threshold
+	return v
+}
+
+// Compression returns the algorithm used to compress serialized messages
+func (v *Serializer) Compression() Compression {
+	return v.compression
+}
+
 // Serialize encodes a envelope.EncodedMessage into a byte slice, adding version information
-// and a CRC checksum. It uses the serializer corresponding to the current serializationVersion.
+// and a CRC checksum. It uses the serializer corresponding to the current serializationVersion,
+// and compresses the result if it reaches the compression threshold.
 func (v *Serializer) Serialize(msg *EncodedMessage) ([]byte, error) {
 	serializer := v.serializers[v.serializationVersion]
 	msgBytes, err := serializer.Serialize(msg)
@@ -78,11 +104,23 @@ func (v *Serializer) Serialize(msg *EncodedMessage) ([]byte, error) {
 		return nil, NewErrSerializationFailed(v.serializationVersion.String(), err)
 	}
 
+	compression := CompressionNone
+	if v.compression != CompressionNone && len(msgBytes) >= v.compressionThreshold {
+		compressed, err := compress(v.compression, msgBytes)
+		if err != nil {
+			return nil, NewErrSerializationFailed(v.compression.String(), err)
+		}
+		if len(compressed) < len(msgBytes) {
+			msgBytes = compressed
+			compression = v.compression
+		}
+	}
+
 	// Allocate the final message buffer
 	finalMsg := make([]byte, HeaderSize+len(msgBytes))
 
-	// Set SchemaVersion
-	finalMsg[0] = byte(v.serializationVersion)
+	// Set SchemaVersion and Compression
+	finalMsg[0] = byte(v.serializationVersion) | byte(compression)<<compressionShift
 
 	// Copy serialized message
 	copy(finalMsg[HeaderSize:], msgBytes)
@@ -101,7 +139,8 @@ func (v *Serializer) Deserialize(data []byte) (*EncodedMessage, error) {
 		return nil, NewErrBadMessage(ErrMsgTooShort)
 	}
 
-	version := SchemaVersion(data[0])
+	version := SchemaVersion(data[0] & schemaVersionMask)
+	compression := Compression(data[0] >> compressionShift)
 	deserializer, ok := v.serializers[version]
 	if !ok {
 		return nil, NewErrUnsupportedEncoding(version.String())
@@ -114,7 +153,12 @@ func (v *Serializer) Deserialize(data []byte) (*EncodedMessage, error) {
 		return nil, NewErrBadMessage(ErrMsgCRCFailed)
 	}
 
-	msg, err := deserializer.Deserialize(data[HeaderSize:])
+	msgBytes, err := decompress(compression, data[HeaderSize:])
+	if err != nil {
+		return nil, NewErrDeserializationFailed(compression.String(), err)
+	}
+
+	msg, err := deserializer.Deserialize(msgBytes)
 	if err != nil {
 		return nil, NewErrDeserializationFailed(version.String(), err)
 	}
@@ -5,6 +5,7 @@ package envelope
 import (
 	"encoding/binary"
 	"hash/crc32"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -97,6 +98,64 @@ func (suite *SerializerTestSuite) TestSerializeNilMessage() {
 	suite.IsType(&ErrSerializationFailed{}, err)
 }
 
+func (suite *SerializerTestSuite) TestSerializeCompressed() {
+	original := &EncodedMessage{
+		Metadata: &Metadata{"key": "value"},
+		Payload:  []byte(`{"test": "` + strings.Repeat("data", 1000) + `"}`),
+	}
+
+	for _, compression := range []Compression{CompressionGzip, CompressionZstd} {
+		suite.Run(compression.String(), func() {
+			uncompressed, err := NewSerializer().Serialize(original)
+			suite.Require().NoError(err)
+
+			suite.serializer.WithCompression(compression, DefaultCompressionThreshold)
+			data, err := suite.serializer.Serialize(original)
+			suite.Require().NoError(err)
+
+			suite.Equal(SchemaVersionJSONV1, SchemaVersion(data[0]&schemaVersionMask))
+			suite.Equal(compression, Compression(data[0]>>compressionShift))
+			suite.Less(len(data), len(uncompressed))
+
+			// any serializer can deserialize compressed messages
+			result, err := NewSerializer().Deserialize(data)
+			suite.Require().NoError(err)
+			suite.Equal(original.Metadata, result.Metadata)
+			suite.JSONEq(string(original.Payload), string(result.Payload))
+		})
+	}
+}
+
+func (suite *SerializerTestSuite) TestSerializeBelowCompressionThreshold() {
+	original := &EncodedMessage{
+		Metadata: &Metadata{"key": "value"},
+		Payload:  []byte(`{"test": "data"}`),
+	}
+
+	suite.serializer.WithCompression(CompressionZstd, DefaultCompressionThreshold)
+	data, err := suite.serializer.Serialize(original)
+	suite.Require().NoError(err)
+
+	// small messages are sent uncompressed, and are readable by older peers
+	suite.Equal(byte(SchemaVersionJSONV1), data[0])
+}
+
+func (suite *SerializerTestSuite) TestDeserializeInvalidCompressed() {
+	original := &EncodedMessage{
+		Metadata: &Metadata{"key": "value"},
+		Payload:  []byte(`{"test": "data"}`),
+	}
+
+	data, err := suite.serializer.Serialize(original)
+	suite.Require().NoError(err)
+
+	// flag the uncompressed message as compressed
+	data[0] |= byte(CompressionZstd) << compressionShift
+	_, err = suite.serializer.Deserialize(data)
+	suite.Error(err)
+	suite.IsType(&ErrDeserializationFailed{}, err)
+}
+
 func TestSerializerTestSuite(t *testing.T) {
 	suite.Run(t, new(SerializerTestSuite))
 }
@@ -0,0 +1,103 @@
+package envelope
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+)
+
+// Error message constants
+const (
+	ErrMsgMissingSignature = "missing signature"
+	ErrMsgInvalidSignature = "invalid signature"
+)
+
+// Ed25519Signer signs messages with an ed25519 private key.
+// The signature covers the payload and all metadata of the message,
+// so that neither can be modified without invalidating it.
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer creates a new signer using the given private key
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+// PublicKey returns the public key verifying the signatures of the signer
+func (s *Ed25519Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign signs the message, adding the signature to its metadata
+func (s *Ed25519Signer) Sign(message *EncodedMessage) error {
+	if message == nil {
+		return NewErrBadMessage(ErrNilMessage)
+	}
+	if message.Metadata == nil {
+		message.Metadata = &Metadata{}
+	}
+	signature := ed25519.Sign(s.key, signedData(message))
+	message.Metadata.Set(KeySignature, base64.StdEncoding.EncodeToString(signature))
+	return nil
+}
+
+// Ed25519Verifier verifies message signatures with an ed25519 public key
+type Ed25519Verifier struct {
+	key ed25519.PublicKey
+}
+
+// NewEd25519Verifier creates a new verifier using the given public key
+func NewEd25519Verifier(key ed25519.PublicKey) *Ed25519Verifier {
+	return &Ed25519Verifier{key: key}
+}
+
+// Verify returns an error if the message is not signed by the private key
+// matching the verifier's public key
+func (v *Ed25519Verifier) Verify(message *EncodedMessage) error {
+	if message == nil {
+		return NewErrBadMessage(ErrNilMessage)
+	}
+	if !IsSigned(message) {
+		return NewErrBadMessage(ErrMsgMissingSignature)
+	}
+	signature, err := base64.StdEncoding.DecodeString(message.Metadata.Get(KeySignature))
+	if err != nil || len(v.key) != ed25519.PublicKeySize || !ed25519.Verify(v.key, signedData(message), signature) {
+		return NewErrBadMessage(ErrMsgInvalidSignature)
+	}
+	return nil
+}
+
+// IsSigned returns true if the message carries a signature
+func IsSigned(message *EncodedMessage) bool {
+	return message.Metadata != nil && message.Metadata.Has(KeySignature)
+}
+
+// signedData returns the bytes covered by the signature of a message: the length-prefixed
+// metadata keys and values sorted by key, excluding the signature, followed by the payload
+func signedData(message *EncodedMessage) []byte {
+	var keys []string
+	if message.Metadata != nil {
+		for k := range *message.Metadata {
+			if k != KeySignature {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	var data []byte
+	for _, k := range keys {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(k)))
+		data = append(data, k...)
+		v := (*message.Metadata)[k]
+		data = binary.BigEndian.AppendUint32(data, uint32(len(v)))
+		data = append(data, v...)
+	}
+	return append(data, message.Payload...)
+}
+
+// compile-time interface assertions
+var _ Signer = (*Ed25519Signer)(nil)
+var _ Verifier = (*Ed25519Verifier)(nil)
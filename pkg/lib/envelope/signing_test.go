@@ -0,0 +1,113 @@
+//go:build unit || !integration
+
+package envelope
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SigningTestSuite struct {
+	suite.Suite
+	signer   *Ed25519Signer
+	verifier *Ed25519Verifier
+}
+
+func TestSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningTestSuite))
+}
+
+func (suite *SigningTestSuite) SetupTest() {
+	_, key, err := ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+	suite.signer = NewEd25519Signer(key)
+	suite.verifier = NewEd25519Verifier(suite.signer.PublicKey())
+}
+
+func (suite *SigningTestSuite) newMessage() *EncodedMessage {
+	// payloads are compact, as produced by the payload serializers
+	return &EncodedMessage{
+		Metadata: &Metadata{"key": "value", KeyMessageType: "test"},
+		Payload:  []byte(`{"test":"data"}`),
+	}
+}
+
+func (suite *SigningTestSuite) TestSignVerify() {
+	msg := suite.newMessage()
+	suite.Require().NoError(suite.signer.Sign(msg))
+	suite.True(IsSigned(msg))
+	suite.NoError(suite.verifier.Verify(msg))
+}
+
+func (suite *SigningTestSuite) TestSignVerifyAfterSerialization() {
+	for _, schemaVersion := range []SchemaVersion{SchemaVersionJSONV1, SchemaVersionProtobufV1} {
+		suite.Run(schemaVersion.String(), func() {
+			msg := suite.newMessage()
+			suite.Require().NoError(suite.signer.Sign(msg))
+
+			serializer := NewSerializer().WithSerializationVersion(schemaVersion)
+			data, err := serializer.Serialize(msg)
+			suite.Require().NoError(err)
+			result, err := serializer.Deserialize(data)
+			suite.Require().NoError(err)
+
+			suite.NoError(suite.verifier.Verify(result))
+		})
+	}
+}
+
+func (suite *SigningTestSuite) TestVerifyTampered() {
+	testCases := []struct {
+		name   string
+		tamper func(msg *EncodedMessage)
+	}{
+		{
+			name:   "payload",
+			tamper: func(msg *EncodedMessage) { msg.Payload = []byte(`{"test": "tampered"}`) },
+		},
+		{
+			name:   "metadata value",
+			tamper: func(msg *EncodedMessage) { msg.Metadata.Set("key", "tampered") },
+		},
+		{
+			name:   "added metadata",
+			tamper: func(msg *EncodedMessage) { msg.Metadata.Set("other", "value") },
+		},
+		{
+			name:   "signature",
+			tamper: func(msg *EncodedMessage) { msg.Metadata.Set(KeySignature, "dGFtcGVyZWQ=") },
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			msg := suite.newMessage()
+			suite.Require().NoError(suite.signer.Sign(msg))
+			tc.tamper(msg)
+
+			err := suite.verifier.Verify(msg)
+			suite.IsType(&ErrBadMessage{}, err)
+			suite.Contains(err.Error(), ErrMsgInvalidSignature)
+		})
+	}
+}
+
+func (suite *SigningTestSuite) TestVerifyOtherKey() {
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+
+	msg := suite.newMessage()
+	suite.Require().NoError(NewEd25519Signer(otherKey).Sign(msg))
+	suite.Error(suite.verifier.Verify(msg))
+}
+
+func (suite *SigningTestSuite) TestVerifyUnsigned() {
+	msg := suite.newMessage()
+	suite.False(IsSigned(msg))
+
+	err := suite.verifier.Verify(msg)
+	suite.IsType(&ErrBadMessage{}, err)
+	suite.Contains(err.Error(), ErrMsgMissingSignature)
+}
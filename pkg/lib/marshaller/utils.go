@@ -180,6 +180,8 @@ var (
 		"type":        {},
 		"priority":    {},
 		"count":       {},
+		"matrix":      {},
+		"partitions":  {},
 		"constraints": {},
 		"meta":        {},
 		"labels":      {},
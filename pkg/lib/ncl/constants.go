@@ -7,4 +7,18 @@ const (
 	KeyMessageUUID = "Bacalhau-MessageUUID"
 	KeyMessageID   = "Bacalhau-MessageID"
 	KeySubject     = "Bacalhau-Subject"
+	// KeyRecipient holds the ID of the node a message is addressed to
+	KeyRecipient = "Bacalhau-Recipient"
+)
+
+// KeyExecutionID is the metadata key of the execution a message relates to, if any.
+// It is used to link the spans of a message to the execution across nodes.
+const KeyExecutionID = "Bacalhau-ExecutionID"
+
+// Span attribute keys
+const (
+	AttrSpanMessageType = "ncl.message_type"
+	AttrSpanSubject     = "ncl.subject"
+	AttrSpanSource      = "ncl.source"
+	AttrSpanExecutionID = "executionid"
 )
@@ -12,9 +12,12 @@ import (
 
 // encoder handles all message serialization and deserialization
 type encoder struct {
-	serializer envelope.MessageSerializer
-	registry   *envelope.Registry
-	source     string
+	serializer      envelope.MessageSerializer
+	registry        *envelope.Registry
+	source          string
+	signer          envelope.Signer
+	verifier        envelope.Verifier
+	payloadVerifier envelope.PayloadVerifier
 }
 
 // encoderConfig contains configuration for encoder
@@ -28,6 +31,18 @@ type encoderConfig struct {
 
 	// MessageRegistry for registering and deserializing message types
 	messageRegistry *envelope.Registry
+
+	// messageSigner signs encoded messages
+	// Optional: messages are not signed if nil
+	messageSigner envelope.Signer
+
+	// messageVerifier verifies decoded messages before their payload is deserialized
+	// Optional: messages are not verified if nil
+	messageVerifier envelope.Verifier
+
+	// payloadVerifier verifies decoded messages once their payload is deserialized
+	// Optional: messages are not verified if nil
+	payloadVerifier envelope.PayloadVerifier
 }
 
 func newEncoder(config encoderConfig) (*encoder, error) {
@@ -47,9 +62,12 @@ func newEncoder(config encoderConfig) (*encoder, error) {
 		}
 	}
 	return &encoder{
-		serializer: config.messageSerializer,
-		registry:   config.messageRegistry,
-		source:     config.source,
+		serializer:      config.messageSerializer,
+		registry:        config.messageRegistry,
+		source:          config.source,
+		signer:          config.messageSigner,
+		verifier:        config.messageVerifier,
+		payloadVerifier: config.payloadVerifier,
 	}, nil
 }
 
@@ -64,6 +82,13 @@ func (m *encoder) encode(message *envelope.Message) ([]byte, error) {
 		return nil, fmt.Errorf("failed to serialize into raw message: %w", err)
 	}
 
+	// Sign message
+	if m.signer != nil {
+		if err = m.signer.Sign(rMsg); err != nil {
+			return nil, fmt.Errorf("failed to sign message: %w", err)
+		}
+	}
+
 	// Serialize to bytes
 	data, err := m.serializer.Serialize(rMsg)
 	if err != nil {
@@ -85,12 +110,25 @@ func (m *encoder) decode(data []byte) (*envelope.Message, error) {
 		return nil, fmt.Errorf("failed to deserialize message envelope: %w", err)
 	}
 
+	// Verify message before deserializing its payload
+	if m.verifier != nil {
+		if err = m.verifier.Verify(rMsg); err != nil {
+			return nil, fmt.Errorf("failed to verify message: %w", err)
+		}
+	}
+
 	// Then try to deserialize the payload
 	message, err := m.registry.Deserialize(rMsg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize message payload: %w", err)
 	}
 
+	if m.payloadVerifier != nil {
+		if err = m.payloadVerifier.VerifyPayload(rMsg, message); err != nil {
+			return nil, fmt.Errorf("failed to verify message: %w", err)
+		}
+	}
+
 	// Ensure times are in UTC when decoding
 	if eventTime := message.Metadata.GetTime(KeyEventTime); !eventTime.IsZero() {
 		message.Metadata.SetTime(KeyEventTime, eventTime.UTC())
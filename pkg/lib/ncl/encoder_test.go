@@ -3,6 +3,7 @@
 package ncl
 
 import (
+	"crypto/ed25519"
 	"testing"
 	"time"
 
@@ -192,6 +193,70 @@ func (suite *EncoderTestSuite) TestErrorResponseRegistration() {
 	suite.Equal("test error", errResp.Error())
 }
 
+func (suite *EncoderTestSuite) TestEncodeDecodeSigned() {
+	_, key, err := ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+	signer := envelope.NewEd25519Signer(key)
+
+	signing, err := newEncoder(encoderConfig{
+		source:            "test-source",
+		messageSerializer: suite.serializer,
+		messageRegistry:   suite.registry,
+		messageSigner:     signer,
+	})
+	suite.Require().NoError(err)
+	verifying, err := newEncoder(encoderConfig{
+		source:            "test-source",
+		messageSerializer: suite.serializer,
+		messageRegistry:   suite.registry,
+		messageVerifier:   envelope.NewEd25519Verifier(signer.PublicKey()),
+	})
+	suite.Require().NoError(err)
+
+	data, err := signing.encode(envelope.NewMessage(TestPayload{Message: "Signed"}))
+	suite.Require().NoError(err)
+
+	decoded, err := verifying.decode(data)
+	suite.Require().NoError(err)
+	suite.True(decoded.Metadata.Has(envelope.KeySignature))
+
+	// unsigned messages are rejected
+	data, err = suite.encoder.encode(envelope.NewMessage(TestPayload{Message: "Unsigned"}))
+	suite.Require().NoError(err)
+	_, err = verifying.decode(data)
+	suite.ErrorContains(err, envelope.ErrMsgMissingSignature)
+}
+
+func (suite *EncoderTestSuite) TestDecodeVerifiesPayload() {
+	var verified []*envelope.Message
+	verifying, err := newEncoder(encoderConfig{
+		source:            "test-source",
+		messageSerializer: suite.serializer,
+		messageRegistry:   suite.registry,
+		payloadVerifier: envelope.PayloadVerifierFunc(func(_ *envelope.EncodedMessage, message *envelope.Message) error {
+			verified = append(verified, message)
+			if message.Payload.(*TestPayload).Message != "Trusted" {
+				return envelope.NewErrBadMessage("untrusted payload")
+			}
+			return nil
+		}),
+	})
+	suite.Require().NoError(err)
+
+	data, err := suite.encoder.encode(envelope.NewMessage(TestPayload{Message: "Trusted"}))
+	suite.Require().NoError(err)
+	decoded, err := verifying.decode(data)
+	suite.Require().NoError(err)
+	suite.Require().Len(verified, 1)
+	// the verified message is the one returned, so its payload is only deserialized once
+	suite.Same(decoded, verified[0])
+
+	data, err = suite.encoder.encode(envelope.NewMessage(TestPayload{Message: "Untrusted"}))
+	suite.Require().NoError(err)
+	_, err = verifying.decode(data)
+	suite.ErrorContains(err, "untrusted payload")
+}
+
 func TestEncoderTestSuite(t *testing.T) {
 	suite.Run(t, new(EncoderTestSuite))
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
@@ -29,6 +30,8 @@ func NewPublisher(nc *nats.Conn, config PublisherConfig) (Publisher, error) {
 		source:            config.Name,
 		messageSerializer: config.MessageSerializer,
 		messageRegistry:   config.MessageRegistry,
+		messageSigner:     config.MessageSigner,
+		messageVerifier:   config.MessageVerifier,
 	})
 	if err != nil {
 		return nil, err
@@ -76,6 +79,9 @@ func (p *publisher) Publish(ctx context.Context, request PublishRequest) error {
 		return err
 	}
 
+	ctx, span := p.startPublishSpan(ctx, "ncl.Publish", request)
+	defer func() { endSpan(span, err) }()
+
 	msg, err := p.encodeMsg(request)
 	if err != nil {
 		return err
@@ -112,6 +118,9 @@ func (p *publisher) Request(ctx context.Context, request PublishRequest) (*envel
 		return nil, err
 	}
 
+	ctx, span := p.startPublishSpan(ctx, "ncl.Request", request)
+	defer func() { endSpan(span, err) }()
+
 	msg, err := p.encodeMsg(request)
 	if err != nil {
 		return nil, err
@@ -178,6 +187,19 @@ func (p *publisher) getSubject(request PublishRequest) string {
 		request.Message.Metadata.Get(envelope.KeyMessageType))
 }
 
+// startPublishSpan starts a producer span for the request and injects
+// its trace context into the message metadata, so that the consumer
+// of the message can continue the trace.
+func (p *publisher) startPublishSpan(
+	ctx context.Context, name string, request PublishRequest) (context.Context, oteltrace.Span) {
+	ctx, span := StartMessageSpan(ctx, name, request.Message,
+		oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+		oteltrace.WithAttributes(attribute.String(AttrSpanSubject, p.getSubject(request))),
+	)
+	InjectTraceContext(ctx, request.Message)
+	return ctx, span
+}
+
 // encodeMsg creates a NATS message from the request
 func (p *publisher) encodeMsg(request PublishRequest) (*nats.Msg, error) {
 	data, err := p.encoder.encode(request.Message)
@@ -31,6 +31,14 @@ type PublisherConfig struct {
 	// MessageRegistry for registering and deserializing message types
 	MessageRegistry *envelope.Registry
 
+	// MessageSigner signs published messages
+	// Optional: messages are not signed if nil
+	MessageSigner envelope.Signer
+
+	// MessageVerifier verifies the responses to requests
+	// Optional: responses are not verified if nil
+	MessageVerifier envelope.Verifier
+
 	// Either Destination or DestinationPrefix must be set, but not both
 
 	// Destination is the exact NATS subject for all messages
@@ -77,6 +85,10 @@ type OrderedPublisherConfig struct {
 	// MessageRegistry for registering and deserializing message types
 	MessageRegistry *envelope.Registry
 
+	// MessageSigner signs published messages
+	// Optional: messages are not signed if nil
+	MessageSigner envelope.Signer
+
 	// Either Destination or DestinationPrefix must be set, but not both
 
 	// Destination is the exact NATS subject for all messages
@@ -156,6 +168,7 @@ func (c *OrderedPublisherConfig) toPublisherConfig() *PublisherConfig {
 		Name:              c.Name,
 		MessageSerializer: c.MessageSerializer,
 		MessageRegistry:   c.MessageRegistry,
+		MessageSigner:     c.MessageSigner,
 		Destination:       c.Destination,
 		DestinationPrefix: c.DestinationPrefix,
 	}
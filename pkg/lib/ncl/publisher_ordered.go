@@ -147,6 +147,9 @@ func (p *orderedPublisher) PublishAsync(ctx context.Context, request PublishRequ
 		return nil, err
 	}
 
+	ctx, span := p.startPublishSpan(ctx, "ncl.PublishAsync", request)
+	defer func() { endSpan(span, err) }()
+
 	msg, err := p.encodeMsg(request)
 	if err != nil {
 		return nil, err
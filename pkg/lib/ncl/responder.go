@@ -42,6 +42,9 @@ func NewResponder(nc *nats.Conn, config ResponderConfig) (Responder, error) {
 		source:            config.Name,
 		messageSerializer: config.MessageSerializer,
 		messageRegistry:   config.MessageRegistry,
+		messageSigner:     config.MessageSigner,
+		messageVerifier:   config.MessageVerifier,
+		payloadVerifier:   config.PayloadVerifier,
 	})
 	if err != nil {
 		return nil, err
@@ -195,12 +198,17 @@ func (r *responder) handleRequest(requestMsg *nats.Msg) {
 }
 
 // sendResponse sends a response message back through NATS.
-// It preserves correlation IDs and handles serialization of the response envelope.
+// It preserves correlation IDs, addresses the response to the requester
+// and handles serialization of the response envelope.
 func (r *responder) sendResponse(ctx context.Context, metrics *telemetry.MetricRecorder, requestMsg *nats.Msg, response *envelope.Message) {
 	// Preserve request correlation ID if present
 	if reqID := requestMsg.Header.Get(KeyMessageID); reqID != "" {
 		response.WithMetadataValue(KeyMessageID, reqID)
 	}
+	// Address the response to the requester, so that signed responses can't be replayed to other nodes
+	if source := requestMsg.Header.Get(KeySource); source != "" {
+		response.WithMetadataValue(KeyRecipient, source)
+	}
 
 	// Serialize response
 	data, err := r.encoder.encode(response)
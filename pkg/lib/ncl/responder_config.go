@@ -20,6 +20,19 @@ type ResponderConfig struct {
 	// MessageRegistry for registering and deserializing message types
 	MessageRegistry *envelope.Registry
 
+	// MessageSigner signs responses
+	// Optional: responses are not signed if nil
+	MessageSigner envelope.Signer
+
+	// MessageVerifier verifies requests, which are answered with an error if verification fails
+	// Optional: requests are not verified if nil
+	MessageVerifier envelope.Verifier
+
+	// PayloadVerifier verifies requests whose verification depends on their payload,
+	// once it is deserialized. Requests are answered with an error if verification fails
+	// Optional: requests are not verified once deserialized if nil
+	PayloadVerifier envelope.PayloadVerifier
+
 	// Subject is the NATS subject to subscribe to
 	Subject string
 
@@ -91,6 +91,7 @@ func (suite *ResponderTestSuite) TestBasicRequestResponse() {
 	payload, ok := response.GetPayload(TestPayload{})
 	suite.True(ok)
 	suite.Equal("Response: Hello", payload.(TestPayload).Message)
+	suite.Equal("test-publisher", response.Metadata.Get(KeyRecipient))
 }
 
 func (suite *ResponderTestSuite) TestHandlerTimeout() {
@@ -10,6 +10,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
@@ -35,6 +36,7 @@ func NewSubscriber(nc *nats.Conn, config SubscriberConfig) (Subscriber, error) {
 		source:            config.Name,
 		messageSerializer: config.MessageSerializer,
 		messageRegistry:   config.MessageRegistry,
+		messageVerifier:   config.MessageVerifier,
 	})
 	if err != nil {
 		return nil, err
@@ -115,7 +117,7 @@ func (s *subscriber) handleNatsMessage(m *nats.Msg) {
 	}
 }
 
-func (s *subscriber) processMessage(ctx context.Context, metrics *telemetry.MetricRecorder, m *nats.Msg) error {
+func (s *subscriber) processMessage(ctx context.Context, metrics *telemetry.MetricRecorder, m *nats.Msg) (err error) {
 	// TODO: interrupt processing if subscriber is closed
 	ctx, cancel := context.WithTimeout(ctx, s.config.ProcessingTimeout)
 	defer cancel()
@@ -136,6 +138,13 @@ func (s *subscriber) processMessage(ctx context.Context, metrics *telemetry.Metr
 	metrics.Latency(ctx, messageProcessPartDuration, "decode")
 	s.addMessageMetrics(ctx, metrics, message)
 
+	// Continue the trace of the publisher, if any
+	ctx, span := StartMessageSpan(ExtractTraceContext(ctx, message), "ncl.Subscriber.processMessage", message,
+		oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+		oteltrace.WithAttributes(attribute.String(AttrSpanSubject, m.Subject)),
+	)
+	defer func() { endSpan(span, err) }()
+
 	// Process with handler
 	if s.config.MessageHandler.ShouldProcess(ctx, message) {
 		if err = s.config.MessageHandler.HandleMessage(ctx, message); err != nil {
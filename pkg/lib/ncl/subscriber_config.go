@@ -21,6 +21,10 @@ type SubscriberConfig struct {
 	// MessageRegistry for registering and deserializing message types
 	MessageRegistry *envelope.Registry
 
+	// MessageVerifier verifies received messages, which are rejected if verification fails
+	// Optional: messages are not verified if nil
+	MessageVerifier envelope.Verifier
+
 	// MessageHandler processes received messages
 	MessageHandler MessageHandler
 
@@ -0,0 +1,87 @@
+package ncl
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
+)
+
+// metadataCarrier adapts message metadata to a propagation.TextMapCarrier,
+// so that trace context travels with the message in the envelope.
+type metadataCarrier struct {
+	metadata *envelope.Metadata
+}
+
+func (c metadataCarrier) Get(key string) string {
+	return c.metadata.Get(key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	c.metadata.Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.metadata))
+	for k := range *c.metadata {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// compile-time interface check
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+// InjectTraceContext injects the trace context of ctx into the message metadata.
+// It must be called before the message is encoded, as the signature covers the metadata.
+func InjectTraceContext(ctx context.Context, message *envelope.Message) {
+	if message == nil || message.Metadata == nil {
+		return
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier{metadata: message.Metadata})
+}
+
+// ExtractTraceContext returns a context holding the trace context found in the message metadata.
+// The context is returned unchanged if the message carries no trace context.
+func ExtractTraceContext(ctx context.Context, message *envelope.Message) context.Context {
+	if message == nil || message.Metadata == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier{metadata: message.Metadata})
+}
+
+// MessageSpanAttributes returns the span attributes describing a message,
+// including the execution it relates to, if any.
+func MessageSpanAttributes(message *envelope.Message) []attribute.KeyValue {
+	if message == nil || message.Metadata == nil {
+		return nil
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String(AttrSpanMessageType, message.Metadata.Get(envelope.KeyMessageType)),
+	}
+	if source := message.Metadata.Get(KeySource); source != "" {
+		attrs = append(attrs, attribute.String(AttrSpanSource, source))
+	}
+	if executionID := message.Metadata.Get(KeyExecutionID); executionID != "" {
+		attrs = append(attrs, attribute.String(AttrSpanExecutionID, executionID))
+	}
+	return attrs
+}
+
+// StartMessageSpan starts a span for handling a message, tagged with the message attributes.
+func StartMessageSpan(
+	ctx context.Context, name string, message *envelope.Message, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	opts = append(opts, oteltrace.WithAttributes(MessageSpanAttributes(message)...))
+	return telemetry.NewSpan(ctx, telemetry.GetTracer(), name, opts...)
+}
+
+// endSpan records the error, if any, and ends the span
+func endSpan(span oteltrace.Span, err error) {
+	_ = telemetry.RecordErrorOnSpan(span)(err)
+	span.End()
+}
@@ -0,0 +1,145 @@
+//go:build unit || !integration
+
+package ncl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+)
+
+// tracingHandler records the span context of the handled messages
+type tracingHandler struct {
+	spanContexts chan oteltrace.SpanContext
+}
+
+func (h *tracingHandler) ShouldProcess(_ context.Context, _ *envelope.Message) bool {
+	return true
+}
+
+func (h *tracingHandler) HandleMessage(ctx context.Context, _ *envelope.Message) error {
+	h.spanContexts <- oteltrace.SpanContextFromContext(ctx)
+	return nil
+}
+
+type TracingTestSuite struct {
+	suite.Suite
+	natsServer *server.Server
+	natsConn   *nats.Conn
+	registry   *envelope.Registry
+	recorder   *tracetest.SpanRecorder
+
+	previousProvider   oteltrace.TracerProvider
+	previousPropagator propagation.TextMapPropagator
+}
+
+func (s *TracingTestSuite) SetupSuite() {
+	s.registry = envelope.NewRegistry()
+	s.Require().NoError(s.registry.Register(TestPayloadType, TestPayload{}))
+	s.natsServer, s.natsConn = StartNats(s.T())
+
+	s.previousProvider = otel.GetTracerProvider()
+	s.previousPropagator = otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+func (s *TracingTestSuite) TearDownSuite() {
+	otel.SetTracerProvider(s.previousProvider)
+	otel.SetTextMapPropagator(s.previousPropagator)
+	s.natsConn.Close()
+	s.natsServer.Shutdown()
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *TracingTestSuite) TestInjectExtract() {
+	ctx, span := otel.Tracer("test").Start(context.Background(), "root")
+	defer span.End()
+
+	message := envelope.NewMessage(TestPayload{Message: "hello"})
+	InjectTraceContext(ctx, message)
+	s.True(message.Metadata.Has("traceparent"))
+
+	extracted := oteltrace.SpanContextFromContext(ExtractTraceContext(context.Background(), message))
+	s.Equal(span.SpanContext().TraceID(), extracted.TraceID())
+	s.Equal(span.SpanContext().SpanID(), extracted.SpanID())
+	s.True(extracted.IsRemote())
+
+	// messages without trace context leave the context untouched
+	ctx = ExtractTraceContext(context.Background(), envelope.NewMessage(TestPayload{}))
+	s.False(oteltrace.SpanContextFromContext(ctx).IsValid())
+}
+
+func (s *TracingTestSuite) TestPropagationAcrossPublishAndSubscribe() {
+	handler := &tracingHandler{spanContexts: make(chan oteltrace.SpanContext, 1)}
+	subscriber, err := NewSubscriber(s.natsConn, SubscriberConfig{
+		Name:            "test-subscriber",
+		MessageRegistry: s.registry,
+		MessageHandler:  handler,
+	})
+	s.Require().NoError(err)
+	defer subscriber.Close(context.Background())
+	s.Require().NoError(subscriber.Subscribe(context.Background(), TestSubject))
+
+	publisher, err := NewPublisher(s.natsConn, PublisherConfig{
+		Name:            "test-publisher",
+		MessageRegistry: s.registry,
+		Destination:     TestSubject,
+	})
+	s.Require().NoError(err)
+
+	ctx, root := otel.Tracer("test").Start(context.Background(), "root")
+	message := envelope.NewMessage(TestPayload{Message: "hello"}).
+		WithMetadataValue(envelope.KeyMessageType, TestPayloadType).
+		WithMetadataValue(KeyExecutionID, "e-123")
+	s.Require().NoError(publisher.Publish(ctx, NewPublishRequest(message)))
+	root.End()
+
+	var handled oteltrace.SpanContext
+	select {
+	case handled = <-handler.spanContexts:
+	case <-time.After(time.Second):
+		s.FailNow("message not handled")
+	}
+	s.Equal(root.SpanContext().TraceID(), handled.TraceID(), "handler should continue the publisher trace")
+
+	s.Eventually(func() bool { return len(s.recorder.Ended()) == 3 }, time.Second, 10*time.Millisecond)
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range s.recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	producer, consumer := spans["ncl.Publish"], spans["ncl.Subscriber.processMessage"]
+	s.Require().NotNil(producer)
+	s.Require().NotNil(consumer)
+	s.Equal(oteltrace.SpanKindProducer, producer.SpanKind())
+	s.Equal(oteltrace.SpanKindConsumer, consumer.SpanKind())
+	s.Equal(producer.SpanContext().SpanID(), consumer.Parent().SpanID())
+
+	for _, span := range []sdktrace.ReadOnlySpan{producer, consumer} {
+		attrs := make(map[string]string)
+		for _, attr := range span.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		s.Equal("e-123", attrs[AttrSpanExecutionID])
+		s.Equal(TestPayloadType, attrs[AttrSpanMessageType])
+		s.Equal(TestSubject, attrs[AttrSpanSubject])
+	}
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
@@ -3,6 +3,7 @@ package policy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"strings"
@@ -67,8 +68,17 @@ type Query[Input, Output any] func(ctx context.Context, input Input) (Output, er
 // certain input type and returns a function that will execute the query when
 // given input of that type.
 func AddQuery[Input, Output any](runner *Policy, rule string) Query[Input, Output] {
+	return lo.Must(PrepareQuery[Input, Output](runner, rule))
+}
+
+// PrepareQuery is like AddQuery, but returns an error instead of panicking if
+// the policy cannot be prepared, e.g. when it was provided at runtime.
+func PrepareQuery[Input, Output any](runner *Policy, rule string) (Query[Input, Output], error) {
 	opts := append(runner.modules, rego.Query("data."+rule), scryptFn, rego.StrictBuiltinErrors(true))
-	query := lo.Must(rego.New(opts...).PrepareForEval(context.Background()))
+	query, err := rego.New(opts...).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, err
+	}
 
 	return func(ctx context.Context, t Input) (Output, error) {
 		var out Output
@@ -97,7 +107,10 @@ func AddQuery[Input, Output any](runner *Policy, rule string) Query[Input, Outpu
 			return out, ErrNoResult
 		}
 
-		out = (result[0].Expressions[0].Value).(Output)
+		out, ok := (result[0].Expressions[0].Value).(Output)
+		if !ok {
+			return out, fmt.Errorf("the query returned %T instead of %T", result[0].Expressions[0].Value, out)
+		}
 		return out, nil
-	}
+	}, nil
 }
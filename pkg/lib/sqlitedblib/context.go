@@ -0,0 +1,124 @@
+package sqlitedblib
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// TxContext is a transactional context that can be used to commit or rollback
+type TxContext interface {
+	context.Context
+	Commit() error
+	Rollback() error
+}
+
+// Tx wraps a SQLite transaction, and runs the registered callbacks once it is committed
+type Tx struct {
+	*sql.Tx
+	writable bool
+	release  func()
+
+	mu       sync.Mutex
+	onCommit []func()
+}
+
+// Writable returns true if the transaction can write to the database
+func (t *Tx) Writable() bool {
+	return t.writable
+}
+
+// OnCommit registers a callback to run once the transaction is committed.
+// Callbacks are not run if the transaction is rolled back.
+func (t *Tx) OnCommit(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onCommit = append(t.onCommit, fn)
+}
+
+// Commit commits the transaction and runs the registered callbacks
+func (t *Tx) Commit() error {
+	err := t.Tx.Commit()
+	t.release()
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	callbacks := t.onCommit
+	t.onCommit = nil
+	t.mu.Unlock()
+	for _, fn := range callbacks {
+		fn()
+	}
+	return nil
+}
+
+// Rollback rolls back the transaction. Rolling back a transaction that is
+// already committed or rolled back is not an error.
+func (t *Tx) Rollback() error {
+	err := t.Tx.Rollback()
+	t.release()
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
+
+// Begin starts a new transaction. The transaction is rolled back if the
+// context is cancelled before it is committed.
+// Writable transactions wait for the writable transaction in progress, if any, to finish.
+func Begin(ctx context.Context, db *DB, writable bool) (*Tx, error) {
+	release := func() {}
+	if writable {
+		select {
+		case db.writeLock <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-db.writeLock }) }
+	}
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: !writable})
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &Tx{Tx: tx, writable: writable, release: release}, nil
+}
+
+// contextKey is a custom type to avoid key collisions in context values
+type contextKey int
+
+// txContextKey is the key used to store the transaction context in the context
+const txContextKey contextKey = 0
+
+// txContext provides a simple wrapper around SQLite transactions
+type txContext struct {
+	context.Context
+	tx *Tx
+}
+
+// NewTxContext creates a new transaction context
+func NewTxContext(ctx context.Context, tx *Tx) TxContext {
+	return &txContext{
+		Context: context.WithValue(ctx, txContextKey, tx),
+		tx:      tx,
+	}
+}
+
+// TxFromContext retrieves the transaction from the context, if available
+func TxFromContext(ctx context.Context) (*Tx, bool) {
+	tx, ok := ctx.Value(txContextKey).(*Tx)
+	return tx, ok
+}
+
+// Commit commits the transaction
+func (t *txContext) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback rolls back the transaction
+func (t *txContext) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,128 @@
+package sqlitedblib
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	// registers the pure Go "sqlite" database/sql driver
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+)
+
+const (
+	component  = "SQLite"
+	driverName = "sqlite"
+
+	// defaultBusyTimeout is how long a connection waits for a lock held by another
+	// connection, or another process, before failing with SQLITE_BUSY
+	defaultBusyTimeout = 5 * time.Second
+)
+
+// DB is a SQLite database handle that serializes the writable transactions of the process.
+type DB struct {
+	*sql.DB
+	// writeLock is held by the writable transaction in progress, so that other writers
+	// wait for it to finish instead of polling the database lock until the busy timeout
+	writeLock chan struct{}
+}
+
+// Open opens the SQLite database at the given path, creating it if it doesn't exist.
+//
+// The database is opened in WAL mode so that readers are never blocked by a writer.
+// Writable transactions take the database write lock as soon as they begin, so that
+// concurrent writable transactions are serialized instead of failing when they upgrade
+// from a read lock. Read-only transactions are deferred and see a consistent snapshot.
+func Open(path string) (*DB, error) {
+	query := url.Values{}
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", defaultBusyTimeout.Milliseconds()))
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "synchronous(NORMAL)")
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Set("_txlock", "immediate")
+	dsn := (&url.URL{Scheme: "file", Path: path, RawQuery: query.Encode()}).String()
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, bacerrors.Wrap(err, "failed to open database at %s", path)
+	}
+	// open a connection to surface errors, such as an invalid path, early
+	if err = db.PingContext(context.Background()); err != nil {
+		_ = db.Close()
+		return nil, bacerrors.Wrap(err, "failed to open database at %s", path).
+			WithCode(bacerrors.ConfigurationError).
+			WithComponent(component)
+	}
+	return &DB{DB: db, writeLock: make(chan struct{}, 1)}, nil
+}
+
+// InUse returns true if the database at path is open by another connection, of this process
+// or another one, after waiting up to timeout for it to be closed.
+//
+// A database in WAL mode is not locked between transactions, so the database is opened in
+// exclusive locking mode, which requires no other connection to have it open.
+func InUse(ctx context.Context, path string, timeout time.Duration) (bool, error) {
+	query := url.Values{}
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", timeout.Milliseconds()))
+	query.Add("_pragma", "locking_mode(EXCLUSIVE)")
+	dsn := (&url.URL{Scheme: "file", Path: path, RawQuery: query.Encode()}).String()
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return false, bacerrors.Wrap(err, "failed to open database at %s", path)
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, bacerrors.Wrap(err, "failed to open database at %s", path)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "BEGIN EXCLUSIVE"); err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) &&
+			(sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY || sqliteErr.Code()&0xff == sqlite3.SQLITE_LOCKED) {
+			return true, nil
+		}
+		return false, bacerrors.Wrap(err, "failed to lock database at %s", path)
+	}
+	_, err = conn.ExecContext(ctx, "ROLLBACK")
+	return false, err
+}
+
+// Migrate brings the schema of the database up to date by running the statements of
+// each schema version newer than the version recorded in the database.
+// versions[i] holds the statements creating version i+1 of the schema.
+func Migrate(ctx context.Context, db *DB, versions [][]string) error {
+	return Update(ctx, db, func(tx *Tx) error {
+		var current int
+		if err := tx.QueryRowContext(ctx, "PRAGMA user_version").Scan(&current); err != nil {
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		if current > len(versions) {
+			return bacerrors.New("database schema version %d is newer than the latest supported version %d",
+				current, len(versions)).
+				WithHint("The database was created by a newer version of bacalhau").
+				WithCode(bacerrors.ConfigurationError).
+				WithComponent(component)
+		}
+		for version := current; version < len(versions); version++ {
+			for _, statement := range versions[version] {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return fmt.Errorf("failed to migrate schema to version %d: %w", version+1, err)
+				}
+			}
+		}
+		if current == len(versions) {
+			return nil
+		}
+		// PRAGMA statements don't support bound parameters
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", len(versions)))
+		return err
+	})
+}
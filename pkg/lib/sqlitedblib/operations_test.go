@@ -0,0 +1,154 @@
+//go:build unit || !integration
+
+package sqlitedblib
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OperationsTestSuite struct {
+	suite.Suite
+	db *DB
+}
+
+func (suite *OperationsTestSuite) SetupTest() {
+	db, err := Open(filepath.Join(suite.T().TempDir(), "operations.db"))
+	suite.Require().NoError(err)
+	suite.db = db
+
+	err = Migrate(context.Background(), suite.db, [][]string{
+		{`CREATE TABLE test (key TEXT PRIMARY KEY, value TEXT NOT NULL)`},
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *OperationsTestSuite) TearDownTest() {
+	suite.NoError(suite.db.Close())
+}
+
+func (suite *OperationsTestSuite) get(key string) (string, bool) {
+	var value string
+	err := suite.db.QueryRow(`SELECT value FROM test WHERE key = ?`, key).Scan(&value)
+	return value, err == nil
+}
+
+func (suite *OperationsTestSuite) TestUpdate() {
+	// Test successful update
+	err := Update(context.Background(), suite.db, func(tx *Tx) error {
+		_, err := tx.Exec(`INSERT INTO test (key, value) VALUES ('key', 'value')`)
+		return err
+	})
+	suite.NoError(err)
+	value, ok := suite.get("key")
+	suite.True(ok)
+	suite.Equal("value", value)
+
+	// Test update with error is rolled back
+	err = Update(context.Background(), suite.db, func(tx *Tx) error {
+		if _, err := tx.Exec(`INSERT INTO test (key, value) VALUES ('key2', 'value2')`); err != nil {
+			return err
+		}
+		return errors.New("test error")
+	})
+	suite.Error(err)
+	suite.Equal("test error", err.Error())
+	_, ok = suite.get("key2")
+	suite.False(ok)
+
+	// Test update with cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Update(ctx, suite.db, func(tx *Tx) error {
+		return nil
+	})
+	suite.Error(err)
+	suite.True(errors.Is(err, context.Canceled))
+
+	// Test update with external transaction
+	tx, err := Begin(context.Background(), suite.db, true)
+	suite.Require().NoError(err)
+	err = Update(NewTxContext(context.Background(), tx), suite.db, func(tx *Tx) error {
+		_, err := tx.Exec(`INSERT INTO test (key, value) VALUES ('key3', 'value3')`)
+		return err
+	})
+	suite.NoError(err)
+	_, ok = suite.get("key3")
+	suite.False(ok, "changes are not visible before the external transaction commits")
+	suite.Require().NoError(tx.Commit())
+	_, ok = suite.get("key3")
+	suite.True(ok)
+
+	// Test update with read-only external transaction
+	txReadOnly, err := Begin(context.Background(), suite.db, false)
+	suite.Require().NoError(err)
+	defer txReadOnly.Rollback() //nolint:errcheck
+
+	err = Update(NewTxContext(context.Background(), txReadOnly), suite.db, func(tx *Tx) error {
+		return nil
+	})
+	suite.Error(err)
+	suite.Contains(err.Error(), "readonly transaction provided in context for update operation")
+}
+
+func (suite *OperationsTestSuite) TestView() {
+	suite.Require().NoError(Update(context.Background(), suite.db, func(tx *Tx) error {
+		_, err := tx.Exec(`INSERT INTO test (key, value) VALUES ('key', 'value')`)
+		return err
+	}))
+
+	// Test successful view
+	err := View(context.Background(), suite.db, func(tx *Tx) error {
+		exists, err := Exists(context.Background(), tx, `SELECT 1 FROM test WHERE key = ?`, "key")
+		suite.NoError(err)
+		suite.True(exists)
+
+		exists, err = Exists(context.Background(), tx, `SELECT 1 FROM test WHERE key = ?`, "missing")
+		suite.NoError(err)
+		suite.False(exists)
+		return nil
+	})
+	suite.NoError(err)
+
+	// Test view with error
+	err = View(context.Background(), suite.db, func(tx *Tx) error {
+		return errors.New("test error")
+	})
+	suite.Error(err)
+	suite.Equal("test error", err.Error())
+
+	// Test view with cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = View(ctx, suite.db, func(tx *Tx) error {
+		return nil
+	})
+	suite.Error(err)
+	suite.True(errors.Is(err, context.Canceled))
+}
+
+func (suite *OperationsTestSuite) TestOnCommit() {
+	committed := false
+	err := Update(context.Background(), suite.db, func(tx *Tx) error {
+		tx.OnCommit(func() { committed = true })
+		return nil
+	})
+	suite.NoError(err)
+	suite.True(committed)
+
+	committed = false
+	err = Update(context.Background(), suite.db, func(tx *Tx) error {
+		tx.OnCommit(func() { committed = true })
+		return errors.New("test error")
+	})
+	suite.Error(err)
+	suite.False(committed)
+}
+
+func TestOperationsTestSuite(t *testing.T) {
+	suite.Run(t, new(OperationsTestSuite))
+}
@@ -0,0 +1,502 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/benbjohnson/clock"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/sqlitedblib"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+)
+
+type subscriber struct {
+	notify chan struct{}
+	done   chan struct{}
+}
+
+// EventStore implements the EventStore interface using SQLite as the underlying storage.
+// It provides efficient storage and retrieval of events with support for caching,
+// checkpointing, and garbage collection.
+//
+// Events are stored with their operation and object type in dedicated columns,
+// so that filtering happens in the database rather than after deserialization.
+type EventStore struct {
+	db             *sqlitedblib.DB
+	options        *eventStoreOptions
+	cache          *lru.Cache[uint64, watcher.Event]
+	latestEventNum atomic.Uint64
+	clock          clock.Clock
+
+	subscribers sync.Map // map[*subscriber]struct{}
+	stopGC      chan struct{}
+}
+
+func (s *EventStore) createSubscriber() *subscriber {
+	sub := &subscriber{
+		notify: make(chan struct{}, 1), // Buffer of 1 is enough since we just need a signal
+		done:   make(chan struct{}),
+	}
+	s.subscribers.Store(sub, struct{}{})
+	return sub
+}
+
+func (s *EventStore) removeSubscriber(sub *subscriber) {
+	if sub == nil {
+		return
+	}
+	s.subscribers.Delete(sub)
+	close(sub.done) // Signal any pending operations to stop
+}
+
+func (s *EventStore) notifySubscribers() {
+	s.subscribers.Range(func(key, _ interface{}) bool {
+		sub := key.(*subscriber)
+		select {
+		case sub.notify <- struct{}{}:
+		case <-sub.done:
+			// Subscriber is being removed, skip it
+		default:
+			// Channel full (signal already pending), skip it
+		}
+		return true
+	})
+}
+
+// NewEventStore creates a new EventStore with the given options.
+//
+// It creates the events and checkpoints tables if they don't exist, sets up caching,
+// and starts the garbage collection process.
+//
+// Example usage:
+//
+//	db, err := sqlitedblib.Open("events.db")
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer db.Close()
+//
+//	store, err := NewEventStore(
+//	    db,
+//	    WithEventsTable("my_events"),
+//	    WithCheckpointTable("my_checkpoints"),
+//	    WithEventSerializer(NewJSONSerializer()),
+//	)
+//	if err != nil {
+//	    // handle error
+//	}
+func NewEventStore(db *sqlitedblib.DB, opts ...EventStoreOption) (*EventStore, error) {
+	options := defaultEventStoreOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	err := errors.Join(
+		validate.NotNil(db, "sqlite database cannot be nil"),
+		options.validate(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := lru.New[uint64, watcher.Event](max(options.cacheSize, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	store := &EventStore{
+		db:      db,
+		options: options,
+		cache:   cache,
+		clock:   options.clock,
+		stopGC:  make(chan struct{}),
+	}
+
+	ctx := context.Background()
+	err = sqlitedblib.Update(ctx, db, func(tx *sqlitedblib.Tx) error {
+		statements := []string{
+			fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+				seq_num     INTEGER PRIMARY KEY AUTOINCREMENT,
+				timestamp   INTEGER NOT NULL,
+				operation   TEXT NOT NULL,
+				object_type TEXT NOT NULL,
+				data        BLOB NOT NULL
+			)`, options.eventsTable),
+			fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%[1]s_timestamp ON %[1]s (timestamp)`, options.eventsTable),
+			fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+				watcher_id TEXT PRIMARY KEY,
+				seq_num    INTEGER NOT NULL
+			)`, options.checkpointTable),
+		}
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+		latest, err := store.lastSequence(ctx, tx)
+		if err != nil {
+			return err
+		}
+		store.latestEventNum.Store(latest)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	// Start the garbage collection process
+	go store.runGCLoop()
+	return store, nil
+}
+
+// lastSequence returns the last sequence number assigned to an event, including
+// events that have since been garbage collected, so that numbers are never reused.
+func (s *EventStore) lastSequence(ctx context.Context, tx *sqlitedblib.Tx) (uint64, error) {
+	var seqNum uint64
+	err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE((SELECT seq FROM sqlite_sequence WHERE name = ?), 0)`, s.options.eventsTable).
+		Scan(&seqNum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last event sequence: %w", err)
+	}
+	return seqNum, nil
+}
+
+// StoreEvent stores a new event in the EventStore.
+// It wraps the storage operation in a SQLite transaction.
+func (s *EventStore) StoreEvent(ctx context.Context, request watcher.StoreEventRequest) error {
+	return sqlitedblib.Update(ctx, s.db, func(tx *sqlitedblib.Tx) error {
+		return s.StoreEventTx(ctx, tx, request)
+	})
+}
+
+// StoreEventTx stores a new event within an existing SQLite transaction.
+// It serializes the event, stores it in the database, and once the transaction
+// is committed, adds it to the cache and notifies watchers of the new event.
+func (s *EventStore) StoreEventTx(ctx context.Context, tx *sqlitedblib.Tx, request watcher.StoreEventRequest) error {
+	// writable transactions are serialized, so the next sequence number can't be taken concurrently
+	last, err := s.lastSequence(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return s.insertEventTx(ctx, tx, watcher.Event{
+		SeqNum:     last + 1,
+		Operation:  request.Operation,
+		ObjectType: request.ObjectType,
+		Object:     request.Object,
+		Timestamp:  s.clock.Now(),
+	})
+}
+
+// ImportEventTx stores an event with its existing sequence number and timestamp
+// within an existing SQLite transaction. It is used to copy events from another
+// event store, and fails if an event with the same sequence number already exists.
+func (s *EventStore) ImportEventTx(ctx context.Context, tx *sqlitedblib.Tx, event watcher.Event) error {
+	return s.insertEventTx(ctx, tx, event)
+}
+
+func (s *EventStore) insertEventTx(ctx context.Context, tx *sqlitedblib.Tx, event watcher.Event) error {
+	eventBytes, err := s.options.serializer.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (seq_num, timestamp, operation, object_type, data) VALUES (?, ?, ?, ?, ?)`,
+			s.options.eventsTable),
+		event.SeqNum, event.Timestamp.UnixNano(), string(event.Operation), event.ObjectType, eventBytes)
+	if err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	tx.OnCommit(func() {
+		// Add to cache
+		s.cache.Add(event.SeqNum, event)
+
+		// Update latest event number - but only if it's higher than current
+		for {
+			current := s.latestEventNum.Load()
+			if event.SeqNum <= current {
+				break // Don't update if our ID is lower/equal
+			}
+			if s.latestEventNum.CompareAndSwap(current, event.SeqNum) {
+				break // Successfully updated
+			}
+			// If CAS failed, loop and try again
+		}
+
+		s.notifySubscribers()
+	})
+
+	return nil
+}
+
+// GetEvents retrieves events from the store based on the provided query parameters.
+// It supports long-polling: if no events are immediately available, it waits for new events
+// or until the long-polling timeout is reached.
+func (s *EventStore) GetEvents(ctx context.Context, request watcher.GetEventsRequest) (*watcher.GetEventsResponse, error) {
+	// Create a new ephemeral subscriber
+	sub := s.createSubscriber()
+	defer s.removeSubscriber(sub)
+
+	log.Trace().Str("watcher_id", request.WatcherID).Msgf("GetEvents request: %+v", request)
+
+	var result *watcher.GetEventsResponse
+	for {
+		err := sqlitedblib.View(ctx, s.db, func(tx *sqlitedblib.Tx) error {
+			var err error
+			result, err = s.getEventsTx(ctx, tx, request)
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		// If we have events or the iterator changed, return immediately
+		if len(result.Events) > 0 || result.NextEventIterator != request.EventIterator {
+			log.Trace().Str("watcher_id", request.WatcherID).Msgf("GetEvents response: %+v", result)
+			return result, nil
+		}
+
+		// No events available, wait for notifications or timeout
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-sub.done:
+			return result, nil
+		case <-sub.notify:
+			continue // Try fetching again
+		case <-s.clock.After(s.options.longPollingTimeout):
+			// Return empty result after timeout
+			return result, nil
+		}
+	}
+}
+
+// getEventsTx retrieves events within a SQLite transaction based on the provided query parameters.
+// It uses the cache when possible to avoid deserializing events.
+func (s *EventStore) getEventsTx(
+	ctx context.Context, tx *sqlitedblib.Tx, request watcher.GetEventsRequest) (*watcher.GetEventsResponse, error) {
+	iterator, err := s.resolveIteratorType(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// events at the start sequence number are only included when starting at it
+	query := fmt.Sprintf(`SELECT seq_num, data FROM %s WHERE seq_num > ?`, s.options.eventsTable)
+	start := iterator.SequenceNumber
+	if iterator.Type == watcher.EventIteratorAtSequenceNumber && start > 0 {
+		start--
+	}
+	args := []any{start}
+	if len(request.Filter.ObjectTypes) > 0 {
+		query += fmt.Sprintf(" AND object_type IN (%s)", placeholders(len(request.Filter.ObjectTypes)))
+		for _, objectType := range request.Filter.ObjectTypes {
+			args = append(args, objectType)
+		}
+	}
+	if len(request.Filter.Operations) > 0 {
+		query += fmt.Sprintf(" AND operation IN (%s)", placeholders(len(request.Filter.Operations)))
+		for _, operation := range request.Filter.Operations {
+			args = append(args, string(operation))
+		}
+	}
+	query += " ORDER BY seq_num"
+	if request.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, request.Limit)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	// results to return
+	events := make([]watcher.Event, 0)
+	nextIterator := iterator
+	for rows.Next() {
+		var seqNum uint64
+		var data []byte
+		if err = rows.Scan(&seqNum, &data); err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		event, found := s.cache.Get(seqNum)
+		if !found {
+			if err = s.options.serializer.Unmarshal(data, &event); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+			}
+			event.SeqNum = seqNum
+		}
+		events = append(events, event)
+		nextIterator = watcher.AfterSequenceNumberIterator(seqNum)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+
+	// Unless the limit was reached, all the events up to the latest one were scanned,
+	// including the ones filtered out, and the next iterator moves after them
+	if request.Limit == 0 || len(events) < request.Limit {
+		var latest sql.NullInt64
+		err = tx.QueryRowContext(ctx,
+			fmt.Sprintf(`SELECT MAX(seq_num) FROM %s WHERE seq_num > ?`, s.options.eventsTable), start).Scan(&latest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query events: %w", err)
+		}
+		if latest.Valid {
+			nextIterator = watcher.AfterSequenceNumberIterator(uint64(latest.Int64))
+		}
+	}
+
+	return &watcher.GetEventsResponse{
+		Events:            events,
+		NextEventIterator: nextIterator,
+	}, nil
+}
+
+// resolveIteratorType resolves the event iterator type based on the provided query parameters.
+// It returns a new event iterator if the type is set to EventIteratorTrimHorizon or EventIteratorLatest.
+func (s *EventStore) resolveIteratorType(ctx context.Context, request watcher.GetEventsRequest) (watcher.EventIterator, error) {
+	switch request.EventIterator.Type {
+	case watcher.EventIteratorTrimHorizon:
+		return watcher.AfterSequenceNumberIterator(0), nil
+	case watcher.EventIteratorLatest:
+		seqNum, err := s.GetLatestEventNum(ctx)
+		if err != nil {
+			return watcher.EventIterator{}, fmt.Errorf("failed to get latest event num: %w", err)
+		}
+		return watcher.AfterSequenceNumberIterator(seqNum), nil
+	default:
+		return request.EventIterator, nil
+	}
+}
+
+// GetLatestEventNum retrieves the sequence number of the latest event in the store.
+func (s *EventStore) GetLatestEventNum(ctx context.Context) (uint64, error) {
+	return s.latestEventNum.Load(), nil
+}
+
+// StoreCheckpoint stores a checkpoint for a specific watcher.
+// Checkpoints are used to track which events have been processed by each watcher.
+func (s *EventStore) StoreCheckpoint(ctx context.Context, watcherID string, eventSeqNum uint64) error {
+	return sqlitedblib.Update(ctx, s.db, func(tx *sqlitedblib.Tx) error {
+		return s.StoreCheckpointTx(ctx, tx, watcherID, eventSeqNum)
+	})
+}
+
+// StoreCheckpointTx stores a checkpoint within an existing SQLite transaction.
+func (s *EventStore) StoreCheckpointTx(ctx context.Context, tx *sqlitedblib.Tx, watcherID string, eventSeqNum uint64) error {
+	_, err := tx.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (watcher_id, seq_num) VALUES (?, ?)
+			ON CONFLICT (watcher_id) DO UPDATE SET seq_num = excluded.seq_num`, s.options.checkpointTable),
+		watcherID, eventSeqNum)
+	return err
+}
+
+// GetCheckpoint retrieves the checkpoint for a specific watcher.
+// If the checkpoint is not found, it returns a CheckpointError.
+func (s *EventStore) GetCheckpoint(ctx context.Context, watcherID string) (uint64, error) {
+	var checkpoint uint64
+	err := sqlitedblib.View(ctx, s.db, func(tx *sqlitedblib.Tx) error {
+		return tx.QueryRowContext(ctx,
+			fmt.Sprintf(`SELECT seq_num FROM %s WHERE watcher_id = ?`, s.options.checkpointTable), watcherID).
+			Scan(&checkpoint)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, watcher.NewCheckpointError(watcherID, watcher.ErrCheckpointNotFound)
+	}
+	if err != nil {
+		return 0, watcher.NewCheckpointError(watcherID, err)
+	}
+	return checkpoint, nil
+}
+
+// runGCLoop runs the garbage collection process at regular intervals.
+func (s *EventStore) runGCLoop() {
+	if s.options.gcAgeThreshold == 0 {
+		return // GC is disabled if threshold is not set
+	}
+
+	ticker := s.clock.Ticker(s.options.gcCadence)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.runGC(); err != nil {
+				log.Error().Err(err).Msg("Error during garbage collection. Continuing...")
+			}
+		case <-s.stopGC:
+			return
+		}
+	}
+}
+
+// runGC performs garbage collection by pruning old events that are beyond all watchers' checkpoints
+// and older than the configured event age threshold.
+func (s *EventStore) runGC() error {
+	if s.options.gcAgeThreshold == 0 {
+		return nil // GC is disabled if threshold is not set
+	}
+
+	ctx := context.Background()
+	return sqlitedblib.Update(ctx, s.db, func(tx *sqlitedblib.Tx) error {
+		// Events are only pruned once processed by all watchers
+		var minCheckpoint sql.NullInt64
+		err := tx.QueryRowContext(ctx,
+			fmt.Sprintf(`SELECT MIN(seq_num) FROM %s`, s.options.checkpointTable)).Scan(&minCheckpoint)
+		if err != nil {
+			return fmt.Errorf("failed to find minimum checkpoint: %w", err)
+		}
+		if !minCheckpoint.Valid {
+			return nil
+		}
+
+		threshold := s.clock.Now().Add(-s.options.gcAgeThreshold).UnixNano()
+		query := fmt.Sprintf(`DELETE FROM %[1]s WHERE seq_num IN (
+			SELECT seq_num FROM %[1]s WHERE seq_num <= ? AND timestamp < ? ORDER BY seq_num LIMIT ?)`,
+			s.options.eventsTable)
+		limit := s.options.gcMaxRecordsPerRun
+		if limit == 0 {
+			limit = -1 // no limit
+		}
+		result, err := tx.ExecContext(ctx, query, minCheckpoint.Int64, threshold, limit)
+		if err != nil {
+			return fmt.Errorf("failed to delete old events: %w", err)
+		}
+		deleted, _ := result.RowsAffected()
+		log.Debug().Msgf("GC deleted %d events upto %d", deleted, minCheckpoint.Int64)
+		return nil
+	})
+}
+
+// Close stops the garbage collection process and purges the cache.
+func (s *EventStore) Close(ctx context.Context) error {
+	close(s.stopGC)
+	s.subscribers.Range(func(key, _ interface{}) bool {
+		s.removeSubscriber(key.(*subscriber))
+		return true
+	})
+	s.cache.Purge()
+	return nil
+}
+
+// placeholders returns a comma separated list of n bound parameter placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// compile-time check for interface conformance
+var _ watcher.EventStore = &EventStore{}
@@ -0,0 +1,381 @@
+//go:build unit || !integration
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/sqlitedblib"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	watchertest "github.com/bacalhau-project/bacalhau/pkg/lib/watcher/test"
+)
+
+type SQLiteEventStoreTestSuite struct {
+	suite.Suite
+	db         *sqlitedblib.DB
+	store      *EventStore
+	serializer *watcher.JSONSerializer
+	clock      *clock.Mock
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func TestSQLiteEventStore(t *testing.T) {
+	suite.Run(t, new(SQLiteEventStoreTestSuite))
+}
+
+func (s *SQLiteEventStoreTestSuite) SetupTest() {
+	var err error
+	s.db, err = sqlitedblib.Open(filepath.Join(s.T().TempDir(), "events.db"))
+	s.Require().NoError(err)
+	s.clock = clock.NewMock()
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	s.serializer = watchertest.CreateSerializer(s.T())
+	s.store = s.newStore()
+}
+
+func (s *SQLiteEventStoreTestSuite) TearDownTest() {
+	s.cancel()
+	s.Require().NoError(s.store.Close(s.ctx))
+	s.Require().NoError(s.db.Close())
+}
+
+func (s *SQLiteEventStoreTestSuite) newStore() *EventStore {
+	store, err := NewEventStore(s.db,
+		WithLongPollingTimeout(100*time.Millisecond),
+		WithGCAgeThreshold(2*time.Hour),
+		WithGCCadence(3*time.Hour),
+		WithClock(s.clock),
+		WithEventSerializer(s.serializer),
+		WithCacheSize(2), // smaller cache size to trigger eviction and unmarshalling of stored events
+	)
+	s.Require().NoError(err)
+	return store
+}
+
+func (s *SQLiteEventStoreTestSuite) TestStoreAndRetrieveEvents() {
+	s.storeEvents(5)
+
+	resp := s.getEvents(watcher.TrimHorizonIterator(), 10, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 5, watcher.AfterSequenceNumberIterator(5))
+	for i, event := range resp.Events {
+		s.assertEventEquals(event, uint64(i+1), watcher.OperationCreate, &watchertest.TestObject{Value: i + 1})
+	}
+
+	resp = s.getEvents(watcher.TrimHorizonIterator(), 2, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 2, watcher.AfterSequenceNumberIterator(2))
+}
+
+func (s *SQLiteEventStoreTestSuite) TestFilterEvents() {
+	s.Require().NoError(s.serializer.RegisterType("TypeA", reflect.TypeOf("")))
+	s.Require().NoError(s.serializer.RegisterType("TypeB", reflect.TypeOf("")))
+
+	s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{Operation: watcher.OperationCreate, ObjectType: "TypeA", Object: "event1"}))
+	s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{Operation: watcher.OperationUpdate, ObjectType: "TypeB", Object: "event2"}))
+	s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{Operation: watcher.OperationDelete, ObjectType: "TypeA", Object: "event3"}))
+
+	testCases := []struct {
+		name           string
+		filter         watcher.EventFilter
+		limit          int
+		expectedSeqNum []uint64
+		expectedNext   uint64
+	}{
+		{
+			name:           "Filter by object type",
+			filter:         watcher.EventFilter{ObjectTypes: []string{"TypeA"}},
+			limit:          10,
+			expectedSeqNum: []uint64{1, 3},
+			expectedNext:   3,
+		},
+		{
+			name:           "Filter by operation",
+			filter:         watcher.EventFilter{Operations: []watcher.Operation{watcher.OperationCreate, watcher.OperationUpdate}},
+			limit:          10,
+			expectedSeqNum: []uint64{1, 2},
+			expectedNext:   3,
+		},
+		{
+			name:           "Filter by both object type and operation",
+			filter:         watcher.EventFilter{ObjectTypes: []string{"TypeA"}, Operations: []watcher.Operation{watcher.OperationDelete}},
+			limit:          10,
+			expectedSeqNum: []uint64{3},
+			expectedNext:   3,
+		},
+		{
+			name:           "Limit reached",
+			filter:         watcher.EventFilter{ObjectTypes: []string{"TypeA"}},
+			limit:          1,
+			expectedSeqNum: []uint64{1},
+			expectedNext:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp := s.getEvents(watcher.TrimHorizonIterator(), tc.limit, tc.filter)
+			s.assertEventsResponse(resp, len(tc.expectedSeqNum), watcher.AfterSequenceNumberIterator(tc.expectedNext))
+			for i, seqNum := range tc.expectedSeqNum {
+				s.Equal(seqNum, resp.Events[i].SeqNum)
+			}
+		})
+	}
+}
+
+func (s *SQLiteEventStoreTestSuite) TestIteratorBehaviors() {
+	s.storeEvents(5)
+	s.store.options.longPollingTimeout = 0
+
+	testCases := []struct {
+		name           string
+		iterator       watcher.EventIterator
+		expectedEvents int
+		expectedFirst  uint64
+		expectedNext   watcher.EventIterator
+	}{
+		{"TrimHorizon", watcher.TrimHorizonIterator(), 5, 1, watcher.AfterSequenceNumberIterator(5)},
+		{"Latest", watcher.LatestIterator(), 0, 0, watcher.AfterSequenceNumberIterator(5)},
+		{"AtSequenceNumber(0)", watcher.AtSequenceNumberIterator(0), 5, 1, watcher.AfterSequenceNumberIterator(5)},
+		{"AtSequenceNumber(3)", watcher.AtSequenceNumberIterator(3), 3, 3, watcher.AfterSequenceNumberIterator(5)},
+		{"AtSequenceNumber(100)", watcher.AtSequenceNumberIterator(100), 0, 0, watcher.AtSequenceNumberIterator(100)},
+		{"AfterSequenceNumber(3)", watcher.AfterSequenceNumberIterator(3), 2, 4, watcher.AfterSequenceNumberIterator(5)},
+		{"AfterSequenceNumber(5)", watcher.AfterSequenceNumberIterator(5), 0, 0, watcher.AfterSequenceNumberIterator(5)},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp := s.getEvents(tc.iterator, 10, watcher.EventFilter{})
+			s.assertEventsResponse(resp, tc.expectedEvents, tc.expectedNext)
+			if tc.expectedEvents > 0 {
+				s.Equal(tc.expectedFirst, resp.Events[0].SeqNum)
+			}
+		})
+	}
+}
+
+func (s *SQLiteEventStoreTestSuite) TestNotifyOnStore() {
+	respCh, errCh := s.getEventsAsync(watcher.AfterSequenceNumberIterator(0))
+	s.assertChannelsEmpty(respCh, errCh)
+
+	s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationCreate,
+		ObjectType: "TestObject",
+		Object:     watchertest.TestObject{Name: "new event"},
+	}))
+
+	resp := s.assertResponseReceived(respCh, errCh)
+	s.assertEventsResponse(resp, 1, watcher.AfterSequenceNumberIterator(1))
+	s.assertEventEquals(resp.Events[0], 1, watcher.OperationCreate, &watchertest.TestObject{Name: "new event"})
+}
+
+func (s *SQLiteEventStoreTestSuite) TestStoreEventTxRollback() {
+	tx, err := sqlitedblib.Begin(s.ctx, s.db, true)
+	s.Require().NoError(err)
+	s.Require().NoError(s.store.StoreEventTx(s.ctx, tx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationCreate,
+		ObjectType: "TestObject",
+		Object:     watchertest.TestObject{Value: 1},
+	}))
+	s.Require().NoError(tx.Rollback())
+
+	latest, err := s.store.GetLatestEventNum(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(latest)
+
+	// the sequence number is not consumed by the rolled back event
+	s.storeEvents(1)
+	resp := s.getEvents(watcher.TrimHorizonIterator(), 10, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 1, watcher.AfterSequenceNumberIterator(1))
+}
+
+func (s *SQLiteEventStoreTestSuite) TestCheckpoints() {
+	s.Require().NoError(s.store.StoreCheckpoint(s.ctx, "watcher1", 5))
+	s.Require().NoError(s.store.StoreCheckpoint(s.ctx, "watcher2", 10))
+	s.Require().NoError(s.store.StoreCheckpoint(s.ctx, "watcher2", 12))
+
+	checkpoint, err := s.store.GetCheckpoint(s.ctx, "watcher1")
+	s.Require().NoError(err)
+	s.Equal(uint64(5), checkpoint)
+
+	checkpoint, err = s.store.GetCheckpoint(s.ctx, "watcher2")
+	s.Require().NoError(err)
+	s.Equal(uint64(12), checkpoint)
+
+	_, err = s.store.GetCheckpoint(s.ctx, "nonexistent")
+	s.Require().Error(err)
+	s.ErrorIs(err, watcher.ErrCheckpointNotFound)
+}
+
+func (s *SQLiteEventStoreTestSuite) TestGarbageCollection() {
+	s.storeEvents(5)
+
+	s.clock.Add(2 * time.Hour)
+	s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{
+		Operation:  watcher.OperationCreate,
+		ObjectType: "TestObject",
+		Object:     watchertest.TestObject{Name: "recent"},
+	}))
+
+	s.Require().NoError(s.store.StoreCheckpoint(s.ctx, "watcher1", 3))
+	s.Require().NoError(s.store.StoreCheckpoint(s.ctx, "watcher2", 6))
+
+	s.clock.Add(1*time.Hour + 1*time.Millisecond)
+	s.Require().NoError(s.store.runGC())
+
+	resp := s.getEvents(watcher.TrimHorizonIterator(), 10, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 3, watcher.AfterSequenceNumberIterator(6))
+	s.assertEventEquals(resp.Events[0], 4, watcher.OperationCreate, &watchertest.TestObject{Value: 4})
+	s.assertEventEquals(resp.Events[2], 6, watcher.OperationCreate, &watchertest.TestObject{Name: "recent"})
+}
+
+func (s *SQLiteEventStoreTestSuite) TestSequenceSurvivesRestart() {
+	s.storeEvents(3)
+	s.Require().NoError(s.store.Close(s.ctx))
+
+	s.store = s.newStore()
+	latest, err := s.store.GetLatestEventNum(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(uint64(3), latest)
+
+	s.storeEvents(1)
+	resp := s.getEvents(watcher.AfterSequenceNumberIterator(3), 10, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 1, watcher.AfterSequenceNumberIterator(4))
+}
+
+func (s *SQLiteEventStoreTestSuite) TestImportEvent() {
+	timestamp := time.Unix(100, 0)
+	tx, err := sqlitedblib.Begin(s.ctx, s.db, true)
+	s.Require().NoError(err)
+	s.Require().NoError(s.store.ImportEventTx(s.ctx, tx, watcher.Event{
+		SeqNum:     42,
+		Operation:  watcher.OperationUpdate,
+		ObjectType: "TestObject",
+		Object:     watchertest.TestObject{Value: 42},
+		Timestamp:  timestamp,
+	}))
+	s.Require().NoError(tx.Commit())
+
+	latest, err := s.store.GetLatestEventNum(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(uint64(42), latest)
+
+	// new events continue after the imported ones
+	s.storeEvents(1)
+	resp := s.getEvents(watcher.TrimHorizonIterator(), 10, watcher.EventFilter{})
+	s.assertEventsResponse(resp, 2, watcher.AfterSequenceNumberIterator(43))
+	s.assertEventEquals(resp.Events[0], 42, watcher.OperationUpdate, &watchertest.TestObject{Value: 42})
+	s.Equal(timestamp.UnixNano(), resp.Events[0].Timestamp.UnixNano())
+}
+
+func (s *SQLiteEventStoreTestSuite) TestConcurrentOperations() {
+	var wg sync.WaitGroup
+	eventCount := 50
+
+	for i := 0; i < eventCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{
+				Operation:  watcher.OperationCreate,
+				ObjectType: "TestObject",
+				Object:     watchertest.TestObject{Value: i + 1},
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	resp := s.getEvents(watcher.TrimHorizonIterator(), eventCount*2, watcher.EventFilter{})
+	s.assertEventsResponse(resp, eventCount, watcher.AfterSequenceNumberIterator(uint64(eventCount)))
+	for i, event := range resp.Events {
+		s.assertEventEquals(event, uint64(i+1), watcher.OperationCreate, nil)
+	}
+}
+
+// Helper methods
+
+func (s *SQLiteEventStoreTestSuite) storeEvents(count int) {
+	for i := 1; i <= count; i++ {
+		s.Require().NoError(s.store.StoreEvent(s.ctx, watcher.StoreEventRequest{
+			Operation:  watcher.OperationCreate,
+			ObjectType: "TestObject",
+			Object:     watchertest.TestObject{Value: i},
+		}))
+	}
+}
+
+func (s *SQLiteEventStoreTestSuite) getEvents(
+	iterator watcher.EventIterator, limit int, filter watcher.EventFilter) *watcher.GetEventsResponse {
+	resp, err := s.store.GetEvents(s.ctx, watcher.GetEventsRequest{
+		EventIterator: iterator,
+		Limit:         limit,
+		Filter:        filter,
+	})
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *SQLiteEventStoreTestSuite) getEventsAsync(
+	iterator watcher.EventIterator) (<-chan *watcher.GetEventsResponse, <-chan error) {
+	respCh := make(chan *watcher.GetEventsResponse, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		resp, err := s.store.GetEvents(s.ctx, watcher.GetEventsRequest{EventIterator: iterator, Limit: 10})
+		if err != nil {
+			errCh <- err
+		} else {
+			respCh <- resp
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	return respCh, errCh
+}
+
+func (s *SQLiteEventStoreTestSuite) assertEventsResponse(
+	resp *watcher.GetEventsResponse, expectedCount int, expectedNext watcher.EventIterator) {
+	s.Require().NotNil(resp)
+	s.Require().Len(resp.Events, expectedCount)
+	s.Equal(expectedNext, resp.NextEventIterator)
+}
+
+func (s *SQLiteEventStoreTestSuite) assertEventEquals(
+	event watcher.Event, expectedSeqNum uint64, expectedOperation watcher.Operation, expectedObject *watchertest.TestObject) {
+	s.Equal(expectedSeqNum, event.SeqNum, "Unexpected sequence number")
+	s.Equal(expectedOperation, event.Operation, "Unexpected operation")
+	s.Equal("TestObject", event.ObjectType, "Unexpected object type")
+	if expectedObject != nil {
+		s.Equal(*expectedObject, event.Object, "Unexpected object")
+	}
+}
+
+func (s *SQLiteEventStoreTestSuite) assertChannelsEmpty(respCh <-chan *watcher.GetEventsResponse, errCh <-chan error) {
+	select {
+	case <-respCh:
+		s.Fail("Received unexpected response")
+	case <-errCh:
+		s.Fail("Received unexpected error")
+	default:
+		// This is the expected case
+	}
+}
+
+func (s *SQLiteEventStoreTestSuite) assertResponseReceived(
+	respCh <-chan *watcher.GetEventsResponse, errCh <-chan error) *watcher.GetEventsResponse {
+	select {
+	case resp := <-respCh:
+		return resp
+	case err := <-errCh:
+		s.Fail("Received unexpected error", err)
+		return nil
+	case <-s.ctx.Done():
+		s.Fail("Context timeout while waiting for response")
+		return nil
+	}
+}
@@ -103,4 +103,11 @@ const (
 	MetaServerInstanceID     = "bacalhau.org/server.instance.id"
 	MetaClientInstallationID = "bacalhau.org/client.installation.id"
 	MetaClientInstanceID     = "bacalhau.org/client.instance.id"
+
+	// MetaJobTemplateName and MetaJobTemplateVersion identify the template a job was created from
+	MetaJobTemplateName    = "bacalhau.org/template.name"
+	MetaJobTemplateVersion = "bacalhau.org/template.version"
+
+	// MetaRerunOf identifies the job that a job reruns
+	MetaRerunOf = "bacalhau.org/rerun.of"
 )
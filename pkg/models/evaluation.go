@@ -26,6 +26,8 @@ const (
 	EvalTriggerExecUpdate     = "exec-update"
 	EvalTriggerExecTimeout    = "exec-timeout"
 	EvalTriggerExecutionLimit = "exec-limit"
+	EvalTriggerExecNodeLost   = "exec-node-lost"
+	EvalTriggerBidTimeout     = "bid-timeout"
 )
 
 // Evaluation is just to ask the scheduler to reassess if additional job instances must be
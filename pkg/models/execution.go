@@ -165,6 +165,13 @@ func (e *Execution) IsExpired(expirationTime time.Time) bool {
 	return e.ComputeState.StateType.IsExecuting() && e.GetModifyTime().Before(expirationTime)
 }
 
+// IsAwaitingBid returns true if the node was asked to bid on the execution,
+// and the orchestrator is still waiting for its response
+func (e *Execution) IsAwaitingBid() bool {
+	return e.DesiredState.StateType == ExecutionDesiredStatePending &&
+		(e.ComputeState.StateType == ExecutionStateNew || e.ComputeState.StateType == ExecutionStateAskForBid)
+}
+
 // Normalize Allocation to ensure fields are initialized to the expectations
 // of this version of Bacalhau. Should be called when restoring persisted
 // Executions or receiving Executions from Bacalhau clients potentially on an
@@ -303,6 +310,9 @@ type RunCommandResult struct {
 
 	// Runner error
 	ErrorMsg string `json:"ErrorMsg"`
+
+	// Usage is the resources actually used by the run, if measured by the executor.
+	Usage *ResourceUsage `json:"Usage,omitempty"`
 }
 
 func NewRunCommandResult() *RunCommandResult {
@@ -322,8 +332,6 @@ func (r *RunCommandResult) Copy() *RunCommandResult {
 
 	newRCR := new(RunCommandResult)
 	*newRCR = *r
-
-	// Since all fields are simple types (string, bool, int),
-	// a shallow copy is sufficient.
+	newRCR.Usage = r.Usage.Copy()
 	return newRCR
 }
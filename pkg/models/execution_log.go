@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type ExecutionLogType int
 
 const (
@@ -12,3 +14,19 @@ type ExecutionLog struct {
 	Type ExecutionLogType
 	Line string
 }
+
+// ExecutionLogLine is a line of output of an execution with the time it was
+// read from the executor's log stream.
+type ExecutionLogLine struct {
+	Type      ExecutionLogType `json:"Type"`
+	Line      string           `json:"Line"`
+	Timestamp time.Time        `json:"Timestamp"`
+}
+
+// ExecutionLogBatch is a batch of output lines of an execution forwarded by
+// compute nodes to the orchestrator.
+type ExecutionLogBatch struct {
+	JobID       string             `json:"JobID"`
+	ExecutionID string             `json:"ExecutionID"`
+	Lines       []ExecutionLogLine `json:"Lines"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// extendedResourceName matches the names of extended resources, such as fpga
+// or example.com/dongle
+var extendedResourceName = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+
+// ValidateExtendedResourceName returns an error if the name can't be used for an extended resource.
+func ValidateExtendedResourceName(name string) error {
+	if !extendedResourceName.MatchString(name) {
+		return fmt.Errorf("invalid extended resource name %q: must consist of alphanumeric characters, "+
+			"'-', '_', '.' or '/', and start and end with an alphanumeric character", name)
+	}
+	return nil
+}
+
+// ExtendedResources are named resources other than CPU, memory, disk and GPUs,
+// such as FPGA cards, software licenses or hardware dongles, counted in units.
+type ExtendedResources map[string]uint64
+
+// Copy returns a copy of the extended resources, or nil if there are none.
+func (e ExtendedResources) Copy() ExtendedResources {
+	if len(e) == 0 {
+		return nil
+	}
+	return maps.Clone(e)
+}
+
+// Add returns the sum of the extended resources.
+func (e ExtendedResources) Add(other ExtendedResources) ExtendedResources {
+	return e.combine(other, func(a, b uint64) uint64 { return a + b })
+}
+
+// Sub returns the difference of the extended resources, replacing negative values with zeros.
+func (e ExtendedResources) Sub(other ExtendedResources) ExtendedResources {
+	return e.combine(other, func(a, b uint64) uint64 {
+		if b > a {
+			return 0
+		}
+		return a - b
+	})
+}
+
+// Max returns the highest value of each extended resource.
+func (e ExtendedResources) Max(other ExtendedResources) ExtendedResources {
+	return e.combine(other, func(a, b uint64) uint64 { return max(a, b) })
+}
+
+// Multiply returns the extended resources scaled by the factor.
+func (e ExtendedResources) Multiply(factor float64) ExtendedResources {
+	return e.combine(nil, func(a, _ uint64) uint64 { return uint64(float64(a) * factor) })
+}
+
+// LessThanEq returns true if there are at least as many units of each extended resource in other.
+func (e ExtendedResources) LessThanEq(other ExtendedResources) bool {
+	for name, value := range e {
+		if value > other[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// LessThan returns true if there are more units of each extended resource in other.
+func (e ExtendedResources) LessThan(other ExtendedResources) bool {
+	for name, value := range e {
+		if value >= other[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero returns true if there are no units of any extended resource.
+func (e ExtendedResources) IsZero() bool {
+	for _, value := range e {
+		if value > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Missing returns the names of the extended resources that other has fewer units of, sorted by name.
+func (e ExtendedResources) Missing(other ExtendedResources) []string {
+	var missing []string
+	for name, value := range e {
+		if value > other[name] {
+			missing = append(missing, name)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// String returns the extended resources as name=value pairs sorted by name.
+func (e ExtendedResources) String() string {
+	pairs := make([]string, 0, len(e))
+	for _, name := range slices.Sorted(maps.Keys(e)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, e[name]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// combine applies the operation to the units of each extended resource in either,
+// dropping the resources left with zero units.
+func (e ExtendedResources) combine(other ExtendedResources, op func(a, b uint64) uint64) ExtendedResources {
+	var result ExtendedResources
+	for _, resources := range []ExtendedResources{e, other} {
+		for name := range resources {
+			if value := op(e[name], other[name]); value > 0 {
+				if result == nil {
+					result = make(ExtendedResources)
+				}
+				result[name] = value
+			}
+		}
+	}
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -110,9 +111,23 @@ type Job struct {
 	// - Values > 1 are invalid and will cause validation to fail
 	Count int `json:"Count"`
 
+	// Matrix declares a parameter sweep for batch jobs. Each partition of the job
+	// runs with one combination of the matrix parameters injected as environment variables.
+	// If Count is omitted, it defaults to the number of combinations.
+	Matrix *JobMatrix `json:"Matrix,omitempty"`
+
+	// Partitions restricts scheduling of batch jobs to the listed partition indices,
+	// such as when rerunning only the failed partitions of a previous job.
+	// Count still defines the total number of partitions. All partitions run if empty.
+	Partitions []int `json:"Partitions,omitempty"`
+
 	// Constraints is a selector which must be true for the compute node to run this job.
 	Constraints []*LabelSelectorRequirement `json:"Constraints"`
 
+	// Placement declares spread, affinity and anti-affinity rules for the executions of this job,
+	// which take into account the current executions of this job and of other jobs.
+	Placement *JobPlacement `json:"Placement,omitempty"`
+
 	// Meta is used to associate arbitrary metadata with this job.
 	Meta map[string]string `json:"Meta"`
 
@@ -195,6 +210,8 @@ func (j *Job) Normalize() {
 		j.Count = 0 // Always 0 for daemon and ops jobs
 	}
 
+	j.Matrix.Normalize()
+
 	for _, task := range j.Tasks {
 		task.Normalize()
 	}
@@ -219,6 +236,9 @@ func (j *Job) Copy() *Job {
 	}
 
 	nj.Meta = maps.Clone(nj.Meta)
+	nj.Matrix = j.Matrix.Copy()
+	nj.Placement = j.Placement.Copy()
+	nj.Partitions = slices.Clone(j.Partitions)
 	return nj
 }
 
@@ -273,12 +293,21 @@ func (j *Job) ValidateSubmission() error {
 	if len(j.Tasks) == 0 {
 		mErr = errors.Join(mErr, errors.New("missing job tasks"))
 	}
+	if j.Matrix != nil {
+		mErr = errors.Join(mErr, j.validateMatrix())
+	}
+	if len(j.Partitions) > 0 {
+		mErr = errors.Join(mErr, j.validatePartitions())
+	}
 	for idx, constr := range j.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("constraint %d validation failed: %s", idx+1, err)
 			mErr = errors.Join(mErr, outer)
 		}
 	}
+	if !j.Placement.IsEmpty() {
+		mErr = errors.Join(mErr, j.validatePlacement())
+	}
 
 	// Validate the task group
 	for _, task := range j.Tasks {
@@ -291,6 +320,55 @@ func (j *Job) ValidateSubmission() error {
 	return mErr
 }
 
+// validateMatrix checks the job matrix is valid and consistent with the job
+func (j *Job) validateMatrix() error {
+	if j.Type != JobTypeBatch {
+		return fmt.Errorf("job matrix is only supported for %s jobs", JobTypeBatch)
+	}
+	if err := j.Matrix.Validate(); err != nil {
+		return err
+	}
+	var mErr error
+	if size := j.Matrix.Size(); j.Count != size {
+		mErr = errors.Join(mErr, fmt.Errorf("job count %d must match the %d combinations of the job matrix", j.Count, size))
+	}
+	for _, task := range j.Tasks {
+		for _, p := range j.Matrix.Parameters {
+			if _, ok := task.Env[p.Name]; ok {
+				mErr = errors.Join(mErr, fmt.Errorf("matrix parameter %s conflicts with an environment variable of task %s", p.Name, task.Name))
+			}
+		}
+	}
+	return mErr
+}
+
+// validatePlacement checks the placement rules are valid for the job type
+func (j *Job) validatePlacement() error {
+	if j.Type != JobTypeBatch && j.Type != JobTypeService {
+		return fmt.Errorf("job placement rules are only supported for %s and %s jobs", JobTypeBatch, JobTypeService)
+	}
+	return j.Placement.Validate()
+}
+
+// validatePartitions checks the partitions to run are within the job's partitions
+func (j *Job) validatePartitions() error {
+	if j.Type != JobTypeBatch {
+		return fmt.Errorf("job partitions can only be set for %s jobs", JobTypeBatch)
+	}
+	var mErr error
+	seen := make(map[int]struct{}, len(j.Partitions))
+	for _, partition := range j.Partitions {
+		if partition < 0 || partition >= j.Count {
+			mErr = errors.Join(mErr, fmt.Errorf("job partition %d is out of range [0, %d)", partition, j.Count))
+		}
+		if _, ok := seen[partition]; ok {
+			mErr = errors.Join(mErr, fmt.Errorf("duplicate job partition %d", partition))
+		}
+		seen[partition] = struct{}{}
+	}
+	return mErr
+}
+
 // SanitizeSubmission is used to sanitize a job for reasonable configuration when it is submitted.
 func (j *Job) SanitizeSubmission() (warnings []string) {
 	if !j.State.StateType.IsUndefined() {
@@ -333,6 +411,22 @@ func (j *Job) SanitizeSubmission() (warnings []string) {
 	return warnings
 }
 
+// IsPartitionScheduled returns true if the partition with the given index should run
+func (j *Job) IsPartitionScheduled(partition int) bool {
+	if partition < 0 || partition >= j.Count {
+		return false
+	}
+	return len(j.Partitions) == 0 || slices.Contains(j.Partitions, partition)
+}
+
+// ScheduledPartitionCount returns the number of partitions that should run
+func (j *Job) ScheduledPartitionCount() int {
+	if len(j.Partitions) > 0 {
+		return len(j.Partitions)
+	}
+	return j.Count
+}
+
 // IsTerminal returns true if the job is in a terminal state
 func (j *Job) IsTerminal() bool {
 	return j.State.StateType.IsTerminal()
@@ -432,6 +526,10 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 	case JobTypeBatch, JobTypeService:
 		if aux.Count == nil {
 			j.Count = 1 // Default to 1 if not present in JSON
+			if j.Matrix != nil {
+				// Default to one partition per matrix combination
+				j.Count = j.Matrix.Size()
+			}
 		} else {
 			j.Count = *aux.Count // Use explicitly set value
 		}
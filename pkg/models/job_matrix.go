@@ -0,0 +1,185 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MaxMatrixSize is the maximum number of parameter combinations a job matrix can expand to
+const MaxMatrixSize = 10000
+
+// MatrixRange is an inclusive range of integer values with a step
+type MatrixRange struct {
+	Start int64 `json:"Start"`
+	End   int64 `json:"End"`
+	// Step between values. Defaults to 1.
+	Step int64 `json:"Step,omitempty"`
+}
+
+// Len returns the number of values in the range. Ranges with more values
+// than fit in an int are reported as math.MaxInt values.
+func (r *MatrixRange) Len() int {
+	step := r.step()
+	if step <= 0 || r.End < r.Start {
+		return 0
+	}
+	// the distance between the bounds can exceed int64, but always fits in uint64
+	span := uint64(r.End) - uint64(r.Start) //nolint:gosec
+	count := span / uint64(step)
+	if count >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(count) + 1
+}
+
+// Value returns the value at the given index of the range
+func (r *MatrixRange) Value(index int) int64 {
+	return r.Start + int64(index)*r.step()
+}
+
+func (r *MatrixRange) step() int64 {
+	if r.Step == 0 {
+		return 1
+	}
+	return r.Step
+}
+
+// MatrixParameter is a dimension of a job matrix. Each partition of the job
+// is assigned one of its values, which is injected as an environment variable
+// with the parameter's name.
+type MatrixParameter struct {
+	// Name of the parameter, used as the environment variable name
+	Name string `json:"Name"`
+	// Values is the list of values of the parameter
+	Values []string `json:"Values,omitempty"`
+	// Range generates integer values for the parameter, as an alternative to Values
+	Range *MatrixRange `json:"Range,omitempty"`
+}
+
+// Len returns the number of values of the parameter
+func (p *MatrixParameter) Len() int {
+	if p.Range != nil {
+		return p.Range.Len()
+	}
+	return len(p.Values)
+}
+
+// Value returns the value at the given index of the parameter
+func (p *MatrixParameter) Value(index int) string {
+	if p.Range != nil {
+		return strconv.FormatInt(p.Range.Value(index), 10)
+	}
+	return p.Values[index]
+}
+
+// JobMatrix declares a parameter sweep for a batch job. The job runs one
+// partition for every combination of parameter values, which is why a job
+// with a matrix must have a Count equal to the size of the matrix.
+type JobMatrix struct {
+	Parameters []MatrixParameter `json:"Parameters"`
+}
+
+// Normalize is used to canonicalize fields in the JobMatrix
+func (m *JobMatrix) Normalize() {
+	if m == nil {
+		return
+	}
+	for i := range m.Parameters {
+		if m.Parameters[i].Range != nil && m.Parameters[i].Range.Step == 0 {
+			m.Parameters[i].Range.Step = 1
+		}
+	}
+}
+
+// Size returns the number of parameter combinations of the matrix
+func (m *JobMatrix) Size() int {
+	if m == nil || len(m.Parameters) == 0 {
+		return 0
+	}
+	size := 1
+	for i := range m.Parameters {
+		// avoid overflowing with large matrices, which are rejected anyway
+		length := m.Parameters[i].Len()
+		if length > MaxMatrixSize {
+			return MaxMatrixSize + 1
+		}
+		size *= length
+		if size > MaxMatrixSize {
+			return MaxMatrixSize + 1
+		}
+	}
+	return size
+}
+
+// Validate checks the matrix is well-formed
+func (m *JobMatrix) Validate() error {
+	if m == nil {
+		return nil
+	}
+	if len(m.Parameters) == 0 {
+		return errors.New("job matrix must have at least one parameter")
+	}
+
+	var mErr error
+	names := make(map[string]EnvVarValue, len(m.Parameters))
+	for i := range m.Parameters {
+		p := &m.Parameters[i]
+		if _, ok := names[p.Name]; ok {
+			mErr = errors.Join(mErr, fmt.Errorf("duplicate matrix parameter %s", p.Name))
+		}
+		names[p.Name] = "value"
+
+		switch {
+		case p.Range != nil && len(p.Values) > 0:
+			mErr = errors.Join(mErr, fmt.Errorf("matrix parameter %s cannot set both values and range", p.Name))
+		case p.Range != nil && p.Range.Step < 0:
+			mErr = errors.Join(mErr, fmt.Errorf("matrix parameter %s range step must be positive", p.Name))
+		case p.Len() == 0:
+			mErr = errors.Join(mErr, fmt.Errorf("matrix parameter %s has no values", p.Name))
+		}
+	}
+	// parameters are injected as environment variables and follow the same naming rules
+	if err := ValidateEnvVars(names); err != nil {
+		mErr = errors.Join(mErr, fmt.Errorf("invalid matrix parameter name: %w", err))
+	}
+	if m.Size() > MaxMatrixSize {
+		mErr = errors.Join(mErr, fmt.Errorf("job matrix cannot expand to more than %d combinations", MaxMatrixSize))
+	}
+	return mErr
+}
+
+// Values returns the parameter values assigned to the partition with the given index.
+// The last parameter varies fastest across partitions.
+func (m *JobMatrix) Values(partitionIndex int) (map[string]string, error) {
+	size := m.Size()
+	if partitionIndex < 0 || partitionIndex >= size {
+		return nil, fmt.Errorf("partition index %d is out of the job matrix range [0, %d)", partitionIndex, size)
+	}
+	values := make(map[string]string, len(m.Parameters))
+	remainder := partitionIndex
+	for i := len(m.Parameters) - 1; i >= 0; i-- {
+		p := &m.Parameters[i]
+		values[p.Name] = p.Value(remainder % p.Len())
+		remainder /= p.Len()
+	}
+	return values, nil
+}
+
+// Copy returns a deep copy of the matrix
+func (m *JobMatrix) Copy() *JobMatrix {
+	if m == nil {
+		return nil
+	}
+	cp := &JobMatrix{Parameters: make([]MatrixParameter, len(m.Parameters))}
+	for i, p := range m.Parameters {
+		if p.Range != nil {
+			r := *p.Range
+			p.Range = &r
+		}
+		p.Values = append([]string(nil), p.Values...)
+		cp.Parameters[i] = p
+	}
+	return cp
+}
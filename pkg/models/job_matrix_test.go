@@ -0,0 +1,134 @@
+//go:build unit || !integration
+
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JobMatrixTestSuite struct {
+	suite.Suite
+}
+
+func TestJobMatrixSuite(t *testing.T) {
+	suite.Run(t, new(JobMatrixTestSuite))
+}
+
+func (s *JobMatrixTestSuite) matrix() *JobMatrix {
+	return &JobMatrix{
+		Parameters: []MatrixParameter{
+			{Name: "MODEL", Values: []string{"small", "medium", "large"}},
+			{Name: "SEED", Range: &MatrixRange{Start: 0, End: 10, Step: 5}},
+		},
+	}
+}
+
+func (s *JobMatrixTestSuite) TestSize() {
+	s.Equal(9, s.matrix().Size())
+	s.Equal(0, (*JobMatrix)(nil).Size())
+	s.Equal(0, (&JobMatrix{}).Size())
+	s.Equal(11, (&JobMatrix{Parameters: []MatrixParameter{{Name: "N", Range: &MatrixRange{End: 10}}}}).Size())
+
+	// ranges longer than an int don't overflow to a negative length
+	huge := &MatrixRange{Start: math.MinInt64, End: math.MaxInt64 / 2}
+	s.Equal(math.MaxInt, huge.Len())
+	s.Equal(3, (&MatrixRange{Start: math.MinInt64, End: math.MaxInt64, Step: math.MaxInt64}).Len())
+}
+
+func (s *JobMatrixTestSuite) TestValues() {
+	m := s.matrix()
+	expected := []map[string]string{
+		{"MODEL": "small", "SEED": "0"},
+		{"MODEL": "small", "SEED": "5"},
+		{"MODEL": "small", "SEED": "10"},
+		{"MODEL": "medium", "SEED": "0"},
+	}
+	for i, want := range expected {
+		values, err := m.Values(i)
+		s.Require().NoError(err)
+		s.Equal(want, values)
+	}
+
+	values, err := m.Values(8)
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"MODEL": "large", "SEED": "10"}, values)
+
+	_, err = m.Values(9)
+	s.Error(err)
+	_, err = m.Values(-1)
+	s.Error(err)
+}
+
+func (s *JobMatrixTestSuite) TestValidate() {
+	s.NoError(s.matrix().Validate())
+	s.NoError((*JobMatrix)(nil).Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(m *JobMatrix)
+	}{
+		{name: "no-parameters", mutate: func(m *JobMatrix) { m.Parameters = nil }},
+		{name: "duplicate-name", mutate: func(m *JobMatrix) { m.Parameters[1].Name = "MODEL" }},
+		{name: "lowercase-name", mutate: func(m *JobMatrix) { m.Parameters[0].Name = "model" }},
+		{name: "reserved-name", mutate: func(m *JobMatrix) { m.Parameters[0].Name = "BACALHAU_MODEL" }},
+		{name: "no-values", mutate: func(m *JobMatrix) { m.Parameters[0].Values = nil }},
+		{name: "values-and-range", mutate: func(m *JobMatrix) { m.Parameters[0].Range = &MatrixRange{End: 1} }},
+		{name: "negative-step", mutate: func(m *JobMatrix) { m.Parameters[1].Range.Step = -1 }},
+		{name: "empty-range", mutate: func(m *JobMatrix) { m.Parameters[1].Range.End = -1 }},
+		{name: "too-large", mutate: func(m *JobMatrix) {
+			m.Parameters[1].Range = &MatrixRange{End: MaxMatrixSize}
+		}},
+		{name: "overflowing-range", mutate: func(m *JobMatrix) {
+			m.Parameters[1].Range = &MatrixRange{Start: math.MinInt64, End: math.MaxInt64 / 2}
+		}},
+		{name: "full-int64-range", mutate: func(m *JobMatrix) {
+			m.Parameters[1].Range = &MatrixRange{Start: math.MinInt64, End: math.MaxInt64}
+		}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			m := s.matrix()
+			tt.mutate(m)
+			s.Error(m.Validate())
+		})
+	}
+}
+
+func (s *JobMatrixTestSuite) TestJobValidation() {
+	job := &Job{
+		Type:   JobTypeBatch,
+		Count:  9,
+		Matrix: s.matrix(),
+		Tasks: []*Task{{
+			Name:   "main",
+			Engine: &SpecConfig{Type: "docker"},
+		}},
+	}
+	job.Normalize()
+	s.NoError(job.ValidateSubmission())
+
+	job.Count = 3
+	s.ErrorContains(job.ValidateSubmission(), "must match")
+
+	job.Count = 9
+	job.Tasks[0].Env["MODEL"] = "tiny"
+	s.ErrorContains(job.ValidateSubmission(), "conflicts")
+
+	delete(job.Tasks[0].Env, "MODEL")
+	job.Type = JobTypeService
+	s.ErrorContains(job.ValidateSubmission(), "only supported")
+}
+
+func (s *JobMatrixTestSuite) TestCopy() {
+	m := s.matrix()
+	cp := m.Copy()
+	s.Equal(m, cp)
+
+	cp.Parameters[0].Values[0] = "tiny"
+	cp.Parameters[1].Range.End = 100
+	s.Equal("small", m.Parameters[0].Values[0])
+	s.Equal(int64(10), m.Parameters[1].Range.End)
+}
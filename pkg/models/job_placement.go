@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SpreadRule spreads the executions of a job across the distinct values of a node label,
+// such as running each execution in a different zone.
+type SpreadRule struct {
+	// LabelKey is the node label whose values the executions are spread across
+	LabelKey string `json:"LabelKey"`
+	// Hard rules reject nodes that don't have the label, or whose label value already runs
+	// more executions of the job than other values. Soft rules only prefer the least used values.
+	Hard bool `json:"Hard,omitempty"`
+}
+
+// Copy returns a deep copy of the spread rule
+func (r *SpreadRule) Copy() *SpreadRule {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	return &cp
+}
+
+// Validate checks the spread rule is well-formed
+func (r *SpreadRule) Validate() error {
+	if r == nil {
+		return errors.New("spread rule is nil")
+	}
+	if r.LabelKey == "" {
+		return errors.New("spread rule must have a label key")
+	}
+	return nil
+}
+
+// AffinityRule selects other jobs in the same namespace as the job, whose active
+// executions attract or repel the executions of the job.
+type AffinityRule struct {
+	// JobName selects the job with the given name
+	JobName string `json:"JobName,omitempty"`
+	// JobLabels selects the jobs whose labels match all the requirements
+	JobLabels []*LabelSelectorRequirement `json:"JobLabels,omitempty"`
+	// Hard rules reject nodes that don't satisfy the rule. Soft rules only prefer nodes that do.
+	Hard bool `json:"Hard,omitempty"`
+}
+
+// Copy returns a deep copy of the affinity rule
+func (r *AffinityRule) Copy() *AffinityRule {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	if r.JobLabels != nil {
+		cp.JobLabels = CopySlice[*LabelSelectorRequirement](r.JobLabels)
+	}
+	return &cp
+}
+
+// Validate checks the affinity rule is well-formed
+func (r *AffinityRule) Validate() error {
+	if r == nil {
+		return errors.New("affinity rule is nil")
+	}
+	if r.JobName == "" && len(r.JobLabels) == 0 {
+		return errors.New("affinity rule must select jobs by name or labels")
+	}
+	var mErr error
+	for idx, req := range r.JobLabels {
+		if err := req.Validate(); err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("job label requirement %d validation failed: %w", idx+1, err))
+		}
+	}
+	return mErr
+}
+
+// JobPlacement declares rules to place the executions of a job relative to each other,
+// and to the executions of other jobs, in addition to the job's Constraints.
+type JobPlacement struct {
+	// Spread distributes the executions of the job across the values of node labels
+	Spread []*SpreadRule `json:"Spread,omitempty"`
+	// Affinity places the executions on nodes running executions of the selected jobs
+	Affinity []*AffinityRule `json:"Affinity,omitempty"`
+	// AntiAffinity keeps the executions away from nodes running executions of the selected jobs
+	AntiAffinity []*AffinityRule `json:"AntiAffinity,omitempty"`
+}
+
+// IsEmpty returns true if the placement has no rules
+func (p *JobPlacement) IsEmpty() bool {
+	return p == nil || (len(p.Spread) == 0 && len(p.Affinity) == 0 && len(p.AntiAffinity) == 0)
+}
+
+// Copy returns a deep copy of the placement
+func (p *JobPlacement) Copy() *JobPlacement {
+	if p == nil {
+		return nil
+	}
+	cp := new(JobPlacement)
+	if p.Spread != nil {
+		cp.Spread = CopySlice[*SpreadRule](p.Spread)
+	}
+	if p.Affinity != nil {
+		cp.Affinity = CopySlice[*AffinityRule](p.Affinity)
+	}
+	if p.AntiAffinity != nil {
+		cp.AntiAffinity = CopySlice[*AffinityRule](p.AntiAffinity)
+	}
+	return cp
+}
+
+// Validate checks the placement rules are well-formed
+func (p *JobPlacement) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var mErr error
+	for idx, rule := range p.Spread {
+		if err := rule.Validate(); err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("spread rule %d validation failed: %w", idx+1, err))
+		}
+	}
+	for idx, rule := range p.Affinity {
+		if err := rule.Validate(); err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("affinity rule %d validation failed: %w", idx+1, err))
+		}
+	}
+	for idx, rule := range p.AntiAffinity {
+		if err := rule.Validate(); err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("anti-affinity rule %d validation failed: %w", idx+1, err))
+		}
+	}
+	return mErr
+}
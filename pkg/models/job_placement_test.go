@@ -0,0 +1,94 @@
+//go:build unit || !integration
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+type JobPlacementTestSuite struct {
+	suite.Suite
+}
+
+func TestJobPlacementSuite(t *testing.T) {
+	suite.Run(t, new(JobPlacementTestSuite))
+}
+
+func (s *JobPlacementTestSuite) placement() *JobPlacement {
+	return &JobPlacement{
+		Spread:   []*SpreadRule{{LabelKey: "zone", Hard: true}},
+		Affinity: []*AffinityRule{{JobName: "cache"}},
+		AntiAffinity: []*AffinityRule{{
+			JobLabels: []*LabelSelectorRequirement{{Key: "tier", Operator: selection.In, Values: []string{"gpu"}}},
+			Hard:      true,
+		}},
+	}
+}
+
+func (s *JobPlacementTestSuite) TestIsEmpty() {
+	s.True((*JobPlacement)(nil).IsEmpty())
+	s.True((&JobPlacement{}).IsEmpty())
+	s.False(s.placement().IsEmpty())
+}
+
+func (s *JobPlacementTestSuite) TestValidate() {
+	s.NoError(s.placement().Validate())
+	s.NoError((*JobPlacement)(nil).Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(p *JobPlacement)
+	}{
+		{name: "nil-spread", mutate: func(p *JobPlacement) { p.Spread[0] = nil }},
+		{name: "no-label-key", mutate: func(p *JobPlacement) { p.Spread[0].LabelKey = "" }},
+		{name: "no-job-selector", mutate: func(p *JobPlacement) { p.Affinity[0].JobName = "" }},
+		{name: "invalid-job-labels", mutate: func(p *JobPlacement) { p.AntiAffinity[0].JobLabels[0].Values = nil }},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			p := s.placement()
+			tt.mutate(p)
+			s.Error(p.Validate())
+		})
+	}
+}
+
+func (s *JobPlacementTestSuite) TestJobValidation() {
+	job := &Job{
+		Type:      JobTypeBatch,
+		Count:     3,
+		Placement: s.placement(),
+		Tasks: []*Task{{
+			Name:   "main",
+			Engine: &SpecConfig{Type: "docker"},
+		}},
+	}
+	job.Normalize()
+	s.NoError(job.ValidateSubmission())
+
+	job.Placement.Spread[0].LabelKey = ""
+	s.ErrorContains(job.ValidateSubmission(), "spread rule 1")
+
+	job.Placement.Spread[0].LabelKey = "zone"
+	job.Type = JobTypeDaemon
+	job.Count = 0
+	s.ErrorContains(job.ValidateSubmission(), "only supported")
+}
+
+func (s *JobPlacementTestSuite) TestCopy() {
+	p := s.placement()
+	cp := p.Copy()
+	s.Equal(p, cp)
+
+	cp.Spread[0].LabelKey = "region"
+	cp.AntiAffinity[0].JobLabels[0].Key = "class"
+	s.Equal("zone", p.Spread[0].LabelKey)
+	s.Equal("tier", p.AntiAffinity[0].JobLabels[0].Key)
+
+	job := &Job{Placement: p}
+	s.NotSame(job.Placement, job.Copy().Placement)
+	s.Nil((&Job{}).Copy().Placement)
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// JobArchiveRecord is a terminal job and everything that was stored about it,
+// as exported to an archive before the job is purged from the job store.
+type JobArchiveRecord struct {
+	Job         Job          `json:"Job"`
+	Executions  []Execution  `json:"Executions,omitempty"`
+	Evaluations []Evaluation `json:"Evaluations,omitempty"`
+	History     []JobHistory `json:"History,omitempty"`
+}
+
+// JobRetentionStats describes the activity of the job retention subsystem
+// of the orchestrator.
+type JobRetentionStats struct {
+	// Enabled is true if terminal jobs are periodically purged from the job store
+	Enabled bool `json:"Enabled"`
+	// MaxAge is how long terminal jobs are retained after they were last modified
+	MaxAge time.Duration `json:"MaxAge,omitempty"`
+	// MaxJobsPerNamespace is the number of terminal jobs retained per namespace
+	MaxJobsPerNamespace int `json:"MaxJobsPerNamespace,omitempty"`
+	// ArchiveDir is where jobs are archived before being purged, if archiving is enabled
+	ArchiveDir string `json:"ArchiveDir,omitempty"`
+	// Runs is the number of retention runs, scheduled or manual, since the orchestrator started
+	Runs uint64 `json:"Runs"`
+	// PrunedJobs is the number of jobs purged since the orchestrator started
+	PrunedJobs uint64 `json:"PrunedJobs"`
+	// ArchivedJobs is the number of jobs archived since the orchestrator started
+	ArchivedJobs uint64 `json:"ArchivedJobs"`
+	// LastRunTime is the time the last retention run completed
+	LastRunTime time.Time `json:"LastRunTime,omitempty"`
+	// LastRunPruned is the number of jobs purged by the last retention run
+	LastRunPruned int `json:"LastRunPruned"`
+	// LastError is the error of the last retention run, if it failed
+	LastError string `json:"LastError,omitempty"`
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
+)
+
+// TemplateParameterType is the type of value a template parameter accepts
+type TemplateParameterType string
+
+const (
+	TemplateParameterTypeString TemplateParameterType = "string"
+	TemplateParameterTypeInt    TemplateParameterType = "int"
+	TemplateParameterTypeFloat  TemplateParameterType = "float"
+	TemplateParameterTypeBool   TemplateParameterType = "bool"
+)
+
+// TemplateParameter describes a variable that can be set when running a job template
+type TemplateParameter struct {
+	// Name of the parameter, referenced in the template spec as {{.Name}}
+	Name string `json:"Name"`
+	// Type of the parameter. Defaults to string.
+	Type TemplateParameterType `json:"Type,omitempty"`
+	// Description is a human-readable description of the parameter
+	Description string `json:"Description,omitempty"`
+	// Default is the value used when the parameter is not provided
+	Default string `json:"Default,omitempty"`
+	// Required parameters must be provided when running the template
+	Required bool `json:"Required,omitempty"`
+	// Allowed restricts the parameter to one of the listed values, if not empty
+	Allowed []string `json:"Allowed,omitempty"`
+}
+
+// ValidateValue checks the value is acceptable for the parameter
+func (p *TemplateParameter) ValidateValue(value string) error {
+	var err error
+	switch p.Type {
+	case TemplateParameterTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case TemplateParameterTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case TemplateParameterTypeBool:
+		_, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		return fmt.Errorf("parameter %s expects a value of type %s, got %q", p.Name, p.Type, value)
+	}
+	if len(p.Allowed) > 0 && !slices.Contains(p.Allowed, value) {
+		return fmt.Errorf("parameter %s must be one of %q, got %q", p.Name, p.Allowed, value)
+	}
+	return nil
+}
+
+// JobTemplate is a named and versioned job spec with typed parameters that is
+// stored on the orchestrator and rendered into a job when run.
+type JobTemplate struct {
+	// Name uniquely identifies the template
+	Name string `json:"Name"`
+	// Version is incremented each time a template with the same name is registered
+	Version uint64 `json:"Version"`
+	// Description is a human-readable description of the template
+	Description string `json:"Description,omitempty"`
+	// Labels is used to categorize templates
+	Labels map[string]string `json:"Labels,omitempty"`
+	// Parameters that can be set when running the template
+	Parameters []TemplateParameter `json:"Parameters,omitempty"`
+	// Spec is the job spec, in YAML or JSON, with {{.Name}} placeholders for parameters
+	Spec string `json:"Spec"`
+	// CreateTime is the time the template version was registered
+	CreateTime int64 `json:"CreateTime"`
+}
+
+// Normalize is used to canonicalize fields in the template
+func (t *JobTemplate) Normalize() {
+	if t == nil {
+		return
+	}
+	if t.Labels == nil {
+		t.Labels = make(map[string]string)
+	}
+	for i := range t.Parameters {
+		if t.Parameters[i].Type == "" {
+			t.Parameters[i].Type = TemplateParameterTypeString
+		}
+	}
+}
+
+// Validate checks the template is well-formed
+func (t *JobTemplate) Validate() error {
+	if t == nil {
+		return errors.New("empty/nil job template")
+	}
+	mErr := errors.Join(
+		validate.NotBlank(t.Name, "missing template name"),
+		validate.NoSpaces(t.Name, "template name contains a space"),
+		validate.NoNullChars(t.Name, "template name contains a null character"),
+		validate.NotBlank(t.Spec, "missing template spec"),
+	)
+
+	seen := make(map[string]struct{})
+	for i := range t.Parameters {
+		p := &t.Parameters[i]
+		if p.Name == "" {
+			mErr = errors.Join(mErr, fmt.Errorf("template parameter %d is missing a name", i))
+			continue
+		}
+		if _, ok := seen[p.Name]; ok {
+			mErr = errors.Join(mErr, fmt.Errorf("duplicate template parameter %s", p.Name))
+		}
+		seen[p.Name] = struct{}{}
+
+		switch p.Type {
+		case "", TemplateParameterTypeString, TemplateParameterTypeInt, TemplateParameterTypeFloat, TemplateParameterTypeBool:
+		default:
+			mErr = errors.Join(mErr, fmt.Errorf("parameter %s has invalid type %q", p.Name, p.Type))
+			continue
+		}
+		if p.Default != "" {
+			if err := p.ValidateValue(p.Default); err != nil {
+				mErr = errors.Join(mErr, fmt.Errorf("invalid default: %w", err))
+			}
+		}
+	}
+	return mErr
+}
+
+// ResolveParameters validates the provided values against the template's
+// parameters and returns the values to render the template with, including defaults.
+func (t *JobTemplate) ResolveParameters(values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(t.Parameters))
+	known := make(map[string]struct{}, len(t.Parameters))
+	var mErr error
+	for i := range t.Parameters {
+		p := &t.Parameters[i]
+		known[p.Name] = struct{}{}
+		value, ok := values[p.Name]
+		if !ok {
+			if p.Required {
+				mErr = errors.Join(mErr, fmt.Errorf("missing required parameter %s", p.Name))
+				continue
+			}
+			value = p.Default
+		}
+		if ok {
+			if err := p.ValidateValue(value); err != nil {
+				mErr = errors.Join(mErr, err)
+				continue
+			}
+		}
+		resolved[p.Name] = value
+	}
+	for name := range values {
+		if _, ok := known[name]; !ok {
+			mErr = errors.Join(mErr, fmt.Errorf("unknown parameter %s for template %s", name, t.Name))
+		}
+	}
+	if mErr != nil {
+		return nil, mErr
+	}
+	return resolved, nil
+}
+
+// Copy returns a deep copy of the template
+func (t *JobTemplate) Copy() *JobTemplate {
+	if t == nil {
+		return nil
+	}
+	cp := *t
+	cp.Labels = make(map[string]string, len(t.Labels))
+	for k, v := range t.Labels {
+		cp.Labels[k] = v
+	}
+	cp.Parameters = make([]TemplateParameter, len(t.Parameters))
+	for i, p := range t.Parameters {
+		p.Allowed = slices.Clone(p.Allowed)
+		cp.Parameters[i] = p
+	}
+	return &cp
+}
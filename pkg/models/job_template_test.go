@@ -0,0 +1,100 @@
+//go:build unit || !integration
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JobTemplateTestSuite struct {
+	suite.Suite
+}
+
+func TestJobTemplateSuite(t *testing.T) {
+	suite.Run(t, new(JobTemplateTestSuite))
+}
+
+func (s *JobTemplateTestSuite) template() *JobTemplate {
+	t := &JobTemplate{
+		Name: "resize",
+		Spec: "Type: batch",
+		Parameters: []TemplateParameter{
+			{Name: "size", Type: TemplateParameterTypeInt, Default: "256"},
+			{Name: "bucket", Required: true},
+			{Name: "format", Allowed: []string{"png", "jpg"}, Default: "png"},
+		},
+	}
+	t.Normalize()
+	return t
+}
+
+func (s *JobTemplateTestSuite) TestValidate() {
+	s.NoError(s.template().Validate())
+
+	var nilTemplate *JobTemplate
+	s.Error(nilTemplate.Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(t *JobTemplate)
+	}{
+		{name: "missing-name", mutate: func(t *JobTemplate) { t.Name = "" }},
+		{name: "name-with-space", mutate: func(t *JobTemplate) { t.Name = "my template" }},
+		{name: "missing-spec", mutate: func(t *JobTemplate) { t.Spec = " " }},
+		{name: "missing-parameter-name", mutate: func(t *JobTemplate) { t.Parameters[0].Name = "" }},
+		{name: "duplicate-parameter", mutate: func(t *JobTemplate) { t.Parameters[1].Name = "size" }},
+		{name: "invalid-type", mutate: func(t *JobTemplate) { t.Parameters[0].Type = "duration" }},
+		{name: "invalid-default", mutate: func(t *JobTemplate) { t.Parameters[0].Default = "big" }},
+		{name: "default-not-allowed", mutate: func(t *JobTemplate) { t.Parameters[2].Default = "gif" }},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			t := s.template()
+			tt.mutate(t)
+			s.Error(t.Validate())
+		})
+	}
+}
+
+func (s *JobTemplateTestSuite) TestResolveParameters() {
+	resolved, err := s.template().ResolveParameters(map[string]string{"bucket": "images"})
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"size": "256", "bucket": "images", "format": "png"}, resolved)
+
+	resolved, err = s.template().ResolveParameters(map[string]string{"bucket": "images", "size": "512", "format": "jpg"})
+	s.Require().NoError(err)
+	s.Equal("512", resolved["size"])
+	s.Equal("jpg", resolved["format"])
+}
+
+func (s *JobTemplateTestSuite) TestResolveParametersErrors() {
+	tests := []struct {
+		name   string
+		values map[string]string
+	}{
+		{name: "missing-required", values: map[string]string{}},
+		{name: "wrong-type", values: map[string]string{"bucket": "images", "size": "big"}},
+		{name: "not-allowed", values: map[string]string{"bucket": "images", "format": "gif"}},
+		{name: "unknown", values: map[string]string{"bucket": "images", "colour": "red"}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.template().ResolveParameters(tt.values)
+			s.Error(err)
+		})
+	}
+}
+
+func (s *JobTemplateTestSuite) TestCopy() {
+	original := s.template()
+	original.Labels["team"] = "media"
+	cp := original.Copy()
+	s.Equal(original, cp)
+
+	cp.Labels["team"] = "other"
+	cp.Parameters[2].Allowed[0] = "bmp"
+	s.Equal("media", original.Labels["team"])
+	s.Equal("png", original.Parameters[2].Allowed[0])
+}
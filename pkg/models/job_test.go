@@ -379,6 +379,44 @@ func (suite *JobTestSuite) TestJobJSONHandling() {
 	}
 }
 
+func (suite *JobTestSuite) TestJobJSONMatrixCount() {
+	jsonData := []byte(`{"Type": "batch", "Matrix": {"Parameters": [
+		{"Name": "MODEL", "Values": ["a", "b", "c"]},
+		{"Name": "SEED", "Range": {"Start": 1, "End": 4}}]}}`)
+
+	var job models.Job
+	suite.Require().NoError(json.Unmarshal(jsonData, &job))
+	suite.Equal(12, job.Count)
+
+	jsonData = []byte(`{"Type": "batch", "Count": 2, "Matrix": {"Parameters": [{"Name": "MODEL", "Values": ["a", "b", "c"]}]}}`)
+	suite.Require().NoError(json.Unmarshal(jsonData, &job))
+	suite.Equal(2, job.Count)
+}
+
+func (suite *JobTestSuite) TestJobPartitions() {
+	job := mock.Job()
+	job.Count = 4
+	suite.Equal(4, job.ScheduledPartitionCount())
+	suite.True(job.IsPartitionScheduled(2))
+	suite.False(job.IsPartitionScheduled(4))
+
+	job.Partitions = []int{1, 3}
+	suite.NoError(job.ValidateSubmission())
+	suite.Equal(2, job.ScheduledPartitionCount())
+	suite.True(job.IsPartitionScheduled(3))
+	suite.False(job.IsPartitionScheduled(2))
+
+	job.Partitions = []int{1, 4}
+	suite.ErrorContains(job.ValidateSubmission(), "out of range")
+
+	job.Partitions = []int{1, 1}
+	suite.ErrorContains(job.ValidateSubmission(), "duplicate")
+
+	job.Partitions = []int{1}
+	job.Type = models.JobTypeService
+	suite.ErrorContains(job.ValidateSubmission(), "only be set")
+}
+
 // Helper function to create pointer to int
 func ptr(i int) *int {
 	return &i
@@ -6,9 +6,10 @@ const (
 	BidRejectedMessageType     = "BidRejected"
 	CancelExecutionMessageType = "CancelExecution"
 
-	BidResultMessageType    = "BidResult"
-	RunResultMessageType    = "RunResult"
-	ComputeErrorMessageType = "ComputeError"
+	BidResultMessageType     = "BidResult"
+	RunResultMessageType     = "RunResult"
+	ComputeErrorMessageType  = "ComputeError"
+	ExecutionLogsMessageType = "ExecutionLogs"
 
 	HandshakeRequestMessageType      = "transport.HandshakeRequest"
 	HeartbeatRequestMessageType      = "transport.HeartbeatRequest"
@@ -11,6 +11,9 @@ type HandshakeRequest struct {
 	NodeInfo               models.NodeInfo `json:"NodeInfo"`
 	StartTime              time.Time       `json:"StartTime"`
 	LastOrchestratorSeqNum uint64          `json:"LastOrchestratorSeqNum"` // Last seq received from orchestrator
+	// PublicKey verifies the signatures of messages sent by the compute node.
+	// Empty if the node doesn't sign its messages.
+	PublicKey []byte `json:"PublicKey,omitempty"`
 }
 
 // HandshakeResponse is sent in response to handshake requests
@@ -19,6 +22,9 @@ type HandshakeResponse struct {
 	Reason                     string `json:"reason,omitempty"`
 	LastComputeSeqNum          uint64 `json:"LastComputeSeqNum"`      // Last seq received from compute node
 	StartingOrchestratorSeqNum uint64 `json:"LastOrchestratorSeqNum"` // Seq to start sending to compute node
+	// PublicKey verifies the signatures of messages sent by the orchestrator.
+	// Empty if the orchestrator doesn't sign its messages.
+	PublicKey []byte `json:"PublicKey,omitempty"`
 }
 
 type HeartbeatRequest struct {
@@ -17,6 +17,8 @@ type RunResult struct {
 
 type ComputeError struct {
 	BaseResponse
+	// RunCommandResult is the output of the run that failed, if it ran, including its resource usage
+	RunCommandResult *models.RunCommandResult
 }
 
 func (e ComputeError) Error() string {
@@ -1,5 +1,9 @@
 package messages
 
+import (
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
 type ExecutionLogsRequest struct {
 	ExecutionID string
 	NodeID      string
@@ -11,3 +15,10 @@ type ExecutionLogsResponse struct {
 	Address           string
 	ExecutionFinished bool
 }
+
+// ExecutionLogs carries output lines of an execution forwarded by compute
+// nodes to the orchestrator.
+type ExecutionLogs struct {
+	BaseResponse
+	Lines []models.ExecutionLogLine
+}
@@ -112,6 +112,25 @@ func (n *NetworkConfig) Disabled() bool {
 	return n.Type == NetworkNone
 }
 
+// AllowsDomain returns whether connections to the domain are allowed according to this config.
+// HTTP networking only allows the listed domains, where a domain starting with a dot also
+// allows its subdomains, while host and bridge networking allow any domain.
+func (n *NetworkConfig) AllowsDomain(domain string) bool {
+	if n == nil || domain == "" {
+		return false
+	}
+	switch n.Type {
+	case NetworkHost, NetworkBridge:
+		return true
+	case NetworkHTTP:
+		return slices.ContainsFunc(n.Domains, func(allowed string) bool {
+			return matchDomain(domain, allowed) == 0
+		})
+	default:
+		return false
+	}
+}
+
 // Normalize ensures that the network config is in a consistent state.
 func (n *NetworkConfig) Normalize() {
 	if n == nil {
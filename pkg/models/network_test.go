@@ -195,6 +195,36 @@ func (s *NetworkTestSuite) TestDomainMatching() {
 	}
 }
 
+func (s *NetworkTestSuite) TestAllowsDomain() {
+	httpConfig := &NetworkConfig{Type: NetworkHTTP, Domains: []string{"foo.com", ".bar.com"}}
+	tests := []struct {
+		name   string
+		config *NetworkConfig
+		domain string
+		want   bool
+	}{
+		{name: "listed", config: httpConfig, domain: "foo.com", want: true},
+		{name: "listed-case", config: httpConfig, domain: "FOO.com", want: true},
+		{name: "subdomain-of-listed", config: httpConfig, domain: "x.foo.com", want: false},
+		{name: "wildcard", config: httpConfig, domain: "bar.com", want: true},
+		{name: "subdomain-of-wildcard", config: httpConfig, domain: "x.bar.com", want: true},
+		{name: "suffix-of-wildcard", config: httpConfig, domain: "xbar.com", want: false},
+		{name: "unlisted", config: httpConfig, domain: "baz.com", want: false},
+		{name: "empty", config: httpConfig, domain: "", want: false},
+		{name: "http-without-domains", config: &NetworkConfig{Type: NetworkHTTP}, domain: "foo.com", want: false},
+		{name: "none", config: &NetworkConfig{Type: NetworkNone, Domains: []string{"foo.com"}}, domain: "foo.com", want: false},
+		{name: "nil", config: nil, domain: "foo.com", want: false},
+		{name: "host", config: &NetworkConfig{Type: NetworkHost}, domain: "foo.com", want: true},
+		{name: "bridge", config: &NetworkConfig{Type: NetworkBridge}, domain: "foo.com", want: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.want, tt.config.AllowsDomain(tt.domain))
+		})
+	}
+}
+
 func (s *NetworkTestSuite) TestNetworkConfigCopy() {
 	original := &NetworkConfig{
 		Type:    NetworkBridge,
@@ -148,6 +148,7 @@ func (n NodeInfo) HasStaticConfigChanged(other NodeInfo) bool {
 
 // ComputeNodeInfo contains metadata about the current state and abilities of a compute node. Compute Nodes share
 // this state with Requester nodes by including it in the NodeInfo they share across the network.
+// AvailableCapacity may exceed MaxCapacity when the node overcommits CPU or memory.
 type ComputeNodeInfo struct {
 	ExecutionEngines   []string  `json:"ExecutionEngines"`
 	Publishers         []string  `json:"Publishers"`
@@ -16,6 +16,10 @@ type NodeState struct {
 
 	// Connection and messaging state
 	ConnectionState ConnectionState `json:"ConnectionState"`
+
+	// PublicKey verifies the signatures of messages sent by the node.
+	// It is pinned on the node's first handshake, and later handshakes must use the same key.
+	PublicKey []byte `json:"PublicKey,omitempty"`
 }
 
 // ConnectionState tracks node's connectivity and messaging state
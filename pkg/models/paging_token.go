@@ -12,6 +12,8 @@ import (
 const (
 	delimiter         = ":"
 	expectedPartCount = 4
+	// cursorPartCount is the number of parts of tokens that include a cursor
+	cursorPartCount = 5
 )
 
 type PagingTokenParams struct {
@@ -19,13 +21,18 @@ type PagingTokenParams struct {
 	SortReverse bool
 	Limit       uint32
 	Offset      uint64
+	Cursor      string
 }
 
+// PagingToken encodes the settings of a paginated query, and the position of the next page
+// either as an offset or as an opaque cursor. The cursor takes precedence when set.
 type PagingToken struct {
 	SortBy      string
 	SortReverse bool
 	Limit       uint32
 	Offset      uint64
+	// Cursor identifies the last item of the previous page. It must not contain the delimiter.
+	Cursor string
 }
 
 func NewPagingToken(params *PagingTokenParams) *PagingToken {
@@ -34,6 +41,7 @@ func NewPagingToken(params *PagingTokenParams) *PagingToken {
 		SortReverse: params.SortReverse,
 		Limit:       params.Limit,
 		Offset:      params.Offset,
+		Cursor:      params.Cursor,
 	}
 }
 
@@ -46,7 +54,7 @@ func NewPagingTokenFromString(s string) (*PagingToken, error) {
 	}
 
 	parts := strings.Split(string(decodedBytes), delimiter)
-	if len(parts) != expectedPartCount {
+	if len(parts) != expectedPartCount && len(parts) != cursorPartCount {
 		return nil, NewErrInvalidPagingToken(s, "invalid number of parts")
 	}
 
@@ -67,6 +75,13 @@ func NewPagingTokenFromString(s string) (*PagingToken, error) {
 		token.Offset = offset
 	}
 
+	if len(parts) == cursorPartCount {
+		if parts[4] == "" {
+			return nil, NewErrInvalidPagingToken(s, "malformed token")
+		}
+		token.Cursor = parts[4]
+	}
+
 	return token, nil
 }
 
@@ -76,12 +91,16 @@ func (pagingToken *PagingToken) RawString() string {
 		reverse = "Y"
 	}
 
-	return strings.Join([]string{
+	parts := []string{
 		pagingToken.SortBy,
 		reverse,
 		strconv.FormatUint(uint64(pagingToken.Limit), 10),
 		strconv.FormatUint(pagingToken.Offset, 10),
-	}, delimiter)
+	}
+	if pagingToken.Cursor != "" {
+		parts = append(parts, pagingToken.Cursor)
+	}
+	return strings.Join(parts, delimiter)
 }
 
 // String returns the token as a base 64 encoded string where each field is
@@ -62,6 +62,17 @@ func (s *PagingTokenTestSuite) TestNew() {
 			decoded:   "created_at:Y:10:10",
 			expectErr: false,
 		},
+		{
+			name: "valid with cursor",
+			params: &models.PagingTokenParams{
+				SortBy: "created_at",
+				Limit:  10,
+				Cursor: "0000000a6a6f622d31",
+			},
+			token:     "Y3JlYXRlZF9hdDpOOjEwOjA6MDAwMDAwMGE2YTZmNjIyZDMx",
+			decoded:   "created_at:N:10:0:0000000a6a6f622d31",
+			expectErr: false,
+		},
 		{
 			name:      "invalid token",
 			params:    &models.PagingTokenParams{},
@@ -3,6 +3,8 @@ package models
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"path"
 	"strconv"
 	"strings"
 
@@ -19,7 +21,17 @@ type ResourcesConfig struct {
 	Memory string `json:"Memory,omitempty"`
 	// Memory github.com/dustin/go-humanize string
 	Disk string `json:"Disk,omitempty"`
-	GPU  string `json:"GPU,omitempty"`
+	// GPU is a number of whole GPUs, or a fraction below 1 of a GPU shared with other jobs
+	GPU string `json:"GPU,omitempty"`
+	// GPUMemory github.com/dustin/go-humanize string of the memory required on each GPU
+	GPUMemory string `json:"GPUMemory,omitempty"`
+	// GPUVendor is the maker of the GPUs required, e.g. NVIDIA, AMD, Intel
+	GPUVendor string `json:"GPUVendor,omitempty"`
+	// GPUModel is a pattern matching the model name of the GPUs required,
+	// e.g. A100 or "Tesla T*"
+	GPUModel string `json:"GPUModel,omitempty"`
+	// Extended is the number of units required of each extended resource, by name
+	Extended map[string]string `json:"Extended,omitempty"`
 }
 
 // Normalize normalizes the resources
@@ -39,6 +51,9 @@ func (r *ResourcesConfig) Normalize() {
 	r.Memory = sanitizeResourceString(r.Memory)
 	r.Disk = sanitizeResourceString(r.Disk)
 	r.GPU = sanitizeResourceString(r.GPU)
+	r.GPUMemory = sanitizeResourceString(r.GPUMemory)
+	r.GPUVendor = strings.TrimSpace(r.GPUVendor)
+	r.GPUModel = strings.TrimSpace(r.GPUModel)
 }
 
 // Copy returns a deep copy of the resources
@@ -48,6 +63,7 @@ func (r *ResourcesConfig) Copy() *ResourcesConfig {
 	}
 	newR := new(ResourcesConfig)
 	*newR = *r
+	newR.Extended = maps.Clone(r.Extended)
 	return newR
 }
 
@@ -94,11 +110,46 @@ func (r *ResourcesConfig) ToResources() (*Resources, error) {
 		res.Disk = disk
 	}
 	if r.GPU != "" {
-		gpu, err := strconv.ParseUint(r.GPU, 10, 64)
-		if err != nil {
+		if gpu, err := strconv.ParseUint(r.GPU, 10, 64); err == nil {
+			res.GPU = gpu
+		} else if share, shareErr := strconv.ParseFloat(r.GPU, 64); shareErr == nil && share > 0 && share < 1 {
+			res.GPUShare = share
+		} else {
 			return nil, fmt.Errorf("invalid GPU value: %s", r.GPU)
 		}
-		res.GPU = gpu
+	}
+	if r.GPUMemory != "" {
+		gpuMemory, err := humanize.ParseBytes(r.GPUMemory)
+		if err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("invalid GPU memory value: %s", r.GPUMemory))
+		}
+		// GPU memory is reported in MiB, so round up requests to the next MiB
+		res.GPUMemory = (gpuMemory + humanize.MiByte - 1) / humanize.MiByte
+	}
+	if r.GPUVendor != "" {
+		vendor, err := ParseGPUVendor(r.GPUVendor)
+		if err != nil {
+			mErr = errors.Join(mErr, err)
+		}
+		res.GPUVendor = vendor
+	}
+	res.GPUModel = r.GPUModel
+	for name, value := range r.Extended {
+		if err := ValidateExtendedResourceName(name); err != nil {
+			mErr = errors.Join(mErr, err)
+			continue
+		}
+		units, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("invalid %s value: %s", name, value))
+			continue
+		}
+		if units > 0 {
+			if res.Extended == nil {
+				res.Extended = make(ExtendedResources)
+			}
+			res.Extended[name] = units
+		}
 	}
 
 	return res, mErr
@@ -112,6 +163,21 @@ const (
 	GPUVendorIntel  GPUVendor = "Intel"
 )
 
+// ParseGPUVendor returns the GPU vendor matching the name case-insensitively,
+// where AMD and ATI are both accepted for AMD/ATI.
+func ParseGPUVendor(name string) (GPUVendor, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "nvidia":
+		return GPUVendorNvidia, nil
+	case "amd", "ati", "amd/ati":
+		return GPUVendorAMDATI, nil
+	case "intel":
+		return GPUVendorIntel, nil
+	default:
+		return "", fmt.Errorf("invalid GPU vendor value: %s", name)
+	}
+}
+
 type GPU struct {
 	// Self-reported index of the device in the system
 	Index uint64
@@ -153,8 +219,19 @@ type Resources struct {
 	Disk uint64 `json:"Disk,omitempty"`
 	// GPU units
 	GPU uint64 `json:"GPU,omitempty"`
+	// GPUShare is the fraction of a single GPU used when sharing it with other
+	// executions through time-slicing, between 0 and 1 exclusive
+	GPUShare float64 `json:"GPUShare,omitempty"`
+	// GPUMemory is the memory required on each GPU in mebibytes (MiB)
+	GPUMemory uint64 `json:"GPUMemory,omitempty"`
+	// GPUVendor is the maker of the GPUs required, if any
+	GPUVendor GPUVendor `json:"GPUVendor,omitempty"`
+	// GPUModel is a pattern matching the model name of the GPUs required, if any
+	GPUModel string `json:"GPUModel,omitempty"`
 	// GPU details
 	GPUs []GPU `json:"GPUs,omitempty"`
+	// Extended resources in units, by name
+	Extended ExtendedResources `json:"Extended,omitempty"`
 }
 
 // Copy returns a deep copy of the resources
@@ -164,6 +241,7 @@ func (r *Resources) Copy() *Resources {
 	}
 	newR := new(Resources)
 	*newR = *r
+	newR.Extended = r.Extended.Copy()
 	return newR
 }
 
@@ -185,9 +263,72 @@ func (r *Resources) Validate() error {
 		// But the number should always be at least the length of the GPUs array
 		mErr = errors.Join(mErr, fmt.Errorf("%d GPUs specified but have details for %d", r.GPU, len(r.GPUs)))
 	}
+	if r.GPUShare < 0 || r.GPUShare >= 1 {
+		mErr = errors.Join(mErr, fmt.Errorf("invalid GPU share value: %f must be between 0 and 1", r.GPUShare))
+	}
+	if r.GPUShare > 0 && r.GPU > 0 {
+		mErr = errors.Join(mErr, fmt.Errorf("cannot request both %d GPUs and a %.2f share of a GPU", r.GPU, r.GPUShare))
+	}
+	if r.IsSharedGPU() && len(r.GPUs) > 1 {
+		mErr = errors.Join(mErr, fmt.Errorf("a shared GPU request can only be allocated a single GPU, got %d", len(r.GPUs)))
+	}
+	if (r.GPUVendor != "" || r.GPUModel != "") && r.GPUCount() == 0 {
+		mErr = errors.Join(mErr, errors.New("GPU vendor and model can only be set when requesting GPUs"))
+	}
+	if _, err := path.Match(strings.ToLower(r.GPUModel), ""); err != nil {
+		mErr = errors.Join(mErr, fmt.Errorf("invalid GPU model pattern %q: %w", r.GPUModel, err))
+	}
+	for name := range r.Extended {
+		mErr = errors.Join(mErr, ValidateExtendedResourceName(name))
+	}
 	return mErr
 }
 
+// MatchesGPU returns true if the GPU is of the vendor and model required,
+// and has at least the GPU memory required.
+func (r *Resources) MatchesGPU(gpu GPU) bool {
+	if r.GPUVendor != "" && gpu.Vendor != r.GPUVendor {
+		return false
+	}
+	if r.GPUModel != "" && !matchGPUModel(r.GPUModel, gpu.Name) {
+		return false
+	}
+	return gpu.Memory >= r.GPUMemory
+}
+
+// HasGPURequirements returns true if the resources require GPUs of a
+// specific vendor, model or memory.
+func (r *Resources) HasGPURequirements() bool {
+	return r.GPUVendor != "" || r.GPUModel != "" || r.GPUMemory > 0
+}
+
+// matchGPUModel matches the model name of a GPU case-insensitively against a
+// glob pattern, or as a substring if the pattern has no wildcards, so that A100
+// matches "NVIDIA A100-SXM4-40GB".
+func matchGPUModel(pattern, name string) bool {
+	pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.Contains(name, pattern)
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// IsSharedGPU returns true if the resources request a GPU shared with other
+// executions, either as a fraction of a GPU or only as an amount of GPU memory.
+func (r *Resources) IsSharedGPU() bool {
+	return r.GPU == 0 && (r.GPUShare > 0 || r.GPUMemory > 0)
+}
+
+// GPUCount returns the number of GPUs needed by the resources,
+// where a shared GPU request needs a single GPU.
+func (r *Resources) GPUCount() uint64 {
+	if r.IsSharedGPU() {
+		return 1
+	}
+	return r.GPU
+}
+
 // Merge merges the resources, preferring the current resources
 func (r *Resources) Merge(other Resources) *Resources {
 	newR := r.Copy()
@@ -200,32 +341,42 @@ func (r *Resources) Merge(other Resources) *Resources {
 	if newR.Disk <= 0 {
 		newR.Disk = other.Disk
 	}
-	if newR.GPU <= 0 {
+	// a shared GPU request doesn't need whole GPUs
+	if newR.GPU <= 0 && !newR.IsSharedGPU() {
 		newR.GPU = other.GPU
 	}
 	if len(newR.GPUs) <= 0 {
 		newR.GPUs = other.GPUs
 	}
+	if len(newR.Extended) == 0 {
+		newR.Extended = other.Extended.Copy()
+	}
 	return newR
 }
 
 // Add returns the sum of the resources
 func (r *Resources) Add(other Resources) *Resources {
 	return &Resources{
-		CPU:    r.CPU + other.CPU,
-		Memory: r.Memory + other.Memory,
-		Disk:   r.Disk + other.Disk,
-		GPU:    r.GPU + other.GPU,
-		GPUs:   append(r.GPUs, other.GPUs...),
+		CPU:       r.CPU + other.CPU,
+		Memory:    r.Memory + other.Memory,
+		Disk:      r.Disk + other.Disk,
+		GPU:       r.GPU + other.GPU,
+		GPUShare:  r.GPUShare + other.GPUShare,
+		GPUMemory: r.GPUMemory + other.GPUMemory,
+		GPUs:      append(r.GPUs, other.GPUs...),
+		Extended:  r.Extended.Add(other.Extended),
 	}
 }
 
 func (r *Resources) Sub(other Resources) *Resources {
 	usage := &Resources{
-		CPU:    r.CPU - other.CPU,
-		Memory: r.Memory - other.Memory,
-		Disk:   r.Disk - other.Disk,
-		GPU:    r.GPU - other.GPU,
+		CPU:       r.CPU - other.CPU,
+		Memory:    r.Memory - other.Memory,
+		Disk:      r.Disk - other.Disk,
+		GPU:       r.GPU - other.GPU,
+		GPUShare:  r.GPUShare - other.GPUShare,
+		GPUMemory: r.GPUMemory - other.GPUMemory,
+		Extended:  r.Extended.Sub(other.Extended),
 	}
 
 	usage.GPUs, _ = lo.Difference(r.GPUs, other.GPUs)
@@ -246,6 +397,13 @@ func (r *Resources) Sub(other Resources) *Resources {
 			usage.GPU = 0
 		}
 	}
+	// LessThan doesn't compare GPU shares and memory, so they are clamped separately
+	if other.GPUShare > r.GPUShare {
+		usage.GPUShare = 0
+	}
+	if other.GPUMemory > r.GPUMemory {
+		usage.GPUMemory = 0
+	}
 
 	return usage
 }
@@ -253,19 +411,24 @@ func (r *Resources) Sub(other Resources) *Resources {
 // Multiply returns the product of the resources
 func (r *Resources) Multiply(factor float64) *Resources {
 	return &Resources{
-		CPU:    r.CPU * factor,
-		Memory: uint64(float64(r.Memory) * factor),
-		Disk:   uint64(float64(r.Disk) * factor),
-		GPU:    uint64(float64(r.GPU) * factor),
+		CPU:       r.CPU * factor,
+		Memory:    uint64(float64(r.Memory) * factor),
+		Disk:      uint64(float64(r.Disk) * factor),
+		GPU:       uint64(float64(r.GPU) * factor),
+		GPUShare:  r.GPUShare * factor,
+		GPUMemory: uint64(float64(r.GPUMemory) * factor),
+		Extended:  r.Extended.Multiply(factor),
 	}
 }
 
 func (r *Resources) LessThan(other Resources) bool {
-	return r.CPU < other.CPU && r.Memory < other.Memory && r.Disk < other.Disk && r.GPU < other.GPU
+	return r.CPU < other.CPU && r.Memory < other.Memory && r.Disk < other.Disk && r.GPUCount() < other.GPUCount() &&
+		r.Extended.LessThan(other.Extended)
 }
 
 func (r *Resources) LessThanEq(other Resources) bool {
-	return r.CPU <= other.CPU && r.Memory <= other.Memory && r.Disk <= other.Disk && r.GPU <= other.GPU
+	return r.CPU <= other.CPU && r.Memory <= other.Memory && r.Disk <= other.Disk && r.GPUCount() <= other.GPUCount() &&
+		r.Extended.LessThanEq(other.Extended)
 }
 
 func (r *Resources) Max(other Resources) *Resources {
@@ -282,19 +445,39 @@ func (r *Resources) Max(other Resources) *Resources {
 	if newR.GPU < other.GPU {
 		newR.GPU = other.GPU
 	}
+	if newR.GPUShare < other.GPUShare {
+		newR.GPUShare = other.GPUShare
+	}
+	if newR.GPUMemory < other.GPUMemory {
+		newR.GPUMemory = other.GPUMemory
+	}
+	newR.Extended = newR.Extended.Max(other.Extended)
 
 	return newR
 }
 
 func (r *Resources) IsZero() bool {
-	return r.CPU == 0 && r.Memory == 0 && r.Disk == 0 && r.GPU == 0
+	return r.CPU == 0 && r.Memory == 0 && r.Disk == 0 && r.GPU == 0 && r.GPUShare == 0 && r.GPUMemory == 0 &&
+		r.Extended.IsZero()
 }
 
 // return string representation of ResourceUsageData
 func (r *Resources) String() string {
 	mem := humanize.Bytes(r.Memory)
 	disk := humanize.Bytes(r.Disk)
-	return fmt.Sprintf("{CPU: %.2f, Memory: %s, Disk: %s, GPU: %d}", r.CPU, mem, disk, r.GPU)
+	var gpu string
+	switch {
+	case r.IsSharedGPU():
+		gpu = fmt.Sprintf("%.2f shared, GPU Memory: %d MiB", r.GPUShare, r.GPUMemory)
+	case r.GPUMemory > 0:
+		gpu = fmt.Sprintf("%d, GPU Memory: %d MiB", r.GPU, r.GPUMemory)
+	default:
+		gpu = fmt.Sprint(r.GPU)
+	}
+	if len(r.Extended) > 0 {
+		return fmt.Sprintf("{CPU: %.2f, Memory: %s, Disk: %s, GPU: %s, Extended: {%s}}", r.CPU, mem, disk, gpu, r.Extended)
+	}
+	return fmt.Sprintf("{CPU: %.2f, Memory: %s, Disk: %s, GPU: %s}", r.CPU, mem, disk, gpu)
 }
 
 // AllocatedResources is the set of resources to be used by an execution, which
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// ResourceUsage summarizes the resources actually used by an execution,
+// as measured by its executor, in contrast to the resources requested by the job.
+// Fields that an executor can't measure are left as zero.
+type ResourceUsage struct {
+	// WallTime is the time the execution ran for
+	WallTime time.Duration `json:"WallTime"`
+	// CPUTime is the CPU time consumed by the execution across all cores
+	CPUTime time.Duration `json:"CPUTime"`
+	// PeakMemory is the highest memory usage sampled during the execution, in bytes
+	PeakMemory uint64 `json:"PeakMemory"`
+	// AvgMemory is the average memory usage sampled during the execution, in bytes
+	AvgMemory uint64 `json:"AvgMemory"`
+	// DiskWritten is the number of bytes written to disk by the execution
+	DiskWritten uint64 `json:"DiskWritten"`
+	// NetworkRx is the number of bytes received over the network by the execution
+	NetworkRx uint64 `json:"NetworkRx"`
+	// NetworkTx is the number of bytes sent over the network by the execution
+	NetworkTx uint64 `json:"NetworkTx"`
+}
+
+// Copy returns a copy of the resource usage
+func (u *ResourceUsage) Copy() *ResourceUsage {
+	if u == nil {
+		return nil
+	}
+	cp := *u
+	return &cp
+}
+
+// CPUUtilization returns the average number of cores used during the wall time of the execution
+func (u *ResourceUsage) CPUUtilization() float64 {
+	if u == nil || u.WallTime <= 0 {
+		return 0
+	}
+	return float64(u.CPUTime) / float64(u.WallTime)
+}
+
+// String returns a human-readable summary of the resource usage
+func (u *ResourceUsage) String() string {
+	if u == nil {
+		return ""
+	}
+	return fmt.Sprintf("wall: %s, cpu: %s, peak memory: %s, avg memory: %s, disk written: %s, network rx/tx: %s/%s",
+		u.WallTime.Round(time.Millisecond), u.CPUTime.Round(time.Millisecond),
+		humanize.IBytes(u.PeakMemory), humanize.IBytes(u.AvgMemory), humanize.IBytes(u.DiskWritten),
+		humanize.IBytes(u.NetworkRx), humanize.IBytes(u.NetworkTx))
+}
@@ -0,0 +1,41 @@
+//go:build unit || !integration
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceUsageTestSuite struct {
+	suite.Suite
+}
+
+func TestResourceUsageSuite(t *testing.T) {
+	suite.Run(t, new(ResourceUsageTestSuite))
+}
+
+func (s *ResourceUsageTestSuite) TestCPUUtilization() {
+	s.InDelta(2.0, (&ResourceUsage{WallTime: time.Second, CPUTime: 2 * time.Second}).CPUUtilization(), 0.001)
+	s.Zero((&ResourceUsage{CPUTime: time.Second}).CPUUtilization())
+	s.Zero((*ResourceUsage)(nil).CPUUtilization())
+}
+
+func (s *ResourceUsageTestSuite) TestRunCommandResultCopy() {
+	result := &RunCommandResult{ExitCode: 1, Usage: &ResourceUsage{PeakMemory: 1024}}
+	cp := result.Copy()
+	s.Equal(result, cp)
+
+	cp.Usage.PeakMemory = 2048
+	s.Equal(uint64(1024), result.Usage.PeakMemory)
+	s.Nil((&RunCommandResult{}).Copy().Usage)
+}
+
+func (s *ResourceUsageTestSuite) TestString() {
+	usage := &ResourceUsage{WallTime: time.Second, CPUTime: 500 * time.Millisecond, PeakMemory: 1024}
+	s.Equal("wall: 1s, cpu: 500ms, peak memory: 1.0 KiB, avg memory: 0 B, disk written: 0 B, network rx/tx: 0 B/0 B",
+		usage.String())
+	s.Empty((*ResourceUsage)(nil).String())
+}
@@ -3,6 +3,7 @@
 package models
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,94 @@ func TestResourceBytes(t *testing.T) {
 		require.Equal(t, p.exp, actual.Disk)
 	}
 }
+
+func TestResourceGPU(t *testing.T) {
+	tests := []struct {
+		gpu       string
+		gpuMemory string
+		exp       Resources
+		shared    bool
+	}{
+		{gpu: "2", exp: Resources{GPU: 2}},
+		{gpu: "0.25", exp: Resources{GPUShare: 0.25}, shared: true},
+		{gpuMemory: "8GiB", exp: Resources{GPUMemory: 8192}, shared: true},
+		{gpu: "0.5", gpuMemory: "1.5MiB", exp: Resources{GPUShare: 0.5, GPUMemory: 2}, shared: true},
+		{gpu: "1", gpuMemory: "16GiB", exp: Resources{GPU: 1, GPUMemory: 16384}},
+	}
+
+	for _, p := range tests {
+		cfg := ResourcesConfig{GPU: p.gpu, GPUMemory: p.gpuMemory}
+		require.NoError(t, cfg.Validate())
+		actual, err := cfg.ToResources()
+		require.NoError(t, err)
+		require.Equal(t, p.exp, *actual)
+		require.Equal(t, p.shared, actual.IsSharedGPU())
+	}
+
+	for _, gpu := range []string{"1.5", "-0.5", "0.0"} {
+		cfg := ResourcesConfig{GPU: gpu}
+		require.Error(t, cfg.Validate(), gpu)
+	}
+}
+
+func TestResourceGPURequirements(t *testing.T) {
+	a100 := GPU{Name: "NVIDIA A100-SXM4-40GB", Vendor: GPUVendorNvidia, Memory: 40960}
+	t4 := GPU{Name: "Tesla T4", Vendor: GPUVendorNvidia, Memory: 15360}
+	mi250 := GPU{Name: "AMD Instinct MI250X", Vendor: GPUVendorAMDATI, Memory: 65536}
+
+	tests := []struct {
+		cfg     ResourcesConfig
+		matches []GPU
+	}{
+		{cfg: ResourcesConfig{GPU: "1", GPUVendor: "nvidia"}, matches: []GPU{a100, t4}},
+		{cfg: ResourcesConfig{GPU: "1", GPUVendor: "AMD"}, matches: []GPU{mi250}},
+		{cfg: ResourcesConfig{GPU: "1", GPUModel: "a100"}, matches: []GPU{a100}},
+		{cfg: ResourcesConfig{GPU: "1", GPUModel: "tesla t*"}, matches: []GPU{t4}},
+		{cfg: ResourcesConfig{GPU: "1", GPUMemory: "40GiB"}, matches: []GPU{a100, mi250}},
+		{cfg: ResourcesConfig{GPU: "0.5", GPUVendor: "nvidia", GPUMemory: "20GiB"}, matches: []GPU{a100}},
+	}
+
+	for _, p := range tests {
+		require.NoError(t, p.cfg.Validate())
+		resources, err := p.cfg.ToResources()
+		require.NoError(t, err)
+		require.True(t, resources.HasGPURequirements())
+		for _, gpu := range []GPU{a100, t4, mi250} {
+			require.Equal(t, slices.Contains(p.matches, gpu), resources.MatchesGPU(gpu), "%+v %s", p.cfg, gpu.Name)
+		}
+	}
+
+	for _, cfg := range []ResourcesConfig{
+		{GPU: "1", GPUVendor: "3dfx"},
+		{GPU: "1", GPUModel: "[a100"},
+		{GPUVendor: "nvidia"},
+	} {
+		require.Error(t, cfg.Validate(), "%+v", cfg)
+	}
+}
+
+func TestResourceExtended(t *testing.T) {
+	cfg := ResourcesConfig{CPU: "1", Extended: map[string]string{"fpga": "2", "example.com/license": "1", "dongle": "0"}}
+	require.NoError(t, cfg.Validate())
+	job, err := cfg.ToResources()
+	require.NoError(t, err)
+	require.Equal(t, ExtendedResources{"fpga": 2, "example.com/license": 1}, job.Extended)
+
+	node := Resources{CPU: 4, Extended: ExtendedResources{"fpga": 4}}
+	require.False(t, job.LessThanEq(node))
+	require.Equal(t, []string{"example.com/license"}, job.Extended.Missing(node.Extended))
+
+	node.Extended["example.com/license"] = 1
+	require.True(t, job.LessThanEq(node))
+	require.Equal(t, ExtendedResources{"fpga": 2}, node.Sub(*job).Extended)
+	require.Equal(t, ExtendedResources{"fpga": 6, "example.com/license": 2}, node.Add(*job).Extended)
+	require.Equal(t, "example.com/license=1, fpga=2", job.Extended.String())
+
+	for _, cfg := range []ResourcesConfig{
+		{Extended: map[string]string{"-fpga": "1"}},
+		{Extended: map[string]string{"fpga": "one"}},
+		{Extended: map[string]string{"fpga": "-1"}},
+	} {
+		require.Error(t, cfg.Validate(), "%+v", cfg)
+	}
+}
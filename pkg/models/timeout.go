@@ -19,6 +19,12 @@ type TimeoutConfig struct {
 	// This includes the time spent in the queue, the time spent executing and the time spent retrying.
 	// Zero means no timeout.
 	TotalTimeout int64 `json:"TotalTimeout,omitempty"`
+	// DisconnectGracePeriod is the amount of time in seconds the orchestrator waits for a
+	// disconnected compute node to reconnect before declaring its executions lost and rescheduling them.
+	// Compute nodes keep running accepted executions while disconnected, and report their results
+	// when they reconnect. When unset, the orchestrator's job defaults for the job type apply.
+	// Zero after defaults are applied means executions are never declared lost while the node is known.
+	DisconnectGracePeriod int64 `json:"DisconnectGracePeriod,omitempty"`
 }
 
 // GetExecutionTimeout returns the execution timeout duration
@@ -42,15 +48,21 @@ func (t *TimeoutConfig) GetTotalTimeout() time.Duration {
 	return time.Duration(t.TotalTimeout) * time.Second
 }
 
+// GetDisconnectGracePeriod returns the disconnect grace period duration
+func (t *TimeoutConfig) GetDisconnectGracePeriod() time.Duration {
+	return time.Duration(t.DisconnectGracePeriod) * time.Second
+}
+
 // Copy returns a deep copy of the timeout config.
 func (t *TimeoutConfig) Copy() *TimeoutConfig {
 	if t == nil {
 		return nil
 	}
 	return &TimeoutConfig{
-		ExecutionTimeout: t.ExecutionTimeout,
-		QueueTimeout:     t.QueueTimeout,
-		TotalTimeout:     t.TotalTimeout,
+		ExecutionTimeout:      t.ExecutionTimeout,
+		QueueTimeout:          t.QueueTimeout,
+		TotalTimeout:          t.TotalTimeout,
+		DisconnectGracePeriod: t.DisconnectGracePeriod,
 	}
 }
 
@@ -86,5 +98,8 @@ func (t *TimeoutConfig) ValidateSubmission() error {
 	if t.TotalTimeout < 0 {
 		mErr = errors.Join(mErr, fmt.Errorf("invalid total timeout value: %s", t.GetTotalTimeout()))
 	}
+	if t.DisconnectGracePeriod < 0 {
+		mErr = errors.Join(mErr, fmt.Errorf("invalid disconnect grace period value: %s", t.GetDisconnectGracePeriod()))
+	}
 	return mErr
 }
@@ -15,20 +15,23 @@ type TimeoutConfigTestSuite struct {
 
 func (suite *TimeoutConfigTestSuite) TestGetters() {
 	config := &TimeoutConfig{
-		ExecutionTimeout: 10,
-		QueueTimeout:     20,
-		TotalTimeout:     30,
+		ExecutionTimeout:      10,
+		QueueTimeout:          20,
+		TotalTimeout:          30,
+		DisconnectGracePeriod: 40,
 	}
 	suite.Equal(10*time.Second, config.GetExecutionTimeout(), "Execution timeout should be 10 seconds")
 	suite.Equal(20*time.Second, config.GetQueueTimeout(), "Queue timeout should be 20 seconds")
 	suite.Equal(30*time.Second, config.GetTotalTimeout(), "Total timeout should be 30 seconds")
+	suite.Equal(40*time.Second, config.GetDisconnectGracePeriod(), "Disconnect grace period should be 40 seconds")
 }
 
 func (suite *TimeoutConfigTestSuite) TestCopy() {
 	original := &TimeoutConfig{
-		ExecutionTimeout: 10,
-		QueueTimeout:     20,
-		TotalTimeout:     30,
+		ExecutionTimeout:      10,
+		QueueTimeout:          20,
+		TotalTimeout:          30,
+		DisconnectGracePeriod: 40,
 	}
 	copyConfig := original.Copy()
 	suite.Equal(original, copyConfig, "Copied config should be equal to the original")
@@ -105,6 +108,14 @@ func (suite *TimeoutConfigTestSuite) TestValidate() {
 			expectErr: true,
 			errMsg:    "invalid total timeout value",
 		},
+		{
+			name: "NegativeDisconnectGracePeriod",
+			config: &TimeoutConfig{
+				DisconnectGracePeriod: -10,
+			},
+			expectErr: true,
+			errMsg:    "invalid disconnect grace period value",
+		},
 		{
 			name: "InvalidTotalTimeout",
 			config: &TimeoutConfig{
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keys that usage reports can be grouped by.
+const (
+	UsageGroupByNamespace = "namespace"
+	UsageGroupByJobType   = "type"
+	UsageGroupByDay       = "day"
+	// UsageGroupByLabelPrefix groups by the value of a job label, e.g. label:team
+	UsageGroupByLabelPrefix = "label:"
+)
+
+// DefaultUsageGroupBy is used when a usage report doesn't specify how to group executions.
+var DefaultUsageGroupBy = []string{UsageGroupByNamespace, UsageGroupByDay}
+
+// UsageReportRow is the resource usage aggregated over the executions of a single group.
+// Resource-seconds are the resources allocated to executions multiplied by how long they ran.
+type UsageReportRow struct {
+	// Group holds the value of each group-by key for the row, e.g. namespace=default
+	Group map[string]string `json:"Group"`
+	// Executions is the number of finished executions in the group
+	Executions uint64 `json:"Executions"`
+	// CPUSeconds is the allocated CPU cores multiplied by the executions' duration
+	CPUSeconds float64 `json:"CPUSeconds"`
+	// MemoryGiBSeconds is the allocated memory, in GiB, multiplied by the executions' duration
+	MemoryGiBSeconds float64 `json:"MemoryGiBSeconds"`
+	// GPUSeconds is the allocated GPUs, including the fraction of a shared GPU, multiplied by the executions' duration
+	GPUSeconds float64 `json:"GPUSeconds"`
+	// UsedCPUSeconds is the CPU time actually consumed, for executions that reported their usage
+	UsedCPUSeconds float64 `json:"UsedCPUSeconds"`
+}
+
+// Add accumulates the usage of another row into this one
+func (r *UsageReportRow) Add(other UsageReportRow) {
+	r.Executions += other.Executions
+	r.CPUSeconds += other.CPUSeconds
+	r.MemoryGiBSeconds += other.MemoryGiBSeconds
+	r.GPUSeconds += other.GPUSeconds
+	r.UsedCPUSeconds += other.UsedCPUSeconds
+}
+
+// ParseUsageGroupBy parses a comma separated list of group-by keys,
+// returning the default grouping when the list is empty.
+func ParseUsageGroupBy(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultUsageGroupBy, nil
+	}
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		keys = append(keys, strings.TrimSpace(key))
+	}
+	if err := ValidateUsageGroupBy(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ValidateUsageGroupBy checks that the keys are supported and not repeated
+func ValidateUsageGroupBy(keys []string) error {
+	seen := make(map[string]bool, len(keys))
+	var errs error
+	for _, key := range keys {
+		switch {
+		case key == UsageGroupByNamespace, key == UsageGroupByJobType, key == UsageGroupByDay:
+		case strings.HasPrefix(key, UsageGroupByLabelPrefix) && len(key) > len(UsageGroupByLabelPrefix):
+		default:
+			errs = errors.Join(errs, fmt.Errorf("unsupported usage group-by key %q, expected one of %s, %s, %s or %s<key>",
+				key, UsageGroupByNamespace, UsageGroupByJobType, UsageGroupByDay, UsageGroupByLabelPrefix))
+			continue
+		}
+		if seen[key] {
+			errs = errors.Join(errs, fmt.Errorf("usage group-by key %q is repeated", key))
+		}
+		seen[key] = true
+	}
+	return errs
+}
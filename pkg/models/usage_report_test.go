@@ -0,0 +1,38 @@
+//go:build unit || !integration
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UsageReportTestSuite struct {
+	suite.Suite
+}
+
+func TestUsageReportSuite(t *testing.T) {
+	suite.Run(t, new(UsageReportTestSuite))
+}
+
+func (s *UsageReportTestSuite) TestParseUsageGroupBy() {
+	keys, err := ParseUsageGroupBy("")
+	s.Require().NoError(err)
+	s.Equal(DefaultUsageGroupBy, keys)
+
+	keys, err = ParseUsageGroupBy("namespace, label:team,type,day")
+	s.Require().NoError(err)
+	s.Equal([]string{"namespace", "label:team", "type", "day"}, keys)
+
+	for _, value := range []string{"node", "label:", "namespace,namespace", "day,"} {
+		_, err = ParseUsageGroupBy(value)
+		s.Error(err, value)
+	}
+}
+
+func (s *UsageReportTestSuite) TestAdd() {
+	row := UsageReportRow{Executions: 1, CPUSeconds: 1, MemoryGiBSeconds: 2, GPUSeconds: 3, UsedCPUSeconds: 0.5}
+	row.Add(UsageReportRow{Executions: 2, CPUSeconds: 1, MemoryGiBSeconds: 1, GPUSeconds: 1, UsedCPUSeconds: 0.5})
+	s.Equal(UsageReportRow{Executions: 3, CPUSeconds: 2, MemoryGiBSeconds: 3, GPUSeconds: 4, UsedCPUSeconds: 1}, row)
+}
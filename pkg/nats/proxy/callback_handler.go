@@ -13,29 +13,32 @@ import (
 )
 
 type CallbackHandlerParams struct {
-	Name     string
-	Conn     *nats.Conn
-	Callback compute.Callback
+	Name          string
+	Conn          *nats.Conn
+	SubjectPrefix string
+	Callback      compute.Callback
 }
 
 // CallbackHandler is a handler for callback events that registers for incoming nats requests to Bacalhau callback
 // protocol, and delegates the handling of the request to the provided callback.
 type CallbackHandler struct {
-	name     string
-	conn     *nats.Conn
-	callback compute.Callback
+	name          string
+	conn          *nats.Conn
+	subjectPrefix string
+	callback      compute.Callback
 }
 
 type callbackHandler[Request any] func(context.Context, Request)
 
 func NewCallbackHandler(params CallbackHandlerParams) (*CallbackHandler, error) {
 	handler := &CallbackHandler{
-		name:     params.Name,
-		conn:     params.Conn,
-		callback: params.Callback,
+		name:          params.Name,
+		conn:          params.Conn,
+		subjectPrefix: params.SubjectPrefix,
+		callback:      params.Callback,
 	}
 
-	subject := callbackSubscribeSubject(handler.name)
+	subject := callbackSubscribeSubject(handler.subjectPrefix, handler.name)
 	_, err := handler.conn.Subscribe(subject, func(m *nats.Msg) {
 		handler.handle(m)
 	})
@@ -14,7 +14,8 @@ import (
 )
 
 type CallbackProxyParams struct {
-	Conn *nats.Conn
+	Conn          *nats.Conn
+	SubjectPrefix string
 }
 
 // CallbackProxy is a proxy for a compute.Callback that can be used to send compute callbacks to the requester node,
@@ -22,31 +23,34 @@ type CallbackProxyParams struct {
 // The proxy can forward callbacks to a remote requester node, or locally if the node is the requester and a
 // LocalCallback is provided.
 type CallbackProxy struct {
-	conn *nats.Conn
+	conn          *nats.Conn
+	subjectPrefix string
 }
 
 func NewCallbackProxy(params CallbackProxyParams) *CallbackProxy {
 	proxy := &CallbackProxy{
-		conn: params.Conn,
+		conn:          params.Conn,
+		subjectPrefix: params.SubjectPrefix,
 	}
 	return proxy
 }
 
 func (p *CallbackProxy) OnBidComplete(ctx context.Context, result legacy.BidResult) {
-	proxyCallbackRequest(ctx, p.conn, result.RoutingMetadata.TargetPeerID, OnBidComplete, result)
+	proxyCallbackRequest(ctx, p.conn, p.subjectPrefix, result.RoutingMetadata.TargetPeerID, OnBidComplete, result)
 }
 
 func (p *CallbackProxy) OnRunComplete(ctx context.Context, result legacy.RunResult) {
-	proxyCallbackRequest(ctx, p.conn, result.RoutingMetadata.TargetPeerID, OnRunComplete, result)
+	proxyCallbackRequest(ctx, p.conn, p.subjectPrefix, result.RoutingMetadata.TargetPeerID, OnRunComplete, result)
 }
 
 func (p *CallbackProxy) OnComputeFailure(ctx context.Context, result legacy.ComputeError) {
-	proxyCallbackRequest(ctx, p.conn, result.RoutingMetadata.TargetPeerID, OnComputeFailure, result)
+	proxyCallbackRequest(ctx, p.conn, p.subjectPrefix, result.RoutingMetadata.TargetPeerID, OnComputeFailure, result)
 }
 
 func proxyCallbackRequest(
 	ctx context.Context,
 	conn *nats.Conn,
+	subjectPrefix string,
 	destNodeID string,
 	method string,
 	request interface{}) {
@@ -57,7 +61,7 @@ func proxyCallbackRequest(
 		return
 	}
 
-	subject := callbackPublishSubject(destNodeID, method)
+	subject := callbackPublishSubject(subjectPrefix, destNodeID, method)
 	log.Ctx(ctx).Trace().Msgf("Sending request %+v to subject %s", request, subject)
 
 	// We use Publish instead of Request as Orchestrator callbacks do not return a response, for now.
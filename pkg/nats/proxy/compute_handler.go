@@ -18,6 +18,7 @@ import (
 type ComputeHandlerParams struct {
 	Name            string
 	Conn            *nats.Conn
+	SubjectPrefix   string
 	ComputeEndpoint compute.Endpoint
 }
 
@@ -25,6 +26,7 @@ type ComputeHandlerParams struct {
 type ComputeHandler struct {
 	name            string
 	conn            *nats.Conn
+	subjectPrefix   string
 	computeEndpoint compute.Endpoint
 	subscription    *nats.Subscription
 }
@@ -34,10 +36,11 @@ func NewComputeHandler(ctx context.Context, params ComputeHandlerParams) (*Compu
 	handler := &ComputeHandler{
 		name:            params.Name,
 		conn:            params.Conn,
+		subjectPrefix:   params.SubjectPrefix,
 		computeEndpoint: params.ComputeEndpoint,
 	}
 
-	subject := computeEndpointSubscribeSubject(handler.name)
+	subject := computeEndpointSubscribeSubject(handler.subjectPrefix, handler.name)
 	subscription, err := handler.conn.Subscribe(subject, func(m *nats.Msg) {
 		handleRequest(m, handler)
 	})
@@ -14,19 +14,22 @@ import (
 )
 
 type ComputeProxyParams struct {
-	Conn *nats.Conn
+	Conn          *nats.Conn
+	SubjectPrefix string
 }
 
 // ComputeProxy is a proxy to a compute node endpoint that will forward requests to remote compute nodes, or
 // to a local compute node if the target peer ID is the same as the local host, and a LocalEndpoint implementation
 // is provided.
 type ComputeProxy struct {
-	conn *nats.Conn
+	conn          *nats.Conn
+	subjectPrefix string
 }
 
 func NewComputeProxy(params ComputeProxyParams) (*ComputeProxy, error) {
 	proxy := &ComputeProxy{
-		conn: params.Conn,
+		conn:          params.Conn,
+		subjectPrefix: params.SubjectPrefix,
 	}
 	return proxy, nil
 }
@@ -34,27 +37,30 @@ func NewComputeProxy(params ComputeProxyParams) (*ComputeProxy, error) {
 func (p *ComputeProxy) AskForBid(ctx context.Context, request legacy.AskForBidRequest) (legacy.AskForBidResponse, error) {
 	return proxyRequest[legacy.AskForBidRequest, legacy.AskForBidResponse](
 		ctx, p.conn, &BaseRequest[legacy.AskForBidRequest]{
-			TargetNodeID: request.TargetPeerID,
-			Method:       AskForBid,
-			Body:         request,
+			SubjectPrefix: p.subjectPrefix,
+			TargetNodeID:  request.TargetPeerID,
+			Method:        AskForBid,
+			Body:          request,
 		})
 }
 
 func (p *ComputeProxy) BidAccepted(ctx context.Context, request legacy.BidAcceptedRequest) (legacy.BidAcceptedResponse, error) {
 	return proxyRequest[legacy.BidAcceptedRequest, legacy.BidAcceptedResponse](
 		ctx, p.conn, &BaseRequest[legacy.BidAcceptedRequest]{
-			TargetNodeID: request.TargetPeerID,
-			Method:       BidAccepted,
-			Body:         request,
+			SubjectPrefix: p.subjectPrefix,
+			TargetNodeID:  request.TargetPeerID,
+			Method:        BidAccepted,
+			Body:          request,
 		})
 }
 
 func (p *ComputeProxy) BidRejected(ctx context.Context, request legacy.BidRejectedRequest) (legacy.BidRejectedResponse, error) {
 	return proxyRequest[legacy.BidRejectedRequest, legacy.BidRejectedResponse](
 		ctx, p.conn, &BaseRequest[legacy.BidRejectedRequest]{
-			TargetNodeID: request.TargetPeerID,
-			Method:       BidRejected,
-			Body:         request,
+			SubjectPrefix: p.subjectPrefix,
+			TargetNodeID:  request.TargetPeerID,
+			Method:        BidRejected,
+			Body:          request,
 		})
 }
 
@@ -62,9 +68,10 @@ func (p *ComputeProxy) CancelExecution(
 	ctx context.Context, request legacy.CancelExecutionRequest) (legacy.CancelExecutionResponse, error) {
 	return proxyRequest[legacy.CancelExecutionRequest, legacy.CancelExecutionResponse](
 		ctx, p.conn, &BaseRequest[legacy.CancelExecutionRequest]{
-			TargetNodeID: request.TargetPeerID,
-			Method:       CancelExecution,
-			Body:         request,
+			SubjectPrefix: p.subjectPrefix,
+			TargetNodeID:  request.TargetPeerID,
+			Method:        CancelExecution,
+			Body:          request,
 		})
 }
 
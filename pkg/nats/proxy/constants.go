@@ -2,6 +2,8 @@ package proxy
 
 import (
 	"fmt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
 const (
@@ -24,26 +26,36 @@ const (
 	UpdateResources = "UpdateResources/v1"
 )
 
-func computeEndpointPublishSubject(nodeID string, method string) string {
-	return fmt.Sprintf("%s.%s.%s", ComputeEndpointSubjectPrefix, nodeID, method)
+// subjectBase returns the base of the legacy proxy subjects for the given subject prefix.
+// The default prefix keeps the unprefixed subjects so that nodes running older versions
+// can still talk to each other, while a custom prefix isolates clusters sharing a NATS server.
+func subjectBase(prefix string, base string) string {
+	if nclprotocol.SubjectPrefix(prefix) == nclprotocol.DefaultSubjectPrefix {
+		return base
+	}
+	return prefix + "." + base
+}
+
+func computeEndpointPublishSubject(prefix string, nodeID string, method string) string {
+	return fmt.Sprintf("%s.%s.%s", subjectBase(prefix, ComputeEndpointSubjectPrefix), nodeID, method)
 }
 
-func computeEndpointSubscribeSubject(nodeID string) string {
-	return fmt.Sprintf("%s.%s.>", ComputeEndpointSubjectPrefix, nodeID)
+func computeEndpointSubscribeSubject(prefix string, nodeID string) string {
+	return fmt.Sprintf("%s.%s.>", subjectBase(prefix, ComputeEndpointSubjectPrefix), nodeID)
 }
 
-func callbackPublishSubject(nodeID string, method string) string {
-	return fmt.Sprintf("%s.%s.%s", CallbackSubjectPrefix, nodeID, method)
+func callbackPublishSubject(prefix string, nodeID string, method string) string {
+	return fmt.Sprintf("%s.%s.%s", subjectBase(prefix, CallbackSubjectPrefix), nodeID, method)
 }
 
-func callbackSubscribeSubject(nodeID string) string {
-	return fmt.Sprintf("%s.%s.>", CallbackSubjectPrefix, nodeID)
+func callbackSubscribeSubject(prefix string, nodeID string) string {
+	return fmt.Sprintf("%s.%s.>", subjectBase(prefix, CallbackSubjectPrefix), nodeID)
 }
 
-func managementPublishSubject(nodeID string, method string) string {
-	return fmt.Sprintf("%s.%s.%s", ManagementSubjectPrefix, nodeID, method)
+func managementPublishSubject(prefix string, nodeID string, method string) string {
+	return fmt.Sprintf("%s.%s.%s", subjectBase(prefix, ManagementSubjectPrefix), nodeID, method)
 }
 
-func managementSubscribeSubject() string {
-	return fmt.Sprintf("%s.>", ManagementSubjectPrefix)
+func managementSubscribeSubject(prefix string) string {
+	return fmt.Sprintf("%s.>", subjectBase(prefix, ManagementSubjectPrefix))
 }
@@ -19,6 +19,7 @@ import (
 type LogStreamHandlerParams struct {
 	Name                       string
 	Conn                       *nats.Conn
+	SubjectPrefix              string
 	LogstreamServer            logstream.Server
 	StreamProducerClientConfig stream.StreamProducerClientConfig
 }
@@ -27,6 +28,7 @@ type LogStreamHandlerParams struct {
 type LogStreamHandler struct {
 	name            string
 	conn            *nats.Conn
+	subjectPrefix   string
 	logstreamServer logstream.Server
 	subscription    *nats.Subscription
 	streamingClient *stream.ProducerClient
@@ -51,11 +53,12 @@ func NewLogStreamHandler(ctx context.Context, params LogStreamHandlerParams) (*L
 	handler := &LogStreamHandler{
 		name:            params.Name,
 		conn:            params.Conn,
+		subjectPrefix:   params.SubjectPrefix,
 		logstreamServer: params.LogstreamServer,
 		streamingClient: streamingClient,
 	}
 
-	subject := computeEndpointSubscribeSubject(handler.name)
+	subject := computeEndpointSubscribeSubject(handler.subjectPrefix, handler.name)
 	subscription, err := handler.conn.Subscribe(subject, func(m *nats.Msg) {
 		handler.handleRequest(m)
 	})
@@ -24,7 +24,8 @@ const (
 )
 
 type LogStreamProxyParams struct {
-	Conn *nats.Conn
+	Conn          *nats.Conn
+	SubjectPrefix string
 }
 
 // LogStreamProxy is a proxy to a compute node endpoint that will forward requests to remote compute nodes, or
@@ -32,6 +33,7 @@ type LogStreamProxyParams struct {
 // is provided.
 type LogStreamProxy struct {
 	conn            *nats.Conn
+	subjectPrefix   string
 	streamingClient *stream.ConsumerClient
 }
 
@@ -47,6 +49,7 @@ func NewLogStreamProxy(params LogStreamProxyParams) (*LogStreamProxy, error) {
 	}
 	proxy := &LogStreamProxy{
 		conn:            params.Conn,
+		subjectPrefix:   params.SubjectPrefix,
 		streamingClient: sc,
 	}
 	return proxy, nil
@@ -56,9 +59,10 @@ func (p *LogStreamProxy) GetLogStream(ctx context.Context, request messages.Exec
 	<-chan *concurrency.AsyncResult[models.ExecutionLog], error) {
 	return proxyStreamingRequest[messages.ExecutionLogsRequest, models.ExecutionLog](
 		ctx, p.streamingClient, &BaseRequest[messages.ExecutionLogsRequest]{
-			TargetNodeID: request.NodeID,
-			Method:       ExecutionLogs,
-			Body:         request,
+			SubjectPrefix: p.subjectPrefix,
+			TargetNodeID:  request.NodeID,
+			Method:        ExecutionLogs,
+			Body:          request,
 		})
 }
 
@@ -16,22 +16,25 @@ import (
 
 type ManagementHandlerParams struct {
 	Conn               *nats.Conn
+	SubjectPrefix      string
 	ManagementEndpoint bprotocol.ManagementEndpoint
 }
 
 // Management handles NATS legacy for cluster management
 type ManagementHandler struct {
-	conn     *nats.Conn
-	endpoint bprotocol.ManagementEndpoint
+	conn          *nats.Conn
+	subjectPrefix string
+	endpoint      bprotocol.ManagementEndpoint
 }
 
 func NewManagementHandler(params ManagementHandlerParams) (*ManagementHandler, error) {
 	handler := &ManagementHandler{
-		conn:     params.Conn,
-		endpoint: params.ManagementEndpoint,
+		conn:          params.Conn,
+		subjectPrefix: params.SubjectPrefix,
+		endpoint:      params.ManagementEndpoint,
 	}
 
-	subject := managementSubscribeSubject()
+	subject := managementSubscribeSubject(handler.subjectPrefix)
 	_, err := handler.conn.Subscribe(subject, func(m *nats.Msg) {
 		handler.handle(m)
 	})
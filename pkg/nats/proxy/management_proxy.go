@@ -27,19 +27,22 @@ type managementResponse interface {
 }
 
 type ManagementProxyParams struct {
-	Conn *nats.Conn
+	Conn          *nats.Conn
+	SubjectPrefix string
 }
 
 // type ManagementProxy is a proxy for a compute node to register itself with a requester node.
 type ManagementProxy struct {
-	conn *nats.Conn
+	conn          *nats.Conn
+	subjectPrefix string
 }
 
 // NewRegistrationProxy creates a new RegistrationProxy for the local compute node
 // bound to a provided NATS connection.
 func NewManagementProxy(params ManagementProxyParams) *ManagementProxy {
 	return &ManagementProxy{
-		conn: params.Conn,
+		conn:          params.Conn,
+		subjectPrefix: params.SubjectPrefix,
 	}
 }
 
@@ -52,7 +55,7 @@ func (p *ManagementProxy) Register(ctx context.Context,
 	var asyncRes *concurrency.AsyncResult[legacy.RegisterResponse]
 
 	asyncRes, err = send[legacy.RegisterRequest, legacy.RegisterResponse](
-		ctx, p.conn, request.Info.ID(), request, RegisterNode)
+		ctx, p.conn, p.subjectPrefix, request.Info.ID(), request, RegisterNode)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to send response to registration request")
@@ -70,7 +73,7 @@ func (p *ManagementProxy) UpdateInfo(ctx context.Context,
 	var asyncRes *concurrency.AsyncResult[legacy.UpdateInfoResponse]
 
 	asyncRes, err = send[legacy.UpdateInfoRequest, legacy.UpdateInfoResponse](
-		ctx, p.conn, request.Info.NodeID, request, UpdateNodeInfo)
+		ctx, p.conn, p.subjectPrefix, request.Info.NodeID, request, UpdateNodeInfo)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to send response to update info request")
@@ -87,7 +90,7 @@ func (p *ManagementProxy) UpdateResources(ctx context.Context,
 	var asyncRes *concurrency.AsyncResult[legacy.UpdateResourcesResponse]
 
 	asyncRes, err = send[legacy.UpdateResourcesRequest, legacy.UpdateResourcesResponse](
-		ctx, p.conn, request.NodeID, request, UpdateResources)
+		ctx, p.conn, p.subjectPrefix, request.NodeID, request, UpdateResources)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to send response to update resources request")
@@ -102,6 +105,7 @@ func (p *ManagementProxy) UpdateResources(ctx context.Context,
 func send[Q managementRequest, R managementResponse](
 	ctx context.Context,
 	conn *nats.Conn,
+	subjectPrefix string,
 	nodeID string,
 	req Q, method string) (*concurrency.AsyncResult[R], error) {
 	data, err := json.Marshal(req)
@@ -110,7 +114,7 @@ func send[Q managementRequest, R managementResponse](
 		return nil, err
 	}
 
-	subject := managementPublishSubject(nodeID, method)
+	subject := managementPublishSubject(subjectPrefix, nodeID, method)
 	log.Ctx(ctx).Trace().Msgf("Sending %T request to subject %s", req, subject)
 
 	respMsg, err := conn.Request(subject, data, requestTimeout)
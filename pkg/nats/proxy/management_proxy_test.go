@@ -0,0 +1,105 @@
+//go:build unit || !integration
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages/legacy"
+	testutils "github.com/bacalhau-project/bacalhau/pkg/test/utils"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
+)
+
+// recordingEndpoint accepts every registration and records the registered node IDs.
+type recordingEndpoint struct {
+	mu         sync.Mutex
+	registered []string
+}
+
+func (e *recordingEndpoint) Register(
+	_ context.Context, request legacy.RegisterRequest) (*legacy.RegisterResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.registered = append(e.registered, request.Info.ID())
+	return &legacy.RegisterResponse{Accepted: true}, nil
+}
+
+func (e *recordingEndpoint) UpdateInfo(
+	_ context.Context, _ legacy.UpdateInfoRequest) (*legacy.UpdateInfoResponse, error) {
+	return &legacy.UpdateInfoResponse{Accepted: true}, nil
+}
+
+func (e *recordingEndpoint) UpdateResources(
+	_ context.Context, _ legacy.UpdateResourcesRequest) (*legacy.UpdateResourcesResponse, error) {
+	return &legacy.UpdateResourcesResponse{}, nil
+}
+
+func (e *recordingEndpoint) nodes() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.registered...)
+}
+
+type ManagementProxyTestSuite struct {
+	suite.Suite
+}
+
+func TestManagementProxyTestSuite(t *testing.T) {
+	suite.Run(t, new(ManagementProxyTestSuite))
+}
+
+// TestSubjectPrefixIsolatesClusters verifies that two clusters sharing a NATS server
+// with different subject prefixes only receive requests addressed to their own cluster.
+func (s *ManagementProxyTestSuite) TestSubjectPrefixIsolatesClusters() {
+	ctx := context.Background()
+	natsServer, conn := testutils.StartNats(s.T())
+	s.T().Cleanup(func() {
+		conn.Close()
+		natsServer.Shutdown()
+	})
+
+	endpointA := &recordingEndpoint{}
+	endpointB := &recordingEndpoint{}
+	_, err := NewManagementHandler(ManagementHandlerParams{
+		Conn:               conn,
+		SubjectPrefix:      "cluster.a",
+		ManagementEndpoint: endpointA,
+	})
+	s.Require().NoError(err)
+	_, err = NewManagementHandler(ManagementHandlerParams{
+		Conn:               conn,
+		SubjectPrefix:      "cluster.b",
+		ManagementEndpoint: endpointB,
+	})
+	s.Require().NoError(err)
+
+	proxyA := NewManagementProxy(ManagementProxyParams{Conn: conn, SubjectPrefix: "cluster.a"})
+	proxyB := NewManagementProxy(ManagementProxyParams{Conn: conn, SubjectPrefix: "cluster.b"})
+
+	resp, err := proxyA.Register(ctx, legacy.RegisterRequest{Info: models.NodeInfo{NodeID: "node-a", NodeType: models.NodeTypeCompute}})
+	s.Require().NoError(err)
+	s.True(resp.Accepted)
+	resp, err = proxyB.Register(ctx, legacy.RegisterRequest{Info: models.NodeInfo{NodeID: "node-b", NodeType: models.NodeTypeCompute}})
+	s.Require().NoError(err)
+	s.True(resp.Accepted)
+
+	s.Equal([]string{"node-a"}, endpointA.nodes())
+	s.Equal([]string{"node-b"}, endpointB.nodes())
+}
+
+// TestDefaultPrefixKeepsLegacySubjects verifies that the default prefix, whether configured
+// explicitly or not, keeps the subjects used by nodes that predate subject prefixes.
+func (s *ManagementProxyTestSuite) TestDefaultPrefixKeepsLegacySubjects() {
+	for _, prefix := range []string{"", nclprotocol.DefaultSubjectPrefix} {
+		s.Equal("node.management.>", managementSubscribeSubject(prefix))
+		s.Equal("node.compute.node-1.AskForBid/v1", computeEndpointPublishSubject(prefix, "node-1", AskForBid))
+		s.Equal("node.orchestrator.node-1.>", callbackSubscribeSubject(prefix, "node-1"))
+	}
+	s.Equal("cluster.a.node.management.>", managementSubscribeSubject("cluster.a"))
+	s.Equal("cluster.a.node.compute.node-1.AskForBid/v1", computeEndpointPublishSubject("cluster.a", "node-1", AskForBid))
+}
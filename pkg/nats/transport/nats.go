@@ -62,6 +62,17 @@ type NATSTransportConfig struct {
 
 	// Used to configure compute node nats client to require TLS connection
 	ComputeClientRequireTLS bool
+
+	// ExternalServer connects requester nodes to the NATS servers listed in Orchestrators,
+	// such as a managed NATS cluster, instead of embedding a NATS server
+	ExternalServer bool
+
+	// Authentication to an external NATS cluster, using either a credentials file
+	// containing a user JWT and nkey seed, a JWT file with an nkey seed file,
+	// or an nkey seed file alone
+	CredentialsFile string
+	JWTFile         string
+	NKeySeedFile    string
 }
 
 func (c *NATSTransportConfig) Validate() error {
@@ -73,7 +84,9 @@ func (c *NATSTransportConfig) Validate() error {
 			"node ID cannot contain any of the following characters: %s", reservedChars),
 	)
 
-	if c.IsRequesterNode {
+	if c.ExternalServer {
+		mErr = errors.Join(mErr, validate.IsNotEmpty(c.Orchestrators, "missing external NATS servers"))
+	} else if c.IsRequesterNode {
 		mErr = errors.Join(mErr, validate.IsGreaterThanZero(c.Port, "port %d must be greater than zero", c.Port))
 
 		// if cluster config is set, validate it
@@ -85,6 +98,14 @@ func (c *NATSTransportConfig) Validate() error {
 		mErr = errors.Join(mErr, validate.IsNotEmpty(c.Orchestrators, "missing orchestrators"))
 	}
 
+	if c.CredentialsFile != "" && (c.JWTFile != "" || c.NKeySeedFile != "") {
+		mErr = errors.Join(mErr,
+			fmt.Errorf("NATS credentials file cannot be set together with a JWT or nkey seed file"))
+	}
+	if c.JWTFile != "" && c.NKeySeedFile == "" {
+		mErr = errors.Join(mErr, fmt.Errorf("NATS JWT file requires an nkey seed file"))
+	}
+
 	serverCertProvided := c.ServerTLSCert != ""
 	serverKeyProvided := c.ServerTLSKey != ""
 
@@ -123,7 +144,7 @@ func NewNATSTransport(ctx context.Context,
 	}
 
 	var sm *nats_helper.ServerManager
-	if config.IsRequesterNode {
+	if config.IsRequesterNode && !config.ExternalServer {
 		var err error
 
 		// create nats server with servers acting as its cluster peers
@@ -266,12 +287,39 @@ func CreateClient(ctx context.Context, config *NATSTransportConfig) (*nats_helpe
 	if config.AuthSecret != "" {
 		clientOptions = append(clientOptions, nats.Token(config.AuthSecret))
 	}
+
+	authOption, err := externalAuthOption(config)
+	if err != nil {
+		return nil, err
+	}
+	if authOption != nil {
+		clientOptions = append(clientOptions, authOption)
+	}
 	return nats_helper.NewClientManager(ctx,
 		strings.Join(config.Orchestrators, ","),
 		clientOptions...,
 	)
 }
 
+// externalAuthOption returns the option authenticating to an external NATS cluster
+// with the configured credentials, or nil if none are configured
+func externalAuthOption(config *NATSTransportConfig) (nats.Option, error) {
+	switch {
+	case config.CredentialsFile != "":
+		return nats.UserCredentials(config.CredentialsFile), nil
+	case config.JWTFile != "":
+		return nats.UserCredentials(config.JWTFile, config.NKeySeedFile), nil
+	case config.NKeySeedFile != "":
+		option, err := nats.NkeyOptionFromSeed(config.NKeySeedFile)
+		if err != nil {
+			return nil, nats_helper.NewConfigurationError("invalid NATS nkey seed file %s: %s", config.NKeySeedFile, err)
+		}
+		return option, nil
+	default:
+		return nil, nil
+	}
+}
+
 // DebugInfoProviders returns the debug info of the NATS transport layer
 func (t *NATSTransport) DebugInfoProviders() []models.DebugInfoProvider {
 	var debugInfoProviders []models.DebugInfoProvider
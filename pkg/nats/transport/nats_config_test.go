@@ -148,6 +148,45 @@ func (suite *NATSTransportConfigSuite) TestValidate() {
 			},
 			expectedErrors: []string{"NATS ServerTLSTimeout must be a positive number, got: -1"},
 		},
+		{
+			name: "External Server in Requester Node without Port",
+			config: NATSTransportConfig{
+				NodeID:          "nodeID",
+				IsRequesterNode: true,
+				ExternalServer:  true,
+				Orchestrators:   []string{"nats://external:4222"},
+				CredentialsFile: "path/to/user.creds",
+			},
+			expectedErrors: nil,
+		},
+		{
+			name: "Missing External Servers",
+			config: NATSTransportConfig{
+				NodeID:          "nodeID",
+				IsRequesterNode: true,
+				ExternalServer:  true,
+			},
+			expectedErrors: []string{"missing external NATS servers"},
+		},
+		{
+			name: "Credentials File with Nkey Seed File",
+			config: NATSTransportConfig{
+				NodeID:          "nodeID",
+				Orchestrators:   []string{"orch1"},
+				CredentialsFile: "path/to/user.creds",
+				NKeySeedFile:    "path/to/user.nk",
+			},
+			expectedErrors: []string{"NATS credentials file cannot be set together with a JWT or nkey seed file"},
+		},
+		{
+			name: "JWT File without Nkey Seed File",
+			config: NATSTransportConfig{
+				NodeID:        "nodeID",
+				Orchestrators: []string{"orch1"},
+				JWTFile:       "path/to/user.jwt",
+			},
+			expectedErrors: []string{"NATS JWT file requires an nkey seed file"},
+		},
 	}
 
 	for _, tt := range tests {
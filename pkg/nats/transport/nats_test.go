@@ -0,0 +1,103 @@
+//go:build unit || !integration
+
+package transport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/network"
+)
+
+// ExternalServerSuite tests connecting to an external NATS server
+// authenticating with nkeys instead of embedding a NATS server
+type ExternalServerSuite struct {
+	suite.Suite
+	server   *server.Server
+	seedFile string
+}
+
+func (s *ExternalServerSuite) SetupTest() {
+	user, err := nkeys.CreateUser()
+	s.Require().NoError(err)
+	publicKey, err := user.PublicKey()
+	s.Require().NoError(err)
+	seed, err := user.Seed()
+	s.Require().NoError(err)
+
+	s.seedFile = filepath.Join(s.T().TempDir(), "user.nk")
+	s.Require().NoError(os.WriteFile(s.seedFile, seed, 0600))
+
+	port, err := network.GetFreePort()
+	s.Require().NoError(err)
+	opts := natstest.DefaultTestOptions
+	opts.Port = port
+	opts.Nkeys = []*server.NkeyUser{{Nkey: publicKey}}
+	s.server = natstest.RunServer(&opts)
+}
+
+func (s *ExternalServerSuite) TearDownTest() {
+	s.server.Shutdown()
+}
+
+func (s *ExternalServerSuite) TestRequesterNodeConnectsToExternalServer() {
+	ctx := context.Background()
+	transport, err := NewNATSTransport(ctx, &NATSTransportConfig{
+		NodeID:          "orchestrator",
+		IsRequesterNode: true,
+		ExternalServer:  true,
+		Orchestrators:   []string{s.server.ClientURL()},
+		NKeySeedFile:    s.seedFile,
+	})
+	s.Require().NoError(err)
+	defer transport.Close(ctx)
+
+	s.Nil(transport.natsServer, "no NATS server should be embedded")
+	s.Equal([]string{s.server.ClientURL()}, transport.Config.Orchestrators)
+
+	client, err := transport.CreateClient(ctx)
+	s.Require().NoError(err)
+	defer client.Close()
+	s.Equal(s.server.ClientURL(), client.ConnectedUrl())
+}
+
+func (s *ExternalServerSuite) TestConnectionWithoutCredentialsFails() {
+	ctx := context.Background()
+	transport, err := NewNATSTransport(ctx, &NATSTransportConfig{
+		NodeID:         "compute",
+		ExternalServer: true,
+		Orchestrators:  []string{s.server.ClientURL()},
+	})
+	s.Require().NoError(err)
+
+	_, err = transport.CreateClient(ctx)
+	s.Error(err)
+}
+
+func (s *ExternalServerSuite) TestInvalidSeedFile() {
+	invalidSeedFile := filepath.Join(s.T().TempDir(), "invalid.nk")
+	s.Require().NoError(os.WriteFile(invalidSeedFile, []byte("invalid"), 0600))
+
+	ctx := context.Background()
+	transport, err := NewNATSTransport(ctx, &NATSTransportConfig{
+		NodeID:         "compute",
+		ExternalServer: true,
+		Orchestrators:  []string{s.server.ClientURL()},
+		NKeySeedFile:   invalidSeedFile,
+	})
+	s.Require().NoError(err)
+
+	_, err = transport.CreateClient(ctx)
+	s.Error(err)
+}
+
+func TestExternalServerSuite(t *testing.T) {
+	suite.Run(t, new(ExternalServerSuite))
+}
@@ -14,12 +14,14 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/compute"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity/disk"
+	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity/system"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/env"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/logstream"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/sensors"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/store"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/store/boltdb"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/watchers"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
 	"github.com/bacalhau-project/bacalhau/pkg/executor"
 	executor_util "github.com/bacalhau-project/bacalhau/pkg/executor/util"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
@@ -91,7 +93,9 @@ func NewComputeNode(
 
 	// executor/backend
 	runningCapacityTracker := capacity.NewLocalTracker(capacity.LocalTrackerParams{
-		MaxCapacity: allocatedResources,
+		MaxCapacity:      allocatedResources,
+		CPUOvercommit:    cfg.BacalhauConfig.Compute.Overcommit.CPU,
+		MemoryOvercommit: cfg.BacalhauConfig.Compute.Overcommit.Memory,
 	})
 	enqueuedUsageTracker := capacity.NewLocalUsageTracker()
 
@@ -124,6 +128,10 @@ func NewComputeNode(
 		ResultsPath:            *resultsPath,
 		EnvResolver:            envResolver,
 		PortAllocator:          portAllocator,
+		LogForwarder: compute.NewLogForwarder(compute.LogForwarderParams{
+			Executors:  executors,
+			EventStore: executionStore.GetEventStore(),
+		}),
 	})
 
 	bufferRunner := compute.NewExecutorBuffer(compute.ExecutorBufferParams{
@@ -156,7 +164,8 @@ func NewComputeNode(
 		},
 	})
 
-	bidder := NewBidder(cfg,
+	bidder, err := NewBidder(ctx,
+		cfg,
 		allocatedResources,
 		publishers,
 		storages,
@@ -164,7 +173,11 @@ func NewComputeNode(
 		executionStore,
 		capacityCalculator,
 		envResolver,
+		nodeInfoProvider,
 	)
+	if err != nil {
+		return nil, err
+	}
 	baseEndpoint := compute.NewBaseEndpoint(compute.BaseEndpointParams{
 		ExecutionStore: executionStore,
 	})
@@ -208,6 +221,7 @@ func NewComputeNode(
 	legacyConnectionManager, err := bprotocolcompute.NewConnectionManager(bprotocolcompute.Config{
 		NodeID:           cfg.NodeID,
 		ClientFactory:    clientFactory,
+		SubjectPrefix:    cfg.BacalhauConfig.NATS.SubjectPrefix,
 		NodeInfoProvider: nodeInfoProvider,
 		HeartbeatConfig:  cfg.BacalhauConfig.Compute.Heartbeat,
 		ComputeEndpoint:  baseEndpoint,
@@ -217,11 +231,27 @@ func NewComputeNode(
 		return nil, err
 	}
 
+	compression, err := parseMessageCompression(
+		cfg.BacalhauConfig.Compute.Compression, types.ComputeCompressionAlgorithmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := loadNodeKey(cfg.BacalhauConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node key: %w", err)
+	}
+
 	// connection manager
 	connectionManager, err := nclprotocolcompute.NewConnectionManager(nclprotocolcompute.Config{
 		NodeID:                  cfg.NodeID,
 		ClientFactory:           clientFactory,
+		SubjectPrefix:           cfg.BacalhauConfig.NATS.SubjectPrefix,
 		NodeInfoProvider:        nodeInfoProvider,
+		Compression:             compression,
+		CompressionThreshold:    cfg.BacalhauConfig.Compute.Compression.Threshold,
+		SigningKey:              signingKey,
+		RequireSignedMessages:   cfg.BacalhauConfig.Compute.RequireSignedMessages,
 		HeartbeatInterval:       cfg.BacalhauConfig.Compute.Heartbeat.Interval.AsTimeDuration(),
 		NodeInfoUpdateInterval:  cfg.BacalhauConfig.Compute.Heartbeat.InfoUpdateInterval.AsTimeDuration(),
 		DataPlaneMessageHandler: compute.NewMessageHandler(executionStore),
@@ -308,6 +338,7 @@ func (c *Compute) Cleanup(ctx context.Context) {
 }
 
 func NewBidder(
+	ctx context.Context,
 	cfg NodeConfig,
 	allocatedResources models.Resources,
 	publishers publisher.PublisherProvider,
@@ -316,9 +347,19 @@ func NewBidder(
 	executionStore store.ExecutionStore,
 	calculator capacity.UsageCalculator,
 	envResolver compute.EnvVarResolver,
-) compute.Bidder {
+	nodeInfoProvider models.NodeInfoProvider,
+) (compute.Bidder, error) {
 	var semanticBidStrats []bidstrategy.SemanticBidStrategy
 	if cfg.SystemConfig.BidSemanticStrategy == nil {
+		bidPolicyStrategy, err := semantic.NewBidPolicyStrategy(semantic.BidPolicyStrategyParams{
+			Path:             cfg.BacalhauConfig.JobAdmissionControl.BidPolicyPath,
+			NodeInfoProvider: nodeInfoProvider,
+		})
+		if err != nil {
+			return compute.Bidder{}, bacerrors.Wrap(err, "failed to create bid policy strategy").
+				WithCode(bacerrors.ConfigurationError)
+		}
+		go bidPolicyStrategy.Start(ctx)
 		semanticBidStrats = []bidstrategy.SemanticBidStrategy{
 			semantic.NewNetworkingStrategy(cfg.BacalhauConfig.JobAdmissionControl.RejectNetworkedJobs ||
 				!cfg.BacalhauConfig.JobAdmissionControl.AcceptNetworkedJobs),
@@ -340,6 +381,7 @@ func NewBidder(
 			semantic.NewExternalHTTPStrategy(semantic.ExternalHTTPStrategyParams{
 				URL: cfg.BacalhauConfig.JobAdmissionControl.ProbeHTTP,
 			}),
+			bidPolicyStrategy,
 			executor_util.NewExecutorSpecificBidStrategy(executors),
 			semantic.NewEnvResolverStrategy(semantic.EnvResolverStrategyParams{
 				Resolver: envResolver,
@@ -357,6 +399,21 @@ func NewBidder(
 			}),
 			executor_util.NewExecutorSpecificBidStrategy(executors),
 		}
+		threshold := cfg.BacalhauConfig.Compute.Overcommit.MemoryPressureThreshold
+		if threshold < 0 || threshold > 1 {
+			return compute.Bidder{}, bacerrors.New("memory pressure threshold must be between 0 and 1, got %f", threshold).
+				WithCode(bacerrors.ConfigurationError)
+		}
+		if threshold > 0 {
+			executionDir, err := cfg.BacalhauConfig.ExecutionDir()
+			if err != nil {
+				return compute.Bidder{}, err
+			}
+			resourceBidStrats = append(resourceBidStrats, resource.NewMemoryPressureStrategy(resource.MemoryPressureStrategyParams{
+				Provider:  system.NewPhysicalCapacityProvider(executionDir),
+				Threshold: threshold,
+			}))
+		}
 	} else {
 		resourceBidStrats = []bidstrategy.ResourceBidStrategy{cfg.SystemConfig.BidResourceStrategy}
 	}
@@ -366,7 +423,7 @@ func NewBidder(
 		ResourceStrategy: resourceBidStrats,
 		UsageCalculator:  calculator,
 		Store:            executionStore,
-	})
+	}), nil
 }
 
 func setupComputeWatchers(
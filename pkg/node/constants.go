@@ -16,4 +16,8 @@ const (
 	// orchestratorExecutionLoggerWatcherID is the ID of the watcher that listens for execution events
 	// and logs them.
 	orchestratorExecutionLoggerWatcherID = "orchestrator-logger"
+
+	// orchestratorUsageCollectorWatcherID is the ID of the watcher that listens for execution events
+	// and accounts the resource usage of finished executions.
+	orchestratorUsageCollectorWatcherID = "usage-collector"
 )
@@ -62,10 +62,21 @@ func NewStandardStorageProvidersFactory(cfg types.Bacalhau) StorageProvidersFact
 func NewStandardExecutorsFactory(cfg types.EngineConfig) ExecutorsFactory {
 	return ExecutorsFactoryFunc(
 		func(ctx context.Context, nodeConfig NodeConfig) (executor.ExecProvider, error) {
+			// compiled wasm modules are only cached when the node has a data dir to store them in
+			var wasmCacheDir string
+			if nodeConfig.BacalhauConfig.DataDir != "" {
+				var err error
+				wasmCacheDir, err = nodeConfig.BacalhauConfig.WASMCompilationCacheDir()
+				if err != nil {
+					return nil, err
+				}
+			}
 			pr, err := executor_util.NewStandardExecutorProvider(
 				cfg,
 				executor_util.StandardExecutorOptions{
-					DockerID: fmt.Sprintf("bacalhau-%s", nodeConfig.NodeID),
+					DockerID:                fmt.Sprintf("bacalhau-%s", nodeConfig.NodeID),
+					Overcommit:              nodeConfig.BacalhauConfig.Compute.Overcommit,
+					WASMCompilationCacheDir: wasmCacheDir,
 				},
 			)
 			if err != nil {
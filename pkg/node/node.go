@@ -343,6 +343,15 @@ func createTransport(ctx context.Context, cfg NodeConfig) (*nats_transport.NATST
 		config.AuthSecret = cfg.BacalhauConfig.Compute.Auth.Token
 	}
 
+	// connect to an external NATS cluster instead of embedding a NATS server
+	if natsConfig := cfg.BacalhauConfig.NATS; len(natsConfig.Servers) > 0 {
+		config.ExternalServer = true
+		config.Orchestrators = natsConfig.Servers
+		config.CredentialsFile = natsConfig.CredentialsFile
+		config.JWTFile = natsConfig.JWTFile
+		config.NKeySeedFile = natsConfig.NKeySeedFile
+	}
+
 	transportLayer, err := nats_transport.NewNATSTransport(ctx, config)
 	if err != nil {
 		return nil, bacerrors.Wrap(err, "failed to create transport layer")
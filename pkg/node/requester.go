@@ -10,8 +10,10 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	boltjobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/boltdb"
+	sqlitejobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/sqlite"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	natsutil "github.com/bacalhau-project/bacalhau/pkg/nats"
@@ -19,21 +21,30 @@ import (
 	nats_transport "github.com/bacalhau-project/bacalhau/pkg/nats/transport"
 	"github.com/bacalhau-project/bacalhau/pkg/node/metrics"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+	boltusagestore "github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting/boltdb"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/backup"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/evaluation"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
+	boltlogstore "github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore/boltdb"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes/kvstore"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/planner"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/retention"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/retry"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/scheduler"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/selection/discovery"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/selection/ranking"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/selection/selector"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
+	bolttemplatestore "github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates/boltdb"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/transformer"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/watchers"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi"
 	auth_endpoint "github.com/bacalhau-project/bacalhau/pkg/publicapi/endpoint/auth"
 	orchestrator_endpoint "github.com/bacalhau-project/bacalhau/pkg/publicapi/endpoint/orchestrator"
 	requester_endpoint "github.com/bacalhau-project/bacalhau/pkg/publicapi/endpoint/requester"
+	"github.com/bacalhau-project/bacalhau/pkg/repo"
 	s3helper "github.com/bacalhau-project/bacalhau/pkg/s3"
 	"github.com/bacalhau-project/bacalhau/pkg/system"
 	bprotocolorchestrator "github.com/bacalhau-project/bacalhau/pkg/transport/bprotocol/orchestrator"
@@ -152,6 +163,8 @@ func NewRequesterNode(
 		RetryStrategy: retryStrategy,
 		QueueBackoff:  cfg.BacalhauConfig.Orchestrator.Scheduler.QueueBackoff.AsTimeDuration(),
 		RateLimiter:   executionRateLimiter,
+		BidTimeout:    cfg.BacalhauConfig.Orchestrator.Scheduler.BidTimeout.AsTimeDuration(),
+		BidOverAsk:    cfg.BacalhauConfig.Orchestrator.Scheduler.BidOverAsk,
 	})
 	schedulerProvider := orchestrator.NewMappedSchedulerProvider(map[string]orchestrator.Scheduler{
 		models.JobTypeBatch:   batchServiceJobScheduler,
@@ -211,16 +224,34 @@ func NewRequesterNode(
 	}
 
 	logStreamProxy, err := proxy.NewLogStreamProxy(proxy.LogStreamProxyParams{
-		Conn: natsConn,
+		Conn:          natsConn,
+		SubjectPrefix: cfg.BacalhauConfig.NATS.SubjectPrefix,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	logStore, err := createLogStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	templateStore, err := createTemplateStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usageStore, err := createUsageStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	endpointV2 := orchestrator.NewBaseEndpoint(&orchestrator.BaseEndpointParams{
 		ID:                nodeID,
 		Store:             jobStore,
 		LogstreamServer:   logStreamProxy,
+		LogStore:          logStore,
+		TemplateStore:     templateStore,
 		JobTransformer:    jobTransformers,
 		ResultTransformer: resultTransformers,
 	})
@@ -229,25 +260,49 @@ func NewRequesterNode(
 		JobStore:      jobStore,
 		Interval:      cfg.BacalhauConfig.Orchestrator.Scheduler.HousekeepingInterval.AsTimeDuration(),
 		TimeoutBuffer: cfg.BacalhauConfig.Orchestrator.Scheduler.HousekeepingTimeout.AsTimeDuration(),
+		LogStore:      logStore,
+		LogRetention:  cfg.BacalhauConfig.Orchestrator.ExecutionLogs.Retention.AsTimeDuration(),
+		NodeLookup:    nodesManager,
 	})
 	if err != nil {
 		return nil, err
 	}
 	housekeeping.Start(ctx)
 
+	jobRetention, err := createJobRetention(cfg, jobStore)
+	if err != nil {
+		return nil, err
+	}
+	jobRetention.Start(ctx)
+
 	// register debug info providers for the /debug endpoint
 	debugInfoProviders := []models.DebugInfoProvider{
 		discovery.NewDebugInfoProvider(nodesManager),
+		jobRetention,
 	}
 
 	// TODO: delete this when we are ready to stop serving a deprecation notice.
 	requester_endpoint.NewEndpoint(apiServer.Router)
 
-	orchestrator_endpoint.NewEndpoint(orchestrator_endpoint.EndpointParams{
-		Router:       apiServer.Router,
-		Orchestrator: endpointV2,
+	snapshotter, err := backup.NewSnapshotter(backup.SnapshotterParams{
 		JobStore:     jobStore,
-		NodeManager:  nodesManager,
+		JobStoreType: jobStoreType(cfg),
+		NodeStore:    nodesManager,
+		RepoVersion:  repo.LatestVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orchestrator_endpoint.NewEndpoint(orchestrator_endpoint.EndpointParams{
+		Router:        apiServer.Router,
+		Orchestrator:  endpointV2,
+		JobStore:      jobStore,
+		NodeManager:   nodesManager,
+		TemplateStore: templateStore,
+		JobRetention:  jobRetention,
+		Snapshotter:   snapshotter,
+		UsageStore:    usageStore,
 	})
 
 	authenticators, err := cfg.DependencyInjector.AuthenticatorsFactory.Get(ctx, cfg)
@@ -263,6 +318,7 @@ func NewRequesterNode(
 	legacyConnectionManager, err := bprotocolorchestrator.NewConnectionManager(bprotocolorchestrator.Config{
 		NodeID:         nodeID,
 		NatsConn:       natsConn,
+		SubjectPrefix:  cfg.BacalhauConfig.NATS.SubjectPrefix,
 		NodeManager:    nodesManager,
 		EventStore:     jobStore.GetEventStore(),
 		ProtocolRouter: protocolRouter,
@@ -275,16 +331,34 @@ func NewRequesterNode(
 		return nil, pkgerrors.Wrap(err, "failed to start connection manager")
 	}
 
+	compression, err := parseMessageCompression(
+		cfg.BacalhauConfig.Orchestrator.Compression, types.OrchestratorCompressionAlgorithmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := loadNodeKey(cfg.BacalhauConfig)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to load node key")
+	}
+
 	// connection manager
 	connectionManager, err := transportorchestrator.NewComputeManager(transportorchestrator.Config{
 		NodeID:                  cfg.NodeID,
 		ClientFactory:           natsutil.ClientFactoryFunc(transportLayer.CreateClient),
+		SubjectPrefix:           cfg.BacalhauConfig.NATS.SubjectPrefix,
 		NodeManager:             nodesManager,
+		Compression:             compression,
+		CompressionThreshold:    cfg.BacalhauConfig.Orchestrator.Compression.Threshold,
+		SigningKey:              signingKey,
+		RequireSignedMessages:   cfg.BacalhauConfig.Orchestrator.RequireSignedMessages,
 		HeartbeatTimeout:        cfg.BacalhauConfig.Orchestrator.NodeManager.DisconnectTimeout.AsTimeDuration(),
-		DataPlaneMessageHandler: orchestrator.NewMessageHandler(jobStore),
+		DataPlaneMessageHandler: orchestrator.NewMessageHandler(jobStore, logStore),
 		DataPlaneMessageCreatorFactory: watchers.NewNCLMessageCreatorFactory(watchers.NCLMessageCreatorFactoryParams{
 			ProtocolRouter: protocolRouter,
-			SubjectFn:      nclprotocol.NatsSubjectComputeInMsgs,
+			SubjectFn: func(nodeID string) string {
+				return nclprotocol.NatsSubjectComputeInMsgs(cfg.BacalhauConfig.NATS.SubjectPrefix, nodeID)
+			},
 		}),
 		EventStore: jobStore.GetEventStore(),
 	})
@@ -296,7 +370,7 @@ func NewRequesterNode(
 		return nil, fmt.Errorf("failed to start connection manager: %w", err)
 	}
 
-	watcherRegistry, err := setupOrchestratorWatchers(ctx, jobStore, evalBroker)
+	watcherRegistry, err := setupOrchestratorWatchers(ctx, jobStore, evalBroker, usageStore)
 	if err != nil {
 		return nil, err
 	}
@@ -316,8 +390,9 @@ func NewRequesterNode(
 			logDebugIfContextCancelled(ctx, cleanupErr, "failed to stop watcher registry")
 		}
 
-		// stop the housekeeping background task
+		// stop the housekeeping background tasks
 		housekeeping.Stop(ctx)
+		jobRetention.Stop(ctx)
 		for _, worker := range workers {
 			worker.Stop()
 		}
@@ -329,6 +404,23 @@ func NewRequesterNode(
 			logDebugIfContextCancelled(ctx, cleanupErr, "failed to cleanly shutdown jobstore")
 		}
 
+		// Close the log store after the watchers writing to it are stopped
+		if logStore != nil {
+			if cleanupErr = logStore.Close(ctx); cleanupErr != nil {
+				logDebugIfContextCancelled(ctx, cleanupErr, "failed to cleanly shutdown log store")
+			}
+		}
+
+		cleanupErr = templateStore.Close(ctx)
+		if cleanupErr != nil {
+			logDebugIfContextCancelled(ctx, cleanupErr, "failed to cleanly shutdown template store")
+		}
+
+		// Close the usage store after the watchers writing to it are stopped
+		if cleanupErr = usageStore.Close(ctx); cleanupErr != nil {
+			logDebugIfContextCancelled(ctx, cleanupErr, "failed to cleanly shutdown usage store")
+		}
+
 		// stop node manager
 		cleanupErr = nodesManager.Stop(ctx)
 		if cleanupErr != nil {
@@ -362,6 +454,8 @@ func createNodeRanker(cfg NodeConfig, jobStore jobstore.Store) (orchestrator.Nod
 		overSubscriptionNodeRanker,
 		ranking.NewMinVersionNodeRanker(ranking.MinVersionNodeRankerParams{MinVersion: minBacalhauVersion}),
 		ranking.NewPreviousExecutionsNodeRanker(ranking.PreviousExecutionsNodeRankerParams{JobStore: jobStore}),
+		ranking.NewSpreadNodeRanker(ranking.SpreadNodeRankerParams{JobStore: jobStore}),
+		ranking.NewAffinityNodeRanker(ranking.AffinityNodeRankerParams{JobStore: jobStore}),
 		ranking.NewAvailableCapacityNodeRanker(),
 		// arbitrary rankers
 		ranking.NewRandomNodeRanker(ranking.RandomNodeRankerParams{
@@ -371,16 +465,124 @@ func createNodeRanker(cfg NodeConfig, jobStore jobstore.Store) (orchestrator.Nod
 	return nodeRankerChain, nil
 }
 
+// jobStoreType returns the database type configured for the job store of the orchestrator
+func jobStoreType(cfg NodeConfig) string {
+	if storeType := cfg.BacalhauConfig.Orchestrator.JobStore.Type; storeType != "" {
+		return storeType
+	}
+	return types.JobStoreTypeBoltDB
+}
+
+// createJobStore creates the job store backed by the database type configured for the orchestrator
 func createJobStore(ct context.Context, cfg NodeConfig) (jobstore.Store, error) {
-	jobStoreDBPath, err := cfg.BacalhauConfig.JobStoreFilePath()
+	var jobStore jobstore.Store
+	switch storeType := jobStoreType(cfg); storeType {
+	case types.JobStoreTypeBoltDB:
+		jobStoreDBPath, err := cfg.BacalhauConfig.JobStoreFilePath()
+		if err != nil {
+			return nil, err
+		}
+		jobStore, err = boltjobstore.NewBoltJobStore(jobStoreDBPath)
+		if err != nil {
+			return nil, bacerrors.Wrap(err, "failed to create job store")
+		}
+	case types.JobStoreTypeSQLite:
+		jobStoreDBPath, err := cfg.BacalhauConfig.JobStoreSQLiteFilePath()
+		if err != nil {
+			return nil, err
+		}
+		jobStore, err = sqlitejobstore.NewSQLiteJobStore(jobStoreDBPath)
+		if err != nil {
+			return nil, bacerrors.Wrap(err, "failed to create job store")
+		}
+	default:
+		return nil, bacerrors.New("unsupported job store type %q", storeType).
+			WithHint("Set %s to %s or %s", types.OrchestratorJobStoreTypeKey,
+				types.JobStoreTypeBoltDB, types.JobStoreTypeSQLite).
+			WithCode(bacerrors.ConfigurationError)
+	}
+	return jobStore, nil
+}
+
+// createLogStore creates the store retaining execution logs on the orchestrator.
+// Returns a nil store if log retention is disabled.
+func createLogStore(cfg NodeConfig) (logstore.Store, error) {
+	logsConfig := cfg.BacalhauConfig.Orchestrator.ExecutionLogs
+	if !logsConfig.Enabled {
+		return nil, nil
+	}
+	logsDBPath, err := cfg.BacalhauConfig.ExecutionLogsFilePath()
 	if err != nil {
 		return nil, err
 	}
-	jobStore, err := boltjobstore.NewBoltJobStore(jobStoreDBPath)
+	logStore, err := boltlogstore.NewBoltLogStore(logsDBPath,
+		boltlogstore.WithMaxLinesPerExecution(logsConfig.MaxLinesPerExecution))
 	if err != nil {
-		return nil, bacerrors.Wrap(err, "failed to create job store")
+		return nil, bacerrors.Wrap(err, "failed to create execution log store")
 	}
-	return jobStore, nil
+	return logStore, nil
+}
+
+// createUsageStore creates the store accounting the resource usage of finished executions.
+func createUsageStore(cfg NodeConfig) (accounting.Store, error) {
+	usageDBPath, err := cfg.BacalhauConfig.UsageFilePath()
+	if err != nil {
+		return nil, err
+	}
+	usageStore, err := boltusagestore.NewBoltUsageStore(usageDBPath)
+	if err != nil {
+		return nil, bacerrors.Wrap(err, "failed to create usage store")
+	}
+	return usageStore, nil
+}
+
+func createTemplateStore(cfg NodeConfig) (templates.Store, error) {
+	templatesDBPath, err := cfg.BacalhauConfig.JobTemplatesFilePath()
+	if err != nil {
+		return nil, err
+	}
+	templateStore, err := bolttemplatestore.NewBoltTemplateStore(templatesDBPath)
+	if err != nil {
+		return nil, bacerrors.Wrap(err, "failed to create job template store")
+	}
+	return templateStore, nil
+}
+
+// createJobRetention creates the collector purging terminal jobs from the job store.
+// The collector is always created to serve manual prune requests, but only applies
+// the retention policy periodically if enabled.
+func createJobRetention(cfg NodeConfig, jobStore jobstore.Store) (*retention.Collector, error) {
+	retentionConfig := cfg.BacalhauConfig.Orchestrator.JobRetention
+	params := retention.CollectorParams{
+		JobStore: jobStore,
+		Policy: retention.Policy{
+			MaxAge:              retentionConfig.MaxAge.AsTimeDuration(),
+			MaxJobsPerNamespace: retentionConfig.MaxJobsPerNamespace,
+		},
+	}
+	if retentionConfig.Enabled {
+		params.Interval = retentionConfig.Interval.AsTimeDuration()
+	}
+	if retentionConfig.Archive {
+		archiveDir := retentionConfig.ArchiveDir
+		if archiveDir == "" {
+			var err error
+			if archiveDir, err = cfg.BacalhauConfig.JobArchiveDir(); err != nil {
+				return nil, err
+			}
+		}
+		archiver, err := retention.NewFileArchiver(archiveDir)
+		if err != nil {
+			return nil, bacerrors.Wrap(err, "failed to create job archiver")
+		}
+		params.Archiver = archiver
+		params.ArchiveDir = archiveDir
+	}
+	collector, err := retention.NewCollector(params)
+	if err != nil {
+		return nil, bacerrors.Wrap(err, "failed to create job retention collector")
+	}
+	return collector, nil
 }
 
 func createNodeManager(ctx context.Context,
@@ -389,13 +591,24 @@ func createNodeManager(ctx context.Context,
 	nodeInfoProvider models.DecoratorNodeInfoProvider,
 	natsConn *nats.Conn) (nodes.Manager, nodes.Store, error) {
 	nodeInfoStore, err := kvstore.NewNodeStore(ctx, kvstore.NodeStoreParams{
-		BucketName: kvstore.BucketNameCurrent,
+		BucketName: kvstore.BucketNameWithPrefix(cfg.BacalhauConfig.NATS.SubjectPrefix),
 		Client:     natsConn,
 	})
 	if err != nil {
 		return nil, nil, pkgerrors.Wrap(err, "failed to create node info store using NATS transport connection info")
 	}
 
+	// import the node states restored from a backup while the orchestrator was stopped
+	restoredNodeStatesPath, err := cfg.BacalhauConfig.RestoredNodeStatesFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	if imported, err := backup.ImportNodeStates(ctx, restoredNodeStatesPath, nodeInfoStore); err != nil {
+		return nil, nil, pkgerrors.Wrap(err, "failed to import restored node states")
+	} else if imported > 0 {
+		log.Ctx(ctx).Info().Msgf("Imported %d node states restored from a backup", imported)
+	}
+
 	nodeManager, err := nodes.NewManager(nodes.ManagerParams{
 		Store:                 nodeInfoStore,
 		NodeDisconnectedAfter: cfg.BacalhauConfig.Orchestrator.NodeManager.DisconnectTimeout.AsTimeDuration(),
@@ -420,6 +633,7 @@ func setupOrchestratorWatchers(
 	ctx context.Context,
 	jobStore jobstore.Store,
 	evalBroker orchestrator.EvaluationBroker,
+	usageStore accounting.Store,
 ) (watcher.Manager, error) {
 	watcherRegistry := watcher.NewManager(jobStore.GetEventStore())
 
@@ -452,6 +666,21 @@ func setupOrchestratorWatchers(
 		return nil, fmt.Errorf("failed to setup orchestrator logger watcher: %w", err)
 	}
 
+	// Set up usage collector watcher to account the resources of finished executions
+	_, err = watcherRegistry.Create(ctx, orchestratorUsageCollectorWatcherID,
+		watcher.WithHandler(watchers.NewUsageCollector(usageStore)),
+		watcher.WithAutoStart(),
+		watcher.WithInitialEventIterator(watcher.LatestIterator()),
+		watcher.WithRetryStrategy(watcher.RetryStrategySkip),
+		watcher.WithFilter(watcher.EventFilter{
+			ObjectTypes: []string{jobstore.EventObjectExecutionUpsert},
+			Operations:  []watcher.Operation{watcher.OperationUpdate},
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup usage collector watcher: %w", err)
+	}
+
 	return watcherRegistry, nil
 }
 
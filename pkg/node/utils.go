@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"net"
 	"os"
@@ -9,9 +10,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity"
 	"github.com/bacalhau-project/bacalhau/pkg/compute/capacity/system"
 	"github.com/bacalhau-project/bacalhau/pkg/config/types"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/crypto"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/util/closer"
 )
@@ -73,9 +77,32 @@ func getAllocatedResources(ctx context.Context, cfg types.Bacalhau, executionsPa
 	if err != nil {
 		return models.Resources{}, err
 	}
+	extended, err := getExtendedResources(ctx, cfg.Compute.ExtendedResources)
+	if err != nil {
+		return models.Resources{}, err
+	}
+	allocatedResources.Extended = extended
 	return allocatedResources, nil
 }
 
+// getExtendedResources returns the extended resources advertised by the node,
+// preferring the output of the discovery command over the configured resources.
+func getExtendedResources(ctx context.Context, cfg types.ExtendedResources) (models.ExtendedResources, error) {
+	if cfg.DiscoveryCommand != "" {
+		discovered, err := capacity.NewExtendedResourcesProvider(cfg.DiscoveryCommand).GetAvailableCapacity(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover extended resources: %w", err)
+		}
+		return discovered.Extended.Copy(), nil
+	}
+	for name := range cfg.Resources {
+		if err := models.ValidateExtendedResourceName(name); err != nil {
+			return nil, err
+		}
+	}
+	return models.ExtendedResources(cfg.Resources).Copy(), nil
+}
+
 func scaleCapacityByAllocation(systemCapacity models.Resources, scaler types.ResourceScaler) (models.Resources, error) {
 	// if the system capacity is zero we should fail as it means the compute node will be unable to accept any work.
 	if systemCapacity.IsZero() {
@@ -106,3 +133,25 @@ func logDebugIfContextCancelled(ctx context.Context, cleanupErr error, msg strin
 		log.Ctx(ctx).Debug().Err(cleanupErr).Msgf("Context canceled: %s", msg)
 	}
 }
+
+// parseMessageCompression returns the compression algorithm configured for messages
+// sent between orchestrator and compute nodes, reporting the config key if it is invalid
+func parseMessageCompression(cfg types.MessageCompression, key string) (envelope.Compression, error) {
+	compression, err := envelope.ParseCompression(cfg.Algorithm)
+	if err != nil {
+		return envelope.CompressionNone, bacerrors.Wrap(err, "invalid message compression algorithm").
+			WithHint("Set %s to zstd, gzip or none", key).
+			WithCode(bacerrors.ConfigurationError)
+	}
+	return compression, nil
+}
+
+// loadNodeKey loads the key signing messages exchanged between orchestrator
+// and compute nodes, creating it in the data dir on first use
+func loadNodeKey(cfg types.Bacalhau) (ed25519.PrivateKey, error) {
+	path, err := cfg.NodeKeyPath()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.LoadEd25519KeyFile(path)
+}
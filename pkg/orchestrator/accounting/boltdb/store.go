@@ -0,0 +1,154 @@
+package boltusagestore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/boltdblib"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+)
+
+const (
+	BucketDays       = "days"
+	BucketExecutions = "executions"
+)
+
+type BoltUsageStore struct {
+	database *bolt.DB
+}
+
+// NewBoltUsageStore creates a new usage store where data is held in buckets.
+// Records are partitioned by the day they are accounted for, so that reports
+// only read the days they cover. Data is structured as follows
+//
+// bucket days
+//
+//	bucket YYYY-MM-DD -> key executionID -> Record
+//
+// bucket executions -> key executionID -> YYYY-MM-DD
+func NewBoltUsageStore(dbPath string) (*BoltUsageStore, error) {
+	db, err := boltdblib.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{BucketDays, BucketExecutions} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return &BoltUsageStore{database: db}, nil
+}
+
+// Add persists the given records, replacing previous records of the same executions.
+func (s *BoltUsageStore) Add(ctx context.Context, records ...accounting.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.database.Update(func(tx *bolt.Tx) error {
+		days := tx.Bucket([]byte(BucketDays))
+		executions := tx.Bucket([]byte(BucketExecutions))
+		for _, record := range records {
+			if record.ExecutionID == "" {
+				return fmt.Errorf("usage record is missing an execution ID")
+			}
+			executionID := []byte(record.ExecutionID)
+
+			// remove the previous record of the execution if it was accounted for another day
+			if previousDay := executions.Get(executionID); previousDay != nil && string(previousDay) != record.Day() {
+				if dayBucket := days.Bucket(previousDay); dayBucket != nil {
+					if err := dayBucket.Delete(executionID); err != nil {
+						return err
+					}
+				}
+			}
+
+			dayBucket, err := days.CreateBucketIfNotExists([]byte(record.Day()))
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err = dayBucket.Put(executionID, data); err != nil {
+				return err
+			}
+			if err = executions.Put(executionID, []byte(record.Day())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Query returns the records matching the query, only reading the days within its time bounds.
+func (s *BoltUsageStore) Query(ctx context.Context, query accounting.Query) ([]accounting.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var records []accounting.Record
+	err := s.database.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket([]byte(BucketDays)).Cursor()
+		var day []byte
+		if query.Since.IsZero() {
+			day, _ = cursor.First()
+		} else {
+			day, _ = cursor.Seek([]byte(query.Since.UTC().Format(time.DateOnly)))
+		}
+		lastDay := ""
+		if !query.Until.IsZero() {
+			lastDay = query.Until.UTC().Format(time.DateOnly)
+		}
+
+		for ; day != nil; day, _ = cursor.Next() {
+			if lastDay != "" && string(day) > lastDay {
+				break
+			}
+			dayBucket := tx.Bucket([]byte(BucketDays)).Bucket(day)
+			if dayBucket == nil {
+				continue
+			}
+			if err := dayBucket.ForEach(func(_, v []byte) error {
+				var record accounting.Record
+				if err := json.Unmarshal(v, &record); err != nil {
+					return err
+				}
+				if query.Matches(record) {
+					records = append(records, record)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Close closes the underlying database.
+func (s *BoltUsageStore) Close(ctx context.Context) error {
+	log.Ctx(ctx).Debug().Msg("closing bolt-backed usage store")
+	return s.database.Close()
+}
+
+// compile time check
+var _ accounting.Store = (*BoltUsageStore)(nil)
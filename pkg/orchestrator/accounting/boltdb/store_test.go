@@ -0,0 +1,98 @@
+//go:build unit || !integration
+
+package boltusagestore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+)
+
+type BoltUsageStoreTestSuite struct {
+	suite.Suite
+	store *BoltUsageStore
+	ctx   context.Context
+}
+
+func TestBoltUsageStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BoltUsageStoreTestSuite))
+}
+
+func (s *BoltUsageStoreTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	var err error
+	s.store, err = NewBoltUsageStore(filepath.Join(s.T().TempDir(), "usage.db"))
+	s.Require().NoError(err)
+}
+
+func (s *BoltUsageStoreTestSuite) TearDownTest() {
+	s.Require().NoError(s.store.Close(s.ctx))
+}
+
+func (s *BoltUsageStoreTestSuite) record(execID, namespace string, endTime time.Time) accounting.Record {
+	return accounting.Record{
+		ExecutionID: execID,
+		JobID:       "job1",
+		Namespace:   namespace,
+		EndTime:     endTime,
+		Duration:    time.Minute,
+	}
+}
+
+func (s *BoltUsageStoreTestSuite) executionIDs(records []accounting.Record) []string {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.ExecutionID
+	}
+	return ids
+}
+
+func (s *BoltUsageStoreTestSuite) TestQueryFilters() {
+	day1 := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	day3 := day2.Add(24 * time.Hour)
+	s.Require().NoError(s.store.Add(s.ctx,
+		s.record("e1", "default", day1),
+		s.record("e2", "team-a", day2),
+		s.record("e3", "default", day2.Add(time.Hour)),
+		s.record("e4", "default", day3),
+	))
+
+	all, err := s.store.Query(s.ctx, accounting.Query{})
+	s.Require().NoError(err)
+	s.Equal([]string{"e1", "e2", "e3", "e4"}, s.executionIDs(all))
+
+	byNamespace, err := s.store.Query(s.ctx, accounting.Query{Namespace: "default"})
+	s.Require().NoError(err)
+	s.Equal([]string{"e1", "e3", "e4"}, s.executionIDs(byNamespace))
+
+	byTime, err := s.store.Query(s.ctx, accounting.Query{Since: day2.Add(time.Minute), Until: day3})
+	s.Require().NoError(err)
+	s.Equal([]string{"e3", "e4"}, s.executionIDs(byTime))
+}
+
+func (s *BoltUsageStoreTestSuite) TestAddReplacesExecution() {
+	day1 := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+	s.Require().NoError(s.store.Add(s.ctx, s.record("e1", "default", day1)))
+
+	// the same execution is recorded again, ending on the next day
+	replaced := s.record("e1", "default", day1.Add(2*time.Minute))
+	replaced.Duration = time.Hour
+	s.Require().NoError(s.store.Add(s.ctx, replaced))
+
+	records, err := s.store.Query(s.ctx, accounting.Query{})
+	s.Require().NoError(err)
+	s.Require().Len(records, 1)
+	s.Equal(time.Hour, records[0].Duration)
+	s.Equal("2024-03-02", records[0].Day())
+}
+
+func (s *BoltUsageStoreTestSuite) TestAddRequiresExecutionID() {
+	s.Error(s.store.Add(s.ctx, s.record("", "default", time.Now())))
+}
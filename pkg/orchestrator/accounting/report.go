@@ -0,0 +1,70 @@
+package accounting
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+const bytesPerGiB = 1 << 30
+
+// Aggregate sums the usage of the records into a row for each distinct combination
+// of group-by values. Rows are sorted by their group values, in the order of the keys.
+// Records without a grouped label are grouped under an empty value.
+func Aggregate(records []Record, groupBy []string) []*models.UsageReportRow {
+	rows := make(map[string]*models.UsageReportRow)
+	for _, record := range records {
+		group := make(map[string]string, len(groupBy))
+		values := make([]string, len(groupBy))
+		for i, key := range groupBy {
+			values[i] = groupValue(record, key)
+			group[key] = values[i]
+		}
+		id := strings.Join(values, "\x00")
+		row, ok := rows[id]
+		if !ok {
+			row = &models.UsageReportRow{Group: group}
+			rows[id] = row
+		}
+		row.Add(toReportRow(record))
+	}
+
+	result := make([]*models.UsageReportRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		for _, key := range groupBy {
+			if result[i].Group[key] != result[j].Group[key] {
+				return result[i].Group[key] < result[j].Group[key]
+			}
+		}
+		return false
+	})
+	return result
+}
+
+func groupValue(record Record, key string) string {
+	switch key {
+	case models.UsageGroupByNamespace:
+		return record.Namespace
+	case models.UsageGroupByJobType:
+		return record.JobType
+	case models.UsageGroupByDay:
+		return record.Day()
+	default:
+		return record.Labels[strings.TrimPrefix(key, models.UsageGroupByLabelPrefix)]
+	}
+}
+
+func toReportRow(record Record) models.UsageReportRow {
+	seconds := record.Duration.Seconds()
+	return models.UsageReportRow{
+		Executions:       1,
+		CPUSeconds:       record.Allocated.CPU * seconds,
+		MemoryGiBSeconds: float64(record.Allocated.Memory) / bytesPerGiB * seconds,
+		GPUSeconds:       (float64(record.Allocated.GPU) + record.Allocated.GPUShare) * seconds,
+		UsedCPUSeconds:   record.CPUTime.Seconds(),
+	}
+}
@@ -0,0 +1,103 @@
+//go:build unit || !integration
+
+package accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type AggregateTestSuite struct {
+	suite.Suite
+	records []Record
+}
+
+func TestAggregateTestSuite(t *testing.T) {
+	suite.Run(t, new(AggregateTestSuite))
+}
+
+func (s *AggregateTestSuite) SetupTest() {
+	day1 := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	s.records = []Record{
+		{
+			ExecutionID: "e1", Namespace: "default", JobType: models.JobTypeBatch,
+			Labels: map[string]string{"team": "ml"}, EndTime: day1, Duration: time.Minute,
+			Allocated: models.Resources{CPU: 2, Memory: 1 << 30, GPU: 1}, CPUTime: 30 * time.Second,
+		},
+		{
+			ExecutionID: "e2", Namespace: "default", JobType: models.JobTypeService,
+			Labels: map[string]string{"team": "web"}, EndTime: day2, Duration: time.Minute,
+			Allocated: models.Resources{CPU: 1, Memory: 2 << 30},
+		},
+		{
+			ExecutionID: "e3", Namespace: "default", JobType: models.JobTypeBatch,
+			Labels: map[string]string{"team": "ml"}, EndTime: day2, Duration: 2 * time.Minute,
+			Allocated: models.Resources{CPU: 0.5},
+		},
+		{
+			ExecutionID: "e4", Namespace: "other", JobType: models.JobTypeBatch,
+			EndTime: day2, Duration: time.Second, Allocated: models.Resources{CPU: 1},
+		},
+	}
+}
+
+func (s *AggregateTestSuite) TestGroupByNamespace() {
+	rows := Aggregate(s.records, []string{models.UsageGroupByNamespace})
+	s.Require().Len(rows, 2)
+
+	s.Equal(map[string]string{"namespace": "default"}, rows[0].Group)
+	s.Equal(uint64(3), rows[0].Executions)
+	s.InDelta(120+60+60, rows[0].CPUSeconds, 0.001)
+	s.InDelta(60+120, rows[0].MemoryGiBSeconds, 0.001)
+	s.InDelta(60, rows[0].GPUSeconds, 0.001)
+	s.InDelta(30, rows[0].UsedCPUSeconds, 0.001)
+
+	s.Equal(map[string]string{"namespace": "other"}, rows[1].Group)
+	s.Equal(uint64(1), rows[1].Executions)
+	s.InDelta(1, rows[1].CPUSeconds, 0.001)
+}
+
+func (s *AggregateTestSuite) TestGroupByLabelAndDay() {
+	rows := Aggregate(s.records, []string{"label:team", models.UsageGroupByDay})
+	s.Require().Len(rows, 4)
+
+	// records without the label are grouped under an empty value, sorted first
+	s.Equal(map[string]string{"label:team": "", "day": "2024-03-02"}, rows[0].Group)
+	s.Equal(map[string]string{"label:team": "ml", "day": "2024-03-01"}, rows[1].Group)
+	s.Equal(map[string]string{"label:team": "ml", "day": "2024-03-02"}, rows[2].Group)
+	s.InDelta(60, rows[2].CPUSeconds, 0.001)
+	s.Equal(map[string]string{"label:team": "web", "day": "2024-03-02"}, rows[3].Group)
+}
+
+func (s *AggregateTestSuite) TestGroupByJobType() {
+	rows := Aggregate(s.records, []string{models.UsageGroupByJobType})
+	s.Require().Len(rows, 2)
+	s.Equal(models.JobTypeBatch, rows[0].Group["type"])
+	s.Equal(uint64(3), rows[0].Executions)
+	s.Equal(models.JobTypeService, rows[1].Group["type"])
+}
+
+func (s *AggregateTestSuite) TestSharedGPU() {
+	records := []Record{
+		{
+			ExecutionID: "e1", Namespace: "default", JobType: models.JobTypeBatch, Duration: time.Minute,
+			Allocated: models.Resources{CPU: 1, GPUShare: 0.25},
+		},
+		{
+			ExecutionID: "e2", Namespace: "default", JobType: models.JobTypeBatch, Duration: time.Minute,
+			Allocated: models.Resources{CPU: 1, GPU: 2},
+		},
+	}
+	rows := Aggregate(records, []string{models.UsageGroupByNamespace})
+	s.Require().Len(rows, 1)
+	s.InDelta(15+120, rows[0].GPUSeconds, 0.001)
+}
+
+func (s *AggregateTestSuite) TestNoRecords() {
+	s.Empty(Aggregate(nil, models.DefaultUsageGroupBy))
+}
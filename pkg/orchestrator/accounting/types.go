@@ -0,0 +1,68 @@
+package accounting
+
+import (
+	"context"
+	"time"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Record is the resource usage of a single finished execution,
+// along with the attributes usage can be grouped by.
+type Record struct {
+	ExecutionID string            `json:"ExecutionID"`
+	JobID       string            `json:"JobID"`
+	Namespace   string            `json:"Namespace"`
+	JobType     string            `json:"JobType"`
+	Labels      map[string]string `json:"Labels,omitempty"`
+	// EndTime is when the execution reached a terminal state. Records are accounted
+	// for the UTC day they ended in.
+	EndTime time.Time `json:"EndTime"`
+	// Duration is how long the execution held its allocated resources.
+	Duration time.Duration `json:"Duration"`
+	// Allocated are the resources allocated to the execution.
+	Allocated models.Resources `json:"Allocated"`
+	// CPUTime is the CPU time actually consumed, if reported by the compute node.
+	CPUTime time.Duration `json:"CPUTime,omitempty"`
+}
+
+// Day returns the UTC day the record is accounted for, formatted as YYYY-MM-DD
+func (r Record) Day() string {
+	return r.EndTime.UTC().Format(time.DateOnly)
+}
+
+// Query describes which usage records to retrieve.
+// Zero values mean no filtering on that field.
+type Query struct {
+	// Since and Until bound the records' end time (inclusive).
+	Since     time.Time
+	Until     time.Time
+	Namespace string
+}
+
+// Matches returns true if the record satisfies the query
+func (q Query) Matches(record Record) bool {
+	if q.Namespace != "" && record.Namespace != q.Namespace {
+		return false
+	}
+	if !q.Since.IsZero() && record.EndTime.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && record.EndTime.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// Store persists the usage records of finished executions.
+type Store interface {
+	// Add persists the given records. Adding a record of an execution
+	// that was already recorded replaces it.
+	Add(ctx context.Context, records ...Record) error
+
+	// Query returns the records matching the query.
+	Query(ctx context.Context, query Query) ([]Record, error)
+
+	// Close releases any resources held by the store.
+	Close(ctx context.Context) error
+}
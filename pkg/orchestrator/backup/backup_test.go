@@ -0,0 +1,202 @@
+//go:build unit || !integration
+
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+	boltjobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/boltdb"
+	sqlitejobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/sqlite"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes/inmemory"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+)
+
+const testRepoVersion = 4
+
+type BackupTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	dir         string
+	clock       *clock.Mock
+	jobStore    *boltjobstore.BoltJobStore
+	nodeStore   *inmemory.NodeStore
+	snapshotter *Snapshotter
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
+
+func (s *BackupTestSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	s.dir = s.T().TempDir()
+	s.clock = clock.NewMock()
+	s.jobStore, err = boltjobstore.NewBoltJobStore(filepath.Join(s.dir, "jobs.boltdb"))
+	s.Require().NoError(err)
+	s.nodeStore = inmemory.NewNodeStore(inmemory.NodeStoreParams{TTL: time.Hour})
+	s.snapshotter, err = NewSnapshotter(SnapshotterParams{
+		JobStore:     s.jobStore,
+		JobStoreType: types.JobStoreTypeBoltDB,
+		NodeStore:    s.nodeStore,
+		RepoVersion:  testRepoVersion,
+		Clock:        s.clock,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *BackupTestSuite) TearDownTest() {
+	s.NoError(s.jobStore.Close(s.ctx))
+}
+
+// writeArchive writes a backup archive of the stores and opens it
+func (s *BackupTestSuite) writeArchive() *Archive {
+	path := filepath.Join(s.T().TempDir(), "backup.zip")
+	f, err := os.Create(path)
+	s.Require().NoError(err)
+	_, err = s.snapshotter.Write(s.ctx, f)
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+
+	archive, err := OpenArchive(path)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = archive.Close() })
+	return archive
+}
+
+func (s *BackupTestSuite) restoreParams() RestoreParams {
+	restoreDir := s.T().TempDir()
+	return RestoreParams{
+		RepoVersion:    testRepoVersion,
+		JobStorePath:   filepath.Join(restoreDir, "restored.boltdb"),
+		NodeStatesPath: filepath.Join(restoreDir, "nodes.json"),
+	}
+}
+
+func (s *BackupTestSuite) TestBackupAndRestore() {
+	job := mock.Job()
+	s.Require().NoError(s.jobStore.CreateJob(s.ctx, *job))
+	s.Require().NoError(s.jobStore.CreateEvaluation(s.ctx, *mock.EvalForJob(job)))
+	s.Require().NoError(s.jobStore.GetEventStore().StoreCheckpoint(s.ctx, "watcher", 1))
+	node := models.NodeState{Info: models.NodeInfo{NodeID: "node-1", NodeType: models.NodeTypeCompute}}
+	s.Require().NoError(s.nodeStore.Put(s.ctx, node))
+
+	archive := s.writeArchive()
+	s.Equal(Manifest{
+		FormatVersion:   FormatVersion,
+		RepoVersion:     testRepoVersion,
+		BacalhauVersion: archive.Manifest().BacalhauVersion,
+		CreateTime:      s.clock.Now().UTC(),
+		JobStoreType:    types.JobStoreTypeBoltDB,
+		Nodes:           1,
+	}, archive.Manifest())
+
+	params := s.restoreParams()
+	s.Require().NoError(archive.Restore(s.ctx, params))
+
+	// the restored job store holds the jobs and event log of the original one
+	restored, err := boltjobstore.NewBoltJobStore(params.JobStorePath)
+	s.Require().NoError(err)
+	defer restored.Close(s.ctx)
+
+	restoredJob, err := restored.GetJob(s.ctx, job.ID)
+	s.Require().NoError(err)
+	s.Equal(job.ID, restoredJob.ID)
+	checkpoint, err := restored.GetEventStore().GetCheckpoint(s.ctx, "watcher")
+	s.Require().NoError(err)
+	s.Equal(uint64(1), checkpoint)
+
+	// the restored node states are imported into the node store once
+	nodeStore := inmemory.NewNodeStore(inmemory.NodeStoreParams{TTL: time.Hour})
+	imported, err := ImportNodeStates(s.ctx, params.NodeStatesPath, nodeStore)
+	s.Require().NoError(err)
+	s.Equal(1, imported)
+	restoredNode, err := nodeStore.Get(s.ctx, node.Info.ID())
+	s.Require().NoError(err)
+	s.Equal(node.Info.NodeType, restoredNode.Info.NodeType)
+
+	imported, err = ImportNodeStates(s.ctx, params.NodeStatesPath, nodeStore)
+	s.Require().NoError(err)
+	s.Zero(imported)
+}
+
+func (s *BackupTestSuite) TestRestoreIncompatibleRepoVersion() {
+	archive := s.writeArchive()
+	params := s.restoreParams()
+	params.RepoVersion = testRepoVersion + 1
+
+	err := archive.Restore(s.ctx, params)
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.VersionMismatch))
+	s.NoFileExists(params.JobStorePath)
+}
+
+func (s *BackupTestSuite) TestRestoreExistingJobStore() {
+	archive := s.writeArchive()
+	params := s.restoreParams()
+	s.Require().NoError(os.WriteFile(params.JobStorePath, []byte("existing"), 0600))
+
+	err := archive.Restore(s.ctx, params)
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ValidationError))
+
+	// the job store is in use by the running orchestrator
+	params.JobStorePath = filepath.Join(s.dir, "jobs.boltdb")
+	params.Force = true
+	err = archive.Restore(s.ctx, params)
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ResourceInUse))
+}
+
+func (s *BackupTestSuite) TestRestoreSQLiteJobStoreInUse() {
+	path := filepath.Join(s.dir, "jobs.sqlite")
+	jobStore, err := sqlitejobstore.NewSQLiteJobStore(path)
+	s.Require().NoError(err)
+	snapshotter, err := NewSnapshotter(SnapshotterParams{
+		JobStore:     jobStore,
+		JobStoreType: types.JobStoreTypeSQLite,
+		NodeStore:    s.nodeStore,
+		RepoVersion:  testRepoVersion,
+		Clock:        s.clock,
+	})
+	s.Require().NoError(err)
+	s.snapshotter = snapshotter
+	archive := s.writeArchive()
+
+	// the job store is in use by the running orchestrator, even between transactions
+	params := s.restoreParams()
+	params.JobStorePath = path
+	params.Force = true
+	err = archive.Restore(s.ctx, params)
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ResourceInUse))
+
+	// the job store can be overwritten once the orchestrator is stopped
+	s.Require().NoError(jobStore.Close(s.ctx))
+	s.Require().NoError(archive.Restore(s.ctx, params))
+}
+
+func (s *BackupTestSuite) TestOpenTruncatedArchive() {
+	path := filepath.Join(s.T().TempDir(), "backup.zip")
+	f, err := os.Create(path)
+	s.Require().NoError(err)
+	_, err = s.snapshotter.Write(s.ctx, f)
+	s.Require().NoError(err)
+	info, err := f.Stat()
+	s.Require().NoError(err)
+	s.Require().NoError(f.Truncate(info.Size() / 2))
+	s.Require().NoError(f.Close())
+
+	_, err = OpenArchive(path)
+	s.Error(err)
+}
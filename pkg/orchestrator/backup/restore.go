@@ -0,0 +1,232 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/sqlitedblib"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
+)
+
+const errorComponent = "Backup"
+
+// lockTimeout is how long to wait for the lock of an existing job store,
+// which is held by the orchestrator while it is running
+const lockTimeout = 1 * time.Second
+
+// Archive is a backup archive opened to be restored
+type Archive struct {
+	reader   *zip.ReadCloser
+	manifest Manifest
+}
+
+// OpenArchive opens the backup archive at path and reads its manifest
+func OpenArchive(path string) (*Archive, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, bacerrors.Wrap(err, "failed to open backup archive %s", path).
+			WithCode(bacerrors.ValidationError).
+			WithComponent(errorComponent)
+	}
+	a := &Archive{reader: reader}
+	if err = a.readJSON(manifestFileName, &a.manifest); err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	if a.manifest.FormatVersion > FormatVersion {
+		_ = reader.Close()
+		return nil, bacerrors.New("backup archive format version %d is not supported", a.manifest.FormatVersion).
+			WithHint("Restore the backup with bacalhau %s or newer", a.manifest.BacalhauVersion).
+			WithCode(bacerrors.VersionMismatch).
+			WithComponent(errorComponent)
+	}
+	return a, nil
+}
+
+// Manifest returns the manifest of the archive
+func (a *Archive) Manifest() Manifest {
+	return a.manifest
+}
+
+// Close closes the archive
+func (a *Archive) Close() error {
+	return a.reader.Close()
+}
+
+// RestoreParams describes where the content of an archive is restored to
+type RestoreParams struct {
+	// RepoVersion is the version of the repo the archive is restored into,
+	// which must match the version of the repo the backup was taken from
+	RepoVersion int
+	// JobStorePath is the file the job store database is restored to
+	JobStorePath string
+	// NodeStatesPath is the file the node states are restored to, to be imported
+	// into the node store when the orchestrator starts
+	NodeStatesPath string
+	// Force overwrites an existing job store
+	Force bool
+}
+
+// Restore writes the job store database and node states held by the archive.
+// The orchestrator must be stopped while restoring.
+func (a *Archive) Restore(ctx context.Context, params RestoreParams) error {
+	if a.manifest.RepoVersion != params.RepoVersion {
+		return bacerrors.New("backup was taken from a repo at version %d and cannot be restored into a repo at version %d",
+			a.manifest.RepoVersion, params.RepoVersion).
+			WithHint("Restore the backup with bacalhau %s into a new data dir, then start the orchestrator "+
+				"with this version to migrate it", a.manifest.BacalhauVersion).
+			WithCode(bacerrors.VersionMismatch).
+			WithComponent(errorComponent)
+	}
+
+	if _, err := os.Stat(params.JobStorePath); err == nil {
+		if !params.Force {
+			return bacerrors.New("job store %s already exists", params.JobStorePath).
+				WithHint("Use --force to overwrite the existing job store").
+				WithCode(bacerrors.ValidationError).
+				WithComponent(errorComponent)
+		}
+		if err = a.checkNotInUse(ctx, params.JobStorePath); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return bacerrors.Wrap(err, "failed to read job store %s", params.JobStorePath).
+			WithCode(bacerrors.IOError).
+			WithComponent(errorComponent)
+	}
+
+	if err := a.restoreFile(ctx, jobStoreName, params.JobStorePath); err != nil {
+		return err
+	}
+	// write-ahead log files of a previous SQLite database would be applied to the restored one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(params.JobStorePath + suffix); err != nil && !os.IsNotExist(err) {
+			return bacerrors.Wrap(err, "failed to remove %s", params.JobStorePath+suffix).
+				WithCode(bacerrors.IOError).
+				WithComponent(errorComponent)
+		}
+	}
+
+	return a.restoreFile(ctx, nodeStatesName, params.NodeStatesPath)
+}
+
+// checkNotInUse returns an error if the existing job store is held by a running orchestrator
+func (a *Archive) checkNotInUse(ctx context.Context, path string) error {
+	switch a.manifest.JobStoreType {
+	case types.JobStoreTypeBoltDB:
+		db, err := bolt.Open(path, restoredFilePerm, &bolt.Options{ReadOnly: true, Timeout: lockTimeout})
+		if err != nil {
+			return inUseError(bacerrors.Wrap(err, "job store %s is in use", path))
+		}
+		return db.Close()
+	case types.JobStoreTypeSQLite:
+		inUse, err := sqlitedblib.InUse(ctx, path, lockTimeout)
+		if err != nil {
+			return bacerrors.Wrap(err, "failed to check whether job store %s is in use", path).
+				WithCode(bacerrors.IOError).
+				WithComponent(errorComponent)
+		}
+		if inUse {
+			return inUseError(bacerrors.New("job store %s is in use", path))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func inUseError(err bacerrors.Error) bacerrors.Error {
+	return err.
+		WithHint("Stop the orchestrator before restoring a backup").
+		WithCode(bacerrors.ResourceInUse).
+		WithComponent(errorComponent)
+}
+
+// restoreFile copies a file of the archive to path, replacing any existing file
+// only once the copy is complete
+func (a *Archive) restoreFile(ctx context.Context, name, path string) (err error) {
+	defer func() {
+		if err != nil {
+			err = bacerrors.Wrap(err, "failed to restore %s to %s", name, path).
+				WithCode(bacerrors.IOError).
+				WithComponent(errorComponent)
+		}
+	}()
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	src, err := a.reader.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	dst, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name()) //nolint:errcheck // removing the temp file fails once renamed
+
+	_, err = io.Copy(dst, src)
+	err = errors.Join(err, dst.Sync(), dst.Chmod(restoredFilePerm), dst.Close())
+	if err != nil {
+		return err
+	}
+	return os.Rename(dst.Name(), path)
+}
+
+func (a *Archive) readJSON(name string, v any) error {
+	f, err := a.reader.Open(name)
+	if err == nil {
+		defer f.Close()
+		err = json.NewDecoder(f).Decode(v)
+	}
+	if err != nil {
+		return bacerrors.Wrap(err, "failed to read %s of backup archive", name).
+			WithCode(bacerrors.ValidationError).
+			WithComponent(errorComponent)
+	}
+	return nil
+}
+
+// ImportNodeStates stores the node states restored to path into the node store,
+// and removes the file once imported. It returns the number of imported states,
+// which is zero if no node states were restored.
+func ImportNodeStates(ctx context.Context, path string, store nodes.Store) (int, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read restored node states: %w", err)
+	}
+
+	var nodeStates []models.NodeState
+	if err = json.Unmarshal(data, &nodeStates); err != nil {
+		return 0, fmt.Errorf("failed to read restored node states: %w", err)
+	}
+	for _, state := range nodeStates {
+		if err = store.Put(ctx, state); err != nil {
+			return 0, fmt.Errorf("failed to import restored state of node %s: %w", state.Info.ID(), err)
+		}
+	}
+	if err = os.Remove(path); err != nil {
+		return 0, fmt.Errorf("failed to remove restored node states: %w", err)
+	}
+	return len(nodeStates), nil
+}
@@ -0,0 +1,111 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
+	"github.com/bacalhau-project/bacalhau/pkg/version"
+)
+
+// SnapshotterParams holds the dependencies of a Snapshotter
+type SnapshotterParams struct {
+	JobStore jobstore.Store
+	// JobStoreType is the type of the job store, recorded in the manifest
+	JobStoreType string
+	NodeStore    nodes.Lookup
+	// RepoVersion is the version of the repo of the orchestrator, recorded in the manifest
+	RepoVersion int
+	Clock       clock.Clock
+}
+
+// Snapshotter writes backup archives of the state of a running orchestrator.
+type Snapshotter struct {
+	jobStore     jobstore.Store
+	jobStoreType string
+	nodeStore    nodes.Lookup
+	repoVersion  int
+	clock        clock.Clock
+}
+
+// NewSnapshotter creates a new Snapshotter
+func NewSnapshotter(params SnapshotterParams) (*Snapshotter, error) {
+	if params.Clock == nil {
+		params.Clock = clock.New()
+	}
+	err := errors.Join(
+		validate.NotNil(params.JobStore, "job store cannot be nil"),
+		validate.NotNil(params.NodeStore, "node store cannot be nil"),
+		validate.NotBlank(params.JobStoreType, "job store type cannot be blank"),
+		validate.IsGreaterThanZero(params.RepoVersion, "repo version must be greater than zero"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error validating backup snapshotter params: %w", err)
+	}
+	return &Snapshotter{
+		jobStore:     params.JobStore,
+		jobStoreType: params.JobStoreType,
+		nodeStore:    params.NodeStore,
+		repoVersion:  params.RepoVersion,
+		clock:        params.Clock,
+	}, nil
+}
+
+// Write writes a zip archive holding the manifest, the node states and a snapshot
+// of the job store database to w. The job store snapshot is consistent and holds
+// the event log of the orchestrator, while the node states are read just before it.
+func (s *Snapshotter) Write(ctx context.Context, w io.Writer) (Manifest, error) {
+	nodeStates, err := s.nodeStore.List(ctx)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to list node states: %w", err)
+	}
+
+	manifest := Manifest{
+		FormatVersion:   FormatVersion,
+		RepoVersion:     s.repoVersion,
+		BacalhauVersion: version.GITVERSION,
+		CreateTime:      s.clock.Now().UTC(),
+		JobStoreType:    s.jobStoreType,
+		Nodes:           len(nodeStates),
+	}
+
+	archive := zip.NewWriter(w)
+	if err = writeJSON(archive, manifestFileName, manifest); err != nil {
+		return manifest, err
+	}
+	if err = writeJSON(archive, nodeStatesName, nodeStates); err != nil {
+		return manifest, err
+	}
+
+	jobStoreWriter, err := archive.Create(jobStoreName)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to add job store to backup archive: %w", err)
+	}
+	if _, err = s.jobStore.Snapshot(ctx, jobStoreWriter); err != nil {
+		return manifest, err
+	}
+
+	if err = archive.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeJSON(archive *zip.Writer, name string, v any) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to backup archive: %w", name, err)
+	}
+	if err = json.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s to backup archive: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package backup
+
+import (
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the layout of the backup archives written by
+	// this version of bacalhau. Archives with a newer format cannot be restored.
+	FormatVersion = 1
+
+	manifestFileName = "manifest.json"
+	nodeStatesName   = "nodes.json"
+	jobStoreName     = "jobstore.db"
+
+	restoredFilePerm = 0600
+)
+
+// Manifest describes the content of a backup archive
+type Manifest struct {
+	// FormatVersion is the version of the layout of the archive
+	FormatVersion int `json:"FormatVersion"`
+	// RepoVersion is the version of the repo of the orchestrator the backup was taken from.
+	// Backups can only be restored into repos of the same version.
+	RepoVersion int `json:"RepoVersion"`
+	// BacalhauVersion is the version of the orchestrator the backup was taken from
+	BacalhauVersion string `json:"BacalhauVersion"`
+	// CreateTime is the time the backup was taken
+	CreateTime time.Time `json:"CreateTime"`
+	// JobStoreType is the type of the job store database held by the archive
+	JobStoreType string `json:"JobStoreType"`
+	// Nodes is the number of node states held by the archive
+	Nodes int `json:"Nodes"`
+}
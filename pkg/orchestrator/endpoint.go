@@ -3,6 +3,8 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +16,8 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/lib/concurrency"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/transformer"
 )
 
@@ -21,6 +25,8 @@ type BaseEndpointParams struct {
 	ID                string
 	Store             jobstore.Store
 	LogstreamServer   logstream.Server
+	LogStore          logstore.Store
+	TemplateStore     templates.Store
 	JobTransformer    transformer.JobTransformer
 	ResultTransformer transformer.ResultTransformer
 }
@@ -29,6 +35,8 @@ type BaseEndpoint struct {
 	id                string
 	store             jobstore.Store
 	logstreamServer   logstream.Server
+	logStore          logstore.Store
+	templateStore     templates.Store
 	jobTransformer    transformer.JobTransformer
 	resultTransformer transformer.ResultTransformer
 }
@@ -38,6 +46,8 @@ func NewBaseEndpoint(params *BaseEndpointParams) *BaseEndpoint {
 		id:                params.ID,
 		store:             params.Store,
 		logstreamServer:   params.LogstreamServer,
+		logStore:          params.LogStore,
+		templateStore:     params.TemplateStore,
 		jobTransformer:    params.JobTransformer,
 		resultTransformer: params.ResultTransformer,
 	}
@@ -59,6 +69,13 @@ func (e *BaseEndpoint) SubmitJob(ctx context.Context, request *SubmitJobRequest)
 	if request.ClientInstanceID != "" {
 		job.Meta[models.MetaClientInstanceID] = request.ClientInstanceID
 	}
+	if request.Template != nil {
+		job.Meta[models.MetaJobTemplateName] = request.Template.Name
+		job.Meta[models.MetaJobTemplateVersion] = strconv.FormatUint(request.Template.Version, 10)
+	}
+	if request.RerunOf != "" {
+		job.Meta[models.MetaRerunOf] = request.RerunOf
+	}
 
 	if err := e.jobTransformer.Transform(ctx, job); err != nil {
 		submitEvent.Error = err.Error()
@@ -105,6 +122,118 @@ func (e *BaseEndpoint) SubmitJob(ctx context.Context, request *SubmitJobRequest)
 	}, nil
 }
 
+// RunTemplate renders a stored job template with the provided variables and submits the resulting job.
+func (e *BaseEndpoint) RunTemplate(ctx context.Context, request *RunTemplateRequest) (*RunTemplateResponse, error) {
+	if e.templateStore == nil {
+		return nil, bacerrors.New("job templates are not enabled on this orchestrator").
+			WithCode(bacerrors.NotImplemented)
+	}
+	jobTemplate, err := e.templateStore.Get(ctx, request.Name, request.Version)
+	if err != nil {
+		return nil, err
+	}
+	job, err := templates.Render(&jobTemplate, request.Variables)
+	if err != nil {
+		return nil, err
+	}
+	if err = job.ValidateSubmission(); err != nil {
+		return nil, templates.NewErrInvalidTemplate(jobTemplate.Name, err)
+	}
+	resp, err := e.SubmitJob(ctx, &SubmitJobRequest{
+		Job:                  job,
+		ClientInstanceID:     request.ClientInstanceID,
+		ClientInstallationID: request.ClientInstallationID,
+		Template:             &jobTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RunTemplateResponse{
+		SubmitJobResponse: *resp,
+		TemplateVersion:   jobTemplate.Version,
+	}, nil
+}
+
+// RerunJob submits a new job with the spec of a terminated job, linked to it through its metadata.
+// If FailedOnly is set, the new job only schedules the partitions of the original job that did not complete.
+func (e *BaseEndpoint) RerunJob(ctx context.Context, request *RerunJobRequest) (*RerunJobResponse, error) {
+	job, err := e.store.GetJob(ctx, request.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if !job.IsTerminal() {
+		return nil, bacerrors.New("cannot rerun job %s in state %s", job.ID, job.State.StateType).
+			WithCode(bacerrors.BadRequestError).
+			WithHint("Wait for the job to finish, or stop it before rerunning it")
+	}
+
+	rerun := job.Copy()
+	rerun.ID = ""
+	rerun.State = models.State[models.JobStateType]{}
+	rerun.Version = 0
+	rerun.Revision = 0
+	rerun.CreateTime = 0
+	rerun.ModifyTime = 0
+	// reserved metadata is set again on submission
+	for k := range rerun.Meta {
+		if strings.HasPrefix(k, models.MetaReservedPrefix) {
+			delete(rerun.Meta, k)
+		}
+	}
+
+	if request.FailedOnly {
+		rerun.Partitions, err = e.incompletePartitions(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := e.SubmitJob(ctx, &SubmitJobRequest{
+		Job:                  rerun,
+		ClientInstanceID:     request.ClientInstanceID,
+		ClientInstallationID: request.ClientInstallationID,
+		RerunOf:              job.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RerunJobResponse{
+		SubmitJobResponse: *resp,
+		Partitions:        rerun.Partitions,
+	}, nil
+}
+
+// incompletePartitions returns the partitions scheduled by the job that have no completed execution
+func (e *BaseEndpoint) incompletePartitions(ctx context.Context, job models.Job) ([]int, error) {
+	if job.Type != models.JobTypeBatch {
+		return nil, bacerrors.New("rerunning failed partitions is only supported for %s jobs", models.JobTypeBatch).
+			WithCode(bacerrors.BadRequestError)
+	}
+	executions, err := e.store.GetExecutions(ctx, jobstore.GetExecutionsOptions{JobID: job.ID})
+	if err != nil {
+		return nil, err
+	}
+	completed := make(map[int]bool)
+	for _, execution := range executions {
+		if execution.ComputeState.StateType == models.ExecutionStateCompleted {
+			completed[execution.PartitionIndex] = true
+		}
+	}
+
+	var partitions []int
+	for i := 0; i < job.Count; i++ {
+		if !completed[i] && job.IsPartitionScheduled(i) {
+			partitions = append(partitions, i)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, bacerrors.New("all partitions of job %s completed successfully", job.ID).
+			WithCode(bacerrors.BadRequestError).
+			WithHint("Rerun the job without the failed-only option to run all partitions again")
+	}
+	return partitions, nil
+}
+
 func (e *BaseEndpoint) StopJob(ctx context.Context, request *StopJobRequest) (StopJobResponse, error) {
 	txContext, err := e.store.BeginTx(ctx)
 	if err != nil {
@@ -210,11 +339,31 @@ func (e *BaseEndpoint) ReadLogs(ctx context.Context, request ReadLogsRequest) (
 		return nil, fmt.Errorf("unable to find execution %s in job %s", request.ExecutionID, request.JobID)
 	}
 
+	filter := request.Filter
+	filter.JobID = execution.JobID
+	filter.ExecutionID = execution.ID
+
+	// serve logs retained by the orchestrator when available, as the compute
+	// node may no longer hold them
+	if execution.IsTerminalState() && e.logStore != nil {
+		found, err := e.logStore.HasLogs(ctx, execution.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup retained logs for execution %s: %w", execution.ID, err)
+		}
+		if found {
+			records, err := e.logStore.Query(ctx, filter)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read retained logs for execution %s: %w", execution.ID, err)
+			}
+			return logstore.StreamRecords(ctx, records), nil
+		}
+	}
+
 	if execution.IsTerminalState() {
 		streamer := logstream.NewCompletedStreamer(logstream.CompletedStreamerParams{
 			Execution: execution,
 		})
-		return streamer.Stream(ctx), nil
+		return logstore.FilterStream(ctx, streamer.Stream(ctx), filter), nil
 	}
 	req := messages.ExecutionLogsRequest{
 		ExecutionID: execution.ID,
@@ -223,7 +372,12 @@ func (e *BaseEndpoint) ReadLogs(ctx context.Context, request ReadLogsRequest) (
 		Follow:      request.Follow,
 	}
 
-	return e.logstreamServer.GetLogStream(ctx, req)
+	// fallback to live streaming from the compute node for running executions
+	stream, err := e.logstreamServer.GetLogStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return logstore.FilterStream(ctx, stream, filter), nil
 }
 
 // GetResults returns the results of a job
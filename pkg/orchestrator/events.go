@@ -35,6 +35,7 @@ const (
 	execStoppedByNodeRejectedMessage     = "Execution stop requested because node has been rejected"
 	execStoppedByOversubscriptionMessage = "Execution stop requested because there are more executions than needed"
 	execStoppedDueToJobFailureMessage    = "Execution stopped due to job failure"
+	execStoppedByBidTimeoutMessage       = "Execution stop requested because node did not respond to the bid request within"
 
 	executionTimeoutMessage = "Execution timed out"
 
@@ -140,6 +141,10 @@ func ExecStoppedByOversubscriptionEvent() models.Event {
 	return event(EventTopicJobScheduling, execStoppedByOversubscriptionMessage, map[string]string{})
 }
 
+func ExecStoppedByBidTimeoutEvent(timeout time.Duration) models.Event {
+	return event(EventTopicJobScheduling, fmt.Sprintf("%s %s", execStoppedByBidTimeoutMessage, timeout), map[string]string{})
+}
+
 func ExecStoppedDueToJobFailureEvent() models.Event {
 	return *models.NewEvent(EventTopicJobScheduling).WithMessage(execStoppedDueToJobFailureMessage)
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
 )
 
 const (
@@ -33,6 +35,14 @@ type HousekeepingParams struct {
 	// Clock is the clock used for time-based operations.
 	// If not provided, the system clock is used.
 	Clock clock.Clock
+	// LogStore holds execution logs retained by the orchestrator. Optional.
+	LogStore logstore.Store
+	// LogRetention is how long execution logs are retained after they were last written.
+	// Logs are kept forever if zero.
+	LogRetention time.Duration
+	// NodeLookup looks up the connection state of the nodes running executions, to reschedule
+	// executions of nodes that were disconnected for longer than their job's grace period. Optional.
+	NodeLookup nodes.Lookup
 }
 
 type Housekeeping struct {
@@ -47,6 +57,10 @@ type Housekeeping struct {
 	stopChan   chan struct{}
 	running    bool
 	clock      clock.Clock
+
+	logStore     logstore.Store
+	logRetention time.Duration
+	nodeLookup   nodes.Lookup
 }
 
 func NewHousekeeping(params HousekeepingParams) (*Housekeeping, error) {
@@ -76,6 +90,9 @@ func NewHousekeeping(params HousekeepingParams) (*Housekeeping, error) {
 		workersSem:    make(chan struct{}, params.Workers),
 		stopChan:      make(chan struct{}),
 		clock:         params.Clock,
+		logStore:      params.LogStore,
+		logRetention:  params.LogRetention,
+		nodeLookup:    params.NodeLookup,
 	}
 
 	return h, nil
@@ -136,6 +153,8 @@ func (h *Housekeeping) runHousekeepingTasks(ctx context.Context) {
 
 			// run housekeeping tasks
 			h.timeoutExecutions(ctx, activeExecutions)
+			h.loseDisconnectedExecutions(ctx, activeExecutions)
+			h.pruneExecutionLogs(ctx)
 		case <-ctx.Done():
 			log.Ctx(ctx).Debug().Msg("Context cancelled, stopping housekeeping task")
 			return
@@ -225,6 +244,64 @@ func (h *Housekeeping) timeoutExecutions(ctx context.Context, activeExecutions [
 	}
 }
 
+// loseDisconnectedExecutions checks for executions running on nodes that have been disconnected
+// for longer than their job's grace period, and enqueue an evaluation for them.
+// It is the responsibility of the scheduler to fail and reschedule the executions
+func (h *Housekeeping) loseDisconnectedExecutions(ctx context.Context, activeExecutions []*models.Execution) {
+	if h.nodeLookup == nil {
+		return
+	}
+	alreadyEvaluatedJobs := make(map[string]struct{})
+	nodeStates := make(map[string]*models.NodeState)
+	for _, execution := range activeExecutions {
+		// skip if the job has already been evaluated by another active execution
+		if _, ok := alreadyEvaluatedJobs[execution.JobID]; ok {
+			continue
+		}
+
+		gracePeriod := execution.Job.Task().Timeouts.GetDisconnectGracePeriod()
+		if gracePeriod <= 0 {
+			continue
+		}
+
+		nodeState, ok := nodeStates[execution.NodeID]
+		if !ok {
+			state, err := h.nodeLookup.Get(ctx, execution.NodeID)
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get state of node %s", execution.NodeID)
+			} else {
+				nodeState = &state
+			}
+			nodeStates[execution.NodeID] = nodeState
+		}
+		if nodeState == nil || nodeState.IsConnected() {
+			continue
+		}
+
+		if h.clock.Since(nodeState.ConnectionState.DisconnectedSince) > gracePeriod {
+			alreadyEvaluatedJobs[execution.JobID] = struct{}{}
+			h.enqueueTimeoutTask(ctx, execution.Job, models.EvalTriggerExecNodeLost,
+				fmt.Sprintf("node %s of execution %s disconnected for longer than %s",
+					execution.NodeID, execution.ID, gracePeriod))
+		}
+	}
+}
+
+// pruneExecutionLogs removes retained execution logs that are older than the retention period
+func (h *Housekeeping) pruneExecutionLogs(ctx context.Context) {
+	if h.logStore == nil || h.logRetention <= 0 {
+		return
+	}
+	pruned, err := h.logStore.Prune(ctx, h.clock.Now().Add(-h.logRetention))
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to prune execution logs")
+		return
+	}
+	if pruned > 0 {
+		log.Ctx(ctx).Debug().Msgf("pruned retained logs of %d executions", pruned)
+	}
+}
+
 func (h *Housekeeping) enqueueTimeoutTask(ctx context.Context, job *models.Job, trigger, comment string) {
 	h.workersSem <- struct{}{}
 	h.waitGroup.Add(1)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
 	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
 )
 
@@ -288,6 +289,38 @@ func (s *HousekeepingTestSuite) TestMultipleHousekeepingRounds() {
 	s.Eventually(func() bool { return s.ctrl.Satisfied() }, 3*time.Second, 50*time.Millisecond)
 }
 
+// TestDisconnectedNodeGracePeriod tests that an evaluation is enqueued for executions
+// of nodes that have been disconnected for longer than their job's grace period
+func (s *HousekeepingTestSuite) TestDisconnectedNodeGracePeriod() {
+	nodeLookup := nodes.NewMockLookup(s.ctrl)
+	s.housekeeping.nodeLookup = nodeLookup
+
+	job1, executions1 := s.mockJob(notExpiredJobCreateTime, notExpiredModifyTime)
+	job1.Task().Timeouts.DisconnectGracePeriod = 600 // 10 minutes
+	job2, executions2 := s.mockJob(notExpiredJobCreateTime, notExpiredModifyTime)
+	job2.Task().Timeouts.DisconnectGracePeriod = 600 // 10 minutes
+
+	s.mockJobStore.EXPECT().GetInProgressJobs(gomock.Any(), "").Times(1).Return([]models.Job{*job1, *job2}, nil)
+	s.mockJobStore.EXPECT().GetInProgressJobs(gomock.Any(), "").AnyTimes().Return([]models.Job{}, nil)
+	s.mockJobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: job1.ID}).Return(executions1, nil)
+	s.mockJobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: job2.ID}).Return(executions2, nil)
+
+	disconnectedNode := func(disconnectedFor time.Duration) models.NodeState {
+		return models.NodeState{ConnectionState: models.ConnectionState{
+			Status:            models.NodeStates.DISCONNECTED,
+			DisconnectedSince: s.clock.Now().Add(-disconnectedFor),
+		}}
+	}
+	nodeLookup.EXPECT().Get(gomock.Any(), executions1[0].NodeID).Return(disconnectedNode(time.Hour), nil)
+	nodeLookup.EXPECT().Get(gomock.Any(), executions2[0].NodeID).Return(disconnectedNode(time.Minute), nil)
+
+	// only the job whose node didn't reconnect within the grace period is evaluated
+	s.assertEvaluationEnqueued(*job1, models.EvalTriggerExecNodeLost)
+
+	s.housekeeping.Start(context.Background())
+	s.Eventually(func() bool { return s.ctrl.Satisfied() }, 3*time.Second, 50*time.Millisecond)
+}
+
 func (s *HousekeepingTestSuite) TestShouldRun() {
 	s.True(s.housekeeping.ShouldRun())
 }
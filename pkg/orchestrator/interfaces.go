@@ -95,8 +95,8 @@ type NodeRanker interface {
 
 // NodeSelector selects nodes based on their suitability to execute a job.
 type NodeSelector interface {
-	// AllNodes returns all nodes in the network.
-	AllNodes(ctx context.Context) ([]models.NodeInfo, error)
+	// AllNodes returns the state of all nodes in the network, including disconnected nodes.
+	AllNodes(ctx context.Context) ([]models.NodeState, error)
 
 	// MatchingNodes return the nodes that match job constraints order by rank in descending order.
 	// Also return the nodes that were filtered out and an error if any.
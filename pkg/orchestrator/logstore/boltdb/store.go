@@ -0,0 +1,330 @@
+package boltlogstore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/rs/zerolog/log"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/boltdblib"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
+)
+
+const (
+	BucketExecutions = "executions"
+	BucketJobs       = "jobs"
+	BucketLines      = "lines"
+
+	// DefaultMaxLinesPerExecution is the number of lines retained per execution
+	// when no explicit limit is configured.
+	DefaultMaxLinesPerExecution = 10000
+)
+
+var metaKey = []byte("meta")
+
+// executionMeta is stored alongside each execution's lines to support
+// retention without scanning the lines themselves.
+type executionMeta struct {
+	JobID     string `json:"JobID"`
+	Count     int    `json:"Count"`
+	UpdatedAt int64  `json:"UpdatedAt"`
+}
+
+type BoltLogStore struct {
+	database             *bolt.DB
+	clock                clock.Clock
+	maxLinesPerExecution int
+}
+
+type Option func(store *BoltLogStore)
+
+func WithClock(clock clock.Clock) Option {
+	return func(store *BoltLogStore) {
+		store.clock = clock
+	}
+}
+
+// WithMaxLinesPerExecution limits the number of lines retained per execution.
+// Older lines are dropped first once the limit is reached.
+func WithMaxLinesPerExecution(maxLines int) Option {
+	return func(store *BoltLogStore) {
+		if maxLines > 0 {
+			store.maxLinesPerExecution = maxLines
+		}
+	}
+}
+
+// NewBoltLogStore creates a new log store where data is held in buckets.
+// Data is structured as follows
+//
+// bucket executions
+//
+//	bucket executionID
+//		key meta -> executionMeta
+//		bucket lines -> key []sequence -> Record
+//
+// bucket jobs
+//
+//	bucket jobID -> key executionID -> {}
+func NewBoltLogStore(dbPath string, options ...Option) (*BoltLogStore, error) {
+	db, err := boltdblib.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &BoltLogStore{
+		database:             db,
+		clock:                clock.New(),
+		maxLinesPerExecution: DefaultMaxLinesPerExecution,
+	}
+	for _, opt := range options {
+		opt(store)
+	}
+
+	if err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{BucketExecutions, BucketJobs} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return store, nil
+}
+
+// Append persists the given records, dropping the oldest lines of an
+// execution once it exceeds the configured limit.
+// The log store uses its own database, so transactions are never shared
+// with the caller's context.
+func (s *BoltLogStore) Append(ctx context.Context, records ...logstore.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.database.Update(func(tx *bolt.Tx) error {
+		for _, record := range records {
+			if record.ExecutionID == "" {
+				return fmt.Errorf("log record is missing an execution ID")
+			}
+			if err := s.appendRecord(tx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltLogStore) appendRecord(tx *bolt.Tx, record logstore.Record) error {
+	execBucket, err := tx.Bucket([]byte(BucketExecutions)).CreateBucketIfNotExists([]byte(record.ExecutionID))
+	if err != nil {
+		return err
+	}
+	lines, err := execBucket.CreateBucketIfNotExists([]byte(BucketLines))
+	if err != nil {
+		return err
+	}
+
+	meta := executionMeta{JobID: record.JobID}
+	if data := execBucket.Get(metaKey); data != nil {
+		if err = json.Unmarshal(data, &meta); err != nil {
+			return err
+		}
+	}
+
+	seq, err := lines.NextSequence()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err = lines.Put(uint64ToBytes(seq), data); err != nil {
+		return err
+	}
+	meta.Count++
+
+	// enforce the per execution limit by evicting the oldest lines
+	for meta.Count > s.maxLinesPerExecution {
+		k, _ := lines.Cursor().First()
+		if k == nil {
+			break
+		}
+		if err = lines.Delete(k); err != nil {
+			return err
+		}
+		meta.Count--
+	}
+
+	meta.UpdatedAt = s.clock.Now().UnixNano()
+	if data, err = json.Marshal(meta); err != nil {
+		return err
+	}
+	if err = execBucket.Put(metaKey, data); err != nil {
+		return err
+	}
+
+	if record.JobID != "" {
+		jobBucket, err := tx.Bucket([]byte(BucketJobs)).CreateBucketIfNotExists([]byte(record.JobID))
+		if err != nil {
+			return err
+		}
+		if err = jobBucket.Put([]byte(record.ExecutionID), []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query returns the records matching the query. Either a job or an execution
+// ID must be provided.
+func (s *BoltLogStore) Query(ctx context.Context, query logstore.Query) ([]logstore.Record, error) {
+	if query.JobID == "" && query.ExecutionID == "" {
+		return nil, fmt.Errorf("log query requires a job or execution ID")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var records []logstore.Record
+	err := s.database.View(func(tx *bolt.Tx) error {
+		executionIDs := []string{query.ExecutionID}
+		if query.ExecutionID == "" {
+			executionIDs = executionIDs[:0]
+			jobBucket := tx.Bucket([]byte(BucketJobs)).Bucket([]byte(query.JobID))
+			if jobBucket == nil {
+				return nil
+			}
+			if err := jobBucket.ForEach(func(k, _ []byte) error {
+				executionIDs = append(executionIDs, string(k))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, executionID := range executionIDs {
+			execBucket := tx.Bucket([]byte(BucketExecutions)).Bucket([]byte(executionID))
+			if execBucket == nil {
+				continue
+			}
+			lines := execBucket.Bucket([]byte(BucketLines))
+			if lines == nil {
+				continue
+			}
+			if err := lines.ForEach(func(_, v []byte) error {
+				var record logstore.Record
+				if err := json.Unmarshal(v, &record); err != nil {
+					return err
+				}
+				if query.Matches(record) {
+					records = append(records, record)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// lines of a single execution are already ordered, so a stable sort
+	// keeps their order while interleaving multiple executions by time
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[len(records)-query.Limit:]
+	}
+	return records, nil
+}
+
+// HasLogs returns true if any records are retained for the execution.
+func (s *BoltLogStore) HasLogs(ctx context.Context, executionID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	var found bool
+	err := s.database.View(func(tx *bolt.Tx) error {
+		execBucket := tx.Bucket([]byte(BucketExecutions)).Bucket([]byte(executionID))
+		found = execBucket != nil
+		return nil
+	})
+	return found, err
+}
+
+// Prune removes logs of executions that were last written before the given time.
+func (s *BoltLogStore) Prune(ctx context.Context, before time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	pruned := 0
+	err := s.database.Update(func(tx *bolt.Tx) error {
+		executions := tx.Bucket([]byte(BucketExecutions))
+		jobs := tx.Bucket([]byte(BucketJobs))
+
+		expired := make(map[string]executionMeta)
+		if err := executions.ForEachBucket(func(k []byte) error {
+			var meta executionMeta
+			if data := executions.Bucket(k).Get(metaKey); data != nil {
+				if err := json.Unmarshal(data, &meta); err != nil {
+					return err
+				}
+			}
+			if time.Unix(0, meta.UpdatedAt).Before(before) {
+				expired[string(k)] = meta
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for executionID, meta := range expired {
+			if err := executions.DeleteBucket([]byte(executionID)); err != nil {
+				return err
+			}
+			if jobBucket := jobs.Bucket([]byte(meta.JobID)); jobBucket != nil {
+				if err := jobBucket.Delete([]byte(executionID)); err != nil {
+					return err
+				}
+				if jobBucket.Stats().KeyN == 0 {
+					if err := jobs.DeleteBucket([]byte(meta.JobID)); err != nil {
+						return err
+					}
+				}
+			}
+			pruned++
+		}
+		return nil
+	})
+	return pruned, err
+}
+
+// Close closes the underlying database.
+func (s *BoltLogStore) Close(ctx context.Context) error {
+	log.Ctx(ctx).Debug().Msg("closing bolt-backed log store")
+	return s.database.Close()
+}
+
+func uint64ToBytes(i uint64) []byte {
+	buf := make([]byte, 8) //nolint:mnd
+	binary.BigEndian.PutUint64(buf, i)
+	return buf
+}
+
+// compile time check
+var _ logstore.Store = (*BoltLogStore)(nil)
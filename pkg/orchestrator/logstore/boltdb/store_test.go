@@ -0,0 +1,131 @@
+//go:build unit || !integration
+
+package boltlogstore
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
+)
+
+type BoltLogStoreTestSuite struct {
+	suite.Suite
+	store *BoltLogStore
+	clock *clock.Mock
+	ctx   context.Context
+}
+
+func TestBoltLogStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BoltLogStoreTestSuite))
+}
+
+func (s *BoltLogStoreTestSuite) SetupTest() {
+	s.clock = clock.NewMock()
+	s.ctx = context.Background()
+
+	var err error
+	s.store, err = NewBoltLogStore(
+		filepath.Join(s.T().TempDir(), "logs.db"),
+		WithClock(s.clock),
+		WithMaxLinesPerExecution(3),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *BoltLogStoreTestSuite) TearDownTest() {
+	s.Require().NoError(s.store.Close(s.ctx))
+}
+
+func (s *BoltLogStoreTestSuite) record(execID string, typ models.ExecutionLogType, line string, ts time.Time) logstore.Record {
+	return logstore.Record{
+		JobID:       "job1",
+		ExecutionID: execID,
+		Type:        typ,
+		Line:        line,
+		Timestamp:   ts,
+	}
+}
+
+func (s *BoltLogStoreTestSuite) TestQueryFilters() {
+	t0 := time.Unix(1000, 0)
+	s.Require().NoError(s.store.Append(s.ctx,
+		s.record("e1", models.ExecutionLogTypeSTDOUT, "hello world\n", t0),
+		s.record("e1", models.ExecutionLogTypeSTDERR, "warning: disk\n", t0.Add(time.Second)),
+		s.record("e2", models.ExecutionLogTypeSTDOUT, "hello again\n", t0.Add(2*time.Second)),
+	))
+
+	all, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1"})
+	s.Require().NoError(err)
+	s.Require().Len(all, 3)
+	s.Equal("e2", all[2].ExecutionID)
+
+	byExecution, err := s.store.Query(s.ctx, logstore.Query{ExecutionID: "e1"})
+	s.Require().NoError(err)
+	s.Len(byExecution, 2)
+
+	byStream, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1", Type: models.ExecutionLogTypeSTDERR})
+	s.Require().NoError(err)
+	s.Require().Len(byStream, 1)
+	s.Equal("warning: disk\n", byStream[0].Line)
+
+	byPattern, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1", Pattern: regexp.MustCompile("^hello")})
+	s.Require().NoError(err)
+	s.Len(byPattern, 2)
+
+	byTime, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1", Since: t0.Add(time.Second), Until: t0.Add(time.Second)})
+	s.Require().NoError(err)
+	s.Require().Len(byTime, 1)
+	s.Equal(models.ExecutionLogTypeSTDERR, byTime[0].Type)
+
+	limited, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1", Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(limited, 1)
+	s.Equal("hello again\n", limited[0].Line)
+
+	_, err = s.store.Query(s.ctx, logstore.Query{})
+	s.Error(err)
+}
+
+func (s *BoltLogStoreTestSuite) TestMaxLinesPerExecution() {
+	for i := 0; i < 5; i++ {
+		s.Require().NoError(s.store.Append(s.ctx,
+			s.record("e1", models.ExecutionLogTypeSTDOUT, string(rune('a'+i)), time.Unix(int64(i), 0))))
+	}
+
+	records, err := s.store.Query(s.ctx, logstore.Query{ExecutionID: "e1"})
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+	s.Equal("c", records[0].Line)
+	s.Equal("e", records[2].Line)
+}
+
+func (s *BoltLogStoreTestSuite) TestPrune() {
+	s.Require().NoError(s.store.Append(s.ctx, s.record("e1", models.ExecutionLogTypeSTDOUT, "old", time.Time{})))
+	s.clock.Add(time.Hour)
+	s.Require().NoError(s.store.Append(s.ctx, s.record("e2", models.ExecutionLogTypeSTDOUT, "new", time.Time{})))
+
+	pruned, err := s.store.Prune(s.ctx, s.clock.Now().Add(-time.Minute))
+	s.Require().NoError(err)
+	s.Equal(1, pruned)
+
+	found, err := s.store.HasLogs(s.ctx, "e1")
+	s.Require().NoError(err)
+	s.False(found)
+
+	found, err = s.store.HasLogs(s.ctx, "e2")
+	s.Require().NoError(err)
+	s.True(found)
+
+	records, err := s.store.Query(s.ctx, logstore.Query{JobID: "job1"})
+	s.Require().NoError(err)
+	s.Require().Len(records, 1)
+	s.Equal("new", records[0].Line)
+}
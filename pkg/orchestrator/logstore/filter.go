@@ -0,0 +1,78 @@
+package logstore
+
+import (
+	"context"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/concurrency"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Matches returns true if the record satisfies the query filters.
+func (q Query) Matches(record Record) bool {
+	if q.JobID != "" && record.JobID != q.JobID {
+		return false
+	}
+	if q.ExecutionID != "" && record.ExecutionID != q.ExecutionID {
+		return false
+	}
+	if !q.Since.IsZero() && record.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && record.Timestamp.After(q.Until) {
+		return false
+	}
+	return q.MatchesLog(record.ToExecutionLog())
+}
+
+// MatchesLog applies the stream and pattern filters to a log line. It is used
+// for live streams where lines carry no timestamp or execution information.
+func (q Query) MatchesLog(log models.ExecutionLog) bool {
+	if q.Type != 0 && log.Type != q.Type {
+		return false
+	}
+	if q.Pattern != nil && !q.Pattern.MatchString(log.Line) {
+		return false
+	}
+	return true
+}
+
+// FilterStream returns a stream that only forwards the log lines matching
+// the query's stream and pattern filters. Errors are always forwarded.
+func FilterStream(
+	ctx context.Context, in <-chan *concurrency.AsyncResult[models.ExecutionLog], query Query,
+) <-chan *concurrency.AsyncResult[models.ExecutionLog] {
+	if query.Type == 0 && query.Pattern == nil {
+		return in
+	}
+	out := make(chan *concurrency.AsyncResult[models.ExecutionLog], cap(in))
+	go func() {
+		defer close(out)
+		for result := range in {
+			if result.Err == nil && !query.MatchesLog(result.Value) {
+				continue
+			}
+			select {
+			case out <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// StreamRecords returns a stream of the given records as execution logs.
+func StreamRecords(ctx context.Context, records []Record) <-chan *concurrency.AsyncResult[models.ExecutionLog] {
+	out := make(chan *concurrency.AsyncResult[models.ExecutionLog])
+	go func() {
+		defer close(out)
+		for _, record := range records {
+			select {
+			case out <- &concurrency.AsyncResult[models.ExecutionLog]{Value: record.ToExecutionLog()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
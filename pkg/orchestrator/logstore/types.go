@@ -0,0 +1,68 @@
+package logstore
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Record is a single log line of an execution retained by the orchestrator.
+type Record struct {
+	JobID       string                  `json:"JobID"`
+	ExecutionID string                  `json:"ExecutionID"`
+	Type        models.ExecutionLogType `json:"Type"`
+	Line        string                  `json:"Line"`
+	// Timestamp is the time the line was read from the executor by the compute node.
+	Timestamp time.Time `json:"Timestamp"`
+}
+
+// ToExecutionLog converts the record to the log type streamed to clients.
+func (r Record) ToExecutionLog() models.ExecutionLog {
+	return models.ExecutionLog{
+		Type: r.Type,
+		Line: r.Line,
+	}
+}
+
+// Query describes which log records to retrieve.
+// Zero values mean no filtering on that field.
+type Query struct {
+	JobID       string
+	ExecutionID string
+	// Type restricts the results to a single stream, e.g. stdout or stderr.
+	Type models.ExecutionLogType
+	// Since and Until bound the records' timestamps (inclusive).
+	Since time.Time
+	Until time.Time
+	// Pattern only returns lines matching the regular expression.
+	Pattern *regexp.Regexp
+	// Limit caps the number of returned records, keeping the latest ones.
+	Limit int
+}
+
+// Sink receives execution logs shipped to the orchestrator.
+type Sink interface {
+	// Append persists the given records.
+	Append(ctx context.Context, records ...Record) error
+}
+
+// Store is a Sink that can also serve historical logs and apply retention.
+type Store interface {
+	Sink
+
+	// Query returns the records matching the query, ordered by the time they
+	// were appended.
+	Query(ctx context.Context, query Query) ([]Record, error)
+
+	// HasLogs returns true if any records are retained for the execution.
+	HasLogs(ctx context.Context, executionID string) (bool, error)
+
+	// Prune removes logs of executions that were last written before the
+	// given time and returns the number of executions removed.
+	Prune(ctx context.Context, before time.Time) (int, error)
+
+	// Close releases any resources held by the store.
+	Close(ctx context.Context) error
+}
@@ -12,27 +12,34 @@ import (
 
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
 	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
 )
 
 // MessageHandler base implementation of requester Endpoint
 type MessageHandler struct {
-	store jobstore.Store
+	store   jobstore.Store
+	logSink logstore.Sink
 }
 
-// NewMessageHandler creates a new MessageHandler
-func NewMessageHandler(store jobstore.Store) *MessageHandler {
+// NewMessageHandler creates a new MessageHandler.
+// Execution logs forwarded by compute nodes are appended to the log sink,
+// and dropped if it is nil.
+func NewMessageHandler(store jobstore.Store, logSink logstore.Sink) *MessageHandler {
 	return &MessageHandler{
-		store: store,
+		store:   store,
+		logSink: logSink,
 	}
 }
 
 func (m *MessageHandler) ShouldProcess(ctx context.Context, message *envelope.Message) bool {
 	return message.Metadata.Get(envelope.KeyMessageType) == messages.BidResultMessageType ||
 		message.Metadata.Get(envelope.KeyMessageType) == messages.RunResultMessageType ||
-		message.Metadata.Get(envelope.KeyMessageType) == messages.ComputeErrorMessageType
+		message.Metadata.Get(envelope.KeyMessageType) == messages.ComputeErrorMessageType ||
+		message.Metadata.Get(envelope.KeyMessageType) == messages.ExecutionLogsMessageType
 }
 
 // HandleMessage handles incoming messages
@@ -47,6 +54,9 @@ func (m *MessageHandler) HandleMessage(ctx context.Context, message *envelope.Me
 		metrics.Done(ctx, messageHandlerProcessDuration)
 	}()
 
+	ctx, span := ncl.StartMessageSpan(ctx, "orchestrator.MessageHandler.HandleMessage", message)
+	defer span.End()
+
 	switch message.Metadata.Get(envelope.KeyMessageType) {
 	case messages.BidResultMessageType:
 		err = m.OnBidComplete(ctx, metrics, message)
@@ -54,9 +64,11 @@ func (m *MessageHandler) HandleMessage(ctx context.Context, message *envelope.Me
 		err = m.OnRunComplete(ctx, metrics, message)
 	case messages.ComputeErrorMessageType:
 		err = m.OnComputeFailure(ctx, metrics, message)
+	case messages.ExecutionLogsMessageType:
+		err = m.OnExecutionLogs(ctx, message)
 	}
 
-	return m.handleError(ctx, metrics, message, err)
+	return m.handleError(ctx, metrics, message, telemetry.RecordErrorOnSpan(span)(err))
 }
 
 // handleError logs the error with context and returns nil.
@@ -215,6 +227,7 @@ func (m *MessageHandler) OnComputeFailure(ctx context.Context, metrics *telemetr
 			},
 		},
 		NewValues: models.Execution{
+			RunOutput:    result.RunCommandResult,
 			ComputeState: models.NewExecutionState(models.ExecutionStateFailed).WithMessage(result.Error()),
 			DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStateStopped).WithMessage("execution failed"),
 		},
@@ -235,6 +248,32 @@ func (m *MessageHandler) OnComputeFailure(ctx context.Context, metrics *telemetr
 	return err
 }
 
+// OnExecutionLogs retains the output lines of an execution forwarded by its compute node
+func (m *MessageHandler) OnExecutionLogs(ctx context.Context, message *envelope.Message) error {
+	result, ok := message.Payload.(*messages.ExecutionLogs)
+	if !ok {
+		return envelope.NewErrUnexpectedPayloadType("ExecutionLogs", reflect.TypeOf(message.Payload).String())
+	}
+	if m.logSink == nil {
+		return nil
+	}
+
+	records := make([]logstore.Record, len(result.Lines))
+	for i, line := range result.Lines {
+		records[i] = logstore.Record{
+			JobID:       result.JobID,
+			ExecutionID: result.ExecutionID,
+			Type:        line.Type,
+			Line:        line.Line,
+			Timestamp:   line.Timestamp,
+		}
+	}
+	if err := m.logSink.Append(ctx, records...); err != nil {
+		return fmt.Errorf("failed to retain logs of execution %s: %w", result.ExecutionID, err)
+	}
+	return nil
+}
+
 // enqueueEvaluation enqueues an evaluation to allow the scheduler to either accept the bid, or find a new node
 func (m *MessageHandler) enqueueEvaluation(ctx context.Context, jobID, jobType string) error {
 	now := time.Now().UTC().UnixNano()
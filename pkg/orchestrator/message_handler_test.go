@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -14,13 +15,25 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
 )
 
+// recordingLogSink records the appended log records
+type recordingLogSink struct {
+	records []logstore.Record
+}
+
+func (s *recordingLogSink) Append(_ context.Context, records ...logstore.Record) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
 type MessageHandlerTestSuite struct {
 	suite.Suite
 	ctrl      *gomock.Controller
 	mockStore *jobstore.MockStore
 	mockTx    *jobstore.MockTxContext
+	logSink   *recordingLogSink
 	handler   *MessageHandler
 }
 
@@ -28,7 +41,8 @@ func (suite *MessageHandlerTestSuite) SetupTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.mockStore = jobstore.NewMockStore(suite.ctrl)
 	suite.mockTx = jobstore.NewMockTxContext(suite.ctrl)
-	suite.handler = NewMessageHandler(suite.mockStore)
+	suite.logSink = &recordingLogSink{}
+	suite.handler = NewMessageHandler(suite.mockStore, suite.logSink)
 }
 
 func (suite *MessageHandlerTestSuite) TearDownTest() {
@@ -39,6 +53,7 @@ func (suite *MessageHandlerTestSuite) TestShouldProcess() {
 	suite.True(suite.handler.ShouldProcess(context.Background(), envelope.NewMessage(nil).WithMetadataValue(envelope.KeyMessageType, messages.BidResultMessageType)))
 	suite.True(suite.handler.ShouldProcess(context.Background(), envelope.NewMessage(nil).WithMetadataValue(envelope.KeyMessageType, messages.RunResultMessageType)))
 	suite.True(suite.handler.ShouldProcess(context.Background(), envelope.NewMessage(nil).WithMetadataValue(envelope.KeyMessageType, messages.ComputeErrorMessageType)))
+	suite.True(suite.handler.ShouldProcess(context.Background(), envelope.NewMessage(nil).WithMetadataValue(envelope.KeyMessageType, messages.ExecutionLogsMessageType)))
 	suite.False(suite.handler.ShouldProcess(context.Background(), envelope.NewMessage(nil).WithMetadataValue(envelope.KeyMessageType, "UnknownType")))
 }
 
@@ -142,11 +157,20 @@ func (suite *MessageHandlerTestSuite) TestHandleComputeFailure() {
 			JobID:       "job-1",
 			JobType:     "batch",
 		},
+		RunCommandResult: &models.RunCommandResult{
+			ExitCode: 1,
+			Usage:    &models.ResourceUsage{PeakMemory: 1024},
+		},
 	}
 	message := envelope.NewMessage(computeError).WithMetadataValue(envelope.KeyMessageType, messages.ComputeErrorMessageType)
 
 	suite.mockStore.EXPECT().BeginTx(gomock.Any()).Return(suite.mockTx, nil)
-	suite.mockStore.EXPECT().UpdateExecution(suite.mockTx, gomock.Any()).Return(nil)
+	suite.mockStore.EXPECT().UpdateExecution(suite.mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, request jobstore.UpdateExecutionRequest) error {
+			// the usage of failed runs is kept for accounting
+			suite.Equal(computeError.RunCommandResult, request.NewValues.RunOutput)
+			return nil
+		})
 	suite.mockStore.EXPECT().CreateEvaluation(suite.mockTx, gomock.Any()).Return(nil)
 	suite.mockTx.EXPECT().Commit().Return(nil)
 	suite.mockTx.EXPECT().Rollback().Return(nil)
@@ -176,6 +200,29 @@ func (suite *MessageHandlerTestSuite) TestHandleMessagePropagatesErrors() {
 	suite.NoError(err) // HandleMessage swallows errors after logging them
 }
 
+func (suite *MessageHandlerTestSuite) TestHandleExecutionLogs() {
+	ctx := context.Background()
+	timestamp := time.Now().UTC()
+	logs := &messages.ExecutionLogs{
+		BaseResponse: messages.BaseResponse{
+			ExecutionID: "exec-1",
+			JobID:       "job-1",
+		},
+		Lines: []models.ExecutionLogLine{
+			{Type: models.ExecutionLogTypeSTDOUT, Line: "out\n", Timestamp: timestamp},
+			{Type: models.ExecutionLogTypeSTDERR, Line: "err\n", Timestamp: timestamp.Add(time.Second)},
+		},
+	}
+	message := envelope.NewMessage(logs).WithMetadataValue(envelope.KeyMessageType, messages.ExecutionLogsMessageType)
+
+	err := suite.handler.HandleMessage(ctx, message)
+	suite.NoError(err)
+	suite.Equal([]logstore.Record{
+		{JobID: "job-1", ExecutionID: "exec-1", Type: models.ExecutionLogTypeSTDOUT, Line: "out\n", Timestamp: timestamp},
+		{JobID: "job-1", ExecutionID: "exec-1", Type: models.ExecutionLogTypeSTDERR, Line: "err\n", Timestamp: timestamp.Add(time.Second)},
+	}, suite.logSink.records)
+}
+
 func TestMessageHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(MessageHandlerTestSuite))
 }
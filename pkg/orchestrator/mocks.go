@@ -369,10 +369,10 @@ func (m *MockNodeSelector) EXPECT() *MockNodeSelectorMockRecorder {
 }
 
 // AllNodes mocks base method.
-func (m *MockNodeSelector) AllNodes(ctx context.Context) ([]models.NodeInfo, error) {
+func (m *MockNodeSelector) AllNodes(ctx context.Context) ([]models.NodeState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AllNodes", ctx)
-	ret0, _ := ret[0].([]models.NodeInfo)
+	ret0, _ := ret[0].([]models.NodeState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
 const (
@@ -20,6 +21,17 @@ const (
 	BucketNameCurrent = "node_v1"
 )
 
+// BucketNameWithPrefix returns the bucket name for the given subject prefix, allowing
+// multiple clusters to share a NATS cluster. NATS bucket names can't contain dots,
+// so they are replaced with underscores. The default prefix, whether configured explicitly
+// or left empty, returns BucketNameCurrent so that existing clusters keep their node store.
+func BucketNameWithPrefix(prefix string) string {
+	if nclprotocol.SubjectPrefix(prefix) == nclprotocol.DefaultSubjectPrefix {
+		return BucketNameCurrent
+	}
+	return strings.ReplaceAll(prefix, ".", "_") + "_" + BucketNameCurrent
+}
+
 type NodeStoreParams struct {
 	BucketName string
 	Client     *nats.Conn
@@ -17,6 +17,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes/kvstore"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
 const TEST_PORT = 8369
@@ -29,8 +30,9 @@ var nodeIDs = []string{
 
 type KVNodeInfoStoreSuite struct {
 	suite.Suite
-	nats  *server.Server
-	store nodes.Store
+	nats       *server.Server
+	natsClient *nats.Conn
+	store      nodes.Store
 }
 
 func (s *KVNodeInfoStoreSuite) SetupTest() {
@@ -40,12 +42,13 @@ func (s *KVNodeInfoStoreSuite) SetupTest() {
 	opts.StoreDir = s.T().TempDir()
 
 	s.nats = natsserver.RunServer(opts)
-	natsClient, err := nats.Connect(s.nats.Addr().String())
+	var err error
+	s.natsClient, err = nats.Connect(s.nats.Addr().String())
 	s.Require().NoError(err)
 
 	s.store, _ = kvstore.NewNodeStore(context.Background(), kvstore.NodeStoreParams{
 		BucketName: "test_nodes",
-		Client:     natsClient,
+		Client:     s.natsClient,
 	})
 }
 
@@ -208,6 +211,31 @@ func (s *KVNodeInfoStoreSuite) Test_Replace() {
 	s.ElementsMatch([]models.NodeState{nodeInfo1}, allNodeInfos)
 }
 
+func (s *KVNodeInfoStoreSuite) Test_BucketNameWithPrefix() {
+	s.Equal(kvstore.BucketNameCurrent, kvstore.BucketNameWithPrefix(""))
+	s.Equal(kvstore.BucketNameCurrent, kvstore.BucketNameWithPrefix(nclprotocol.DefaultSubjectPrefix))
+	s.Equal("cluster_a_node_v1", kvstore.BucketNameWithPrefix("cluster.a"))
+
+	// clusters with different prefixes sharing a NATS server don't see each other's nodes
+	ctx := context.Background()
+	storeA, err := kvstore.NewNodeStore(ctx, kvstore.NodeStoreParams{
+		BucketName: kvstore.BucketNameWithPrefix("cluster.a"),
+		Client:     s.natsClient,
+	})
+	s.Require().NoError(err)
+	storeB, err := kvstore.NewNodeStore(ctx, kvstore.NodeStoreParams{
+		BucketName: kvstore.BucketNameWithPrefix("cluster.b"),
+		Client:     s.natsClient,
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(storeA.Put(ctx, generateNodeState(nodeIDs[0], models.EngineDocker)))
+	_, err = storeA.Get(ctx, nodeIDs[0])
+	s.NoError(err)
+	_, err = storeB.Get(ctx, nodeIDs[0])
+	s.Error(err)
+}
+
 func generateNodeState(peerID string, engines ...string) models.NodeState {
 	return models.NodeState{
 		Info: generateNodeInfo(peerID, engines...),
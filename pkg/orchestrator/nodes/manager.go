@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -431,6 +432,16 @@ func (n *nodesManager) Handshake(
 			}, nil
 		}
 
+		// The node must sign its messages with the key pinned on its first handshake
+		if len(existing.PublicKey) > 0 && !bytes.Equal(existing.PublicKey, request.PublicKey) {
+			log.Warn().Msgf("handshake of node %s rejected due to a public key mismatch", request.NodeInfo.ID())
+			return messages.HandshakeResponse{
+				Accepted: false,
+				Reason: "node public key does not match the key it registered with. " +
+					"Delete the node from the orchestrator to register it with a new key",
+			}, nil
+		}
+
 		isReconnect = true
 		existingConnectionState = existing.ConnectionState.Status
 
@@ -452,6 +463,7 @@ func (n *nodesManager) Handshake(
 	state := models.NodeState{
 		Info:       request.NodeInfo,
 		Membership: n.defaultApprovalState,
+		PublicKey:  request.PublicKey,
 		ConnectionState: models.ConnectionState{
 			Status:         models.NodeStates.CONNECTED,
 			ConnectedSince: n.clock.Now().UTC(),
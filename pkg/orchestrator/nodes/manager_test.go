@@ -133,6 +133,32 @@ func (s *NodeManagerTestSuite) TestReconnectHandshake() {
 	assert.Contains(s.T(), resp2.Reason, "reconnected")
 }
 
+func (s *NodeManagerTestSuite) TestHandshakePinsPublicKey() {
+	nodeInfo := s.createNodeInfo("node1")
+	key := []byte("compute-node-public-key")
+
+	resp, err := s.manager.Handshake(s.ctx, messages.HandshakeRequest{NodeInfo: nodeInfo, PublicKey: key})
+	s.Require().NoError(err)
+	s.Require().True(resp.Accepted)
+
+	state, err := s.manager.Get(s.ctx, nodeInfo.ID())
+	s.Require().NoError(err)
+	s.Equal(key, state.PublicKey)
+
+	// reconnecting with the same key is accepted
+	resp, err = s.manager.Handshake(s.ctx, messages.HandshakeRequest{NodeInfo: nodeInfo, PublicKey: key})
+	s.Require().NoError(err)
+	s.True(resp.Accepted)
+
+	// reconnecting with another key, or without a key, is rejected
+	for _, otherKey := range [][]byte{[]byte("other-public-key"), nil} {
+		resp, err = s.manager.Handshake(s.ctx, messages.HandshakeRequest{NodeInfo: nodeInfo, PublicKey: otherKey})
+		s.Require().NoError(err)
+		s.False(resp.Accepted)
+		s.Contains(resp.Reason, "public key does not match")
+	}
+}
+
 func (s *NodeManagerTestSuite) TestHeartbeatMaintainsConnection() {
 	// Initial handshake
 	nodeInfo := s.createNodeInfo("node1")
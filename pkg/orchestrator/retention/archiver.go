@@ -0,0 +1,111 @@
+package retention
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+const (
+	archiveFilePrefix = "jobs-"
+	archiveFileSuffix = ".jsonl.gz"
+	archiveFilePerm   = 0600
+)
+
+// FileArchiver archives jobs to gzip compressed JSON lines files, with one
+// record per line and one file per retention run.
+type FileArchiver struct {
+	dir   string
+	clock clock.Clock
+}
+
+// FileArchiverOption configures a FileArchiver
+type FileArchiverOption func(*FileArchiver)
+
+// WithArchiverClock sets the clock used to name archive files
+func WithArchiverClock(clock clock.Clock) FileArchiverOption {
+	return func(a *FileArchiver) {
+		a.clock = clock
+	}
+}
+
+// NewFileArchiver creates a FileArchiver writing archives to the given directory
+func NewFileArchiver(dir string, opts ...FileArchiverOption) (*FileArchiver, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("job archive directory not set")
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create job archive directory %s: %w", dir, err)
+	}
+	a := &FileArchiver{
+		dir:   dir,
+		clock: clock.New(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
+}
+
+// Dir returns the directory archives are written to
+func (a *FileArchiver) Dir() string {
+	return a.dir
+}
+
+// Archive writes the records to a new archive file and returns its path.
+// The file is written under a temporary name and only renamed once complete,
+// so a partially written archive is never mistaken for a complete one.
+func (a *FileArchiver) Archive(ctx context.Context, records []models.JobArchiveRecord) (string, error) {
+	if len(records) == 0 {
+		return "", nil
+	}
+	path := filepath.Join(a.dir, fmt.Sprintf("%s%d%s", archiveFilePrefix, a.clock.Now().UnixNano(), archiveFileSuffix))
+	tmpPath := path + ".tmp"
+
+	if err := writeArchive(ctx, tmpPath, records); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write job archive %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write job archive %s: %w", path, err)
+	}
+	return path, nil
+}
+
+func writeArchive(ctx context.Context, path string, records []models.JobArchiveRecord) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, archiveFilePerm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(file)
+	encoder := json.NewEncoder(gz)
+	for i := range records {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if err = encoder.Encode(&records[i]); err != nil {
+			return err
+		}
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// compile-time check that FileArchiver implements Archiver
+var _ Archiver = (*FileArchiver)(nil)
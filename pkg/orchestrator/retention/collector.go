@@ -0,0 +1,324 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/validate"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+const debugInfoComponent = "JobRetention"
+
+type CollectorParams struct {
+	JobStore jobstore.Store
+	// Policy is the retention policy applied on each scheduled run
+	Policy Policy
+	// Interval is the interval at which the retention policy is applied.
+	// The policy is only applied on manual prune requests if zero.
+	Interval time.Duration
+	// Archiver exports jobs before they are purged. Optional.
+	Archiver Archiver
+	// ArchiveDir is the directory jobs are archived to, reported in stats. Optional.
+	ArchiveDir string
+	// Clock is the clock used for time-based operations.
+	// If not provided, the system clock is used.
+	Clock clock.Clock
+}
+
+// Collector purges terminal jobs, along with their executions, history and
+// evaluations, from the job store once they fall outside the retention policy.
+// Jobs are optionally archived before being purged.
+type Collector struct {
+	jobStore jobstore.Store
+	policy   Policy
+	interval time.Duration
+	archiver Archiver
+	clock    clock.Clock
+
+	// runMu serializes retention runs
+	runMu     sync.Mutex
+	statsMu   sync.RWMutex
+	stats     models.JobRetentionStats
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+}
+
+// NewCollector creates a new Collector
+func NewCollector(params CollectorParams) (*Collector, error) {
+	if params.Clock == nil {
+		params.Clock = clock.New()
+	}
+	err := errors.Join(
+		validate.NotNil(params.JobStore, "job store cannot be nil"),
+		validate.IsGreaterOrEqualToZero(params.Interval, "interval cannot be negative"),
+		validate.IsGreaterOrEqualToZero(params.Policy.MaxAge, "max age cannot be negative"),
+		validate.IsGreaterOrEqualToZero(params.Policy.MaxJobsPerNamespace, "max jobs per namespace cannot be negative"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error validating job retention params: %w", err)
+	}
+
+	return &Collector{
+		jobStore: params.JobStore,
+		policy:   params.Policy,
+		interval: params.Interval,
+		archiver: params.Archiver,
+		clock:    params.Clock,
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+		stats: models.JobRetentionStats{
+			MaxAge:              params.Policy.MaxAge,
+			MaxJobsPerNamespace: params.Policy.MaxJobsPerNamespace,
+			ArchiveDir:          params.ArchiveDir,
+		},
+	}, nil
+}
+
+// Start periodically applies the retention policy until stopped.
+// It does nothing if the interval or the policy is not set.
+func (c *Collector) Start(ctx context.Context) {
+	if c.interval <= 0 || c.policy.IsEmpty() {
+		return
+	}
+	c.startOnce.Do(func() {
+		c.statsMu.Lock()
+		c.stats.Enabled = true
+		c.statsMu.Unlock()
+		go c.run(ctx)
+	})
+}
+
+// Stop stops applying the retention policy, waiting for an inflight run
+// to complete or until the context is done.
+func (c *Collector) Stop(ctx context.Context) {
+	c.stopOnce.Do(func() {
+		close(c.stopChan)
+		c.statsMu.RLock()
+		started := c.stats.Enabled
+		c.statsMu.RUnlock()
+		if !started {
+			return
+		}
+		select {
+		case <-c.doneChan:
+		case <-ctx.Done():
+		}
+	})
+}
+
+func (c *Collector) run(ctx context.Context) {
+	defer close(c.doneChan)
+	ticker := c.clock.Ticker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			result, err := c.ApplyPolicy(ctx)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to apply job retention policy")
+				continue
+			}
+			if len(result.JobIDs) > 0 {
+				log.Ctx(ctx).Info().Msgf("purged %d terminal jobs from the job store", len(result.JobIDs))
+			}
+		case <-ctx.Done():
+			return
+		case <-c.stopChan:
+			return
+		}
+	}
+}
+
+// ApplyPolicy purges the terminal jobs that fall outside the retention policy
+func (c *Collector) ApplyPolicy(ctx context.Context) (PruneResult, error) {
+	return c.prune(ctx, "", c.policy, false)
+}
+
+// Prune purges terminal jobs that were last modified before the requested age,
+// regardless of the configured retention policy.
+func (c *Collector) Prune(ctx context.Context, request PruneRequest) (PruneResult, error) {
+	if request.OlderThan <= 0 {
+		return PruneResult{}, bacerrors.New("prune age must be greater than zero").
+			WithCode(bacerrors.ValidationError).
+			WithComponent(debugInfoComponent)
+	}
+	return c.prune(ctx, request.Namespace, Policy{MaxAge: request.OlderThan}, request.DryRun)
+}
+
+// GetStats returns the activity of the collector
+func (c *Collector) GetStats() models.JobRetentionStats {
+	c.statsMu.RLock()
+	defer c.statsMu.RUnlock()
+	return c.stats
+}
+
+// GetDebugInfo returns the activity of the collector for the debug endpoint
+func (c *Collector) GetDebugInfo(_ context.Context) (models.DebugInfo, error) {
+	return models.DebugInfo{
+		Component: debugInfoComponent,
+		Info:      c.GetStats(),
+	}, nil
+}
+
+func (c *Collector) prune(ctx context.Context, namespace string, policy Policy, dryRun bool) (PruneResult, error) {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	jobs, err := c.selectJobs(ctx, namespace, policy)
+	if err != nil {
+		return PruneResult{}, c.recordRun(0, 0, err)
+	}
+	result := PruneResult{JobIDs: make([]string, 0, len(jobs))}
+	if dryRun {
+		for i := range jobs {
+			result.JobIDs = append(result.JobIDs, jobs[i].ID)
+		}
+		return result, nil
+	}
+	if len(jobs) == 0 {
+		return result, c.recordRun(0, 0, nil)
+	}
+
+	// archive all jobs before purging any, so that a failure to archive never loses jobs
+	archived := 0
+	if c.archiver != nil {
+		records, archiveErr := c.archiveRecords(ctx, jobs)
+		if archiveErr != nil {
+			return result, c.recordRun(0, 0, archiveErr)
+		}
+		result.ArchivePath, archiveErr = c.archiver.Archive(ctx, records)
+		if archiveErr != nil {
+			return result, c.recordRun(0, 0, archiveErr)
+		}
+		archived = len(records)
+	}
+
+	var errs error
+	for i := range jobs {
+		if err = c.jobStore.DeleteJob(ctx, jobs[i].ID); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to purge job %s: %w", jobs[i].ID, err))
+			continue
+		}
+		result.JobIDs = append(result.JobIDs, jobs[i].ID)
+	}
+	return result, c.recordRun(len(result.JobIDs), archived, errs)
+}
+
+// selectJobs returns the terminal jobs that fall outside the policy
+func (c *Collector) selectJobs(ctx context.Context, namespace string, policy Policy) ([]models.Job, error) {
+	if policy.IsEmpty() {
+		return nil, nil
+	}
+	response, err := c.jobStore.GetJobs(ctx, jobstore.JobQuery{
+		Namespace: namespace,
+		ReturnAll: namespace == "",
+		SortBy:    "modified_at",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	// group terminal jobs by namespace, keeping them sorted from oldest to newest
+	byNamespace := make(map[string][]models.Job)
+	var namespaces []string
+	for _, job := range response.Jobs {
+		if !job.IsTerminal() {
+			continue
+		}
+		if _, ok := byNamespace[job.Namespace]; !ok {
+			namespaces = append(namespaces, job.Namespace)
+		}
+		byNamespace[job.Namespace] = append(byNamespace[job.Namespace], job)
+	}
+	slices.Sort(namespaces)
+
+	cutoff := c.clock.Now().Add(-policy.MaxAge).UnixNano()
+	var selected []models.Job
+	for _, ns := range namespaces {
+		jobs := byNamespace[ns]
+		excess := 0
+		if policy.MaxJobsPerNamespace > 0 && len(jobs) > policy.MaxJobsPerNamespace {
+			excess = len(jobs) - policy.MaxJobsPerNamespace
+		}
+		for i := range jobs {
+			if i < excess || (policy.MaxAge > 0 && jobs[i].ModifyTime < cutoff) {
+				selected = append(selected, jobs[i])
+			}
+		}
+	}
+	return selected, nil
+}
+
+// archiveRecords collects everything stored about the jobs to be archived
+func (c *Collector) archiveRecords(ctx context.Context, jobs []models.Job) ([]models.JobArchiveRecord, error) {
+	records := make([]models.JobArchiveRecord, 0, len(jobs))
+	for i := range jobs {
+		executions, err := c.jobStore.GetExecutions(ctx, jobstore.GetExecutionsOptions{JobID: jobs[i].ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get executions of job %s: %w", jobs[i].ID, err)
+		}
+		evaluations, err := c.jobStore.GetEvaluations(ctx, jobs[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get evaluations of job %s: %w", jobs[i].ID, err)
+		}
+		history, err := c.jobHistory(ctx, jobs[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history of job %s: %w", jobs[i].ID, err)
+		}
+		records = append(records, models.JobArchiveRecord{
+			Job:         jobs[i],
+			Executions:  executions,
+			Evaluations: evaluations,
+			History:     history,
+		})
+	}
+	return records, nil
+}
+
+func (c *Collector) jobHistory(ctx context.Context, jobID string) ([]models.JobHistory, error) {
+	var history []models.JobHistory
+	query := jobstore.JobHistoryQuery{}
+	for {
+		response, err := c.jobStore.GetJobHistory(ctx, jobID, query)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, response.JobHistory...)
+		if response.NextToken == "" || len(response.JobHistory) == 0 {
+			return history, nil
+		}
+		query.NextToken = response.NextToken
+	}
+}
+
+// recordRun updates the stats with the outcome of a retention run and returns the run error
+func (c *Collector) recordRun(pruned, archived int, err error) error {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	c.stats.Runs++
+	c.stats.PrunedJobs += uint64(pruned)     //nolint:gosec // G115: counts are never negative
+	c.stats.ArchivedJobs += uint64(archived) //nolint:gosec // G115: counts are never negative
+	c.stats.LastRunTime = c.clock.Now()
+	c.stats.LastRunPruned = pruned
+	c.stats.LastError = ""
+	if err != nil {
+		c.stats.LastError = err.Error()
+	}
+	return err
+}
+
+// compile-time check that Collector implements models.DebugInfoProvider
+var _ models.DebugInfoProvider = (*Collector)(nil)
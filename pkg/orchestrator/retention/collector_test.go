@@ -0,0 +1,203 @@
+//go:build unit || !integration
+
+package retention
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	boltjobstore "github.com/bacalhau-project/bacalhau/pkg/jobstore/boltdb"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+)
+
+type CollectorTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	clock *clock.Mock
+	store jobstore.Store
+}
+
+func TestCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectorTestSuite))
+}
+
+func (s *CollectorTestSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	s.clock = clock.NewMock()
+	s.clock.Set(time.Unix(1_000_000, 0))
+	s.store, err = boltjobstore.NewBoltJobStore(
+		filepath.Join(s.T().TempDir(), "jobs.db"), boltjobstore.WithClock(s.clock))
+	s.Require().NoError(err)
+}
+
+func (s *CollectorTestSuite) TearDownTest() {
+	s.Require().NoError(s.store.Close(s.ctx))
+}
+
+// createJob creates a job in the given namespace and state, with an execution
+func (s *CollectorTestSuite) createJob(namespace string, state models.JobStateType) *models.Job {
+	job := mock.Job()
+	job.Namespace = namespace
+	s.Require().NoError(s.store.CreateJob(s.ctx, *job))
+	s.Require().NoError(s.store.CreateExecution(s.ctx, *mock.ExecutionForJob(job)))
+	s.Require().NoError(s.store.AddJobHistory(s.ctx, job.ID, *models.NewEvent("test").WithMessage("created")))
+	if state != models.JobStateTypePending {
+		s.Require().NoError(s.store.UpdateJobState(s.ctx, jobstore.UpdateJobStateRequest{
+			JobID:    job.ID,
+			NewState: state,
+		}))
+	}
+	return job
+}
+
+func (s *CollectorTestSuite) newCollector(policy Policy, archiver Archiver) *Collector {
+	collector, err := NewCollector(CollectorParams{
+		JobStore: s.store,
+		Policy:   policy,
+		Archiver: archiver,
+		Clock:    s.clock,
+	})
+	s.Require().NoError(err)
+	return collector
+}
+
+func (s *CollectorTestSuite) assertJobExists(jobID string, exists bool) {
+	_, err := s.store.GetJob(s.ctx, jobID)
+	if exists {
+		s.NoError(err)
+	} else {
+		s.True(bacerrors.IsErrorWithCode(err, bacerrors.NotFoundError), "expected job %s to be purged", jobID)
+	}
+}
+
+func (s *CollectorTestSuite) TestApplyPolicyMaxAge() {
+	oldCompleted := s.createJob("ns1", models.JobStateTypeCompleted)
+	oldRunning := s.createJob("ns1", models.JobStateTypeRunning)
+	s.clock.Add(2 * time.Hour)
+	recentFailed := s.createJob("ns1", models.JobStateTypeFailed)
+
+	collector := s.newCollector(Policy{MaxAge: time.Hour}, nil)
+	result, err := collector.ApplyPolicy(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{oldCompleted.ID}, result.JobIDs)
+	s.Empty(result.ArchivePath)
+
+	s.assertJobExists(oldCompleted.ID, false)
+	s.assertJobExists(oldRunning.ID, true)
+	s.assertJobExists(recentFailed.ID, true)
+
+	stats := collector.GetStats()
+	s.Equal(uint64(1), stats.Runs)
+	s.Equal(uint64(1), stats.PrunedJobs)
+	s.Equal(1, stats.LastRunPruned)
+	s.Equal(s.clock.Now(), stats.LastRunTime)
+	s.Empty(stats.LastError)
+}
+
+func (s *CollectorTestSuite) TestApplyPolicyMaxJobsPerNamespace() {
+	var ns1 []*models.Job
+	for i := 0; i < 3; i++ {
+		ns1 = append(ns1, s.createJob("ns1", models.JobStateTypeCompleted))
+		s.clock.Add(time.Minute)
+	}
+	ns2 := s.createJob("ns2", models.JobStateTypeStopped)
+	running := s.createJob("ns1", models.JobStateTypeRunning)
+
+	collector := s.newCollector(Policy{MaxJobsPerNamespace: 1}, nil)
+	result, err := collector.ApplyPolicy(s.ctx)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{ns1[0].ID, ns1[1].ID}, result.JobIDs)
+
+	s.assertJobExists(ns1[2].ID, true)
+	s.assertJobExists(ns2.ID, true)
+	s.assertJobExists(running.ID, true)
+}
+
+func (s *CollectorTestSuite) TestPrune() {
+	ns1 := s.createJob("ns1", models.JobStateTypeCompleted)
+	ns2 := s.createJob("ns2", models.JobStateTypeCompleted)
+	s.clock.Add(2 * time.Hour)
+
+	// policy is ignored by manual prune requests
+	collector := s.newCollector(Policy{}, nil)
+
+	_, err := collector.Prune(s.ctx, PruneRequest{})
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ValidationError))
+
+	result, err := collector.Prune(s.ctx, PruneRequest{OlderThan: time.Hour, Namespace: "ns1", DryRun: true})
+	s.Require().NoError(err)
+	s.Equal([]string{ns1.ID}, result.JobIDs)
+	s.assertJobExists(ns1.ID, true)
+	s.Equal(uint64(0), collector.GetStats().Runs)
+
+	result, err = collector.Prune(s.ctx, PruneRequest{OlderThan: time.Hour, Namespace: "ns1"})
+	s.Require().NoError(err)
+	s.Equal([]string{ns1.ID}, result.JobIDs)
+	s.assertJobExists(ns1.ID, false)
+	s.assertJobExists(ns2.ID, true)
+
+	result, err = collector.Prune(s.ctx, PruneRequest{OlderThan: 3 * time.Hour})
+	s.Require().NoError(err)
+	s.Empty(result.JobIDs)
+	s.assertJobExists(ns2.ID, true)
+}
+
+func (s *CollectorTestSuite) TestArchive() {
+	job := s.createJob("ns1", models.JobStateTypeCompleted)
+	eval := mock.EvalForJob(job)
+	s.Require().NoError(s.store.CreateEvaluation(s.ctx, *eval))
+	s.clock.Add(2 * time.Hour)
+
+	archiver, err := NewFileArchiver(filepath.Join(s.T().TempDir(), "archive"), WithArchiverClock(s.clock))
+	s.Require().NoError(err)
+	collector := s.newCollector(Policy{MaxAge: time.Hour}, archiver)
+
+	result, err := collector.ApplyPolicy(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{job.ID}, result.JobIDs)
+	s.Require().NotEmpty(result.ArchivePath)
+	s.Equal(archiver.Dir(), filepath.Dir(result.ArchivePath))
+	s.Equal(uint64(1), collector.GetStats().ArchivedJobs)
+	s.assertJobExists(job.ID, false)
+
+	records := s.readArchive(result.ArchivePath)
+	s.Require().Len(records, 1)
+	s.Equal(job.ID, records[0].Job.ID)
+	s.Equal(models.JobStateTypeCompleted, records[0].Job.State.StateType)
+	s.Len(records[0].Executions, 1)
+	s.Require().Len(records[0].Evaluations, 1)
+	s.Equal(eval.ID, records[0].Evaluations[0].ID)
+	s.NotEmpty(records[0].History)
+}
+
+func (s *CollectorTestSuite) readArchive(path string) []models.JobArchiveRecord {
+	file, err := os.Open(path)
+	s.Require().NoError(err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	s.Require().NoError(err)
+
+	var records []models.JobArchiveRecord
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 10*1024*1024)
+	for scanner.Scan() {
+		var record models.JobArchiveRecord
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	s.Require().NoError(scanner.Err())
+	return records
+}
@@ -0,0 +1,48 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Policy describes which terminal jobs are purged from the job store.
+// A job is purged if it matches any of the configured rules.
+type Policy struct {
+	// MaxAge is how long terminal jobs are retained after they were last modified.
+	// Jobs are not purged based on their age if zero.
+	MaxAge time.Duration
+	// MaxJobsPerNamespace is the number of most recent terminal jobs retained per namespace.
+	// Jobs are not purged based on their count if zero.
+	MaxJobsPerNamespace int
+}
+
+// IsEmpty returns true if the policy never purges any job
+func (p Policy) IsEmpty() bool {
+	return p.MaxAge <= 0 && p.MaxJobsPerNamespace <= 0
+}
+
+// PruneRequest is a request to purge terminal jobs older than a given age
+type PruneRequest struct {
+	// OlderThan is the minimum time since terminal jobs were last modified to be purged
+	OlderThan time.Duration
+	// Namespace limits the purge to a single namespace. All namespaces are considered if empty.
+	Namespace string
+	// DryRun returns the jobs that would be purged without purging them
+	DryRun bool
+}
+
+// PruneResult describes the jobs purged by a retention run
+type PruneResult struct {
+	// JobIDs are the IDs of the purged jobs
+	JobIDs []string
+	// ArchivePath is the file the jobs were archived to before being purged, if any
+	ArchivePath string
+}
+
+// Archiver exports jobs before they are purged from the job store
+type Archiver interface {
+	// Archive persists the records and returns the location they were archived to
+	Archive(ctx context.Context, records []models.JobArchiveRecord) (string, error)
+}
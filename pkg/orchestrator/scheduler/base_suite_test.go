@@ -44,13 +44,26 @@ func (s *BaseTestSuite) mockJobStore(scenario *Scenario) {
 	s.jobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: scenario.job.ID}).Return(scenario.executions, nil)
 }
 
-func (s *BaseTestSuite) mockAllNodes(nodeIDs ...string) []models.NodeInfo {
-	nodeInfos := make([]models.NodeInfo, len(nodeIDs))
+func (s *BaseTestSuite) mockAllNodes(nodeIDs ...string) []models.NodeState {
+	nodeStates := make([]models.NodeState, len(nodeIDs))
 	for i, nodeID := range nodeIDs {
-		nodeInfos[i] = fakeNodeInfo(s.T(), nodeID)
+		nodeStates[i] = fakeNodeState(s.T(), nodeID)
 	}
-	s.nodeSelector.EXPECT().AllNodes(gomock.Any()).Return(nodeInfos, nil)
-	return nodeInfos
+	s.nodeSelector.EXPECT().AllNodes(gomock.Any()).Return(nodeStates, nil)
+	return nodeStates
+}
+
+// mockDisconnectedNodes mocks the node selector to return nodes that have been
+// disconnected since the given duration
+func (s *BaseTestSuite) mockDisconnectedNodes(disconnectedFor time.Duration, nodeIDs ...string) []models.NodeState {
+	nodeStates := make([]models.NodeState, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		nodeStates[i] = fakeNodeState(s.T(), nodeID)
+		nodeStates[i].ConnectionState.Status = models.NodeStates.DISCONNECTED
+		nodeStates[i].ConnectionState.DisconnectedSince = s.clock.Now().Add(-disconnectedFor)
+	}
+	s.nodeSelector.EXPECT().AllNodes(gomock.Any()).Return(nodeStates, nil)
+	return nodeStates
 }
 
 func (s *BaseTestSuite) mockMatchingNodes(scenario *Scenario, nodeIDs ...string) []orchestrator.NodeRank {
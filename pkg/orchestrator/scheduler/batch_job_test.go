@@ -266,3 +266,39 @@ func (s *BatchJobSchedulerTestSuite) TestProcess_ShouldStopExpiredExecutions() {
 	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
 	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
 }
+
+func (s *BatchJobSchedulerTestSuite) TestProcess_ShouldOnlyScheduleJobPartitions() {
+	scenario := NewScenario(
+		WithCount(4),
+		WithPartitions(1, 3),
+	)
+	s.mockJobStore(scenario)
+	s.mockMatchingNodes(scenario, "node0", "node1", "node2", "node3")
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		NewExecutions: []*models.Execution{
+			{NodeID: "node0", PartitionIndex: 1},
+			{NodeID: "node1", PartitionIndex: 3},
+		},
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchJobSchedulerTestSuite) TestProcess_ShouldMarkJobAsCompleted_JobPartitions() {
+	scenario := NewScenario(
+		WithCount(4),
+		WithPartitions(1, 3),
+		WithPartitionedExecution("node0", models.ExecutionStateCompleted, 1),
+		WithPartitionedExecution("node1", models.ExecutionStateCompleted, 3),
+	)
+	s.mockJobStore(scenario)
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		JobState:   models.JobStateTypeCompleted,
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
@@ -29,6 +30,12 @@ type BatchServiceJobSchedulerParams struct {
 	// Clock is the clock used for time-based operations.
 	// If not provided, the system clock is used.
 	Clock clock.Clock
+	// BidTimeout is how long to wait for a node to respond to a bid request
+	// before offering the execution to the next ranked node. Zero disables bid timeouts.
+	BidTimeout time.Duration
+	// BidOverAsk is the number of additional nodes asked to bid in parallel for each partition.
+	// The first accepted bid is approved, and the others are rejected.
+	BidOverAsk int
 }
 
 // BatchServiceJobScheduler handles scheduling of batch and service jobs, with support for
@@ -62,6 +69,8 @@ type BatchServiceJobScheduler struct {
 	queueBackoff  time.Duration
 	rateLimiter   ExecutionRateLimiter
 	clock         clock.Clock
+	bidTimeout    time.Duration
+	bidOverAsk    int
 }
 
 func NewBatchServiceJobScheduler(params BatchServiceJobSchedulerParams) *BatchServiceJobScheduler {
@@ -79,6 +88,8 @@ func NewBatchServiceJobScheduler(params BatchServiceJobSchedulerParams) *BatchSe
 		queueBackoff:  params.QueueBackoff,
 		rateLimiter:   params.RateLimiter,
 		clock:         params.Clock,
+		bidTimeout:    params.BidTimeout,
+		bidOverAsk:    max(params.BidOverAsk, 0),
 	}
 }
 
@@ -124,7 +135,7 @@ func (b *BatchServiceJobScheduler) Process(ctx context.Context, evaluation *mode
 	}
 
 	// Retrieve the info for all the nodes that have executions for this job
-	nodeInfos, err := existingNodeInfos(ctx, b.selector, nonTerminalExecs)
+	nodeStates, err := existingNodeStates(ctx, b.selector, nonTerminalExecs)
 	if err != nil {
 		return err
 	}
@@ -134,7 +145,8 @@ func (b *BatchServiceJobScheduler) Process(ctx context.Context, evaluation *mode
 	allFailedExecs := existingExecs.filterFailed()
 
 	// Mark executions that are running on nodes that are not healthy as failed
-	nonTerminalExecs, lost := nonTerminalExecs.groupByNodeHealth(nodeInfos)
+	nonTerminalExecs, lost := nonTerminalExecs.groupByNodeHealth(
+		nodeStates, job.Task().Timeouts.GetDisconnectGracePeriod(), b.clock.Now())
 	if len(lost) > 0 {
 		lost.markFailed(plan, orchestrator.ExecStoppedByNodeUnhealthyEvent())
 		metrics.CountAndHistogram(ctx, executionsLostTotal, executionsLost, float64(len(lost)))
@@ -142,6 +154,7 @@ func (b *BatchServiceJobScheduler) Process(ctx context.Context, evaluation *mode
 	}
 
 	nonTerminalExecs, allFailedExecs = b.handleTimeouts(ctx, metrics, plan, nonTerminalExecs, allFailedExecs)
+	nonTerminalExecs = b.handleBidTimeouts(ctx, metrics, plan, nonTerminalExecs)
 
 	// nonDiscardedExec is the set of executions that are either active or successfully completed
 	nonDiscardedExecs := nonTerminalExecs.union(existingExecs.filterCompleted())
@@ -225,6 +238,23 @@ func (b *BatchServiceJobScheduler) handleTimeouts(ctx context.Context, metrics *
 	return nonTerminalExecs, allFailedExecs
 }
 
+// handleBidTimeouts rejects executions whose node did not respond to the bid request within the bid timeout.
+// Their partitions are offered to the next ranked nodes, without counting as a failed attempt,
+// as the nodes were never confirmed to run the job.
+func (b *BatchServiceJobScheduler) handleBidTimeouts(ctx context.Context, metrics *telemetry.MetricRecorder,
+	plan *models.Plan, nonTerminalExecs execSet) execSet {
+	if b.bidTimeout <= 0 {
+		return nonTerminalExecs
+	}
+	remaining, timedOut := nonTerminalExecs.groupByBidTimeout(b.clock.Now().Add(-b.bidTimeout))
+	if len(timedOut) > 0 {
+		timedOut.markRejected(plan, orchestrator.ExecStoppedByBidTimeoutEvent(b.bidTimeout))
+		metrics.CountN(ctx, bidsTimedOut, int64(len(timedOut)))
+		log.Ctx(ctx).Debug().Msgf("%d bid requests timed out after %s", len(timedOut), b.bidTimeout)
+	}
+	return remaining
+}
+
 // approveRejectExecs processes executions partition by partition (0 to job.Count-1).
 // For each partition:
 // - If partition has a completed execution: reject all other executions
@@ -244,7 +274,10 @@ func (b *BatchServiceJobScheduler) approveRejectExecs(nonDiscardedExecs execSet,
 
 func (b *BatchServiceJobScheduler) scheduleRemainingPartitions(ctx context.Context, metrics *telemetry.MetricRecorder, plan *models.Plan,
 	nonDiscardedExecs execSet, allFailedExecs execSet) error {
-	remainingPartitions := nonDiscardedExecs.remainingPartitions(plan.Job.Count)
+	// only schedule the partitions the job should run, such as when rerunning failed partitions of a previous job
+	remainingPartitions := lo.Filter(nonDiscardedExecs.remainingPartitions(plan.Job.Count), func(partition int, _ int) bool {
+		return plan.Job.IsPartitionScheduled(partition)
+	})
 	if len(remainingPartitions) == 0 {
 		return nil
 	}
@@ -319,12 +352,14 @@ func (b *BatchServiceJobScheduler) createMissingExecs(
 		}
 	}
 
-	// Apply rate limiting
-	execsToCreate := min(len(matching), len(remainingPartitions))
+	// Apply rate limiting. When over-asking, each partition is first assigned to a node
+	// before additional nodes are asked to bid for the same partitions.
+	execsToCreate := min(len(matching), len(remainingPartitions)*(1+b.bidOverAsk))
 	execsToCreate = b.rateLimiter.Apply(ctx, plan, execsToCreate)
 
 	// Create executions
 	var count float64
+	now := b.clock.Now()
 	for i := 0; i < execsToCreate; i++ {
 		execution := &models.Execution{
 			NodeID:         matching[i].NodeInfo.ID(),
@@ -335,7 +370,8 @@ func (b *BatchServiceJobScheduler) createMissingExecs(
 			Namespace:      plan.Job.Namespace,
 			ComputeState:   models.NewExecutionState(models.ExecutionStateNew),
 			DesiredState:   models.NewExecutionDesiredState(models.ExecutionDesiredStatePending),
-			PartitionIndex: remainingPartitions[i],
+			PartitionIndex: remainingPartitions[i%len(remainingPartitions)],
+			CreateTime:     now.UnixNano(),
 		}
 		execution.Normalize()
 		plan.AppendExecution(execution, orchestrator.ExecCreatedEvent(execution))
@@ -343,6 +379,14 @@ func (b *BatchServiceJobScheduler) createMissingExecs(
 	}
 	metrics.CountAndHistogram(ctx, executionsCreatedTotal, executionsCreated, count)
 
+	// re-evaluate the job at the bid deadline, in case some nodes never respond
+	if execsToCreate > 0 && b.bidTimeout > 0 {
+		deadlineEvaluation := plan.Eval.NewDelayedEvaluation(now.Add(b.bidTimeout)).
+			WithTriggeredBy(models.EvalTriggerBidTimeout).
+			WithComment(fmt.Sprintf("bid deadline of executions created by evaluation %s", plan.EvalID))
+		plan.AppendEvaluation(deadlineEvaluation)
+	}
+
 	return nil
 }
 
@@ -360,15 +404,14 @@ func (b *BatchServiceJobScheduler) createDelayedEvaluation(ctx context.Context,
 // For a job with Count = N, this means:
 // - We have exactly N completed partitions (one per index 0 to N-1)
 // - Each partition has exactly one successful execution
+// If the job only runs a subset of its partitions, only those partitions need to complete.
 // Only applicable to batch jobs as service jobs run continuously.
 func (b *BatchServiceJobScheduler) isJobComplete(job *models.Job, existingExecs execSet) bool {
 	if job.Type != models.JobTypeBatch {
 		return false
 	}
-	if len(existingExecs.completedPartitions()) < job.Count {
-		return false
-	}
-	return true
+	completed := lo.CountBy(lo.Keys(existingExecs.completedPartitions()), job.IsPartitionScheduled)
+	return completed >= job.ScheduledPartitionCount()
 }
 
 // compile-time assertion that BatchServiceJobScheduler satisfies the Scheduler interface
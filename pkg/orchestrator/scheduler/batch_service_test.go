@@ -262,6 +262,173 @@ func (s *BatchServiceJobSchedulerTestSuite) TestProcess_ShouldPreservePartitionO
 	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
 }
 
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_DisconnectedNodeWithinGracePeriod() {
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(1),
+		WithPartitionedExecution("node0", models.ExecutionStateBidAccepted, 0),
+		WithDisconnectGracePeriod(10*time.Minute),
+	)
+	s.mockJobStore(scenario)
+
+	// node0 may still be running the execution while offline, and report its result on reconnect
+	s.mockDisconnectedNodes(time.Minute, "node0")
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_DisconnectedNodeAfterGracePeriod() {
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(1),
+		WithPartitionedExecution("node0", models.ExecutionStateBidAccepted, 0),
+		WithDisconnectGracePeriod(10*time.Minute),
+	)
+	s.mockJobStore(scenario)
+
+	// node0 didn't reconnect within the grace period, so its execution is rescheduled
+	s.mockDisconnectedNodes(time.Hour, "node0")
+	s.mockMatchingNodes(scenario, "node1")
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		NewExecutions: []*models.Execution{
+			{NodeID: "node1", PartitionIndex: 0},
+		},
+		UpdatedExecutions: []ExecutionStateUpdate{
+			{
+				ExecutionID:  scenario.executions[0].ID,
+				DesiredState: models.ExecutionDesiredStateStopped,
+				ComputeState: models.ExecutionStateFailed,
+			},
+		},
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_BidWithinTimeout() {
+	s.scheduler.bidTimeout = 30 * time.Second
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(1),
+		WithPartitionedExecution("node0", models.ExecutionStateNew, 0),
+	)
+	scenario.executions[0].CreateTime = s.clock.Now().Add(-10 * time.Second).UnixNano()
+	s.mockJobStore(scenario)
+	s.mockAllNodes("node0")
+
+	// node0 still has time to respond to the bid request
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_BidTimeout() {
+	s.scheduler.bidTimeout = 30 * time.Second
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(1),
+		WithPartitionedExecution("node0", models.ExecutionStateNew, 0),
+	)
+	scenario.executions[0].CreateTime = s.clock.Now().Add(-time.Minute).UnixNano()
+	s.mockJobStore(scenario)
+	s.mockAllNodes("node0")
+
+	// node0 didn't respond in time, so the partition is offered to the next ranked node
+	s.mockMatchingNodes(scenario, "node1")
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		NewExecutions: []*models.Execution{
+			{NodeID: "node1", PartitionIndex: 0},
+		},
+		UpdatedExecutions: []ExecutionStateUpdate{
+			{
+				ExecutionID:  scenario.executions[0].ID,
+				DesiredState: models.ExecutionDesiredStateStopped,
+				ComputeState: models.ExecutionStateBidRejected,
+			},
+		},
+		ExpectedNewEvaluations: []ExpectedEvaluation{
+			{
+				TriggeredBy: models.EvalTriggerBidTimeout,
+				WaitUntil:   s.clock.Now().Add(s.scheduler.bidTimeout),
+			},
+		},
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_BidOverAsk() {
+	s.scheduler.bidOverAsk = 1
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(2),
+	)
+	s.mockJobStore(scenario)
+
+	// each partition is offered to two nodes in parallel, as long as there are enough nodes
+	s.mockMatchingNodes(scenario, "node0", "node1", "node2")
+
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		NewExecutions: []*models.Execution{
+			{NodeID: "node0", PartitionIndex: 0},
+			{NodeID: "node1", PartitionIndex: 1},
+			{NodeID: "node2", PartitionIndex: 0},
+		},
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
+func (s *BatchServiceJobSchedulerTestSuite) TestProcess_BidOverAskApprovesFirstBid() {
+	s.scheduler.bidOverAsk = 2
+	scenario := NewScenario(
+		WithJobType(s.jobType),
+		WithCount(1),
+		WithPartitionedExecution("node0", models.ExecutionStateNew, 0),
+		WithPartitionedExecution("node1", models.ExecutionStateAskForBidAccepted, 0),
+		WithPartitionedExecution("node2", models.ExecutionStateAskForBidAccepted, 0),
+	)
+	scenario.executions[2].ModifyTime = scenario.executions[1].ModifyTime + 1
+	s.mockJobStore(scenario)
+	s.mockAllNodes("node0", "node1", "node2")
+
+	// the first bid is approved, and the other nodes are rejected, whether they bid or not
+	matcher := NewPlanMatcher(s.T(), PlanMatcherParams{
+		Evaluation: scenario.evaluation,
+		JobState:   models.JobStateTypeRunning,
+		UpdatedExecutions: []ExecutionStateUpdate{
+			{
+				ExecutionID:  scenario.executions[0].ID,
+				DesiredState: models.ExecutionDesiredStateStopped,
+				ComputeState: models.ExecutionStateCancelled,
+			},
+			{
+				ExecutionID:  scenario.executions[1].ID,
+				DesiredState: models.ExecutionDesiredStateRunning,
+				ComputeState: models.ExecutionStateBidAccepted,
+			},
+			{
+				ExecutionID:  scenario.executions[2].ID,
+				DesiredState: models.ExecutionDesiredStateStopped,
+				ComputeState: models.ExecutionStateBidRejected,
+			},
+		},
+	})
+	s.planner.EXPECT().Process(gomock.Any(), matcher).Times(1)
+	s.Require().NoError(s.scheduler.Process(context.Background(), scenario.evaluation))
+}
+
 func (s *BatchServiceJobSchedulerTestSuite) TestProcess_RateLimit_ShouldLimitInitialExecutions() {
 	// Configure rate limiter in scheduler
 	s.scheduler.rateLimiter = NewBatchRateLimiter(BatchRateLimiterParams{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/benbjohnson/clock"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel/attribute"
@@ -23,6 +24,9 @@ type DaemonJobSchedulerParams struct {
 	// RateLimiter controls the rate at which new executions are created
 	// If not provided, a NoopRateLimiter is used
 	RateLimiter ExecutionRateLimiter
+	// Clock is the clock used for time-based operations.
+	// If not provided, the system clock is used.
+	Clock clock.Clock
 }
 
 type DaemonJobScheduler struct {
@@ -30,9 +34,13 @@ type DaemonJobScheduler struct {
 	planner      orchestrator.Planner
 	nodeSelector orchestrator.NodeSelector
 	rateLimiter  ExecutionRateLimiter
+	clock        clock.Clock
 }
 
 func NewDaemonJobScheduler(params DaemonJobSchedulerParams) *DaemonJobScheduler {
+	if params.Clock == nil {
+		params.Clock = clock.New()
+	}
 	if params.RateLimiter == nil {
 		params.RateLimiter = NewNoopRateLimiter()
 	}
@@ -41,6 +49,7 @@ func NewDaemonJobScheduler(params DaemonJobSchedulerParams) *DaemonJobScheduler
 		planner:      params.Planner,
 		nodeSelector: params.NodeSelector,
 		rateLimiter:  params.RateLimiter,
+		clock:        params.Clock,
 	}
 }
 
@@ -93,14 +102,15 @@ func (b *DaemonJobScheduler) Process(ctx context.Context, evaluation *models.Eva
 	}
 
 	// Retrieve the info for all the nodes that have executions for this job
-	nodeInfos, err := existingNodeInfos(ctx, b.nodeSelector, nonTerminalExecs)
+	nodeStates, err := existingNodeStates(ctx, b.nodeSelector, nonTerminalExecs)
 	if err != nil {
 		return err
 	}
 	metrics.Latency(ctx, processPartDuration, AttrOperationPartGetNodes)
 
 	// Mark executions that are running on nodes that are not healthy as failed
-	_, lost := nonTerminalExecs.groupByNodeHealth(nodeInfos)
+	_, lost := nonTerminalExecs.groupByNodeHealth(
+		nodeStates, job.Task().Timeouts.GetDisconnectGracePeriod(), b.clock.Now())
 	lost.markFailed(plan, orchestrator.ExecStoppedByNodeUnhealthyEvent())
 	metrics.CountAndHistogram(ctx, executionsLostTotal, executionsLost, float64(len(lost)))
 
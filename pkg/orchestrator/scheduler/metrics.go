@@ -69,6 +69,12 @@ var (
 		metric.WithUnit("1"),
 	))
 
+	bidsTimedOut = telemetry.Must(Meter.Int64Counter(
+		"scheduler.bids.timeout",
+		metric.WithDescription("Number of bid requests that nodes did not respond to in time"),
+		metric.WithUnit("1"),
+	))
+
 	// Node metrics
 	nodesMatched = telemetry.Must(Meter.Float64Histogram(
 		"scheduler.nodes.matched",
@@ -101,7 +101,7 @@ func (b *OpsJobScheduler) Process(ctx context.Context, evaluation *models.Evalua
 	}
 
 	// Retrieve the info for all the nodes that have executions for this job
-	nodeInfos, err := existingNodeInfos(ctx, b.selector, nonTerminalExecs)
+	nodeStates, err := existingNodeStates(ctx, b.selector, nonTerminalExecs)
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,8 @@ func (b *OpsJobScheduler) Process(ctx context.Context, evaluation *models.Evalua
 	allFailedExecs := existingExecs.filterFailed()
 
 	// Mark executions that are running on nodes that are not healthy as failed
-	nonTerminalExecs, lost := nonTerminalExecs.groupByNodeHealth(nodeInfos)
+	nonTerminalExecs, lost := nonTerminalExecs.groupByNodeHealth(
+		nodeStates, job.Task().Timeouts.GetDisconnectGracePeriod(), b.clock.Now())
 	lost.markFailed(plan, orchestrator.ExecStoppedByNodeUnhealthyEvent())
 	metrics.CountAndHistogram(ctx, executionsLostTotal, executionsLost, float64(len(lost)))
 	allFailedExecs = allFailedExecs.union(lost)
@@ -112,13 +112,23 @@ func (set execSet) filterCompleted() execSet {
 }
 
 // groupByNodeHealth partitions executions based on their node's health status.
-func (set execSet) groupByNodeHealth(nodeInfos map[string]*models.NodeInfo) (healthy execSet, lost execSet) {
+// Executions are lost if their node no longer exists, or if their node has been disconnected
+// for longer than the grace period. A zero grace period means executions are not lost
+// while their node is disconnected, as the node may reconnect and report their results.
+func (set execSet) groupByNodeHealth(
+	nodeStates map[string]*models.NodeState, gracePeriod time.Duration, now time.Time) (healthy execSet, lost execSet) {
 	healthy = make(execSet)
 	lost = make(execSet)
 	for _, exec := range set {
-		if _, ok := nodeInfos[exec.NodeID]; !ok {
+		nodeState, ok := nodeStates[exec.NodeID]
+		if !ok {
 			lost[exec.ID] = exec
 			log.Debug().Msgf("Execution %s is running on node %s which is not healthy", exec.ID, exec.NodeID)
+		} else if gracePeriod > 0 && !nodeState.IsConnected() &&
+			now.Sub(nodeState.ConnectionState.DisconnectedSince) > gracePeriod {
+			lost[exec.ID] = exec
+			log.Debug().Msgf("Execution %s is running on node %s which is disconnected since %s",
+				exec.ID, exec.NodeID, nodeState.ConnectionState.DisconnectedSince)
 		} else {
 			healthy[exec.ID] = exec
 		}
@@ -140,6 +150,21 @@ func (set execSet) groupByExecutionTimeout(expirationTime time.Time) (remaining,
 	return remaining, timedOut
 }
 
+// groupByBidTimeout partitions executions based on whether their node responded to the bid request
+// before the expiration time. Only executions that are still waiting for a bid can time out.
+func (set execSet) groupByBidTimeout(expirationTime time.Time) (remaining, timedOut execSet) {
+	remaining = make(execSet)
+	timedOut = make(execSet)
+	for _, exec := range set {
+		if exec.IsAwaitingBid() && !exec.GetCreateTime().After(expirationTime) {
+			timedOut[exec.ID] = exec
+		} else {
+			remaining[exec.ID] = exec
+		}
+	}
+	return remaining, timedOut
+}
+
 // groupByPartition groups executions by their partition index, allowing operations
 // to be performed independently on each partition's set of executions. This is crucial
 // for maintaining partition isolation and ensuring correct scheduling behavior.
@@ -268,6 +293,16 @@ func (set execSet) getApprovalStatuses() executionsByApprovalStatus {
 		}
 	}
 
+	// When nodes were asked to bid in parallel, cancel the bids still awaited
+	// once one has been approved, as the partition no longer needs them
+	if approved {
+		for _, exec := range orderedExecs {
+			if exec.ComputeState.StateType != models.ExecutionStateAskForBidAccepted {
+				result.toCancel[exec.ID] = exec
+			}
+		}
+	}
+
 	return result
 }
 
@@ -97,7 +97,7 @@ func TestExecSet_String(t *testing.T) {
 }
 
 func TestExecSet_FilterByNodeHealth(t *testing.T) {
-	nodeInfos := map[string]*models.NodeInfo{
+	nodeStates := map[string]*models.NodeState{
 		"node1": {},
 		"node2": {},
 	}
@@ -109,7 +109,7 @@ func TestExecSet_FilterByNodeHealth(t *testing.T) {
 	}
 
 	set := execSetFromSlice(executions)
-	healthy, lost := set.groupByNodeHealth(nodeInfos)
+	healthy, lost := set.groupByNodeHealth(nodeStates, 0, time.Now())
 
 	assert.Len(t, healthy, 2)
 	assert.Len(t, lost, 1)
@@ -117,6 +117,38 @@ func TestExecSet_FilterByNodeHealth(t *testing.T) {
 	assert.ElementsMatch(t, lost.keys(), []string{"exec3"})
 }
 
+func TestExecSet_FilterByNodeHealthGracePeriod(t *testing.T) {
+	now := time.Now()
+	nodeStates := map[string]*models.NodeState{
+		"connected": {ConnectionState: models.ConnectionState{Status: models.NodeStates.CONNECTED}},
+		"recently-disconnected": {ConnectionState: models.ConnectionState{
+			Status:            models.NodeStates.DISCONNECTED,
+			DisconnectedSince: now.Add(-time.Minute),
+		}},
+		"long-disconnected": {ConnectionState: models.ConnectionState{
+			Status:            models.NodeStates.DISCONNECTED,
+			DisconnectedSince: now.Add(-time.Hour),
+		}},
+	}
+
+	executions := []*models.Execution{
+		{ID: "exec1", NodeID: "connected"},
+		{ID: "exec2", NodeID: "recently-disconnected"},
+		{ID: "exec3", NodeID: "long-disconnected"},
+	}
+	set := execSetFromSlice(executions)
+
+	// executions on disconnected nodes are not lost without a grace period
+	healthy, lost := set.groupByNodeHealth(nodeStates, 0, now)
+	assert.ElementsMatch(t, healthy.keys(), []string{"exec1", "exec2", "exec3"})
+	assert.Empty(t, lost)
+
+	// executions are lost once their node has been disconnected for longer than the grace period
+	healthy, lost = set.groupByNodeHealth(nodeStates, 10*time.Minute, now)
+	assert.ElementsMatch(t, healthy.keys(), []string{"exec1", "exec2"})
+	assert.ElementsMatch(t, lost.keys(), []string{"exec3"})
+}
+
 func TestExecSet_GetApprovalStatuses(t *testing.T) {
 	t.Run("with completed execution", func(t *testing.T) {
 		executions := []*models.Execution{
@@ -223,6 +255,27 @@ func TestExecSet_GetApprovalStatuses(t *testing.T) {
 		assert.Empty(t, status.toCancel)
 	})
 
+	t.Run("with pending bid and awaited bids", func(t *testing.T) {
+		now := time.Now()
+		executions := []*models.Execution{
+			{ID: "exec1",
+				ComputeState: models.NewExecutionState(models.ExecutionStateNew),
+				DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStatePending),
+				ModifyTime:   now.UnixNano()},
+			{ID: "exec2",
+				ComputeState: models.NewExecutionState(models.ExecutionStateAskForBidAccepted),
+				DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStatePending),
+				ModifyTime:   now.Add(time.Second).UnixNano()},
+		}
+
+		set := execSetFromSlice(executions)
+		status := set.getApprovalStatuses()
+
+		assert.ElementsMatch(t, status.toApprove.keys(), []string{"exec2"})
+		assert.Empty(t, status.toReject)
+		assert.ElementsMatch(t, status.toCancel.keys(), []string{"exec1"})
+	})
+
 	t.Run("with mix of states and desired states", func(t *testing.T) {
 		now := time.Now()
 		executions := []*models.Execution{
@@ -252,6 +305,26 @@ func TestExecSet_GetApprovalStatuses(t *testing.T) {
 		assert.ElementsMatch(t, status.toCancel.keys(), []string{"exec1"})
 	})
 }
+func TestExecSet_GroupByBidTimeout(t *testing.T) {
+	now := time.Now()
+	pending := models.NewExecutionDesiredState(models.ExecutionDesiredStatePending)
+	executions := []*models.Execution{
+		{ID: "exec1", CreateTime: now.Add(-10 * time.Second).UnixNano(), DesiredState: pending,
+			ComputeState: models.NewExecutionState(models.ExecutionStateNew)},
+		{ID: "exec2", CreateTime: now.Add(-time.Minute).UnixNano(), DesiredState: pending,
+			ComputeState: models.NewExecutionState(models.ExecutionStateNew)},
+		{ID: "exec3", CreateTime: now.Add(-time.Minute).UnixNano(), DesiredState: pending,
+			ComputeState: models.NewExecutionState(models.ExecutionStateAskForBidAccepted)},
+		{ID: "exec4", CreateTime: now.Add(-time.Minute).UnixNano(),
+			DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStateRunning),
+			ComputeState: models.NewExecutionState(models.ExecutionStateBidAccepted)},
+	}
+
+	remaining, timedOut := execSetFromSlice(executions).groupByBidTimeout(now.Add(-30 * time.Second))
+	assert.ElementsMatch(t, timedOut.keys(), []string{"exec2"})
+	assert.ElementsMatch(t, remaining.keys(), []string{"exec1", "exec3", "exec4"})
+}
+
 func TestExecSet_FilterByExecutionTimeout(t *testing.T) {
 	// Create a set of executions with varying execution times
 	now := time.Now()
@@ -8,11 +8,11 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
 )
 
-// existingNodeInfos returns a map of nodeID to NodeInfo for all the nodes that have executions for this job
-func existingNodeInfos(ctx context.Context,
+// existingNodeStates returns a map of nodeID to NodeState for all the nodes that have executions for this job
+func existingNodeStates(ctx context.Context,
 	nodeSelector orchestrator.NodeSelector,
-	existingExecutions execSet) (map[string]*models.NodeInfo, error) {
-	out := make(map[string]*models.NodeInfo)
+	existingExecutions execSet) (map[string]*models.NodeState, error) {
+	out := make(map[string]*models.NodeState)
 	if len(existingExecutions) == 0 {
 		return out, nil
 	}
@@ -20,13 +20,13 @@ func existingNodeInfos(ctx context.Context,
 
 	// TODO: implement a better way to retrieve node info instead of listing all nodes
 	//  Also we should detect if a node is still available, but does not support the job constraints any longer.
-	nodesMap := make(map[string]*models.NodeInfo)
+	nodesMap := make(map[string]*models.NodeState)
 	discoveredNodes, err := nodeSelector.AllNodes(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 	for i, node := range discoveredNodes {
-		nodesMap[node.ID()] = &discoveredNodes[i]
+		nodesMap[node.Info.ID()] = &discoveredNodes[i]
 	}
 
 	for _, execution := range existingExecutions {
@@ -35,9 +35,9 @@ func existingNodeInfos(ctx context.Context,
 		if _, ok := checked[execution.NodeID]; ok {
 			continue
 		}
-		nodeInfo, ok := nodesMap[execution.NodeID]
+		nodeState, ok := nodesMap[execution.NodeID]
 		if ok {
-			out[execution.NodeID] = nodeInfo
+			out[execution.NodeID] = nodeState
 		}
 		checked[execution.NodeID] = struct{}{}
 	}
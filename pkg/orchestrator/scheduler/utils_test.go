@@ -32,6 +32,12 @@ func WithCount(count int) ScenarioBuilderOption {
 	}
 }
 
+func WithPartitions(partitions ...int) ScenarioBuilderOption {
+	return func(b *Scenario) {
+		b.job.Partitions = partitions
+	}
+}
+
 func WithExecution(nodeID string, state models.ExecutionStateType) ScenarioBuilderOption {
 	return func(b *Scenario) {
 		execution := mock.ExecutionForJob(b.job)
@@ -79,6 +85,12 @@ func WithTotalTimeout(timeout time.Duration) ScenarioBuilderOption {
 	}
 }
 
+func WithDisconnectGracePeriod(gracePeriod time.Duration) ScenarioBuilderOption {
+	return func(b *Scenario) {
+		b.job.Task().Timeouts.DisconnectGracePeriod = int64(gracePeriod.Seconds())
+	}
+}
+
 func WithCreateTime(t int64) ScenarioBuilderOption {
 	return func(b *Scenario) {
 		b.job.CreateTime = t
@@ -299,6 +311,15 @@ func fakeNodeInfo(t *testing.T, nodeID string) models.NodeInfo {
 
 }
 
+func fakeNodeState(t *testing.T, nodeID string) models.NodeState {
+	return models.NodeState{
+		Info: fakeNodeInfo(t, nodeID),
+		ConnectionState: models.ConnectionState{
+			Status: models.NodeStates.CONNECTED,
+		},
+	}
+}
+
 func fakeNodeRank(t *testing.T, nodeID string) *orchestrator.NodeRank {
 	return &orchestrator.NodeRank{
 		NodeInfo: fakeNodeInfo(t, nodeID),
@@ -0,0 +1,156 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+)
+
+type AffinityNodeRankerParams struct {
+	JobStore jobstore.Store
+}
+
+// AffinityNodeRanker ranks nodes based on the affinity and anti-affinity rules of the job's placement,
+// to place the executions of the job on or away from nodes running other jobs.
+type AffinityNodeRanker struct {
+	jobStore jobstore.Store
+}
+
+func NewAffinityNodeRanker(params AffinityNodeRankerParams) *AffinityNodeRanker {
+	return &AffinityNodeRanker{
+		jobStore: params.JobStore,
+	}
+}
+
+// RankNodes ranks nodes based on whether they run active executions of the jobs selected by
+// each affinity and anti-affinity rule. Jobs are selected from the namespace of the job:
+// - Rank 30: Node satisfies a soft rule, by running the selected jobs for affinity
+// or by not running them for anti-affinity.
+// - Rank 0: Job has no affinity rules, or the node doesn't satisfy a soft rule.
+// - Rank -1: Node doesn't satisfy a hard rule.
+//
+// The executions of each selected job are fetched once per ranking, and shared by the rules selecting it.
+func (s *AffinityNodeRanker) RankNodes(ctx context.Context,
+	job models.Job, nodes []models.NodeInfo) ([]orchestrator.NodeRank, error) {
+	ranks := make([]orchestrator.NodeRank, len(nodes))
+	for i, node := range nodes {
+		ranks[i] = orchestrator.NodeRank{
+			NodeInfo:  node,
+			Rank:      orchestrator.RankPossible,
+			Reason:    "no affinity rules",
+			Retryable: true,
+		}
+	}
+	if job.Placement == nil || (len(job.Placement.Affinity) == 0 && len(job.Placement.AntiAffinity) == 0) {
+		return ranks, nil
+	}
+
+	for i := range ranks {
+		ranks[i].Reason = "affinity rules satisfied"
+	}
+	// nodes running active executions, by job ID
+	runningPerJob := make(map[string]map[string]bool)
+	for _, rule := range job.Placement.Affinity {
+		if err := s.rankRule(ctx, job, rule, true, ranks, runningPerJob); err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range job.Placement.AntiAffinity {
+		if err := s.rankRule(ctx, job, rule, false, ranks, runningPerJob); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ranks {
+		log.Ctx(ctx).Trace().Object("Rank", ranks[i]).Msg("Ranked node")
+	}
+	return ranks, nil
+}
+
+// rankRule adds the rank of a single affinity, or anti-affinity, rule to the ranks of the nodes
+func (s *AffinityNodeRanker) rankRule(ctx context.Context, job models.Job, rule *models.AffinityRule,
+	affinity bool, ranks []orchestrator.NodeRank, runningPerJob map[string]map[string]bool) error {
+	running, err := s.nodesRunningJobs(ctx, job, rule, runningPerJob)
+	if err != nil {
+		return err
+	}
+	kind := "anti-affinity"
+	if affinity {
+		kind = "affinity"
+	}
+	for i := range ranks {
+		if !ranks[i].MeetsRequirement() {
+			continue
+		}
+		satisfied := running[ranks[i].NodeInfo.ID()] == affinity
+		switch {
+		case satisfied && !rule.Hard:
+			ranks[i].Rank += placementPreferredRank
+			ranks[i].Reason = fmt.Sprintf("node satisfies %s with %s", kind, describeAffinityRule(rule))
+		case !satisfied && rule.Hard:
+			ranks[i].Rank = orchestrator.RankUnsuitable
+			ranks[i].Reason = fmt.Sprintf("node doesn't satisfy %s with %s", kind, describeAffinityRule(rule))
+		}
+	}
+	return nil
+}
+
+// nodesRunningJobs returns the IDs of the nodes running active executions of the jobs selected by the rule,
+// excluding the job being ranked. The nodes running each job are looked up in runningPerJob, and added to it
+// when the executions of the job weren't fetched yet.
+func (s *AffinityNodeRanker) nodesRunningJobs(ctx context.Context,
+	job models.Job, rule *models.AffinityRule, runningPerJob map[string]map[string]bool) (map[string]bool, error) {
+	query := jobstore.JobQuery{
+		Namespace:  job.Namespace,
+		NamePrefix: rule.JobName,
+		States:     []models.JobStateType{models.JobStateTypePending, models.JobStateTypeQueued, models.JobStateTypeRunning},
+	}
+	if len(rule.JobLabels) > 0 {
+		requirements, err := models.FromLabelSelectorRequirements(rule.JobLabels...)
+		if err != nil {
+			return nil, err
+		}
+		query.Selector = labels.NewSelector().Add(requirements...)
+	}
+	response, err := s.jobStore.GetJobs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	running := make(map[string]bool)
+	for _, other := range response.Jobs {
+		if other.ID == job.ID || (rule.JobName != "" && other.Name != rule.JobName) {
+			continue
+		}
+		nodes, ok := runningPerJob[other.ID]
+		if !ok {
+			executions, err := s.jobStore.GetExecutions(ctx, jobstore.GetExecutionsOptions{JobID: other.ID})
+			if err != nil {
+				return nil, err
+			}
+			nodes = make(map[string]bool)
+			for _, execution := range executions {
+				if !execution.IsTerminalState() {
+					nodes[execution.NodeID] = true
+				}
+			}
+			runningPerJob[other.ID] = nodes
+		}
+		for nodeID := range nodes {
+			running[nodeID] = true
+		}
+	}
+	return running, nil
+}
+
+func describeAffinityRule(rule *models.AffinityRule) string {
+	if rule.JobName != "" {
+		return fmt.Sprintf("job %s", rule.JobName)
+	}
+	return fmt.Sprintf("jobs %s", rule.JobLabels)
+}
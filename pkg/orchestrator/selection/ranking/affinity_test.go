@@ -0,0 +1,171 @@
+//go:build unit || !integration
+
+package ranking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+)
+
+type AffinityNodeRankerSuite struct {
+	suite.Suite
+	jobStore *jobstore.MockStore
+	ranker   *AffinityNodeRanker
+	nodes    []models.NodeInfo
+}
+
+func TestAffinityNodeRankerSuite(t *testing.T) {
+	suite.Run(t, new(AffinityNodeRankerSuite))
+}
+
+func (s *AffinityNodeRankerSuite) SetupTest() {
+	s.jobStore = jobstore.NewMockStore(gomock.NewController(s.T()))
+	s.ranker = NewAffinityNodeRanker(AffinityNodeRankerParams{JobStore: s.jobStore})
+	s.nodes = []models.NodeInfo{{NodeID: "node1"}, {NodeID: "node2"}, {NodeID: "node3"}}
+}
+
+type jobExecutions struct {
+	job        models.Job
+	executions []models.Execution
+}
+
+// expectJobs mocks the jobs returned by the store, and the executions of each job
+func (s *AffinityNodeRankerSuite) expectJobs(jobs ...jobExecutions) {
+	response := &jobstore.JobQueryResponse{}
+	for _, j := range jobs {
+		response.Jobs = append(response.Jobs, j.job)
+		s.jobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: j.job.ID}).
+			Return(j.executions, nil).AnyTimes()
+	}
+	s.jobStore.EXPECT().GetJobs(gomock.Any(), gomock.Any()).Return(response, nil)
+}
+
+func (s *AffinityNodeRankerSuite) TestNoAffinityRules() {
+	ranks, err := s.ranker.RankNodes(context.Background(), models.Job{ID: "job"}, s.nodes)
+	s.Require().NoError(err)
+	for _, node := range s.nodes {
+		assertEquals(s.T(), ranks, node.ID(), orchestrator.RankPossible, "no affinity rules")
+	}
+}
+
+func (s *AffinityNodeRankerSuite) TestAffinity() {
+	for _, hard := range []bool{false, true} {
+		s.Run(map[bool]string{false: "soft", true: "hard"}[hard], func() {
+			s.SetupTest()
+			s.expectJobs(
+				jobExecutions{
+					job:        models.Job{ID: "cache-1", Name: "cache"},
+					executions: []models.Execution{activeExecution("cache-1", "node1"), stoppedExecution("cache-1", "node2")},
+				},
+				// jobs only matching the name prefix are ignored
+				jobExecutions{
+					job:        models.Job{ID: "cache-2", Name: "cache-warmer"},
+					executions: []models.Execution{activeExecution("cache-2", "node3")},
+				},
+			)
+			job := models.Job{
+				ID:        "job",
+				Namespace: "default",
+				Placement: &models.JobPlacement{Affinity: []*models.AffinityRule{{JobName: "cache", Hard: hard}}},
+			}
+			ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+			s.Require().NoError(err)
+
+			if hard {
+				assertEquals(s.T(), ranks, "node1", orchestrator.RankPossible)
+				assertEquals(s.T(), ranks, "node2", orchestrator.RankUnsuitable)
+				assertEquals(s.T(), ranks, "node3", orchestrator.RankUnsuitable)
+			} else {
+				assertEquals(s.T(), ranks, "node1", placementPreferredRank)
+				assertEquals(s.T(), ranks, "node2", orchestrator.RankPossible)
+				assertEquals(s.T(), ranks, "node3", orchestrator.RankPossible)
+			}
+		})
+	}
+}
+
+func (s *AffinityNodeRankerSuite) TestAntiAffinity() {
+	for _, hard := range []bool{false, true} {
+		s.Run(map[bool]string{false: "soft", true: "hard"}[hard], func() {
+			s.SetupTest()
+			s.expectJobs(
+				jobExecutions{job: models.Job{ID: "gpu-1"}, executions: []models.Execution{activeExecution("gpu-1", "node1")}},
+				jobExecutions{job: models.Job{ID: "gpu-2"}, executions: []models.Execution{activeExecution("gpu-2", "node2")}},
+				// the ranked job is never considered as another job
+				jobExecutions{job: models.Job{ID: "job"}, executions: []models.Execution{activeExecution("job", "node3")}},
+			)
+			job := models.Job{
+				ID:        "job",
+				Namespace: "default",
+				Placement: &models.JobPlacement{AntiAffinity: []*models.AffinityRule{{
+					JobLabels: []*models.LabelSelectorRequirement{{Key: "tier", Operator: selection.In, Values: []string{"gpu"}}},
+					Hard:      hard,
+				}}},
+			}
+			ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+			s.Require().NoError(err)
+
+			if hard {
+				assertEquals(s.T(), ranks, "node1", orchestrator.RankUnsuitable)
+				assertEquals(s.T(), ranks, "node2", orchestrator.RankUnsuitable)
+				assertEquals(s.T(), ranks, "node3", orchestrator.RankPossible)
+			} else {
+				assertEquals(s.T(), ranks, "node1", orchestrator.RankPossible)
+				assertEquals(s.T(), ranks, "node2", orchestrator.RankPossible)
+				assertEquals(s.T(), ranks, "node3", placementPreferredRank)
+			}
+		})
+	}
+}
+
+func (s *AffinityNodeRankerSuite) TestJobQuery() {
+	s.jobStore.EXPECT().GetJobs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query jobstore.JobQuery) (*jobstore.JobQueryResponse, error) {
+			s.Equal("ns", query.Namespace)
+			s.Equal("cache", query.NamePrefix)
+			s.NotNil(query.Selector)
+			s.Equal("tier in (gpu)", query.Selector.String())
+			s.NotContains(query.States, models.JobStateTypeCompleted)
+			return &jobstore.JobQueryResponse{}, nil
+		})
+	job := models.Job{
+		ID:        "job",
+		Namespace: "ns",
+		Placement: &models.JobPlacement{Affinity: []*models.AffinityRule{{
+			JobName:   "cache",
+			JobLabels: []*models.LabelSelectorRequirement{{Key: "tier", Operator: selection.In, Values: []string{"gpu"}}},
+		}}},
+	}
+	_, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+}
+
+func (s *AffinityNodeRankerSuite) TestFetchesExecutionsOncePerJob() {
+	cache := models.Job{ID: "cache-1", Name: "cache", Labels: map[string]string{"tier": "cache"}}
+	s.jobStore.EXPECT().GetJobs(gomock.Any(), gomock.Any()).
+		Return(&jobstore.JobQueryResponse{Jobs: []models.Job{cache}}, nil).Times(2)
+	s.jobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: cache.ID}).
+		Return([]models.Execution{activeExecution(cache.ID, "node1")}, nil).Times(1)
+
+	// both rules select the same job, whose executions are only fetched once
+	job := models.Job{
+		ID:        "job",
+		Namespace: "default",
+		Placement: &models.JobPlacement{Affinity: []*models.AffinityRule{
+			{JobName: "cache"},
+			{JobLabels: []*models.LabelSelectorRequirement{{Key: "tier", Operator: selection.In, Values: []string{"cache"}}}},
+		}},
+	}
+	ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+	assertEquals(s.T(), ranks, "node1", 2*placementPreferredRank)
+	assertEquals(s.T(), ranks, "node2", orchestrator.RankPossible)
+}
@@ -3,8 +3,10 @@ package ranking
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
 
 	"github.com/bacalhau-project/bacalhau/pkg/lib/math"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
@@ -180,6 +182,69 @@ func (s *AvailableCapacityNodeRanker) RankNodes(
 		s.calculateWeightedCapacities(nodes, weights)
 
 	// Rank nodes based on normalized weighted capacities
-	return s.rankNodesBasedOnCapacities(
+	ranks, err := s.rankNodesBasedOnCapacities(
 		ctx, nodes, wAvailableCapacities, wQueueCapacities, maxAvailableCapacity, maxQueueCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out nodes without the GPUs required by the job
+	if jobResources.HasGPURequirements() {
+		for i := range ranks {
+			filterGPUs(ctx, &ranks[i], jobResources)
+		}
+	}
+
+	// Filter out nodes without the extended resources required by the job
+	if len(jobResources.Extended) > 0 {
+		for i := range ranks {
+			filterExtendedResources(ctx, &ranks[i], jobResources)
+		}
+	}
+	return ranks, nil
+}
+
+// filterGPUs marks the node as unsuitable if it doesn't have enough GPUs
+// matching the vendor, model and memory required by the job. Nodes that have
+// them but are using them are retryable, as they become available once the
+// executions using them are done. Shared GPU requests are only matched against
+// the GPUs of the node, as shared GPUs are not reported as available.
+func filterGPUs(ctx context.Context, rank *orchestrator.NodeRank, jobResources *models.Resources) {
+	required := jobResources.GPUCount()
+	info := rank.NodeInfo.ComputeNodeInfo
+	if matching := uint64(lo.CountBy(info.MaxCapacity.GPUs, jobResources.MatchesGPU)); matching < required {
+		rank.Rank = orchestrator.RankUnsuitable
+		rank.Reason = fmt.Sprintf("node has %d GPUs matching the job requirements, but the job requires %d", matching, required)
+		rank.Retryable = false
+	} else if matching = uint64(lo.CountBy(info.AvailableCapacity.GPUs, jobResources.MatchesGPU)); matching < required &&
+		!jobResources.IsSharedGPU() {
+		rank.Rank = orchestrator.RankUnsuitable
+		rank.Reason = fmt.Sprintf("node has %d available GPUs matching the job requirements, but the job requires %d",
+			matching, required)
+		rank.Retryable = true
+	} else {
+		return
+	}
+	log.Ctx(ctx).Trace().Object("Rank", rank).Msg("Ranked node")
+}
+
+// filterExtendedResources marks the node as unsuitable if it doesn't advertise
+// enough of the extended resources required by the job. Nodes that advertise
+// them but are using them are retryable.
+func filterExtendedResources(ctx context.Context, rank *orchestrator.NodeRank, jobResources *models.Resources) {
+	info := rank.NodeInfo.ComputeNodeInfo
+	if missing := jobResources.Extended.Missing(info.MaxCapacity.Extended); len(missing) > 0 {
+		rank.Rank = orchestrator.RankUnsuitable
+		rank.Reason = fmt.Sprintf("node does not have enough extended resources %s required by the job",
+			strings.Join(missing, ", "))
+		rank.Retryable = false
+	} else if missing = jobResources.Extended.Missing(info.AvailableCapacity.Extended); len(missing) > 0 {
+		rank.Rank = orchestrator.RankUnsuitable
+		rank.Reason = fmt.Sprintf("node does not have enough available extended resources %s required by the job",
+			strings.Join(missing, ", "))
+		rank.Retryable = true
+	} else {
+		return
+	}
+	log.Ctx(ctx).Trace().Object("Rank", rank).Msg("Ranked node")
 }
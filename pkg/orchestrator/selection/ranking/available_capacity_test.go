@@ -288,6 +288,108 @@ func (suite *AvailableCapacityNodeRankerSuite) TestRankNodes() {
 	}
 }
 
+func (suite *AvailableCapacityNodeRankerSuite) TestRankNodesByGPURequirements() {
+	a100 := models.GPU{Index: 0, Name: "NVIDIA A100-SXM4-40GB", Vendor: models.GPUVendorNvidia, Memory: 40960}
+	t4 := models.GPU{Index: 0, Name: "Tesla T4", Vendor: models.GPUVendorNvidia, Memory: 15360}
+	arc := models.GPU{Index: 0, Name: "Intel Arc A770", Vendor: models.GPUVendorIntel, Memory: 16384}
+	node := func(id string, gpu models.GPU, available bool) models.NodeInfo {
+		info := models.NodeInfo{NodeID: id}
+		info.ComputeNodeInfo.MaxCapacity = models.Resources{GPU: 1, GPUs: []models.GPU{gpu}}
+		if available {
+			info.ComputeNodeInfo.AvailableCapacity = info.ComputeNodeInfo.MaxCapacity
+		}
+		return info
+	}
+	nodes := []models.NodeInfo{
+		node("a100", a100, true),
+		node("busy-a100", a100, false),
+		node("t4", t4, true),
+		node("arc", arc, true),
+	}
+
+	testCases := []struct {
+		name         string
+		jobResources models.ResourcesConfig
+		suitable     []string
+		retryable    []string
+	}{
+		{
+			name:         "model",
+			jobResources: models.ResourcesConfig{GPU: "1", GPUModel: "a100"},
+			suitable:     []string{"a100"},
+			retryable:    []string{"busy-a100"},
+		},
+		{
+			name:         "vendor",
+			jobResources: models.ResourcesConfig{GPU: "1", GPUVendor: "nvidia"},
+			suitable:     []string{"a100", "t4"},
+			retryable:    []string{"busy-a100"},
+		},
+		{
+			name:         "memory",
+			jobResources: models.ResourcesConfig{GPU: "1", GPUMemory: "16GiB"},
+			suitable:     []string{"a100", "arc"},
+			retryable:    []string{"busy-a100"},
+		},
+		{
+			name:         "shared",
+			jobResources: models.ResourcesConfig{GPU: "0.5", GPUModel: "*A100*"},
+			suitable:     []string{"a100", "busy-a100"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			job := mock.Job()
+			job.Task().ResourcesConfig = &tc.jobResources
+			ranks, err := suite.ranker.RankNodes(context.Background(), *job, nodes)
+			suite.NoError(err)
+
+			var suitable, retryable []string
+			for _, r := range ranks {
+				if r.MeetsRequirement() {
+					suitable = append(suitable, r.NodeInfo.ID())
+				} else if r.Retryable {
+					retryable = append(retryable, r.NodeInfo.ID())
+				}
+			}
+			suite.Equal(tc.suitable, suitable)
+			suite.Equal(tc.retryable, retryable)
+		})
+	}
+}
+
+func (suite *AvailableCapacityNodeRankerSuite) TestRankNodesByExtendedResources() {
+	node := func(id string, max, available models.ExtendedResources) models.NodeInfo {
+		info := models.NodeInfo{NodeID: id}
+		info.ComputeNodeInfo.MaxCapacity = models.Resources{CPU: 4, Extended: max}
+		info.ComputeNodeInfo.AvailableCapacity = models.Resources{CPU: 4, Extended: available}
+		return info
+	}
+	nodes := []models.NodeInfo{
+		node("fpga", models.ExtendedResources{"fpga": 2}, models.ExtendedResources{"fpga": 2}),
+		node("busy-fpga", models.ExtendedResources{"fpga": 2}, nil),
+		node("dongle", models.ExtendedResources{"dongle": 1}, models.ExtendedResources{"dongle": 1}),
+		node("plain", nil, nil),
+	}
+
+	job := mock.Job()
+	job.Task().ResourcesConfig = &models.ResourcesConfig{Extended: map[string]string{"fpga": "2"}}
+	ranks, err := suite.ranker.RankNodes(context.Background(), *job, nodes)
+	suite.NoError(err)
+
+	var suitable, retryable []string
+	for _, r := range ranks {
+		if r.MeetsRequirement() {
+			suitable = append(suitable, r.NodeInfo.ID())
+		} else if r.Retryable {
+			retryable = append(retryable, r.NodeInfo.ID())
+		}
+	}
+	suite.Equal([]string{"fpga"}, suitable)
+	suite.Equal([]string{"busy-fpga"}, retryable)
+}
+
 func TestAvailableCapacityNodeRankerSuite(t *testing.T) {
 	suite.Run(t, new(AvailableCapacityNodeRankerSuite))
 }
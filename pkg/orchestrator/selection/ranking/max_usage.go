@@ -81,5 +81,17 @@ func (s *MaxUsageNodeRanker) formatReason(requested, maximum models.Resources) s
 			fmt.Sprint(maximum.GPU),
 		))
 	}
+	if requested.IsSharedGPU() && maximum.GPU == 0 {
+		reasons = append(reasons, fmt.Sprintf(perResourceReason, "GPUs",
+			"a shared GPU",
+			fmt.Sprint(maximum.GPU),
+		))
+	}
+	for _, name := range requested.Extended.Missing(maximum.Extended) {
+		reasons = append(reasons, fmt.Sprintf(perResourceReason, name,
+			fmt.Sprint(requested.Extended[name]),
+			fmt.Sprint(maximum.Extended[name]),
+		))
+	}
 	return fmt.Sprintf("job requires %s", strings.Join(reasons, " and "))
 }
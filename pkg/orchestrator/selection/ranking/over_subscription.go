@@ -46,8 +46,10 @@ func (s *OverSubscriptionNodeRanker) RankNodes(
 			// overSubscriptionCapacity is the capacity at which the node can accept more jobs
 			overSubscriptionCapacity := node.ComputeNodeInfo.MaxCapacity.Multiply(s.factor)
 
-			// totalUsage is the sub of actively running capacity, queued capacity and new job resources
+			// totalUsage is the sub of actively running capacity, queued capacity and new job resources.
+			// The available capacity of overcommitted nodes may exceed their max capacity.
 			totalUsage := node.ComputeNodeInfo.MaxCapacity.
+				Max(node.ComputeNodeInfo.AvailableCapacity).
 				Sub(node.ComputeNodeInfo.AvailableCapacity).
 				Add(node.ComputeNodeInfo.QueueUsedCapacity).
 				Add(*jobResourceUsage)
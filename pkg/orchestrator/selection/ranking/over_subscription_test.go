@@ -189,6 +189,19 @@ func (suite *OverSubscriptionNodeRankerSuite) TestRankNodes() {
 			job:      models.Resources{CPU: 0, Memory: 0, Disk: 0, GPU: 0},
 			expected: orchestrator.RankPossible,
 		},
+		{
+			name:   "overcommitted memory, available capacity higher than max capacity",
+			factor: 1,
+			node: models.NodeInfo{
+				ComputeNodeInfo: models.ComputeNodeInfo{
+					MaxCapacity:       models.Resources{CPU: 4, Memory: 4000, Disk: 4000, GPU: 0},
+					AvailableCapacity: models.Resources{CPU: 2, Memory: 6000, Disk: 2000, GPU: 0},
+					QueueUsedCapacity: models.Resources{CPU: 0, Memory: 0, Disk: 0, GPU: 0},
+				},
+			},
+			job:      models.Resources{CPU: 1, Memory: 1000, Disk: 1000, GPU: 0},
+			expected: orchestrator.RankPossible,
+		},
 	}
 
 	for _, tc := range testCases {
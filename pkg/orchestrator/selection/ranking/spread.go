@@ -0,0 +1,178 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+)
+
+// placementPreferredRank is the rank given to nodes preferred by a soft placement rule.
+// It is higher than RankPreferred so that placement rules outweigh the randomness of the ranking.
+const placementPreferredRank = 3 * orchestrator.RankPreferred
+
+type SpreadNodeRankerParams struct {
+	JobStore jobstore.Store
+}
+
+// SpreadNodeRanker ranks nodes based on the spread rules of the job's placement,
+// to distribute the executions of the job across the values of node labels.
+type SpreadNodeRanker struct {
+	jobStore jobstore.Store
+}
+
+func NewSpreadNodeRanker(params SpreadNodeRankerParams) *SpreadNodeRanker {
+	return &SpreadNodeRanker{
+		jobStore: params.JobStore,
+	}
+}
+
+// RankNodes ranks nodes based on the number of active executions of the job
+// running on nodes with the same label value, for each spread rule.
+//
+// The executions the evaluation is about to place, which are the job's count minus its
+// active executions, are planned one at a time on the label value with the least executions,
+// counting the executions already planned, and on the first candidate node of the value by ID.
+// At least one execution is planned on each least used value, so that nodes of all of them are preferred:
+// - Rank 30: Node is the candidate of a planned execution.
+// - Rank 0: Job has no spread rules, or no execution is planned on the node.
+// - Rank -1: A hard spread rule is violated, as the node doesn't have the label or
+// no execution is planned on its label value, which has more executions than other values.
+//
+// Planning the executions of the evaluation spreads the nodes selected for the same
+// evaluation across values, as they are selected from a single ranking.
+func (s *SpreadNodeRanker) RankNodes(ctx context.Context,
+	job models.Job, nodes []models.NodeInfo) ([]orchestrator.NodeRank, error) {
+	ranks := make([]orchestrator.NodeRank, len(nodes))
+	for i, node := range nodes {
+		ranks[i] = orchestrator.NodeRank{
+			NodeInfo:  node,
+			Rank:      orchestrator.RankPossible,
+			Reason:    "no spread rules",
+			Retryable: true,
+		}
+	}
+	if job.Placement == nil || len(job.Placement.Spread) == 0 {
+		return ranks, nil
+	}
+
+	executions, err := s.jobStore.GetExecutions(ctx, jobstore.GetExecutionsOptions{JobID: job.ID})
+	if err != nil {
+		return nil, err
+	}
+	executionsPerNode := make(map[string]int)
+	placements := job.Count
+	for _, execution := range executions {
+		if !execution.IsTerminalState() {
+			executionsPerNode[execution.NodeID]++
+			placements--
+		}
+	}
+
+	for i := range ranks {
+		ranks[i].Reason = "spread rules satisfied"
+	}
+	for _, rule := range job.Placement.Spread {
+		s.rankRule(rule, ranks, executionsPerNode, placements)
+	}
+	for i := range ranks {
+		log.Ctx(ctx).Trace().Object("Rank", ranks[i]).Msg("Ranked node")
+	}
+	return ranks, nil
+}
+
+// rankRule adds the rank of a single spread rule to the ranks of the nodes,
+// planning the given number of placements across the values of the label
+func (s *SpreadNodeRanker) rankRule(
+	rule *models.SpreadRule, ranks []orchestrator.NodeRank, executionsPerNode map[string]int, placements int) {
+	// count the executions and the candidate nodes of each label value
+	executionsPerValue := make(map[string]int)
+	candidatesPerValue := make(map[string][]string)
+	for _, rank := range ranks {
+		value, ok := rank.NodeInfo.Labels[rule.LabelKey]
+		if !ok {
+			continue
+		}
+		executionsPerValue[value] += executionsPerNode[rank.NodeInfo.ID()]
+		if executionsPerNode[rank.NodeInfo.ID()] == 0 {
+			candidatesPerValue[value] = append(candidatesPerValue[value], rank.NodeInfo.ID())
+		}
+	}
+	if len(executionsPerValue) == 0 {
+		// no node has the label, which rejects all nodes for hard rules
+		if rule.Hard {
+			for i := range ranks {
+				ranks[i].Rank = orchestrator.RankUnsuitable
+				ranks[i].Reason = fmt.Sprintf("node has no label %s to spread executions across", rule.LabelKey)
+			}
+		}
+		return
+	}
+
+	// values whose nodes all run the job already can't get more executions,
+	// and are ignored when looking for the least used values
+	leastExecutions := -1
+	values := make([]string, 0, len(candidatesPerValue))
+	for value, candidates := range candidatesPerValue {
+		if count := executionsPerValue[value]; leastExecutions < 0 || count < leastExecutions {
+			leastExecutions = count
+		}
+		values = append(values, value)
+		sort.Strings(candidates)
+	}
+	if leastExecutions < 0 {
+		return
+	}
+	sort.Strings(values)
+	leastUsedValues := 0
+	for _, value := range values {
+		if executionsPerValue[value] == leastExecutions {
+			leastUsedValues++
+		}
+	}
+
+	// plan the placements one at a time on the least used value, counting the planned ones
+	preferred := make(map[string]bool)
+	plannedPerValue := make(map[string]int)
+	for i := 0; i < max(placements, leastUsedValues); i++ {
+		next := ""
+		for _, value := range values {
+			if plannedPerValue[value] == len(candidatesPerValue[value]) {
+				continue
+			}
+			if next == "" ||
+				executionsPerValue[value]+plannedPerValue[value] < executionsPerValue[next]+plannedPerValue[next] {
+				next = value
+			}
+		}
+		if next == "" {
+			break
+		}
+		preferred[candidatesPerValue[next][plannedPerValue[next]]] = true
+		plannedPerValue[next]++
+	}
+
+	for i := range ranks {
+		if !ranks[i].MeetsRequirement() {
+			continue
+		}
+		value, ok := ranks[i].NodeInfo.Labels[rule.LabelKey]
+		switch {
+		case !ok && rule.Hard:
+			ranks[i].Rank = orchestrator.RankUnsuitable
+			ranks[i].Reason = fmt.Sprintf("node has no label %s to spread executions across", rule.LabelKey)
+		case ok && rule.Hard && plannedPerValue[value] == 0:
+			ranks[i].Rank = orchestrator.RankUnsuitable
+			ranks[i].Reason = fmt.Sprintf("%s=%s already runs %d executions of the job, while other values run %d",
+				rule.LabelKey, value, executionsPerValue[value], leastExecutions)
+		case preferred[ranks[i].NodeInfo.ID()]:
+			ranks[i].Rank += placementPreferredRank
+			ranks[i].Reason = fmt.Sprintf("%s=%s runs the least executions of the job", rule.LabelKey, value)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+//go:build unit || !integration
+
+package ranking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+)
+
+type SpreadNodeRankerSuite struct {
+	suite.Suite
+	jobStore *jobstore.MockStore
+	ranker   *SpreadNodeRanker
+	nodes    []models.NodeInfo
+}
+
+func TestSpreadNodeRankerSuite(t *testing.T) {
+	suite.Run(t, new(SpreadNodeRankerSuite))
+}
+
+func (s *SpreadNodeRankerSuite) SetupTest() {
+	s.jobStore = jobstore.NewMockStore(gomock.NewController(s.T()))
+	s.ranker = NewSpreadNodeRanker(SpreadNodeRankerParams{JobStore: s.jobStore})
+	s.nodes = []models.NodeInfo{
+		{NodeID: "a1", Labels: map[string]string{"zone": "a"}},
+		{NodeID: "a2", Labels: map[string]string{"zone": "a"}},
+		{NodeID: "b1", Labels: map[string]string{"zone": "b"}},
+		{NodeID: "b2", Labels: map[string]string{"zone": "b"}},
+		{NodeID: "c1", Labels: map[string]string{"zone": "c"}},
+		{NodeID: "none"},
+	}
+}
+
+// activeExecution returns an execution of a job running on the node
+func activeExecution(jobID, nodeID string) models.Execution {
+	return models.Execution{
+		JobID:        jobID,
+		NodeID:       nodeID,
+		DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStateRunning),
+		ComputeState: models.NewExecutionState(models.ExecutionStateBidAccepted),
+	}
+}
+
+// stoppedExecution returns a terminal execution of a job on the node
+func stoppedExecution(jobID, nodeID string) models.Execution {
+	return models.Execution{
+		JobID:        jobID,
+		NodeID:       nodeID,
+		DesiredState: models.NewExecutionDesiredState(models.ExecutionDesiredStateStopped),
+		ComputeState: models.NewExecutionState(models.ExecutionStateCompleted),
+	}
+}
+
+func (s *SpreadNodeRankerSuite) job(hard bool) models.Job {
+	return models.Job{
+		ID:        "job",
+		Placement: &models.JobPlacement{Spread: []*models.SpreadRule{{LabelKey: "zone", Hard: hard}}},
+	}
+}
+
+func (s *SpreadNodeRankerSuite) expectExecutions(executions ...models.Execution) {
+	s.jobStore.EXPECT().GetExecutions(gomock.Any(), jobstore.GetExecutionsOptions{JobID: "job"}).Return(executions, nil)
+}
+
+func (s *SpreadNodeRankerSuite) TestNoSpreadRules() {
+	ranks, err := s.ranker.RankNodes(context.Background(), models.Job{ID: "job"}, s.nodes)
+	s.Require().NoError(err)
+	for _, node := range s.nodes {
+		assertEquals(s.T(), ranks, node.ID(), orchestrator.RankPossible, "no spread rules")
+	}
+}
+
+func (s *SpreadNodeRankerSuite) TestSoftSpreadWithoutExecutions() {
+	s.expectExecutions()
+	ranks, err := s.ranker.RankNodes(context.Background(), s.job(false), s.nodes)
+	s.Require().NoError(err)
+
+	// a single node of each zone is preferred
+	assertEquals(s.T(), ranks, "a1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "a2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "b1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "c1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "none", orchestrator.RankPossible)
+}
+
+func (s *SpreadNodeRankerSuite) TestSoftSpreadWithExecutions() {
+	s.expectExecutions(
+		activeExecution("job", "a1"),
+		activeExecution("job", "c1"),
+		stoppedExecution("job", "b1"),
+	)
+	ranks, err := s.ranker.RankNodes(context.Background(), s.job(false), s.nodes)
+	s.Require().NoError(err)
+
+	assertEquals(s.T(), ranks, "a2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "b1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "none", orchestrator.RankPossible)
+}
+
+func (s *SpreadNodeRankerSuite) TestHardSpread() {
+	s.expectExecutions(
+		activeExecution("job", "a1"),
+		activeExecution("job", "c1"),
+	)
+	ranks, err := s.ranker.RankNodes(context.Background(), s.job(true), s.nodes)
+	s.Require().NoError(err)
+
+	assertEquals(s.T(), ranks, "a2", orchestrator.RankUnsuitable)
+	assertEquals(s.T(), ranks, "b1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "none", orchestrator.RankUnsuitable)
+}
+
+func (s *SpreadNodeRankerSuite) TestHardSpreadIgnoresFullValues() {
+	// zone c has a single node which already runs the job, so zones a and b are still
+	// eligible for a second execution
+	s.expectExecutions(
+		activeExecution("job", "a1"),
+		activeExecution("job", "b1"),
+		activeExecution("job", "c1"),
+	)
+	ranks, err := s.ranker.RankNodes(context.Background(), s.job(true), s.nodes)
+	s.Require().NoError(err)
+
+	assertEquals(s.T(), ranks, "a2", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", placementPreferredRank)
+}
+
+func (s *SpreadNodeRankerSuite) TestHardSpreadWithoutLabel() {
+	s.expectExecutions()
+	job := s.job(true)
+	job.Placement.Spread[0].LabelKey = "rack"
+	ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+	for _, node := range s.nodes {
+		assertEquals(s.T(), ranks, node.ID(), orchestrator.RankUnsuitable)
+	}
+}
+
+func (s *SpreadNodeRankerSuite) TestSpreadsPlacementsOfEvaluation() {
+	s.expectExecutions()
+	job := s.job(true)
+	job.Count = 4
+	ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+
+	// the fourth execution is planned on zone a, as all zones have one execution planned
+	assertEquals(s.T(), ranks, "a1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "a2", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "c1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "none", orchestrator.RankUnsuitable)
+}
+
+func (s *SpreadNodeRankerSuite) TestHardSpreadCountsPlannedPlacements() {
+	s.expectExecutions(activeExecution("job", "a1"))
+	job := s.job(true)
+	job.Count = 3
+	ranks, err := s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+
+	// the two remaining executions are planned on zones b and c, leaving none for zone a
+	assertEquals(s.T(), ranks, "a2", orchestrator.RankUnsuitable)
+	assertEquals(s.T(), ranks, "b1", placementPreferredRank)
+	assertEquals(s.T(), ranks, "b2", orchestrator.RankPossible)
+	assertEquals(s.T(), ranks, "c1", placementPreferredRank)
+
+	// a third execution would be planned on zone a once zones b and c have one
+	s.expectExecutions(activeExecution("job", "a1"))
+	job.Count = 4
+	ranks, err = s.ranker.RankNodes(context.Background(), job, s.nodes)
+	s.Require().NoError(err)
+	assertEquals(s.T(), ranks, "a2", placementPreferredRank)
+}
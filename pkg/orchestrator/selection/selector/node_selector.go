@@ -31,17 +31,12 @@ func NewNodeSelector(
 	}
 }
 
-func (n NodeSelector) AllNodes(ctx context.Context) ([]models.NodeInfo, error) {
+func (n NodeSelector) AllNodes(ctx context.Context) ([]models.NodeState, error) {
 	nodeStates, err := n.discoverer.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list discovered nodes: %w", err)
 	}
-	// extract slice of models.NodeInfo from slice of models.NodeConnectionState
-	nodeInfos := make([]models.NodeInfo, 0, len(nodeStates))
-	for _, ns := range nodeStates {
-		nodeInfos = append(nodeInfos, ns.Info)
-	}
-	return nodeInfos, nil
+	return nodeStates, nil
 }
 
 func (n NodeSelector) MatchingNodes(
@@ -0,0 +1,199 @@
+package bolttemplatestore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/benbjohnson/clock"
+	"github.com/rs/zerolog/log"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/boltdblib"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
+)
+
+const BucketTemplates = "templates"
+
+type BoltTemplateStore struct {
+	database *bolt.DB
+	clock    clock.Clock
+}
+
+type Option func(store *BoltTemplateStore)
+
+func WithClock(clock clock.Clock) Option {
+	return func(store *BoltTemplateStore) {
+		store.clock = clock
+	}
+}
+
+// NewBoltTemplateStore creates a new template store where data is held in buckets.
+// Data is structured as follows
+//
+// bucket templates
+//
+//	bucket name -> key []version -> JobTemplate
+func NewBoltTemplateStore(dbPath string, options ...Option) (*BoltTemplateStore, error) {
+	db, err := boltdblib.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &BoltTemplateStore{
+		database: db,
+		clock:    clock.New(),
+	}
+	for _, opt := range options {
+		opt(store)
+	}
+
+	if err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(BucketTemplates))
+		return err
+	}); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return store, nil
+}
+
+// Put registers a new version of the template
+func (s *BoltTemplateStore) Put(ctx context.Context, template models.JobTemplate) (models.JobTemplate, error) {
+	template.Normalize()
+	if err := template.Validate(); err != nil {
+		return models.JobTemplate{}, templates.NewErrInvalidTemplate(template.Name, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return models.JobTemplate{}, err
+	}
+
+	err := s.database.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.Bucket([]byte(BucketTemplates)).CreateBucketIfNotExists([]byte(template.Name))
+		if err != nil {
+			return err
+		}
+		version, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		template.Version = version
+		template.CreateTime = s.clock.Now().UTC().UnixNano()
+
+		data, err := json.Marshal(template)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(uint64ToBytes(version), data)
+	})
+	if err != nil {
+		return models.JobTemplate{}, err
+	}
+	return template, nil
+}
+
+// Get returns the template with the given name and version, or the latest
+// version if version is zero.
+func (s *BoltTemplateStore) Get(ctx context.Context, name string, version uint64) (models.JobTemplate, error) {
+	if err := ctx.Err(); err != nil {
+		return models.JobTemplate{}, err
+	}
+	var template models.JobTemplate
+	err := s.database.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(BucketTemplates)).Bucket([]byte(name))
+		if bucket == nil {
+			return templates.NewErrTemplateNotFound(name)
+		}
+		var data []byte
+		if version == 0 {
+			_, data = bucket.Cursor().Last()
+		} else {
+			data = bucket.Get(uint64ToBytes(version))
+		}
+		if data == nil {
+			if version == 0 {
+				return templates.NewErrTemplateNotFound(name)
+			}
+			return templates.NewErrTemplateVersionNotFound(name, version)
+		}
+		return json.Unmarshal(data, &template)
+	})
+	return template, err
+}
+
+// List returns the latest version of every template, ordered by name.
+func (s *BoltTemplateStore) List(ctx context.Context) ([]models.JobTemplate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var result []models.JobTemplate
+	err := s.database.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket([]byte(BucketTemplates))
+		return root.ForEachBucket(func(k []byte) error {
+			_, data := root.Bucket(k).Cursor().Last()
+			if data == nil {
+				return nil
+			}
+			var template models.JobTemplate
+			if err := json.Unmarshal(data, &template); err != nil {
+				return err
+			}
+			result = append(result, template)
+			return nil
+		})
+	})
+	return result, err
+}
+
+// ListVersions returns all versions of the named template, oldest first.
+func (s *BoltTemplateStore) ListVersions(ctx context.Context, name string) ([]models.JobTemplate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var result []models.JobTemplate
+	err := s.database.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(BucketTemplates)).Bucket([]byte(name))
+		if bucket == nil {
+			return templates.NewErrTemplateNotFound(name)
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			var template models.JobTemplate
+			if err := json.Unmarshal(v, &template); err != nil {
+				return err
+			}
+			result = append(result, template)
+			return nil
+		})
+	})
+	return result, err
+}
+
+// Delete removes all versions of the named template.
+func (s *BoltTemplateStore) Delete(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.database.Update(func(tx *bolt.Tx) error {
+		err := tx.Bucket([]byte(BucketTemplates)).DeleteBucket([]byte(name))
+		if errors.Is(err, bolt.ErrBucketNotFound) {
+			return templates.NewErrTemplateNotFound(name)
+		}
+		return err
+	})
+}
+
+// Close closes the underlying database.
+func (s *BoltTemplateStore) Close(ctx context.Context) error {
+	log.Ctx(ctx).Debug().Msg("closing bolt-backed template store")
+	return s.database.Close()
+}
+
+func uint64ToBytes(i uint64) []byte {
+	buf := make([]byte, 8) //nolint:mnd
+	binary.BigEndian.PutUint64(buf, i)
+	return buf
+}
+
+// compile time check
+var _ templates.Store = (*BoltTemplateStore)(nil)
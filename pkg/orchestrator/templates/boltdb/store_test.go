@@ -0,0 +1,113 @@
+//go:build unit || !integration
+
+package bolttemplatestore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type BoltTemplateStoreTestSuite struct {
+	suite.Suite
+	store *BoltTemplateStore
+	clock *clock.Mock
+	ctx   context.Context
+}
+
+func TestBoltTemplateStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BoltTemplateStoreTestSuite))
+}
+
+func (s *BoltTemplateStoreTestSuite) SetupTest() {
+	s.clock = clock.NewMock()
+	s.ctx = context.Background()
+
+	var err error
+	s.store, err = NewBoltTemplateStore(filepath.Join(s.T().TempDir(), "templates.db"), WithClock(s.clock))
+	s.Require().NoError(err)
+}
+
+func (s *BoltTemplateStoreTestSuite) TearDownTest() {
+	s.Require().NoError(s.store.Close(s.ctx))
+}
+
+func (s *BoltTemplateStoreTestSuite) put(name, spec string) models.JobTemplate {
+	stored, err := s.store.Put(s.ctx, models.JobTemplate{Name: name, Spec: spec})
+	s.Require().NoError(err)
+	return stored
+}
+
+func (s *BoltTemplateStoreTestSuite) TestPutAssignsVersions() {
+	first := s.put("resize", "v1")
+	s.clock.Add(time.Minute)
+	second := s.put("resize", "v2")
+	other := s.put("convert", "v1")
+
+	s.Equal(uint64(1), first.Version)
+	s.Equal(uint64(2), second.Version)
+	s.Equal(uint64(1), other.Version)
+	s.Equal(s.clock.Now().UnixNano(), second.CreateTime)
+}
+
+func (s *BoltTemplateStoreTestSuite) TestPutInvalid() {
+	_, err := s.store.Put(s.ctx, models.JobTemplate{Name: "resize"})
+	s.Require().Error(err)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.ValidationError))
+}
+
+func (s *BoltTemplateStoreTestSuite) TestGet() {
+	s.put("resize", "v1")
+	s.put("resize", "v2")
+
+	latest, err := s.store.Get(s.ctx, "resize", 0)
+	s.Require().NoError(err)
+	s.Equal("v2", latest.Spec)
+
+	first, err := s.store.Get(s.ctx, "resize", 1)
+	s.Require().NoError(err)
+	s.Equal("v1", first.Spec)
+
+	_, err = s.store.Get(s.ctx, "resize", 3)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.NotFoundError))
+
+	_, err = s.store.Get(s.ctx, "missing", 0)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.NotFoundError))
+}
+
+func (s *BoltTemplateStoreTestSuite) TestList() {
+	s.put("resize", "v1")
+	s.put("resize", "v2")
+	s.put("convert", "v1")
+
+	templates, err := s.store.List(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(templates, 2)
+	s.Equal("convert", templates[0].Name)
+	s.Equal("resize", templates[1].Name)
+	s.Equal(uint64(2), templates[1].Version)
+
+	versions, err := s.store.ListVersions(s.ctx, "resize")
+	s.Require().NoError(err)
+	s.Require().Len(versions, 2)
+	s.Equal("v1", versions[0].Spec)
+	s.Equal("v2", versions[1].Spec)
+}
+
+func (s *BoltTemplateStoreTestSuite) TestDelete() {
+	s.put("resize", "v1")
+	s.Require().NoError(s.store.Delete(s.ctx, "resize"))
+
+	_, err := s.store.Get(s.ctx, "resize", 0)
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.NotFoundError))
+
+	err = s.store.Delete(s.ctx, "resize")
+	s.True(bacerrors.IsErrorWithCode(err, bacerrors.NotFoundError))
+}
@@ -0,0 +1,26 @@
+package templates
+
+import (
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+)
+
+const TemplateStoreComponent = "TemplateStore"
+
+func NewErrTemplateNotFound(name string) bacerrors.Error {
+	return bacerrors.New("job template not found: %s", name).
+		WithCode(bacerrors.NotFoundError).
+		WithComponent(TemplateStoreComponent)
+}
+
+func NewErrTemplateVersionNotFound(name string, version uint64) bacerrors.Error {
+	return bacerrors.New("job template %s has no version %d", name, version).
+		WithCode(bacerrors.NotFoundError).
+		WithComponent(TemplateStoreComponent).
+		WithHint("Omit the version to use the latest version of the template")
+}
+
+func NewErrInvalidTemplate(name string, err error) bacerrors.Error {
+	return bacerrors.Wrap(err, "invalid job template %s", name).
+		WithCode(bacerrors.ValidationError).
+		WithComponent(TemplateStoreComponent)
+}
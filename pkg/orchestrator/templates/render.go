@@ -0,0 +1,36 @@
+package templates
+
+import (
+	"fmt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/marshaller"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/template"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Render validates the provided parameter values against the template and
+// renders its spec into a job. Parameters are validated before rendering so
+// that users get a clear error instead of a template execution failure.
+func Render(jobTemplate *models.JobTemplate, values map[string]string) (*models.Job, error) {
+	resolved, err := jobTemplate.ResolveParameters(values)
+	if err != nil {
+		return nil, NewErrInvalidTemplate(jobTemplate.Name, err)
+	}
+
+	parser, err := template.NewParser(template.ParserParams{
+		Replacements: resolved,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template parser: %w", err)
+	}
+	spec, err := parser.Parse(jobTemplate.Spec)
+	if err != nil {
+		return nil, NewErrInvalidTemplate(jobTemplate.Name, err)
+	}
+
+	job, err := marshaller.UnmarshalJob([]byte(spec))
+	if err != nil {
+		return nil, NewErrInvalidTemplate(jobTemplate.Name, err)
+	}
+	return job, nil
+}
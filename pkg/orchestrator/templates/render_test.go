@@ -0,0 +1,70 @@
+//go:build unit || !integration
+
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+const testSpec = `
+Name: resize-{{.size}}
+Type: batch
+Count: 1
+Tasks:
+  - Name: main
+    Engine:
+      Type: docker
+      Params:
+        Image: {{.image}}
+        Parameters: ["--size", "{{.size}}"]
+`
+
+type RenderTestSuite struct {
+	suite.Suite
+	template *models.JobTemplate
+}
+
+func TestRenderTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderTestSuite))
+}
+
+func (s *RenderTestSuite) SetupTest() {
+	s.template = &models.JobTemplate{
+		Name: "resize",
+		Spec: testSpec,
+		Parameters: []models.TemplateParameter{
+			{Name: "size", Type: models.TemplateParameterTypeInt, Default: "256"},
+			{Name: "image", Required: true},
+		},
+	}
+	s.template.Normalize()
+}
+
+func (s *RenderTestSuite) TestRender() {
+	job, err := Render(s.template, map[string]string{"image": "resizer:latest"})
+	s.Require().NoError(err)
+	s.Equal("resize-256", job.Name)
+	s.Require().Len(job.Tasks, 1)
+	s.Equal("resizer:latest", job.Tasks[0].Engine.Params["Image"])
+	s.Equal([]interface{}{"--size", "256"}, job.Tasks[0].Engine.Params["Parameters"])
+}
+
+func (s *RenderTestSuite) TestRenderInvalidParameters() {
+	_, err := Render(s.template, map[string]string{"size": "large"})
+	s.Require().Error(err)
+
+	var bErr bacerrors.Error
+	s.Require().ErrorAs(err, &bErr)
+	s.Equal(bacerrors.ValidationError, bErr.Code())
+}
+
+func (s *RenderTestSuite) TestRenderInvalidSpec() {
+	s.template.Spec = "Type: batch\nUnknownField: {{.size}}"
+	_, err := Render(s.template, map[string]string{"image": "resizer"})
+	s.Error(err)
+}
@@ -0,0 +1,30 @@
+package templates
+
+import (
+	"context"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// Store persists versioned job templates on the orchestrator.
+type Store interface {
+	// Put registers a new version of the template, and returns the stored
+	// template with its assigned version.
+	Put(ctx context.Context, template models.JobTemplate) (models.JobTemplate, error)
+
+	// Get returns the template with the given name and version. The latest
+	// version is returned if version is zero.
+	Get(ctx context.Context, name string, version uint64) (models.JobTemplate, error)
+
+	// List returns the latest version of every template, ordered by name.
+	List(ctx context.Context) ([]models.JobTemplate, error)
+
+	// ListVersions returns all versions of the named template, oldest first.
+	ListVersions(ctx context.Context, name string) ([]models.JobTemplate, error)
+
+	// Delete removes all versions of the named template.
+	Delete(ctx context.Context, name string) error
+
+	// Close releases any resources held by the store.
+	Close(ctx context.Context) error
+}
@@ -79,6 +79,9 @@ func applyBatchTaskDefaults(defaults types.BatchTaskDefaultConfig, task *models.
 	if task.Timeouts.TotalTimeout <= 0 {
 		task.Timeouts.TotalTimeout = int64(time.Duration(defaults.Timeouts.TotalTimeout).Seconds())
 	}
+	if task.Timeouts.DisconnectGracePeriod <= 0 {
+		task.Timeouts.DisconnectGracePeriod = int64(time.Duration(defaults.Timeouts.DisconnectGracePeriod).Seconds())
+	}
 
 	return nil
 }
@@ -105,6 +108,9 @@ func applyLongRunningTaskDefaults(defaults types.LongRunningTaskDefaultConfig, t
 	if task.ResourcesConfig.GPU == "" {
 		task.ResourcesConfig.GPU = defaults.Resources.GPU
 	}
+	if task.Timeouts.DisconnectGracePeriod <= 0 {
+		task.Timeouts.DisconnectGracePeriod = int64(time.Duration(defaults.Timeouts.DisconnectGracePeriod).Seconds())
+	}
 }
 
 // RequesterInfo is a transformer that sets the requester ID in the job meta.
@@ -0,0 +1,77 @@
+//go:build unit || !integration
+
+package transformer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/config/types"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+)
+
+type DefaultsApplierSuite struct {
+	suite.Suite
+	defaults types.JobDefaults
+}
+
+func TestDefaultsApplierSuite(t *testing.T) {
+	suite.Run(t, new(DefaultsApplierSuite))
+}
+
+func (s *DefaultsApplierSuite) SetupTest() {
+	s.defaults = types.JobDefaults{
+		Batch: types.BatchJobDefaultsConfig{
+			Task: types.BatchTaskDefaultConfig{
+				Timeouts: types.TaskTimeoutConfig{DisconnectGracePeriod: types.Duration(5 * time.Minute)},
+			},
+		},
+		Service: types.LongRunningJobDefaultsConfig{
+			Task: types.LongRunningTaskDefaultConfig{
+				Timeouts: types.LongRunningTaskTimeoutConfig{DisconnectGracePeriod: types.Duration(10 * time.Minute)},
+			},
+		},
+		Daemon: types.LongRunningJobDefaultsConfig{
+			Task: types.LongRunningTaskDefaultConfig{
+				Timeouts: types.LongRunningTaskTimeoutConfig{DisconnectGracePeriod: types.Duration(15 * time.Minute)},
+			},
+		},
+	}
+}
+
+// TestDisconnectGracePeriodDefault verifies that a task without a grace period gets
+// the default of its job type, including long running jobs.
+func (s *DefaultsApplierSuite) TestDisconnectGracePeriodDefault() {
+	tests := []struct {
+		jobType  string
+		expected time.Duration
+	}{
+		{jobType: models.JobTypeBatch, expected: 5 * time.Minute},
+		{jobType: models.JobTypeService, expected: 10 * time.Minute},
+		{jobType: models.JobTypeDaemon, expected: 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		s.Run(tt.jobType, func() {
+			job := mock.Job()
+			job.Type = tt.jobType
+			job.Task().Timeouts.DisconnectGracePeriod = 0
+
+			s.Require().NoError(DefaultsApplier(s.defaults).Transform(context.Background(), job))
+			s.Equal(tt.expected, job.Task().Timeouts.GetDisconnectGracePeriod())
+		})
+	}
+}
+
+// TestDisconnectGracePeriodKept verifies that a grace period set on the task is not overridden.
+func (s *DefaultsApplierSuite) TestDisconnectGracePeriodKept() {
+	job := mock.Job()
+	job.Type = models.JobTypeService
+	job.Task().Timeouts.DisconnectGracePeriod = 60
+
+	s.Require().NoError(DefaultsApplier(s.defaults).Transform(context.Background(), job))
+	s.Equal(time.Minute, job.Task().Timeouts.GetDisconnectGracePeriod())
+}
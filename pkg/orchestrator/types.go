@@ -4,12 +4,17 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
 )
 
 type SubmitJobRequest struct {
 	Job                  *models.Job
 	ClientInstanceID     string
 	ClientInstallationID string
+	// Template is the template the job was rendered from, if any
+	Template *models.JobTemplate
+	// RerunOf is the ID of the job this job reruns, if any
+	RerunOf string
 }
 
 type SubmitJobResponse struct {
@@ -18,6 +23,34 @@ type SubmitJobResponse struct {
 	Warnings     []string
 }
 
+type RunTemplateRequest struct {
+	Name                 string
+	Version              uint64
+	Variables            map[string]string
+	ClientInstanceID     string
+	ClientInstallationID string
+}
+
+type RunTemplateResponse struct {
+	SubmitJobResponse
+	// TemplateVersion is the version of the template that was run
+	TemplateVersion uint64
+}
+
+type RerunJobRequest struct {
+	JobID string
+	// FailedOnly reruns only the partitions that did not complete successfully
+	FailedOnly           bool
+	ClientInstanceID     string
+	ClientInstallationID string
+}
+
+type RerunJobResponse struct {
+	SubmitJobResponse
+	// Partitions are the partitions scheduled by the new job. Empty if all partitions are rerun.
+	Partitions []int
+}
+
 type StopJobRequest struct {
 	JobID         string
 	Reason        string
@@ -33,6 +66,9 @@ type ReadLogsRequest struct {
 	ExecutionID string
 	Tail        bool
 	Follow      bool
+	// Filter restricts the returned lines by stream, time range and pattern.
+	// Time range filters only apply to logs retained by the orchestrator.
+	Filter logstore.Query
 }
 
 type ReadLogsResponse struct {
@@ -48,11 +48,19 @@ func (t *executionTransitions) shouldCancel() bool {
 
 // shouldRejectBid returns true if we need to send a bid rejection:
 // - Moving from pending to stopped
-// - Current state is one that can be rejected (new or bid accepted)
+// - Previous state is one where the node was asked to bid, or has already bid
+// - The node did not reject the bid itself
 func (t *executionTransitions) shouldRejectBid() bool {
-	return t.upsert.Previous != nil &&
-		t.upsert.Previous.DesiredState.StateType == models.ExecutionDesiredStatePending &&
-		t.upsert.Current.DesiredState.StateType == models.ExecutionDesiredStateStopped &&
-		(t.upsert.Current.ComputeState.StateType == models.ExecutionStateAskForBid ||
-			t.upsert.Current.ComputeState.StateType == models.ExecutionStateAskForBidAccepted)
+	if t.upsert.Previous == nil ||
+		t.upsert.Previous.DesiredState.StateType != models.ExecutionDesiredStatePending ||
+		t.upsert.Current.DesiredState.StateType != models.ExecutionDesiredStateStopped ||
+		t.upsert.Current.ComputeState.StateType == models.ExecutionStateAskForBidRejected {
+		return false
+	}
+	switch t.upsert.Previous.ComputeState.StateType {
+	case models.ExecutionStateNew, models.ExecutionStateAskForBid, models.ExecutionStateAskForBidAccepted:
+		return true
+	default:
+		return false
+	}
 }
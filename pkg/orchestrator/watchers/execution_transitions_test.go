@@ -279,7 +279,8 @@ func (s *ExecutionTransitionsTestSuite) TestShouldRejectBid() {
 			expected: true,
 		},
 		{
-			name: "pending_to_stopped_no_bid",
+			// the node was asked to bid, but did not respond yet
+			name: "pending_to_stopped_awaiting_bid",
 			previous: func() *models.Execution {
 				e := mock.Execution()
 				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStatePending)
@@ -289,9 +290,41 @@ func (s *ExecutionTransitionsTestSuite) TestShouldRejectBid() {
 			current: func() *models.Execution {
 				e := mock.Execution()
 				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStateStopped)
+				e.ComputeState = models.NewExecutionState(models.ExecutionStateBidRejected)
+				return e
+			}(),
+			expected: true,
+		},
+		{
+			name: "bid_rejected_by_scheduler",
+			previous: func() *models.Execution {
+				e := mock.Execution()
+				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStatePending)
+				e.ComputeState = models.NewExecutionState(models.ExecutionStateAskForBidAccepted)
+				return e
+			}(),
+			current: func() *models.Execution {
+				e := mock.Execution()
+				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStateStopped)
+				e.ComputeState = models.NewExecutionState(models.ExecutionStateBidRejected)
+				return e
+			}(),
+			expected: true,
+		},
+		{
+			name: "bid_rejected_by_node",
+			previous: func() *models.Execution {
+				e := mock.Execution()
+				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStatePending)
 				e.ComputeState = models.NewExecutionState(models.ExecutionStateNew)
 				return e
 			}(),
+			current: func() *models.Execution {
+				e := mock.Execution()
+				e.DesiredState = models.NewExecutionDesiredState(models.ExecutionDesiredStateStopped)
+				e.ComputeState = models.NewExecutionState(models.ExecutionStateAskForBidRejected)
+				return e
+			}(),
 			expected: false,
 		},
 		{
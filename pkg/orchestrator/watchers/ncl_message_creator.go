@@ -129,6 +129,7 @@ func (d *NCLMessageCreator) CreateMessage(event watcher.Event) (*envelope.Messag
 
 	if message != nil {
 		message.WithMetadataValue(ncl.KeySubject, d.subjectFn(upsert.Current.NodeID))
+		message.WithMetadataValue(ncl.KeyExecutionID, execution.ID)
 	}
 	return message, nil
 }
@@ -0,0 +1,86 @@
+package watchers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+)
+
+// UsageCollector accounts the resources held by executions once they finish,
+// so usage reports are built incrementally instead of scanning the job store.
+type UsageCollector struct {
+	store accounting.Store
+}
+
+// NewUsageCollector creates a new UsageCollector
+func NewUsageCollector(store accounting.Store) *UsageCollector {
+	return &UsageCollector{
+		store: store,
+	}
+}
+
+// HandleEvent records the usage of executions transitioning from executing to a terminal state.
+// Executions that never ran, such as rejected bids, didn't hold any resources and are skipped.
+func (c *UsageCollector) HandleEvent(ctx context.Context, event watcher.Event) error {
+	upsert, ok := event.Object.(models.ExecutionUpsert)
+	if !ok {
+		log.Ctx(ctx).Debug().Msgf("Skipping event of type %s", event.ObjectType)
+		return nil
+	}
+	if upsert.Current == nil || upsert.Previous == nil {
+		return nil
+	}
+	if !upsert.Previous.ComputeState.StateType.IsExecuting() || !upsert.Current.ComputeState.StateType.IsTerminal() {
+		return nil
+	}
+
+	if err := c.store.Add(ctx, toUsageRecord(upsert.Current)); err != nil {
+		return fmt.Errorf("failed to record usage of execution %s: %w", upsert.Current.ID, err)
+	}
+	return nil
+}
+
+func toUsageRecord(execution *models.Execution) accounting.Record {
+	record := accounting.Record{
+		ExecutionID: execution.ID,
+		JobID:       execution.JobID,
+		Namespace:   execution.Namespace,
+		EndTime:     execution.GetModifyTime(),
+	}
+	if execution.Job != nil {
+		record.JobType = execution.Job.Type
+		record.Labels = execution.Job.Labels
+		if record.Namespace == "" {
+			record.Namespace = execution.Job.Namespace
+		}
+	}
+	if execution.AllocatedResources != nil {
+		record.Allocated = *execution.TotalAllocatedResources()
+	}
+
+	// prefer the wall time metered by the compute node, and fall back to the
+	// time since the execution was created
+	if usage := executionUsage(execution); usage != nil && usage.WallTime > 0 {
+		record.Duration = usage.WallTime
+		record.CPUTime = usage.CPUTime
+	} else {
+		record.Duration = max(execution.GetModifyTime().Sub(execution.GetCreateTime()), time.Duration(0))
+	}
+	return record
+}
+
+func executionUsage(execution *models.Execution) *models.ResourceUsage {
+	if execution.RunOutput == nil {
+		return nil
+	}
+	return execution.RunOutput.Usage
+}
+
+// compile-time check that UsageCollector implements watcher.EventHandler
+var _ watcher.EventHandler = (*UsageCollector)(nil)
@@ -0,0 +1,118 @@
+//go:build unit || !integration
+
+package watchers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+)
+
+type recordingUsageStore struct {
+	records []accounting.Record
+}
+
+func (s *recordingUsageStore) Add(_ context.Context, records ...accounting.Record) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *recordingUsageStore) Query(context.Context, accounting.Query) ([]accounting.Record, error) {
+	return s.records, nil
+}
+
+func (s *recordingUsageStore) Close(context.Context) error {
+	return nil
+}
+
+type UsageCollectorTestSuite struct {
+	suite.Suite
+	store     *recordingUsageStore
+	collector *UsageCollector
+}
+
+func TestUsageCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(UsageCollectorTestSuite))
+}
+
+func (s *UsageCollectorTestSuite) SetupTest() {
+	s.store = &recordingUsageStore{}
+	s.collector = NewUsageCollector(s.store)
+}
+
+func (s *UsageCollectorTestSuite) completedUpsert() models.ExecutionUpsert {
+	upsert := setupStateTransition(
+		models.ExecutionDesiredStateRunning, models.ExecutionStateRunning,
+		models.ExecutionDesiredStateStopped, models.ExecutionStateCompleted,
+	)
+	upsert.Current.Job.Labels = map[string]string{"team": "ml"}
+	upsert.Current.AllocatedResources = &models.AllocatedResources{
+		Tasks: map[string]*models.Resources{"main": {CPU: 2, Memory: 1 << 30, GPU: 1}},
+	}
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	upsert.Current.CreateTime = created.UnixNano()
+	upsert.Current.ModifyTime = created.Add(5 * time.Minute).UnixNano()
+	return upsert
+}
+
+func (s *UsageCollectorTestSuite) TestRecordsFinishedExecution() {
+	upsert := s.completedUpsert()
+	s.Require().NoError(s.collector.HandleEvent(context.Background(), createExecutionEvent(upsert)))
+	s.Require().Len(s.store.records, 1)
+
+	record := s.store.records[0]
+	s.Equal(upsert.Current.ID, record.ExecutionID)
+	s.Equal(upsert.Current.JobID, record.JobID)
+	s.Equal(upsert.Current.Namespace, record.Namespace)
+	s.Equal(upsert.Current.Job.Type, record.JobType)
+	s.Equal(map[string]string{"team": "ml"}, record.Labels)
+	s.Equal("2024-03-01", record.Day())
+	s.Equal(5*time.Minute, record.Duration)
+	s.Equal(2.0, record.Allocated.CPU)
+	s.Equal(uint64(1), record.Allocated.GPU)
+}
+
+func (s *UsageCollectorTestSuite) TestPrefersMeteredUsage() {
+	upsert := s.completedUpsert()
+	upsert.Current.RunOutput = &models.RunCommandResult{
+		Usage: &models.ResourceUsage{WallTime: time.Minute, CPUTime: 30 * time.Second},
+	}
+	s.Require().NoError(s.collector.HandleEvent(context.Background(), createExecutionEvent(upsert)))
+	s.Require().Len(s.store.records, 1)
+	s.Equal(time.Minute, s.store.records[0].Duration)
+	s.Equal(30*time.Second, s.store.records[0].CPUTime)
+}
+
+func (s *UsageCollectorTestSuite) TestSkipsExecutionsThatDidNotRun() {
+	tests := []struct {
+		name     string
+		previous models.ExecutionStateType
+		current  models.ExecutionStateType
+	}{
+		{name: "bid-rejected", previous: models.ExecutionStateAskForBidAccepted, current: models.ExecutionStateBidRejected},
+		{name: "still-running", previous: models.ExecutionStateBidAccepted, current: models.ExecutionStateRunning},
+		{name: "already-terminal", previous: models.ExecutionStateCompleted, current: models.ExecutionStateCompleted},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			upsert := setupStateTransition(
+				models.ExecutionDesiredStateRunning, tt.previous,
+				models.ExecutionDesiredStateStopped, tt.current,
+			)
+			s.Require().NoError(s.collector.HandleEvent(context.Background(), createExecutionEvent(upsert)))
+			s.Empty(s.store.records)
+		})
+	}
+}
+
+func (s *UsageCollectorTestSuite) TestSkipsNewExecutions() {
+	upsert := setupNewExecution(models.ExecutionDesiredStatePending, models.ExecutionStateNew)
+	s.Require().NoError(s.collector.HandleEvent(context.Background(), createExecutionEvent(upsert)))
+	s.Empty(s.store.records)
+}
@@ -0,0 +1,5 @@
+package apimodels
+
+type GetBackupRequest struct {
+	BaseGetRequest
+}
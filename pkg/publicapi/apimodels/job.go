@@ -76,6 +76,16 @@ func (r *GetJobResponse) Normalize() {
 type ListJobsRequest struct {
 	BaseListRequest
 	Labels []labels.Requirement `query:"-"` // don't auto bind as it requires special handling
+	// States filters jobs to those in any of the given states
+	States []string `query:"state"`
+	// Types filters jobs to those of any of the given types
+	Types []string `query:"type"`
+	// CreatedAfter filters jobs to those created at or after the given unix time, in seconds
+	CreatedAfter int64 `query:"created_after" validate:"min=0"`
+	// CreatedBefore filters jobs to those created before the given unix time, in seconds
+	CreatedBefore int64 `query:"created_before" validate:"min=0"`
+	// NamePrefix filters jobs to those with a name starting with the prefix
+	NamePrefix string `query:"name_prefix"`
 }
 
 // ToHTTPRequest is used to convert the request to an HTTP request
@@ -85,6 +95,21 @@ func (o *ListJobsRequest) ToHTTPRequest() *HTTPRequest {
 	for _, v := range o.Labels {
 		r.Params.Add("labels", v.String())
 	}
+	for _, v := range o.States {
+		r.Params.Add("state", v)
+	}
+	for _, v := range o.Types {
+		r.Params.Add("type", v)
+	}
+	if o.CreatedAfter != 0 {
+		r.Params.Set("created_after", strconv.FormatInt(o.CreatedAfter, 10))
+	}
+	if o.CreatedBefore != 0 {
+		r.Params.Set("created_before", strconv.FormatInt(o.CreatedBefore, 10))
+	}
+	if o.NamePrefix != "" {
+		r.Params.Set("name_prefix", o.NamePrefix)
+	}
 	return r
 }
 
@@ -150,6 +175,22 @@ type ListJobResultsResponse struct {
 	Items []*models.SpecConfig `json:"Items"`
 }
 
+type RerunJobRequest struct {
+	BasePutRequest
+	JobID string `json:"-"`
+	// FailedOnly reruns only the partitions that did not complete successfully
+	FailedOnly bool `json:"FailedOnly"`
+}
+
+type RerunJobResponse struct {
+	BasePutResponse
+	JobID        string `json:"JobID"`
+	EvaluationID string `json:"EvaluationID"`
+	// Partitions are the partitions scheduled by the new job. Empty if all partitions are rerun.
+	Partitions []int    `json:"Partitions,omitempty"`
+	Warnings   []string `json:"Warnings"`
+}
+
 type StopJobRequest struct {
 	BasePutRequest
 	JobID  string `json:"-"`
@@ -167,6 +208,13 @@ type GetLogsRequest struct {
 	ExecutionID string `query:"execution_id" validate:"omitempty"`
 	Tail        bool   `query:"tail"`
 	Follow      bool   `query:"follow"`
+	// Stream only returns lines from the given stream: stdout or stderr.
+	Stream string `query:"stream" validate:"omitempty,oneof=stdout stderr"`
+	// Since and Until bound retained logs by time, in unix seconds.
+	Since int64 `query:"since" validate:"min=0"`
+	Until int64 `query:"until" validate:"min=0"`
+	// Pattern only returns lines matching the regular expression.
+	Pattern string `query:"pattern"`
 }
 
 // ToHTTPRequest is used to convert the request to an HTTP request
@@ -182,5 +230,17 @@ func (o *GetLogsRequest) ToHTTPRequest() *HTTPRequest {
 	if o.Follow {
 		r.Params.Set("follow", "true")
 	}
+	if o.Stream != "" {
+		r.Params.Set("stream", o.Stream)
+	}
+	if o.Since != 0 {
+		r.Params.Set("since", strconv.FormatInt(o.Since, 10))
+	}
+	if o.Until != 0 {
+		r.Params.Set("until", strconv.FormatInt(o.Until, 10))
+	}
+	if o.Pattern != "" {
+		r.Params.Set("pattern", o.Pattern)
+	}
 	return r
 }
@@ -0,0 +1,44 @@
+package apimodels
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type PruneJobsRequest struct {
+	BasePutRequest
+	// OlderThan is the minimum time since terminal jobs were last modified to be purged
+	OlderThan time.Duration `json:"OlderThan"`
+	// Namespace limits the purge to a single namespace. All namespaces are considered if empty.
+	Namespace string `json:"Namespace,omitempty"`
+	// DryRun returns the jobs that would be purged without purging them
+	DryRun bool `json:"DryRun,omitempty"`
+}
+
+// Validate is used to validate fields in the PruneJobsRequest.
+func (r *PruneJobsRequest) Validate() error {
+	if r.OlderThan <= 0 {
+		return errors.New("prune age must be greater than zero")
+	}
+	return nil
+}
+
+type PruneJobsResponse struct {
+	BasePutResponse
+	// JobIDs are the IDs of the purged jobs, or of the jobs that would be purged on a dry run
+	JobIDs []string `json:"JobIDs"`
+	// ArchivePath is the file on the orchestrator the jobs were archived to, if archiving is enabled
+	ArchivePath string `json:"ArchivePath,omitempty"`
+	DryRun      bool   `json:"DryRun,omitempty"`
+}
+
+type GetRetentionStatsRequest struct {
+	BaseGetRequest
+}
+
+type GetRetentionStatsResponse struct {
+	BaseGetResponse
+	Stats models.JobRetentionStats `json:"Stats"`
+}
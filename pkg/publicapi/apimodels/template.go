@@ -0,0 +1,89 @@
+package apimodels
+
+import (
+	"strconv"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+type PutTemplateRequest struct {
+	BasePutRequest
+	Template *models.JobTemplate `json:"Template"`
+}
+
+// Normalize is used to canonicalize fields in the PutTemplateRequest.
+func (r *PutTemplateRequest) Normalize() {
+	if r.Template != nil {
+		r.Template.Normalize()
+	}
+}
+
+// Validate is used to validate fields in the PutTemplateRequest.
+func (r *PutTemplateRequest) Validate() error {
+	return r.Template.Validate()
+}
+
+type PutTemplateResponse struct {
+	BasePutResponse
+	Template *models.JobTemplate `json:"Template"`
+}
+
+type GetTemplateRequest struct {
+	BaseGetRequest
+	Name    string `query:"-"`
+	Version uint64 `query:"version"`
+}
+
+// ToHTTPRequest is used to convert the request to an HTTP request
+func (o *GetTemplateRequest) ToHTTPRequest() *HTTPRequest {
+	r := o.BaseGetRequest.ToHTTPRequest()
+	if o.Version != 0 {
+		r.Params.Set("version", strconv.FormatUint(o.Version, 10))
+	}
+	return r
+}
+
+type GetTemplateResponse struct {
+	BaseGetResponse
+	Template *models.JobTemplate `json:"Template"`
+}
+
+type ListTemplatesRequest struct {
+	BaseListRequest
+}
+
+type ListTemplatesResponse struct {
+	BaseListResponse
+	Items []*models.JobTemplate `json:"Items"`
+}
+
+type ListTemplateVersionsRequest struct {
+	BaseListRequest
+	Name string `query:"-"`
+}
+
+type DeleteTemplateRequest struct {
+	BasePutRequest
+	Name string `json:"-"`
+}
+
+type DeleteTemplateResponse struct {
+	BasePutResponse
+}
+
+type RunTemplateRequest struct {
+	BasePutRequest
+	Name string `json:"-"`
+	// Version of the template to run. The latest version is used if zero.
+	Version uint64 `json:"Version,omitempty"`
+	// Variables are the values of the template parameters
+	Variables map[string]string `json:"Variables,omitempty"`
+}
+
+type RunTemplateResponse struct {
+	BasePutResponse
+	JobID           string   `json:"JobID"`
+	EvaluationID    string   `json:"EvaluationID"`
+	TemplateVersion uint64   `json:"TemplateVersion"`
+	Warnings        []string `json:"Warnings"`
+}
@@ -0,0 +1,43 @@
+package apimodels
+
+import (
+	"strconv"
+
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+)
+
+// GetUsageReportRequest requests the usage of finished executions. The namespace of
+// the base request limits the report to a single namespace, and all namespaces
+// are reported if empty or AllNamespacesNamespace.
+type GetUsageReportRequest struct {
+	BaseGetRequest
+	// Since and Until bound the end time of accounted executions, in unix seconds.
+	Since int64 `query:"since" validate:"min=0"`
+	Until int64 `query:"until" validate:"min=0"`
+	// GroupBy is a comma separated list of keys to group executions by:
+	// namespace, type, day or label:<key>. Defaults to namespace,day.
+	GroupBy string `query:"group_by"`
+}
+
+// ToHTTPRequest is used to convert the request to an HTTP request
+func (o *GetUsageReportRequest) ToHTTPRequest() *HTTPRequest {
+	r := o.BaseGetRequest.ToHTTPRequest()
+
+	if o.Since != 0 {
+		r.Params.Set("since", strconv.FormatInt(o.Since, 10))
+	}
+	if o.Until != 0 {
+		r.Params.Set("until", strconv.FormatInt(o.Until, 10))
+	}
+	if o.GroupBy != "" {
+		r.Params.Set("group_by", o.GroupBy)
+	}
+	return r
+}
+
+type GetUsageReportResponse struct {
+	BaseGetResponse
+	// GroupBy are the keys the rows are grouped by
+	GroupBy []string                 `json:"GroupBy"`
+	Rows    []*models.UsageReportRow `json:"Rows"`
+}
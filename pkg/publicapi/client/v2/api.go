@@ -4,16 +4,23 @@ package client
 // structure of the API: each method returns an object that can submit requests
 // to control a single part of the system.
 type API interface {
+	Admin() *Admin
 	Agent() *Agent
 	Auth() *Auth
 	Jobs() *Jobs
 	Nodes() *Nodes
+	Templates() *Templates
+	Usage() *Usage
 }
 
 type api struct {
 	Client
 }
 
+func (c *api) Admin() *Admin {
+	return &Admin{client: c.Client}
+}
+
 func (c *api) Agent() *Agent {
 	return &Agent{client: c.Client}
 }
@@ -30,6 +37,14 @@ func (c *api) Nodes() *Nodes {
 	return &Nodes{client: c.Client}
 }
 
+func (c *api) Templates() *Templates {
+	return &Templates{client: c.Client}
+}
+
+func (c *api) Usage() *Usage {
+	return &Usage{client: c.Client}
+}
+
 func NewAPI(transport Client) API {
 	return &api{Client: transport}
 }
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+const backupPath = "/api/v1/orchestrator/backup"
+
+type Admin struct {
+	client Client
+}
+
+// Backup is used to download a backup archive of the state of the orchestrator to w.
+func (a *Admin) Backup(ctx context.Context, r *apimodels.GetBackupRequest, w io.Writer) error {
+	return a.client.Download(ctx, backupPath, r, w)
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
 )
 
-const jobsPath = "/api/v1/orchestrator/jobs"
+const (
+	jobsPath      = "/api/v1/orchestrator/jobs"
+	retentionPath = "/api/v1/orchestrator/retention"
+)
 
 type Jobs struct {
 	client Client
@@ -69,6 +72,35 @@ func (j *Jobs) Results(ctx context.Context, r *apimodels.ListJobResultsRequest)
 	return &resp, nil
 }
 
+// Rerun is used to submit a new job with the spec of a terminated job, optionally
+// running only the partitions that did not complete.
+func (j *Jobs) Rerun(ctx context.Context, r *apimodels.RerunJobRequest) (*apimodels.RerunJobResponse, error) {
+	var resp apimodels.RerunJobResponse
+	if err := j.client.Post(ctx, jobsPath+"/"+r.JobID+"/rerun", r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Prune is used to purge terminal jobs older than the requested age from the orchestrator.
+func (j *Jobs) Prune(ctx context.Context, r *apimodels.PruneJobsRequest) (*apimodels.PruneJobsResponse, error) {
+	var resp apimodels.PruneJobsResponse
+	if err := j.client.Post(ctx, retentionPath+"/prune", r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RetentionStats is used to get the activity of the job retention subsystem of the orchestrator.
+func (j *Jobs) RetentionStats(
+	ctx context.Context, r *apimodels.GetRetentionStatsRequest) (*apimodels.GetRetentionStatsResponse, error) {
+	var resp apimodels.GetRetentionStatsResponse
+	if err := j.client.Get(ctx, retentionPath, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Stop is used to stop a job by ID.
 func (j *Jobs) Stop(ctx context.Context, r *apimodels.StopJobRequest) (*apimodels.StopJobResponse, error) {
 	var resp apimodels.StopJobResponse
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+const templatesPath = "/api/v1/orchestrator/templates"
+
+type Templates struct {
+	client Client
+}
+
+// Put is used to register a new version of a job template.
+func (t *Templates) Put(ctx context.Context, r *apimodels.PutTemplateRequest) (*apimodels.PutTemplateResponse, error) {
+	var resp apimodels.PutTemplateResponse
+	if err := t.client.Put(ctx, templatesPath, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Get is used to get a job template by name, and optionally version.
+func (t *Templates) Get(ctx context.Context, r *apimodels.GetTemplateRequest) (*apimodels.GetTemplateResponse, error) {
+	var resp apimodels.GetTemplateResponse
+	if err := t.client.Get(ctx, templatesPath+"/"+r.Name, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List is used to list the latest version of all job templates.
+func (t *Templates) List(ctx context.Context, r *apimodels.ListTemplatesRequest) (*apimodels.ListTemplatesResponse, error) {
+	var resp apimodels.ListTemplatesResponse
+	if err := t.client.List(ctx, templatesPath, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Versions is used to list all versions of a job template.
+func (t *Templates) Versions(
+	ctx context.Context, r *apimodels.ListTemplateVersionsRequest) (*apimodels.ListTemplatesResponse, error) {
+	var resp apimodels.ListTemplatesResponse
+	if err := t.client.List(ctx, templatesPath+"/"+r.Name+"/versions", r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete is used to delete all versions of a job template.
+func (t *Templates) Delete(ctx context.Context, r *apimodels.DeleteTemplateRequest) (*apimodels.DeleteTemplateResponse, error) {
+	var resp apimodels.DeleteTemplateResponse
+	if err := t.client.Delete(ctx, templatesPath+"/"+r.Name, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Run is used to render a job template with the provided variables and submit the resulting job.
+func (t *Templates) Run(ctx context.Context, r *apimodels.RunTemplateRequest) (*apimodels.RunTemplateResponse, error) {
+	var resp apimodels.RunTemplateResponse
+	if err := t.client.Post(ctx, templatesPath+"/"+r.Name+"/run", r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+const usagePath = "/api/v1/orchestrator/usage"
+
+type Usage struct {
+	client Client
+}
+
+// Report is used to get the resource usage of finished executions, grouped as requested.
+func (u *Usage) Report(ctx context.Context, r *apimodels.GetUsageReportRequest) (*apimodels.GetUsageReportResponse, error) {
+	var resp apimodels.GetUsageReportResponse
+	if err := u.client.Get(ctx, usagePath, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
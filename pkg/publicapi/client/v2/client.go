@@ -34,6 +34,7 @@ type Client interface {
 	Post(context.Context, string, apimodels.PutRequest, apimodels.PutResponse) error
 	Delete(context.Context, string, apimodels.PutRequest, apimodels.Response) error
 	Dial(context.Context, string, apimodels.Request) (<-chan *concurrency.AsyncResult[[]byte], error)
+	Download(context.Context, string, apimodels.GetRequest, io.Writer) error
 }
 
 // New creates a new transport.
@@ -91,6 +92,35 @@ func (c *httpClient) Get(ctx context.Context, endpoint string, in apimodels.GetR
 	return nil
 }
 
+// Download is used to do a GET request against an endpoint
+// and copy the raw response body to w
+func (c *httpClient) Download(ctx context.Context, endpoint string, in apimodels.GetRequest, w io.Writer) error {
+	r := in.ToHTTPRequest()
+
+	_, resp, err := c.doRequest(ctx, http.MethodGet, endpoint, r) //nolint:bodyclose // this is being closed
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return apimodels.NewUnauthorizedError("invalid token")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if apiError := apimodels.GenerateAPIErrorFromHTTPResponse(resp); apiError != nil {
+			return apiError
+		}
+	}
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return bacerrors.Wrap(err, "failed to read response body").
+			WithComponent(errorComponent).
+			WithCode(bacerrors.IOError)
+	}
+	return nil
+}
+
 // write is used to do a write request against an endpoint
 // You probably want the delete, post, or put methods.
 func (c *httpClient) write(ctx context.Context, verb, endpoint string, in apimodels.PutRequest,
@@ -527,6 +557,12 @@ func (t *AuthenticatingClient) Dial(
 	return output, err
 }
 
+func (t *AuthenticatingClient) Download(ctx context.Context, path string, in apimodels.GetRequest, w io.Writer) error {
+	return doRequest(ctx, t, in, func(req apimodels.GetRequest) error {
+		return t.Client.Download(ctx, path, req, w)
+	})
+}
+
 func doRequest[R apimodels.Request](ctx context.Context, t *AuthenticatingClient, request R, runRequest func(R) error) (err error) {
 	if t.Credential != nil {
 		request.SetCredential(t.Credential)
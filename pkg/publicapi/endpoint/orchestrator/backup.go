@@ -0,0 +1,53 @@
+package orchestrator
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+)
+
+// godoc for Orchestrator Backup
+//
+//	@ID				orchestrator/backup
+//	@Summary		Returns a backup archive of the state of the orchestrator.
+//	@Description	Streams a zip archive holding a consistent snapshot of the job store, including the event log, and the state of the nodes.
+//	@Description	Only available to admins, whose access token has write access to all namespaces.
+//	@Tags			Orchestrator
+//	@Produce		application/zip
+//	@Success		200	{file}		file
+//	@Failure		403	{object}	string
+//	@Failure		500	{object}	string
+//	@Router			/api/v1/orchestrator/backup [get]
+func (e *Endpoint) backup(c echo.Context) error {
+	ctx := c.Request().Context()
+	if e.snapshotter == nil {
+		return bacerrors.New("backups are not supported by this orchestrator").
+			WithCode(bacerrors.NotImplemented)
+	}
+
+	// the archive is streamed as it is written, and can take longer than the write timeout of the server
+	if err := http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{}); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to clear write deadline of backup response")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "bacalhau-backup.zip"))
+	c.Response().WriteHeader(http.StatusOK)
+
+	manifest, err := e.snapshotter.Write(ctx, c.Response())
+	if err != nil {
+		// the response has already started, so the client detects the failure
+		// from the truncated archive
+		log.Ctx(ctx).Error().Err(err).Msg("failed to write backup archive")
+		return nil
+	}
+	log.Ctx(ctx).Info().Msgf("Wrote backup archive of %s job store and %d nodes",
+		manifest.JobStoreType, manifest.Nodes)
+	return nil
+}
@@ -5,15 +5,23 @@ import (
 
 	"github.com/bacalhau-project/bacalhau/pkg/jobstore"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/backup"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/retention"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/middleware"
 )
 
 type EndpointParams struct {
-	Router       *echo.Echo
-	Orchestrator *orchestrator.BaseEndpoint
-	JobStore     jobstore.Store
-	NodeManager  nodes.Manager
+	Router        *echo.Echo
+	Orchestrator  *orchestrator.BaseEndpoint
+	JobStore      jobstore.Store
+	NodeManager   nodes.Manager
+	TemplateStore templates.Store
+	JobRetention  *retention.Collector
+	Snapshotter   *backup.Snapshotter
+	UsageStore    accounting.Store
 }
 
 type Endpoint struct {
@@ -21,6 +29,10 @@ type Endpoint struct {
 	orchestrator *orchestrator.BaseEndpoint
 	store        jobstore.Store
 	nodeManager  nodes.Manager
+	templates    templates.Store
+	retention    *retention.Collector
+	snapshotter  *backup.Snapshotter
+	usage        accounting.Store
 }
 
 func NewEndpoint(params EndpointParams) *Endpoint {
@@ -29,6 +41,10 @@ func NewEndpoint(params EndpointParams) *Endpoint {
 		orchestrator: params.Orchestrator,
 		store:        params.JobStore,
 		nodeManager:  params.NodeManager,
+		templates:    params.TemplateStore,
+		retention:    params.JobRetention,
+		snapshotter:  params.Snapshotter,
+		usage:        params.UsageStore,
 	}
 
 	// JSON group
@@ -39,10 +55,22 @@ func NewEndpoint(params EndpointParams) *Endpoint {
 	g.GET("/jobs", e.listJobs)
 	g.GET("/jobs/:id", e.getJob)
 	g.DELETE("/jobs/:id", e.stopJob)
+	g.POST("/jobs/:id/rerun", e.rerunJob)
 	g.GET("/jobs/:id/history", e.listHistory)
 	g.GET("/jobs/:id/executions", e.jobExecutions)
 	g.GET("/jobs/:id/results", e.jobResults)
 	g.GET("/jobs/:id/logs", e.logs)
+	g.PUT("/templates", e.putTemplate)
+	g.POST("/templates", e.putTemplate)
+	g.GET("/templates", e.listTemplates)
+	g.GET("/templates/:name", e.getTemplate)
+	g.DELETE("/templates/:name", e.deleteTemplate)
+	g.GET("/templates/:name/versions", e.listTemplateVersions)
+	g.POST("/templates/:name/run", e.runTemplate)
+	g.GET("/retention", e.getRetentionStats)
+	g.POST("/retention/prune", e.pruneJobs)
+	g.GET("/backup", e.backup)
+	g.GET("/usage", e.getUsageReport)
 	g.GET("/nodes", e.listNodes)
 	g.GET("/nodes/:id", e.getNode)
 	g.PUT("/nodes/:id", e.updateNode)
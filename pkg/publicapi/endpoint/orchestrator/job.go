@@ -3,7 +3,9 @@ package orchestrator
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
@@ -14,6 +16,7 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/lib/concurrency"
 	"github.com/bacalhau-project/bacalhau/pkg/models"
 	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/logstore"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi"
 	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
 )
@@ -144,6 +147,11 @@ func (e *Endpoint) getJob(c echo.Context) error { //nolint: gocyclo
 //	@Param			next_token	query		string	false	"Token to get the next page of jobs"
 //	@Param			reverse		query		bool	false	"Reverse the order of the jobs"
 //	@Param			order_by	query		string	false	"Order the jobs by the given field"
+//	@Param			state		query		[]string	false	"Only return jobs in any of the given states"
+//	@Param			type		query		[]string	false	"Only return jobs of any of the given types"
+//	@Param			created_after	query	int		false	"Only return jobs created at or after the given unix time"
+//	@Param			created_before	query	int		false	"Only return jobs created before the given unix time"
+//	@Param			name_prefix	query		string	false	"Only return jobs with a name starting with the prefix"
 //	@Success		200			{object}	apimodels.ListJobsResponse
 //	@Failure		400			{object}	string
 //	@Failure		500			{object}	string
@@ -159,6 +167,7 @@ func (e *Endpoint) listJobs(c echo.Context) error {
 	}
 
 	var offset uint64
+	var cursor string
 	var err error
 
 	// If the request contains a paging token then it is decoded and used to replace
@@ -175,6 +184,7 @@ func (e *Endpoint) listJobs(c echo.Context) error {
 		args.Reverse = token.SortReverse
 		args.Limit = token.Limit
 		offset = token.Offset
+		cursor = token.Cursor
 	}
 
 	selector, err := parseLabels(c)
@@ -182,13 +192,28 @@ func (e *Endpoint) listJobs(c echo.Context) error {
 		return err
 	}
 
+	states, err := parseJobStates(args.States)
+	if err != nil {
+		return err
+	}
+
 	query := jobstore.JobQuery{
 		Namespace:   args.Namespace,
 		Limit:       args.Limit,
 		Offset:      offset,
+		Cursor:      cursor,
 		SortBy:      args.OrderBy,
 		SortReverse: args.Reverse,
 		Selector:    selector,
+		States:      states,
+		Types:       args.Types,
+		NamePrefix:  args.NamePrefix,
+	}
+	if args.CreatedAfter > 0 {
+		query.CreatedAfter = time.Unix(args.CreatedAfter, 0)
+	}
+	if args.CreatedBefore > 0 {
+		query.CreatedBefore = time.Unix(args.CreatedBefore, 0)
 	}
 
 	if args.Namespace == apimodels.AllNamespacesNamespace {
@@ -202,16 +227,17 @@ func (e *Endpoint) listJobs(c echo.Context) error {
 	}
 
 	var nextToken string
-	// If the next offset > 0 then it means there are more records to be returned, so
+	// If there is a next cursor then it means there are more records to be returned, so
 	// we should give the user a token to use that will return the next page of results.
 	// We encode the current settings into the token to maintain a stable sort across
 	// pages.
-	if response.NextOffset != 0 {
+	if response.NextCursor != "" {
 		nextToken = models.NewPagingToken(&models.PagingTokenParams{
 			SortBy:      args.OrderBy,
 			SortReverse: args.Reverse,
 			Limit:       args.Limit,
 			Offset:      response.NextOffset,
+			Cursor:      response.NextCursor,
 		}).String()
 	}
 
@@ -227,6 +253,47 @@ func (e *Endpoint) listJobs(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+// godoc for Orchestrator RerunJob
+//
+//	@ID				orchestrator/rerunJob
+//	@Summary		Reruns a terminated job.
+//	@Description	Submits a new job with the spec of a terminated job, optionally running only the partitions that did not complete.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string						true	"ID of the job to rerun"
+//	@Param			rerunJobRequest	body		apimodels.RerunJobRequest	true	"Rerun options"
+//	@Success		200				{object}	apimodels.RerunJobResponse
+//	@Failure		400				{object}	string
+//	@Failure		404				{object}	string
+//	@Failure		500				{object}	string
+//	@Router			/api/v1/orchestrator/jobs/{id}/rerun [post]
+func (e *Endpoint) rerunJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	var args apimodels.RerunJobRequest
+	if err := c.Bind(&args); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(&args); err != nil {
+		return err
+	}
+	resp, err := e.orchestrator.RerunJob(ctx, &orchestrator.RerunJobRequest{
+		JobID:                c.Param("id"),
+		FailedOnly:           args.FailedOnly,
+		ClientInstanceID:     c.Request().Header.Get(apimodels.HTTPHeaderBacalhauInstanceID),
+		ClientInstallationID: c.Request().Header.Get(apimodels.HTTPHeaderBacalhauInstallationID),
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.RerunJobResponse{
+		JobID:        resp.JobID,
+		EvaluationID: resp.EvaluationID,
+		Partitions:   resp.Partitions,
+		Warnings:     resp.Warnings,
+	})
+}
+
 // godoc for Orchestrator StopJob
 //
 //	@ID				orchestrator/stopJob
@@ -422,6 +489,10 @@ func (e *Endpoint) jobResults(c echo.Context) error {
 //	@Param			execution_id	query		string				false	"Fetch logs for a specific execution"
 //	@Param			tail			query		bool				false	"Fetch historical logs"
 //	@Param			follow			query		bool				false	"Follow the logs"
+//	@Param			stream			query		string				false	"Only return logs from stdout or stderr"
+//	@Param			since			query		int					false	"Only return retained logs since this unix time"
+//	@Param			until			query		int					false	"Only return retained logs until this unix time"
+//	@Param			pattern			query		string				false	"Only return lines matching this regular expression"
 //	@Success		101				{object}	models.ExecutionLog	"Switching Protocols to WebSocket"
 //	@Failure		400				{object}	string				"Bad Request"
 //	@Failure		500				{object}	string				"Internal Server Error"
@@ -457,11 +528,17 @@ func (e *Endpoint) logsWS(c echo.Context, ws *websocket.Conn) error {
 		return err
 	}
 
+	filter, err := logsFilter(args)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	logstreamCh, err := e.orchestrator.ReadLogs(c.Request().Context(), orchestrator.ReadLogsRequest{
 		JobID:       jobID,
 		ExecutionID: args.ExecutionID,
 		Tail:        args.Tail,
 		Follow:      args.Follow,
+		Filter:      filter,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to open log stream for job %s: %w", jobID, err)
@@ -474,3 +551,28 @@ func (e *Endpoint) logsWS(c echo.Context, ws *websocket.Conn) error {
 	}
 	return nil
 }
+
+// logsFilter converts the filters of a logs request to a log store query
+func logsFilter(args apimodels.GetLogsRequest) (logstore.Query, error) {
+	filter := logstore.Query{}
+	switch args.Stream {
+	case "stdout":
+		filter.Type = models.ExecutionLogTypeSTDOUT
+	case "stderr":
+		filter.Type = models.ExecutionLogTypeSTDERR
+	}
+	if args.Since > 0 {
+		filter.Since = time.Unix(args.Since, 0)
+	}
+	if args.Until > 0 {
+		filter.Until = time.Unix(args.Until, 0)
+	}
+	if args.Pattern != "" {
+		pattern, err := regexp.Compile(args.Pattern)
+		if err != nil {
+			return filter, fmt.Errorf("invalid log pattern %q: %w", args.Pattern, err)
+		}
+		filter.Pattern = pattern
+	}
+	return filter, nil
+}
@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/retention"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+// godoc for Orchestrator GetRetentionStats
+//
+//	@ID				orchestrator/getRetentionStats
+//	@Summary		Returns the activity of the job retention subsystem.
+//	@Description	Returns the retention policy applied to terminal jobs and the number of jobs purged and archived.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	apimodels.GetRetentionStatsResponse
+//	@Failure		500	{object}	string
+//	@Router			/api/v1/orchestrator/retention [get]
+func (e *Endpoint) getRetentionStats(c echo.Context) error {
+	collector, err := e.jobRetention()
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.GetRetentionStatsResponse{
+		Stats: collector.GetStats(),
+	})
+}
+
+// godoc for Orchestrator PruneJobs
+//
+//	@ID				orchestrator/pruneJobs
+//	@Summary		Purges terminal jobs from the job store.
+//	@Description	Purges terminal jobs, along with their executions, history and evaluations, that were last modified before the requested age.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			pruneJobsRequest	body		apimodels.PruneJobsRequest	true	"Prune options"
+//	@Success		200					{object}	apimodels.PruneJobsResponse
+//	@Failure		400					{object}	string
+//	@Failure		500					{object}	string
+//	@Router			/api/v1/orchestrator/retention/prune [post]
+func (e *Endpoint) pruneJobs(c echo.Context) error {
+	ctx := c.Request().Context()
+	collector, err := e.jobRetention()
+	if err != nil {
+		return err
+	}
+	var args apimodels.PruneJobsRequest
+	if err = c.Bind(&args); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err = c.Validate(&args); err != nil {
+		return err
+	}
+	result, err := collector.Prune(ctx, retention.PruneRequest{
+		OlderThan: args.OlderThan,
+		Namespace: args.Namespace,
+		DryRun:    args.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.PruneJobsResponse{
+		JobIDs:      result.JobIDs,
+		ArchivePath: result.ArchivePath,
+		DryRun:      args.DryRun,
+	})
+}
+
+// jobRetention returns the job retention collector, or an error if it is not available
+func (e *Endpoint) jobRetention() (*retention.Collector, error) {
+	if e.retention == nil {
+		return nil, bacerrors.New("job retention is not enabled on this orchestrator").
+			WithCode(bacerrors.NotImplemented)
+	}
+	return e.retention, nil
+}
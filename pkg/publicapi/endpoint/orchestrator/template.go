@@ -0,0 +1,235 @@
+package orchestrator
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/templates"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+// godoc for Orchestrator PutTemplate
+//
+//	@ID				orchestrator/putTemplate
+//	@Summary		Registers a new version of a job template.
+//	@Description	Registers a new version of a job template. The version is assigned by the orchestrator.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			putTemplateRequest	body		apimodels.PutTemplateRequest	true	"Template to register"
+//	@Success		200					{object}	apimodels.PutTemplateResponse
+//	@Failure		400					{object}	string
+//	@Failure		500					{object}	string
+//	@Router			/api/v1/orchestrator/templates [put]
+func (e *Endpoint) putTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	templateStore, err := e.templateStore()
+	if err != nil {
+		return err
+	}
+	var args apimodels.PutTemplateRequest
+	if err = c.Bind(&args); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	args.Normalize()
+	if err = c.Validate(&args); err != nil {
+		return err
+	}
+	stored, err := templateStore.Put(ctx, *args.Template)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.PutTemplateResponse{
+		Template: &stored,
+	})
+}
+
+// godoc for Orchestrator ListTemplates
+//
+//	@ID				orchestrator/listTemplates
+//	@Summary		Returns the latest version of every job template.
+//	@Description	Returns the latest version of every job template.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	apimodels.ListTemplatesResponse
+//	@Failure		400	{object}	string
+//	@Failure		500	{object}	string
+//	@Router			/api/v1/orchestrator/templates [get]
+func (e *Endpoint) listTemplates(c echo.Context) error {
+	ctx := c.Request().Context()
+	templateStore, err := e.templateStore()
+	if err != nil {
+		return err
+	}
+	items, err := templateStore.List(ctx)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &apimodels.ListTemplatesResponse{
+		Items: toTemplatePointers(items),
+	})
+}
+
+// godoc for Orchestrator GetTemplate
+//
+//	@ID				orchestrator/getTemplate
+//	@Summary		Returns a job template.
+//	@Description	Returns a job template. The latest version is returned unless a version is requested.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string	true	"Name of the template"
+//	@Param			version	query		int		false	"Version of the template"
+//	@Success		200		{object}	apimodels.GetTemplateResponse
+//	@Failure		400		{object}	string
+//	@Failure		404		{object}	string
+//	@Failure		500		{object}	string
+//	@Router			/api/v1/orchestrator/templates/{name} [get]
+func (e *Endpoint) getTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	templateStore, err := e.templateStore()
+	if err != nil {
+		return err
+	}
+	version, err := parseTemplateVersion(c)
+	if err != nil {
+		return err
+	}
+	template, err := templateStore.Get(ctx, c.Param("name"), version)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.GetTemplateResponse{
+		Template: &template,
+	})
+}
+
+// godoc for Orchestrator ListTemplateVersions
+//
+//	@ID				orchestrator/listTemplateVersions
+//	@Summary		Returns all versions of a job template.
+//	@Description	Returns all versions of a job template, oldest first.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string	true	"Name of the template"
+//	@Success		200		{object}	apimodels.ListTemplatesResponse
+//	@Failure		400		{object}	string
+//	@Failure		404		{object}	string
+//	@Failure		500		{object}	string
+//	@Router			/api/v1/orchestrator/templates/{name}/versions [get]
+func (e *Endpoint) listTemplateVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+	templateStore, err := e.templateStore()
+	if err != nil {
+		return err
+	}
+	items, err := templateStore.ListVersions(ctx, c.Param("name"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &apimodels.ListTemplatesResponse{
+		Items: toTemplatePointers(items),
+	})
+}
+
+// godoc for Orchestrator DeleteTemplate
+//
+//	@ID				orchestrator/deleteTemplate
+//	@Summary		Deletes all versions of a job template.
+//	@Description	Deletes all versions of a job template.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string	true	"Name of the template"
+//	@Success		200		{object}	apimodels.DeleteTemplateResponse
+//	@Failure		400		{object}	string
+//	@Failure		404		{object}	string
+//	@Failure		500		{object}	string
+//	@Router			/api/v1/orchestrator/templates/{name} [delete]
+func (e *Endpoint) deleteTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	templateStore, err := e.templateStore()
+	if err != nil {
+		return err
+	}
+	if err = templateStore.Delete(ctx, c.Param("name")); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.DeleteTemplateResponse{})
+}
+
+// godoc for Orchestrator RunTemplate
+//
+//	@ID				orchestrator/runTemplate
+//	@Summary		Renders a job template and submits the resulting job.
+//	@Description	Renders a job template with the provided variables and submits the resulting job.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			name				path		string							true	"Name of the template"
+//	@Param			runTemplateRequest	body		apimodels.RunTemplateRequest	true	"Template variables"
+//	@Success		200					{object}	apimodels.RunTemplateResponse
+//	@Failure		400					{object}	string
+//	@Failure		404					{object}	string
+//	@Failure		500					{object}	string
+//	@Router			/api/v1/orchestrator/templates/{name}/run [post]
+func (e *Endpoint) runTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	var args apimodels.RunTemplateRequest
+	if err := c.Bind(&args); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(&args); err != nil {
+		return err
+	}
+	resp, err := e.orchestrator.RunTemplate(ctx, &orchestrator.RunTemplateRequest{
+		Name:                 c.Param("name"),
+		Version:              args.Version,
+		Variables:            args.Variables,
+		ClientInstanceID:     c.Request().Header.Get(apimodels.HTTPHeaderBacalhauInstanceID),
+		ClientInstallationID: c.Request().Header.Get(apimodels.HTTPHeaderBacalhauInstallationID),
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.RunTemplateResponse{
+		JobID:           resp.JobID,
+		EvaluationID:    resp.EvaluationID,
+		TemplateVersion: resp.TemplateVersion,
+		Warnings:        resp.Warnings,
+	})
+}
+
+func (e *Endpoint) templateStore() (templates.Store, error) {
+	if e.templates == nil {
+		return nil, bacerrors.New("job templates are not enabled on this orchestrator").
+			WithCode(bacerrors.NotImplemented)
+	}
+	return e.templates, nil
+}
+
+func parseTemplateVersion(c echo.Context) (uint64, error) {
+	version := c.QueryParam("version")
+	if version == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseUint(version, 10, 64)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid template version: "+version)
+	}
+	return parsed, nil
+}
+
+func toTemplatePointers(items []models.JobTemplate) []*models.JobTemplate {
+	return lo.Map(items, func(item models.JobTemplate, _ int) *models.JobTemplate {
+		return &item
+	})
+}
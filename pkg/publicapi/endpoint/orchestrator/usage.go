@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/accounting"
+	"github.com/bacalhau-project/bacalhau/pkg/publicapi/apimodels"
+)
+
+// godoc for Orchestrator GetUsageReport
+//
+//	@ID				orchestrator/getUsageReport
+//	@Summary		Returns the resource usage of finished executions.
+//	@Description	Returns the resource-seconds and number of finished executions, grouped by namespace, job type, day or job label.
+//	@Description	Requires read access to the reported namespace, or to all namespaces if the report is not limited to one.
+//	@Tags			Orchestrator
+//	@Accept			json
+//	@Produce		json
+//	@Param			since		query		int		false	"Only account executions that ended after this unix time"
+//	@Param			until		query		int		false	"Only account executions that ended before this unix time"
+//	@Param			namespace	query		string	false	"Only account executions of this namespace"
+//	@Param			group_by	query		string	false	"Comma separated keys to group by: namespace, type, day or label:<key>"
+//	@Success		200			{object}	apimodels.GetUsageReportResponse
+//	@Failure		400			{object}	string
+//	@Failure		403			{object}	string
+//	@Failure		500			{object}	string
+//	@Router			/api/v1/orchestrator/usage [get]
+func (e *Endpoint) getUsageReport(c echo.Context) error {
+	ctx := c.Request().Context()
+	if e.usage == nil {
+		return bacerrors.New("usage accounting is not enabled on this orchestrator").
+			WithCode(bacerrors.NotImplemented)
+	}
+	var args apimodels.GetUsageReportRequest
+	if err := c.Bind(&args); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(&args); err != nil {
+		return err
+	}
+	groupBy, err := models.ParseUsageGroupBy(args.GroupBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	query := accounting.Query{Namespace: args.Namespace}
+	if query.Namespace == apimodels.AllNamespacesNamespace {
+		query.Namespace = ""
+	}
+	if args.Since != 0 {
+		query.Since = time.Unix(args.Since, 0)
+	}
+	if args.Until != 0 {
+		query.Until = time.Unix(args.Until, 0)
+	}
+	records, err := e.usage.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, apimodels.GetUsageReportResponse{
+		GroupBy: groupBy,
+		Rows:    accounting.Aggregate(records, groupBy),
+	})
+}
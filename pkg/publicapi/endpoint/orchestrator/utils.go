@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -25,6 +26,20 @@ func parseLabels(c echo.Context) (labels.Selector, error) {
 	return selector, nil
 }
 
+// parseJobStates parses the job states to filter jobs by
+func parseJobStates(names []string) ([]models.JobStateType, error) {
+	var states []models.JobStateType
+	for _, name := range names {
+		var state models.JobStateType
+		if err := state.UnmarshalText([]byte(name)); err != nil || state.IsUndefined() {
+			return nil, echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("invalid job state %q. Valid states are %v", name, models.JobStateTypes()))
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
 // backwardCompatibleHistoryIfNecessary sets the state change fields to non-nil for backward compatibility
 // with v1.4.x clients. Otherwise, nil exceptions will be thrown when the client tries to describe job or list history.
 //
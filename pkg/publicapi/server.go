@@ -32,6 +32,10 @@ import (
 
 const TimeoutMessage = "Server Timeout!"
 
+// orchestratorBackupPath is the path of the endpoint streaming backup archives,
+// which is not bound by the request handler timeout
+const orchestratorBackupPath = "/api/v1/orchestrator/backup"
+
 var minClientVersion = semver.MustParse("v1.4.0")
 
 type ServerParams struct {
@@ -131,7 +135,11 @@ func NewAPIServer(params ServerParams) (*Server, error) {
 			echomiddelware.TimeoutConfig{
 				Timeout:      params.Config.RequestHandlerTimeout,
 				ErrorMessage: TimeoutMessage,
-				Skipper:      middleware.WebsocketSkipper,
+				Skipper: middleware.ChainedSkipper(
+					middleware.WebsocketSkipper,
+					// backup archives are streamed for as long as it takes to write them
+					middleware.PathMatchSkipper([]string{orchestratorBackupPath}),
+				),
 			}),
 
 		middleware.Otel(),
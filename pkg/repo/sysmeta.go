@@ -25,6 +25,9 @@ const (
 	Version4
 )
 
+// LatestVersion is the version of new repos, and of existing repos once opened and migrated.
+const LatestVersion = Version4
+
 // IsValidVersion returns true if the version is valid.
 func IsValidVersion(version int) bool {
 	return version >= Version1 && version <= Version4
@@ -107,7 +107,7 @@ func (s *ComputeSuite) setupConfig() {
 	s.executor = noop_executor.NewNoopExecutor()
 	s.publisher = noop_publisher.NewNoopPublisher()
 
-	dockerExecutor, err := dockerexecutor.NewExecutor(nodeID, bacalhauConfig.Engines.Types.Docker)
+	dockerExecutor, err := dockerexecutor.NewExecutor(nodeID, bacalhauConfig.Engines.Types.Docker, bacalhauConfig.Compute.Overcommit)
 
 	s.config = node.NodeConfig{
 		NodeID:         nodeID,
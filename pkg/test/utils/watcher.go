@@ -25,6 +25,7 @@ func CreateComputeEventStore(t *testing.T) watcher.EventStore {
 	err := errors.Join(
 		eventObjectSerializer.RegisterType(compute.EventObjectExecutionUpsert, reflect.TypeOf(models.ExecutionUpsert{})),
 		eventObjectSerializer.RegisterType(compute.EventObjectExecutionEvent, reflect.TypeOf(models.Event{})),
+		eventObjectSerializer.RegisterType(compute.EventObjectExecutionLogs, reflect.TypeOf(models.ExecutionLogBatch{})),
 	)
 	require.NoError(t, err)
 
@@ -34,6 +34,10 @@ type Config struct {
 	// ClientFactory creates NATS client connections with the appropriate settings
 	ClientFactory natsutil.ClientFactory
 
+	// SubjectPrefix is the prefix of the heartbeat and proxy subjects.
+	// The default prefix is used if empty.
+	SubjectPrefix string
+
 	// NodeInfoProvider supplies current node information for registration and updates
 	NodeInfoProvider models.NodeInfoProvider
 
@@ -106,6 +110,7 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 	_, err = proxy.NewComputeHandler(ctx, proxy.ComputeHandlerParams{
 		Name:            cm.config.NodeID,
 		Conn:            cm.natsConn,
+		SubjectPrefix:   cm.config.SubjectPrefix,
 		ComputeEndpoint: cm.config.ComputeEndpoint,
 	})
 	if err != nil {
@@ -114,13 +119,14 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 
 	// create nats callback proxy
 	callbackProxy := proxy.NewCallbackProxy(proxy.CallbackProxyParams{
-		Conn: cm.natsConn,
+		Conn:          cm.natsConn,
+		SubjectPrefix: cm.config.SubjectPrefix,
 	})
 
 	// heartbeat client
 	cm.heartbeatPublisher, err = ncl.NewPublisher(cm.natsConn, ncl.PublisherConfig{
 		Name:            cm.config.NodeID,
-		Destination:     bprotocol.ComputeHeartbeatTopic(cm.config.NodeID),
+		Destination:     bprotocol.ComputeHeartbeatTopic(cm.config.SubjectPrefix, cm.config.NodeID),
 		MessageRegistry: bprotocol.MustCreateMessageRegistry(),
 	})
 	if err != nil {
@@ -138,7 +144,8 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 	managementClient := NewManagementClient(&ManagementClientParams{
 		NodeInfoProvider: cm.config.NodeInfoProvider,
 		ManagementProxy: proxy.NewManagementProxy(proxy.ManagementProxyParams{
-			Conn: cm.natsConn,
+			Conn:          cm.natsConn,
+			SubjectPrefix: cm.config.SubjectPrefix,
 		}),
 		HeartbeatClient: cm.heartbeatClient,
 		HeartbeatConfig: cm.config.HeartbeatConfig,
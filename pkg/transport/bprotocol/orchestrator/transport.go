@@ -34,6 +34,10 @@ type Config struct {
 	//NatsConn is the NATS connection to use for communication
 	NatsConn *nats.Conn
 
+	// SubjectPrefix is the prefix of the heartbeat and proxy subjects.
+	// The default prefix is used if empty.
+	SubjectPrefix string
+
 	// NodeManager handles node discovery, tracking, and health monitoring
 	NodeManager nodes.Manager
 
@@ -106,13 +110,14 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 	if err != nil {
 		return pkgerrors.Wrap(err, "failed to create heartbeat ncl subscriber")
 	}
-	if err = cm.heartbeatSubscriber.Subscribe(ctx, bprotocol.OrchestratorHeartbeatSubscription()); err != nil {
+	if err = cm.heartbeatSubscriber.Subscribe(ctx, bprotocol.OrchestratorHeartbeatSubscription(cm.config.SubjectPrefix)); err != nil {
 		return err
 	}
 
 	// management handler
 	_, err = proxy.NewManagementHandler(proxy.ManagementHandlerParams{
 		Conn:               cm.config.NatsConn,
+		SubjectPrefix:      cm.config.SubjectPrefix,
 		ManagementEndpoint: heartbeatServer,
 	})
 	if err != nil {
@@ -121,7 +126,8 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 
 	// compute proxy
 	computeProxy, err := proxy.NewComputeProxy(proxy.ComputeProxyParams{
-		Conn: cm.config.NatsConn,
+		Conn:          cm.config.NatsConn,
+		SubjectPrefix: cm.config.SubjectPrefix,
 	})
 	if err != nil {
 		return err
@@ -129,9 +135,10 @@ func (cm *ConnectionManager) Start(ctx context.Context) error {
 
 	// setup callback handler
 	_, err = proxy.NewCallbackHandler(proxy.CallbackHandlerParams{
-		Name:     cm.config.NodeID,
-		Conn:     cm.config.NatsConn,
-		Callback: cm.config.Callback,
+		Name:          cm.config.NodeID,
+		Conn:          cm.config.NatsConn,
+		SubjectPrefix: cm.config.SubjectPrefix,
+		Callback:      cm.config.Callback,
 	})
 	if err != nil {
 		return err
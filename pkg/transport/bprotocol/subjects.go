@@ -2,19 +2,23 @@ package bprotocol
 
 import (
 	"fmt"
+
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
-const HeartbeatTopicFormat = "bacalhau.global.compute.%s.out.heartbeat"
+// heartbeatTopicFormat is the format of heartbeat subjects, following the
+// subject prefix and the ID of the compute node
+const heartbeatTopicFormat = "%s.compute.%s.out.heartbeat"
 
 // ComputeHeartbeatTopic returns the subject to publish heartbeat messages to.
 // it publishes to the outgoing heartbeat subject of a specific compute node, which
 // the orchestrator subscribes to.
-func ComputeHeartbeatTopic(nodeID string) string {
-	return fmt.Sprintf(HeartbeatTopicFormat, nodeID)
+func ComputeHeartbeatTopic(prefix, nodeID string) string {
+	return fmt.Sprintf(heartbeatTopicFormat, nclprotocol.SubjectPrefix(prefix), nodeID)
 }
 
 // OrchestratorHeartbeatSubscription returns the subject to subscribe for compute heartbeats.
 // it subscribes for heartbeat messages from all compute nodes
-func OrchestratorHeartbeatSubscription() string {
-	return fmt.Sprintf(HeartbeatTopicFormat, "*")
+func OrchestratorHeartbeatSubscription(prefix string) string {
+	return fmt.Sprintf(heartbeatTopicFormat, nclprotocol.SubjectPrefix(prefix), "*")
 }
@@ -0,0 +1,30 @@
+//go:build unit || !integration
+
+package bprotocol_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/transport/bprotocol"
+)
+
+type SubjectsTestSuite struct {
+	suite.Suite
+}
+
+func TestSubjectsTestSuite(t *testing.T) {
+	suite.Run(t, new(SubjectsTestSuite))
+}
+
+func (s *SubjectsTestSuite) TestDefaultPrefix() {
+	s.Equal("bacalhau.global.compute.node1.out.heartbeat", bprotocol.ComputeHeartbeatTopic("", "node1"))
+	s.Equal("bacalhau.global.compute.*.out.heartbeat", bprotocol.OrchestratorHeartbeatSubscription(""))
+}
+
+func (s *SubjectsTestSuite) TestCustomPrefix() {
+	prefix := "team-a.bacalhau"
+	s.Equal("team-a.bacalhau.compute.node1.out.heartbeat", bprotocol.ComputeHeartbeatTopic(prefix, "node1"))
+	s.Equal("team-a.bacalhau.compute.*.out.heartbeat", bprotocol.OrchestratorHeartbeatSubscription(prefix))
+}
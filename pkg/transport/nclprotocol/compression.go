@@ -0,0 +1,30 @@
+package nclprotocol
+
+import (
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+)
+
+// WithAcceptCompression advertises the compression algorithms this node can
+// decompress in the metadata of a handshake message, so that the peer can
+// compress the data plane messages it sends. Older peers ignore the metadata.
+func WithAcceptCompression(msg *envelope.Message) *envelope.Message {
+	return msg.WithMetadataValue(envelope.KeyAcceptCompression,
+		envelope.FormatAcceptCompression(envelope.SupportedCompressions()))
+}
+
+// NegotiateSerializer returns the serializer to use for data plane messages sent to a peer.
+// If the peer advertised support for the preferred compression algorithm in its handshake
+// metadata, a serializer compressing messages of at least threshold bytes is returned.
+// Otherwise, messages are sent uncompressed with the default serializer.
+func NegotiateSerializer(
+	serializer envelope.MessageSerializer,
+	compression envelope.Compression,
+	threshold int,
+	peer *envelope.Metadata,
+) envelope.MessageSerializer {
+	negotiated := envelope.NegotiateCompression(compression, peer)
+	if negotiated == envelope.CompressionNone {
+		return serializer
+	}
+	return envelope.NewSerializer().WithCompression(negotiated, threshold)
+}
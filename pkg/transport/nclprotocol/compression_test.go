@@ -0,0 +1,122 @@
+//go:build unit || !integration
+
+package nclprotocol_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/test/mock"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
+)
+
+type CompressionTestSuite struct {
+	suite.Suite
+	registry *envelope.Registry
+}
+
+func TestCompressionTestSuite(t *testing.T) {
+	suite.Run(t, new(CompressionTestSuite))
+}
+
+func (s *CompressionTestSuite) SetupTest() {
+	s.registry = nclprotocol.MustCreateMessageRegistry()
+}
+
+func (s *CompressionTestSuite) TestNegotiateSerializer() {
+	serializer := envelope.NewSerializer()
+	handshake := nclprotocol.WithAcceptCompression(envelope.NewMessage(messages.HandshakeRequest{}))
+
+	negotiated := nclprotocol.NegotiateSerializer(serializer, envelope.CompressionZstd, 1, handshake.Metadata)
+	s.Require().IsType(&envelope.Serializer{}, negotiated)
+	s.Equal(envelope.CompressionZstd, negotiated.(*envelope.Serializer).Compression())
+
+	// compressed messages are decoded by any serializer
+	msg := askForBidMessage(0)
+	encoded, err := s.registry.Serialize(msg)
+	s.Require().NoError(err)
+	data, err := negotiated.Serialize(encoded)
+	s.Require().NoError(err)
+
+	decoded, err := serializer.Deserialize(data)
+	s.Require().NoError(err)
+	result, err := s.registry.Deserialize(decoded)
+	s.Require().NoError(err)
+	s.Equal(msg.Metadata, result.Metadata)
+	expected := msg.Payload.(messages.AskForBidRequest).Execution
+	actual := result.Payload.(*messages.AskForBidRequest).Execution
+	s.Equal(expected.ID, actual.ID)
+	s.Equal(expected.Job.ID, actual.Job.ID)
+}
+
+func (s *CompressionTestSuite) TestNegotiateSerializerWithOlderPeer() {
+	serializer := envelope.NewSerializer()
+	handshake := envelope.NewMessage(messages.HandshakeRequest{})
+
+	// older peers don't advertise compression support, and are sent uncompressed messages
+	s.Same(serializer, nclprotocol.NegotiateSerializer(serializer, envelope.CompressionZstd, 1, handshake.Metadata))
+}
+
+func (s *CompressionTestSuite) TestNegotiateSerializerDisabled() {
+	serializer := envelope.NewSerializer()
+	handshake := nclprotocol.WithAcceptCompression(envelope.NewMessage(messages.HandshakeRequest{}))
+
+	s.Same(serializer, nclprotocol.NegotiateSerializer(serializer, envelope.CompressionNone, 1, handshake.Metadata))
+}
+
+// askForBidMessage returns an AskForBidRequest message for a mock job
+// with the given number of additional env variables and labels, to grow the message
+func askForBidMessage(extraFields int) *envelope.Message {
+	job := mock.Job()
+	if job.Labels == nil {
+		job.Labels = make(map[string]string)
+	}
+	if job.Task().Env == nil {
+		job.Task().Env = make(map[string]models.EnvVarValue)
+	}
+	for i := 0; i < extraFields; i++ {
+		job.Labels[fmt.Sprintf("label-%d", i)] = fmt.Sprintf("value-%d", i)
+		job.Task().Env[fmt.Sprintf("ENV_VAR_%d", i)] = models.EnvVarValue(fmt.Sprintf("some-env-value-%d", i))
+	}
+	execution := mock.ExecutionForJob(job)
+	return envelope.NewMessage(messages.AskForBidRequest{
+		BaseRequest: messages.BaseRequest{Events: []*models.Event{models.NewEvent("test")}},
+		Execution:   execution,
+	}).WithMetadataValue(envelope.KeyMessageType, messages.AskForBidMessageType)
+}
+
+// BenchmarkAskForBidRequestCompression reports the serialized size of typical
+// AskForBidRequest messages with each compression algorithm
+func BenchmarkAskForBidRequestCompression(b *testing.B) {
+	registry := nclprotocol.MustCreateMessageRegistry()
+	sizes := map[string]int{"small": 0, "medium": 20, "large": 200}
+
+	for _, name := range []string{"small", "medium", "large"} {
+		encoded, err := registry.Serialize(askForBidMessage(sizes[name]))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, compression := range []envelope.Compression{
+			envelope.CompressionNone,
+			envelope.CompressionGzip,
+			envelope.CompressionZstd,
+		} {
+			serializer := envelope.NewSerializer().WithCompression(compression, 0)
+			b.Run(fmt.Sprintf("%s/%s", name, compression), func(b *testing.B) {
+				var data []byte
+				for i := 0; i < b.N; i++ {
+					data, err = serializer.Serialize(encoded)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data)), "bytes/msg")
+			})
+		}
+	}
+}
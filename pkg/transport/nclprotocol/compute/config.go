@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"time"
 
@@ -23,9 +24,23 @@ type Config struct {
 	ClientFactory    nats.ClientFactory
 	NodeInfoProvider models.NodeInfoProvider
 
+	// SubjectPrefix is the prefix of the NATS subjects, allowing multiple clusters
+	// to share a NATS cluster. The default prefix is used if empty.
+	SubjectPrefix string
+
 	MessageSerializer envelope.MessageSerializer
 	MessageRegistry   *envelope.Registry
 
+	// Compression is the algorithm used to compress data plane messages of at least
+	// CompressionThreshold bytes, if the orchestrator supports it
+	Compression          envelope.Compression
+	CompressionThreshold int
+
+	// SigningKey signs messages sent to the orchestrator, and is not used if nil.
+	// RequireSignedMessages rejects orchestrators that don't sign their messages.
+	SigningKey            ed25519.PrivateKey
+	RequireSignedMessages bool
+
 	// Control plane config
 	ReconnectInterval      time.Duration
 	HeartbeatInterval      time.Duration
@@ -53,6 +68,7 @@ func (c *Config) Validate() error {
 	return errors.Join(
 		validate.NotBlank(c.NodeID, "nodeID cannot be blank"),
 		validate.NotNil(c.ClientFactory, "client factory cannot be nil"),
+		nclprotocol.ValidateSubjectPrefix(c.SubjectPrefix),
 		validate.NotNil(c.MessageSerializer, "message serializer cannot be nil"),
 		validate.NotNil(c.MessageRegistry, "message registry cannot be nil"),
 		validate.NotNil(c.NodeInfoProvider, "node info provider cannot be nil"),
@@ -66,6 +82,7 @@ func (c *Config) Validate() error {
 		validate.IsGreaterThanZero(c.RequestTimeout, "request timeout must be positive"),
 		validate.IsGreaterThanZero(c.ReconnectInterval, "reconnect interval must be positive"),
 		validate.IsGreaterThanZero(c.CheckpointInterval, "checkpoint interval must be positive"),
+		validate.IsGreaterOrEqualToZero(c.CompressionThreshold, "compression threshold cannot be negative"),
 
 		// validations for data plane components
 		validate.NotNil(c.EventStore, "event store cannot be nil"),
@@ -84,15 +101,16 @@ func DefaultConfig() Config {
 	// defaults for heartbeatInterval and nodeInfoUpdateInterval are provided by BacalhauConfig,
 	// and equal to 15 seconds and 1 minute respectively
 	return Config{
-		HeartbeatMissFactor: 5, // allow up to 5 missed heartbeats before marking a node as disconnected
-		RequestTimeout:      10 * time.Second,
-		ReconnectInterval:   10 * time.Second,
-		CheckpointInterval:  30 * time.Second,
-		ReconnectBackoff:    backoff.NewExponential(10*time.Second, 2*time.Minute),
-		MessageSerializer:   envelope.NewSerializer(),
-		MessageRegistry:     nclprotocol.MustCreateMessageRegistry(),
-		DispatcherConfig:    dispatcher.DefaultConfig(),
-		Clock:               clock.New(),
+		HeartbeatMissFactor:  5, // allow up to 5 missed heartbeats before marking a node as disconnected
+		RequestTimeout:       10 * time.Second,
+		ReconnectInterval:    10 * time.Second,
+		CheckpointInterval:   30 * time.Second,
+		ReconnectBackoff:     backoff.NewExponential(10*time.Second, 2*time.Minute),
+		MessageSerializer:    envelope.NewSerializer(),
+		MessageRegistry:      nclprotocol.MustCreateMessageRegistry(),
+		CompressionThreshold: envelope.DefaultCompressionThreshold,
+		DispatcherConfig:     dispatcher.DefaultConfig(),
+		Clock:                clock.New(),
 	}
 }
 
@@ -116,6 +134,9 @@ func (c *Config) SetDefaults() {
 	if c.MessageRegistry == nil {
 		c.MessageRegistry = defaults.MessageRegistry
 	}
+	if c.CompressionThreshold == 0 {
+		c.CompressionThreshold = defaults.CompressionThreshold
+	}
 	if c.ReconnectBackoff == nil {
 		c.ReconnectBackoff = defaults.ReconnectBackoff
 	}
@@ -101,6 +101,7 @@ func (dp *DataPlane) Start(ctx context.Context) error {
 	_, err = proxy.NewLogStreamHandler(ctx, proxy.LogStreamHandlerParams{
 		Name:            dp.config.NodeID,
 		Conn:            dp.Client,
+		SubjectPrefix:   dp.config.SubjectPrefix,
 		LogstreamServer: dp.config.LogStreamServer,
 	})
 	if err != nil {
@@ -111,7 +112,8 @@ func (dp *DataPlane) Start(ctx context.Context) error {
 		Name:              dp.config.NodeID,
 		MessageRegistry:   dp.config.MessageRegistry,
 		MessageSerializer: dp.config.MessageSerializer,
-		Destination:       nclprotocol.NatsSubjectComputeOutMsgs(dp.config.NodeID),
+		MessageSigner:     nclprotocol.NewMessageSigner(dp.config.SigningKey, ""),
+		Destination:       nclprotocol.NatsSubjectComputeOutMsgs(dp.config.SubjectPrefix, dp.config.NodeID),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create publisher: %w", err)
@@ -123,7 +125,7 @@ func (dp *DataPlane) Start(ctx context.Context) error {
 		watcher.WithRetryStrategy(watcher.RetryStrategyBlock),
 		watcher.WithInitialEventIterator(dp.resolveStartingIterator(dp.lastReceivedSeqNum)),
 		watcher.WithFilter(watcher.EventFilter{
-			ObjectTypes: []string{compute.EventObjectExecutionUpsert},
+			ObjectTypes: []string{compute.EventObjectExecutionUpsert, compute.EventObjectExecutionLogs},
 		}),
 	)
 	if err != nil {
@@ -79,7 +79,7 @@ func (s *DataPlaneTestSuite) setupSubscriber() {
 	})
 	s.Require().NoError(err)
 
-	err = sub.Subscribe(s.ctx, nclprotocol.NatsSubjectComputeOutMsgs(s.config.NodeID))
+	err = sub.Subscribe(s.ctx, nclprotocol.NatsSubjectComputeOutMsgs(s.config.SubjectPrefix, s.config.NodeID))
 	s.Require().NoError(err)
 	s.sub = sub
 }
@@ -297,3 +297,60 @@ func (s *DataPlaneTestSuite) TestStartingPosition() {
 		}
 	}
 }
+
+func (s *DataPlaneTestSuite) TestReplayAfterReconnect() {
+	storeEvent := func(executionID string) {
+		err := s.config.EventStore.StoreEvent(s.ctx, watcher.StoreEventRequest{
+			Operation:  watcher.OperationCreate,
+			ObjectType: compute.EventObjectExecutionUpsert,
+			Object: models.ExecutionUpsert{
+				Current: &models.Execution{ID: executionID, NodeID: "test-node"},
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	// receiveUntil returns the sequence numbers of the messages received until the given sequence number
+	receiveUntil := func(lastSeqNum uint64) []uint64 {
+		var seqNums []uint64
+		timeout := time.After(time.Second)
+		for len(seqNums) == 0 || seqNums[len(seqNums)-1] < lastSeqNum {
+			select {
+			case msg := <-s.msgChan:
+				seqNums = append(seqNums, msg.Metadata.GetUint64(nclprotocol.KeySeqNum))
+			case <-timeout:
+				s.Require().Failf("Timeout waiting for messages", "received %v until %d", seqNums, lastSeqNum)
+			}
+		}
+		return seqNums
+	}
+
+	s.Require().NoError(s.dataPlane.Start(s.ctx))
+	storeEvent("exec-1")
+	s.Require().Equal([]uint64{1}, receiveUntil(1))
+
+	// executions keep running while the node is disconnected, and their events are queued in the event store
+	s.Require().NoError(s.dataPlane.Stop(s.ctx))
+	storeEvent("exec-2")
+	storeEvent("exec-3")
+
+	select {
+	case msg := <-s.msgChan:
+		s.Require().Failf("Unexpected message while disconnected", "message: %v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// queued messages are replayed in order on reconnect
+	dp, err := nclprotocolcompute.NewDataPlane(nclprotocolcompute.DataPlaneParams{
+		Config: s.config,
+		Client: s.natsConn,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(dp.Start(s.ctx))
+	defer dp.Stop(context.Background())
+
+	// messages that were not acknowledged before disconnecting are delivered again
+	seqNums := receiveUntil(3)
+	s.Require().IsIncreasing(seqNums)
+	s.Require().Subset(seqNums, []uint64{2, 3})
+}
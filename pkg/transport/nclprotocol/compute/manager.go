@@ -1,7 +1,9 @@
 package compute
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"sync"
@@ -42,6 +44,10 @@ type ConnectionManager struct {
 	incomingCheckpointName string                       // Name used for checkpoint storage
 	incomingSeqTracker     *nclprotocol.SequenceTracker // Tracks processed message sequences
 
+	// Message verification with the orchestrator's public key, pinned on the first handshake
+	orchestratorVerifier *nclprotocol.PeerVerifier
+	orchestratorKey      ed25519.PublicKey
+
 	// Health monitoring
 	healthTracker *HealthTracker // Tracks connection health and state
 
@@ -74,8 +80,10 @@ func NewConnectionManager(cfg Config) (*ConnectionManager, error) {
 		config:                 cfg,
 		healthTracker:          NewHealthTracker(cfg.Clock),
 		incomingCheckpointName: fmt.Sprintf("incoming-%s", cfg.NodeID),
-		stopCh:                 make(chan struct{}),
-		stateChanges:           make(chan stateChange, stateChangesBuffer), // buffered to avoid blocking
+		orchestratorVerifier: nclprotocol.NewPeerVerifier(cfg.NodeID, cfg.RequireSignedMessages).
+			WithReadyTimeout(cfg.RequestTimeout),
+		stopCh:       make(chan struct{}),
+		stateChanges: make(chan stateChange, stateChangesBuffer), // buffered to avoid blocking
 	}
 
 	return cm, nil
@@ -233,7 +241,7 @@ func (cm *ConnectionManager) connect(ctx context.Context) error {
 		return fmt.Errorf("failed to setup requester: %w", err)
 	}
 
-	handshakeResponse, err := cm.performHandshake(ctx, requester)
+	handshakeResponse, dataPlaneSerializer, err := cm.performHandshake(ctx, requester)
 	if err != nil {
 		return fmt.Errorf("handshake failed: %w", err)
 	}
@@ -242,7 +250,7 @@ func (cm *ConnectionManager) connect(ctx context.Context) error {
 		return fmt.Errorf("failed to setup control plane: %w", err)
 	}
 
-	if err = cm.setupDataPlane(ctx, handshakeResponse); err != nil {
+	if err = cm.setupDataPlane(ctx, handshakeResponse, dataPlaneSerializer); err != nil {
 		return fmt.Errorf("failed to setup data plane: %w", err)
 	}
 
@@ -264,12 +272,23 @@ func (cm *ConnectionManager) setupTransport(ctx context.Context) error {
 func (cm *ConnectionManager) setupRequester(ctx context.Context) (ncl.Publisher, error) {
 	return ncl.NewPublisher(cm.natsConn, ncl.PublisherConfig{
 		Name:              cm.config.NodeID,
-		Destination:       nclprotocol.NatsSubjectComputeOutCtrl(cm.config.NodeID),
+		Destination:       nclprotocol.NatsSubjectComputeOutCtrl(cm.config.SubjectPrefix, cm.config.NodeID),
 		MessageSerializer: cm.config.MessageSerializer,
 		MessageRegistry:   cm.config.MessageRegistry,
+		MessageSigner:     nclprotocol.NewMessageSigner(cm.config.SigningKey, ""),
+		MessageVerifier:   envelope.VerifierFunc(cm.verifyResponse),
 	})
 }
 
+// verifyResponse verifies control plane responses with the orchestrator's public key.
+// The response to the first handshake is trusted, as it carries the key to pin.
+func (cm *ConnectionManager) verifyResponse(message *envelope.EncodedMessage) error {
+	if !cm.orchestratorVerifier.Ready() {
+		return nil
+	}
+	return cm.orchestratorVerifier.Verify(message)
+}
+
 // setupSubscriber creates and starts the data plane message subscriber
 func (cm *ConnectionManager) setupSubscriber(ctx context.Context) error {
 	var err error
@@ -277,23 +296,25 @@ func (cm *ConnectionManager) setupSubscriber(ctx context.Context) error {
 		Name:               cm.config.NodeID,
 		MessageRegistry:    cm.config.MessageRegistry,
 		MessageSerializer:  cm.config.MessageSerializer,
-		MessageHandler:     cm.config.DataPlaneMessageHandler,
+		MessageVerifier:    cm.orchestratorVerifier,
+		MessageHandler:     nclprotocol.NewReplayGuard(cm.config.DataPlaneMessageHandler, cm.incomingSeqTracker),
 		ProcessingNotifier: cm.incomingSeqTracker,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create subscriber: %w", err)
 	}
 
-	if err = cm.subscriber.Subscribe(ctx, nclprotocol.NatsSubjectComputeInMsgs(cm.config.NodeID)); err != nil {
+	if err = cm.subscriber.Subscribe(ctx, nclprotocol.NatsSubjectComputeInMsgs(cm.config.SubjectPrefix, cm.config.NodeID)); err != nil {
 		return fmt.Errorf("failed to subscribe: %w", err)
 	}
 	return nil
 }
 
 // performHandshake executes the initial handshake with the orchestrator
-// sending node information and start time
+// sending node information and start time. It returns the orchestrator's response
+// and the serializer negotiated for data plane messages sent to the orchestrator.
 func (cm *ConnectionManager) performHandshake(
-	ctx context.Context, requester ncl.Publisher) (messages.HandshakeResponse, error) {
+	ctx context.Context, requester ncl.Publisher) (messages.HandshakeResponse, envelope.MessageSerializer, error) {
 	ctx, cancel := context.WithTimeout(ctx, cm.config.RequestTimeout)
 	defer cancel()
 
@@ -301,34 +322,56 @@ func (cm *ConnectionManager) performHandshake(
 		NodeInfo:               cm.config.NodeInfoProvider.GetNodeInfo(ctx),
 		StartTime:              cm.GetHealth().StartTime,
 		LastOrchestratorSeqNum: cm.incomingSeqTracker.GetLastSeqNum(),
+		PublicKey:              nclprotocol.PublicKey(cm.config.SigningKey),
 	}
 
 	// Send handshake
-	msg := envelope.NewMessage(handshake).
-		WithMetadataValue(envelope.KeyMessageType, messages.HandshakeRequestMessageType)
+	msg := nclprotocol.WithAcceptCompression(envelope.NewMessage(handshake).
+		WithMetadataValue(envelope.KeyMessageType, messages.HandshakeRequestMessageType))
 
 	response, err := requester.Request(ctx, ncl.NewPublishRequest(msg))
 	if err != nil {
-		return messages.HandshakeResponse{}, fmt.Errorf("handshake request failed: %w", err)
+		return messages.HandshakeResponse{}, nil, fmt.Errorf("handshake request failed: %w", err)
 	}
 
 	payload, ok := response.GetPayload(messages.HandshakeResponse{})
 	if !ok {
-		return messages.HandshakeResponse{}, fmt.Errorf(
+		return messages.HandshakeResponse{}, nil, fmt.Errorf(
 			"invalid handshake response payload. expected messages.HandshakeResponse, got %T", payload)
 	}
 
 	handshakeResponse := payload.(messages.HandshakeResponse)
 	if !handshakeResponse.Accepted {
-		return messages.HandshakeResponse{}, fmt.Errorf(
+		return messages.HandshakeResponse{}, nil, fmt.Errorf(
 			"handshake rejected by orchestrator due to %s", handshakeResponse.Reason)
 	}
 
+	// Pin the orchestrator's public key on the first handshake, and reject
+	// orchestrators presenting another key on reconnection
+	if err = cm.setOrchestratorKey(handshakeResponse.PublicKey); err != nil {
+		return messages.HandshakeResponse{}, nil, err
+	}
+
 	// Always trust the orchestrator's starting sequence number as it may have been reset
 	// or decided to start from a different point
 	cm.incomingSeqTracker.UpdateLastSeqNum(handshakeResponse.StartingOrchestratorSeqNum)
 
-	return handshakeResponse, nil
+	serializer := nclprotocol.NegotiateSerializer(
+		cm.config.MessageSerializer, cm.config.Compression, cm.config.CompressionThreshold, response.Metadata)
+	return handshakeResponse, serializer, nil
+}
+
+// setOrchestratorKey sets the public key the orchestrator sent during the handshake
+// to verify its messages, failing if it differs from the key pinned on the first handshake
+func (cm *ConnectionManager) setOrchestratorKey(key ed25519.PublicKey) error {
+	if cm.orchestratorVerifier.Ready() && !bytes.Equal(cm.orchestratorKey, key) {
+		return fmt.Errorf("orchestrator public key does not match the key of the first handshake")
+	}
+	if err := cm.orchestratorVerifier.SetPeerKey(key); err != nil {
+		return fmt.Errorf("failed to verify orchestrator messages: %w", err)
+	}
+	cm.orchestratorKey = key
+	return nil
 }
 
 // setupControlPlane creates and starts the control plane
@@ -352,11 +395,16 @@ func (cm *ConnectionManager) setupControlPlane(ctx context.Context, requester nc
 	return nil
 }
 
-// setupDataPlane creates and starts the data plane
-func (cm *ConnectionManager) setupDataPlane(ctx context.Context, handshake messages.HandshakeResponse) error {
+// setupDataPlane creates and starts the data plane, sending messages with the serializer
+// negotiated during the handshake
+func (cm *ConnectionManager) setupDataPlane(
+	ctx context.Context, handshake messages.HandshakeResponse, serializer envelope.MessageSerializer) error {
+	config := cm.config
+	config.MessageSerializer = serializer
+
 	var err error
 	cm.dataPlane, err = NewDataPlane(DataPlaneParams{
-		Config:             cm.config,
+		Config:             config,
 		Client:             cm.natsConn,
 		LastReceivedSeqNum: handshake.LastComputeSeqNum,
 	})
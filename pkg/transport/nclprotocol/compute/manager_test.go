@@ -4,6 +4,8 @@ package compute_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -217,6 +219,56 @@ func (s *ConnectionManagerTestSuite) TestRejectedHandshake() {
 	}, time.Second, 10*time.Millisecond, "manager should be connected")
 }
 
+func (s *ConnectionManagerTestSuite) TestSignedMessages() {
+	orchestratorPub, orchestratorKey, err := ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+	computePub, computeKey, err := ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+
+	// Replace responder with one signing its responses
+	behavior := *s.mockResponder.Behaviour()
+	behavior.SigningKey = orchestratorKey
+	s.Require().NoError(s.mockResponder.Close(s.ctx))
+	s.mockResponder, err = ncltest.NewMockResponder(s.ctx, s.natsConn, &behavior)
+	s.Require().NoError(err)
+
+	s.config.SigningKey = computeKey
+	s.config.RequireSignedMessages = true
+	s.manager, err = nclprotocolcompute.NewConnectionManager(s.config)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.manager.Start(s.ctx))
+
+	// Handshake fails as the orchestrator did not send its public key
+	s.Require().Eventually(func() bool {
+		health := s.manager.GetHealth()
+		return health.CurrentState == nclprotocol.Disconnected &&
+			health.LastError != nil &&
+			errors.Is(health.LastError, nclprotocol.ErrUnsignedPeer)
+	}, time.Second, 10*time.Millisecond)
+
+	// Send the orchestrator's public key and verify the node connects and sends heartbeats
+	s.mockResponder.Behaviour().HandshakeResponse.Response = messages.HandshakeResponse{
+		Accepted:  true,
+		PublicKey: orchestratorPub,
+	}
+	s.Require().Eventually(func() bool {
+		return s.manager.GetHealth().CurrentState == nclprotocol.Connected &&
+			len(s.mockResponder.GetHeartbeats()) > 0
+	}, time.Second, 10*time.Millisecond, "manager should be connected")
+
+	// Signed heartbeat responses are addressed to the node and verified
+	connectedAt := s.manager.GetHealth().LastSuccessfulHeartbeat
+	s.Require().Eventually(func() bool {
+		return s.manager.GetHealth().LastSuccessfulHeartbeat.After(connectedAt)
+	}, time.Second, 10*time.Millisecond, "heartbeat responses should be verified")
+	s.Zero(s.manager.GetHealth().ConsecutiveFailures)
+
+	handshakes := s.mockResponder.GetHandshakes()
+	s.Require().NotEmpty(handshakes)
+	s.Equal([]byte(computePub), handshakes[len(handshakes)-1].PublicKey)
+}
+
 func (s *ConnectionManagerTestSuite) TestHeartbeatFailure() {
 	err := s.manager.Start(s.ctx)
 	s.Require().NoError(err)
@@ -3,3 +3,6 @@ package dispatcher
 const (
 	KeySeqNum = "Bacalhau-SeqNum"
 )
+
+// AttrSpanSeqNum is the span attribute holding the sequence number of the dispatched event
+const AttrSpanSeqNum = "ncl.seq_num"
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
+	"github.com/bacalhau-project/bacalhau/pkg/telemetry"
 	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
@@ -28,7 +32,7 @@ func newMessageHandler(creator nclprotocol.MessageCreator, publisher ncl.Ordered
 // HandleEvent processes a single event from the watcher.
 // It creates and publishes a message asynchronously if needed.
 // The method returns quickly, with actual publishing handled asynchronously.
-func (h *messageHandler) HandleEvent(ctx context.Context, event watcher.Event) error {
+func (h *messageHandler) HandleEvent(ctx context.Context, event watcher.Event) (err error) {
 	message, err := h.creator.CreateMessage(event)
 	if err != nil {
 		return newPublishError(fmt.Errorf("create message: %w", err))
@@ -39,6 +43,14 @@ func (h *messageHandler) HandleEvent(ctx context.Context, event watcher.Event) e
 		return nil
 	}
 
+	// Each dispatched event starts the trace of the message across nodes
+	ctx, span := ncl.StartMessageSpan(ctx, "nclprotocol.Dispatcher.HandleEvent", message,
+		oteltrace.WithAttributes(attribute.Int64(AttrSpanSeqNum, int64(event.SeqNum))))
+	defer func() {
+		_ = telemetry.RecordErrorOnSpan(span)(err)
+		span.End()
+	}()
+
 	if err = h.enrichAndPublish(ctx, message, event); err != nil {
 		return err
 	}
@@ -5,9 +5,15 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
@@ -16,19 +22,49 @@ import (
 	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
 )
 
+// dispatcherSpanMatcher matches a context carrying the span started by the
+// dispatcher for an event, as a child of the span of the handled context.
+type dispatcherSpanMatcher struct {
+	parent oteltrace.SpanContext
+	seqNum uint64
+}
+
+func (m dispatcherSpanMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	span, ok := oteltrace.SpanFromContext(ctx).(sdktrace.ReadOnlySpan)
+	if !ok {
+		return false
+	}
+	return span.Name() == "nclprotocol.Dispatcher.HandleEvent" &&
+		span.Parent().Equal(m.parent) &&
+		slices.Contains(span.Attributes(), attribute.Int64(AttrSpanSeqNum, int64(m.seqNum)))
+}
+
+func (m dispatcherSpanMatcher) String() string {
+	return fmt.Sprintf("context with the dispatcher span of event %d under span %s", m.seqNum, m.parent.SpanID())
+}
+
 type HandlerTestSuite struct {
 	suite.Suite
 	ctrl      *gomock.Controller
 	ctx       context.Context
+	span      oteltrace.Span
 	creator   *nclprotocol.MockMessageCreator
 	publisher *ncl.MockOrderedPublisher
 	state     *dispatcherState
 	handler   *messageHandler
+
+	previousProvider oteltrace.TracerProvider
 }
 
 func (suite *HandlerTestSuite) SetupTest() {
 	suite.ctrl = gomock.NewController(suite.T())
-	suite.ctx = context.Background()
+	suite.previousProvider = otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracetest.NewSpanRecorder())))
+	suite.ctx, suite.span = otel.Tracer("test").Start(context.Background(), "root")
 	suite.creator = nclprotocol.NewMockMessageCreator(suite.ctrl)
 	suite.publisher = ncl.NewMockOrderedPublisher(suite.ctrl)
 	suite.state = newDispatcherState()
@@ -37,6 +73,13 @@ func (suite *HandlerTestSuite) SetupTest() {
 
 func (suite *HandlerTestSuite) TearDownTest() {
 	suite.ctrl.Finish()
+	suite.span.End()
+	otel.SetTracerProvider(suite.previousProvider)
+}
+
+// publishContext matches the context messages of the event are published with
+func (suite *HandlerTestSuite) publishContext(event watcher.Event) gomock.Matcher {
+	return dispatcherSpanMatcher{parent: suite.span.SpanContext(), seqNum: event.SeqNum}
 }
 
 func (suite *HandlerTestSuite) TestHandleEventCreatorError() {
@@ -75,8 +118,9 @@ func (suite *HandlerTestSuite) TestHandleEventPublishError() {
 		CreateMessage(event).
 		Return(msg, nil)
 
+	// messages are published with the context of the span tracing them
 	suite.publisher.EXPECT().
-		PublishAsync(suite.ctx, gomock.Any()).
+		PublishAsync(suite.publishContext(event), gomock.Any()).
 		DoAndReturn(func(_ context.Context, req ncl.PublishRequest) (ncl.PubFuture, error) {
 			// Verify message enrichment
 			suite.Equal(msg, req.Message)
@@ -101,8 +145,9 @@ func (suite *HandlerTestSuite) TestHandleEventSuccess() {
 		CreateMessage(event).
 		Return(msg, nil)
 
+	// messages are published with the context of the span tracing them
 	suite.publisher.EXPECT().
-		PublishAsync(suite.ctx, gomock.Any()).
+		PublishAsync(suite.publishContext(event), gomock.Any()).
 		DoAndReturn(func(_ context.Context, req ncl.PublishRequest) (ncl.PubFuture, error) {
 			// Verify message enrichment
 			suite.Equal("test.subject", req.Subject)
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"time"
 
@@ -24,6 +25,10 @@ type Config struct {
 	// ClientFactory creates NATS clients for transport connections
 	ClientFactory natsutil.ClientFactory
 
+	// SubjectPrefix is the prefix of the NATS subjects, allowing multiple clusters
+	// to share a NATS cluster. The default prefix is used if empty.
+	SubjectPrefix string
+
 	// NodeManager handles compute node lifecycle and state management
 	NodeManager nodes.Manager
 
@@ -31,6 +36,15 @@ type Config struct {
 	MessageRegistry   *envelope.Registry         // Registry of message types for serialization
 	MessageSerializer envelope.MessageSerializer // Handles message envelope serialization
 
+	// Compression of data plane messages sent to compute nodes that support it
+	Compression          envelope.Compression // Algorithm used to compress messages
+	CompressionThreshold int                  // Minimum size in bytes of compressed messages
+
+	// Message signing with the orchestrator's key, and verification of compute node signatures
+	SigningKey            ed25519.PrivateKey // Key signing messages sent to compute nodes. Messages are not signed if nil
+	RequireSignedMessages bool               // Reject compute nodes that don't sign their messages
+	ReplayWindow          time.Duration      // Maximum clock difference of signed control plane requests, within which their nonces are remembered
+
 	// Control plane timeouts and intervals
 	HeartbeatTimeout      time.Duration // Maximum time to wait for node heartbeat before considering it disconnected
 	NodeCleanupInterval   time.Duration // How often to check for and cleanup disconnected nodes
@@ -51,12 +65,15 @@ func (c *Config) Validate() error {
 	return errors.Join(
 		validate.NotBlank(c.NodeID, "node ID cannot be blank"),
 		validate.NotNil(c.ClientFactory, "client factory cannot be nil"),
+		nclprotocol.ValidateSubjectPrefix(c.SubjectPrefix),
 		validate.NotNil(c.NodeManager, "node manager cannot be nil"),
 		validate.NotNil(c.MessageRegistry, "message registry cannot be nil"),
 		validate.NotNil(c.MessageSerializer, "message serializer cannot be nil"),
 		validate.IsGreaterThanZero(c.HeartbeatTimeout, "heartbeat timeout must be positive"),
 		validate.IsGreaterThanZero(c.NodeCleanupInterval, "node cleanup interval must be positive"),
 		validate.IsGreaterThanZero(c.RequestHandlerTimeout, "request handler timeout must be positive"),
+		validate.IsGreaterThanZero(c.ReplayWindow, "replay window must be positive"),
+		validate.IsGreaterOrEqualToZero(c.CompressionThreshold, "compression threshold cannot be negative"),
 		validate.NotNil(c.DataPlaneMessageHandler, "data plane message handler cannot be nil"),
 		validate.NotNil(c.DataPlaneMessageCreatorFactory, "data plane message creator factory cannot be nil"),
 		validate.NotNil(c.EventStore, "event store cannot be nil"),
@@ -72,11 +89,15 @@ func DefaultConfig() Config {
 		HeartbeatTimeout:      2 * time.Minute,  // Time before node considered disconnected
 		NodeCleanupInterval:   30 * time.Second, // Check for disconnected nodes every 30s
 		RequestHandlerTimeout: 2 * time.Second,  // Individual request timeout
+		ReplayWindow:          nclprotocol.DefaultReplayWindow,
 
 		// Default message handling
 		MessageSerializer: envelope.NewSerializer(),
 		MessageRegistry:   nclprotocol.MustCreateMessageRegistry(),
 
+		// Default compression threshold, with compression disabled unless configured
+		CompressionThreshold: envelope.DefaultCompressionThreshold,
+
 		// Default dispatcher configuration
 		DispatcherConfig: dispatcher.DefaultConfig(),
 	}
@@ -97,6 +118,9 @@ func (c *Config) setDefaults() {
 	if c.RequestHandlerTimeout == 0 {
 		c.RequestHandlerTimeout = defaults.RequestHandlerTimeout
 	}
+	if c.ReplayWindow == 0 {
+		c.ReplayWindow = defaults.ReplayWindow
+	}
 
 	// Apply default message handling if not set
 	if c.MessageSerializer == nil {
@@ -105,6 +129,9 @@ func (c *Config) setDefaults() {
 	if c.MessageRegistry == nil {
 		c.MessageRegistry = defaults.MessageRegistry
 	}
+	if c.CompressionThreshold == 0 {
+		c.CompressionThreshold = defaults.CompressionThreshold
+	}
 
 	// Apply default dispatcher config if not set
 	if c.DispatcherConfig == (dispatcher.Config{}) {
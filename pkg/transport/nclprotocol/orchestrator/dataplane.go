@@ -43,8 +43,9 @@ type DataPlane struct {
 // DataPlaneConfig defines the configuration for a DataPlane instance.
 // Each config corresponds to a single compute node connection.
 type DataPlaneConfig struct {
-	NodeID string     // ID of the compute node this data plane serves
-	Client *nats.Conn // NATS connection
+	NodeID        string     // ID of the compute node this data plane serves
+	Client        *nats.Conn // NATS connection
+	SubjectPrefix string     // Prefix of the NATS subjects, or empty for the default prefix
 
 	// Message handling
 	MessageHandler        ncl.MessageHandler
@@ -52,6 +53,12 @@ type DataPlaneConfig struct {
 	MessageRegistry       *envelope.Registry
 	MessageSerializer     envelope.MessageSerializer
 
+	// Message signing and verification. Messages with already processed
+	// sequence numbers are skipped when the compute node signs its messages.
+	MessageSigner     envelope.Signer   // Signs messages sent to the compute node
+	MessageVerifier   envelope.Verifier // Verifies messages received from the compute node
+	LastComputeSeqNum uint64            // Last sequence number processed from the compute node
+
 	// Event tracking
 	EventStore  watcher.EventStore
 	StartSeqNum uint64 // Initial sequence for event watching
@@ -80,7 +87,7 @@ func NewDataPlane(config DataPlaneConfig) (*DataPlane, error) {
 
 	return &DataPlane{
 		config:                  config,
-		incomingSequenceTracker: nclprotocol.NewSequenceTracker(),
+		incomingSequenceTracker: nclprotocol.NewSequenceTracker().WithLastSeqNum(config.LastComputeSeqNum),
 	}, nil
 }
 
@@ -107,8 +114,8 @@ func (dp *DataPlane) Start(ctx context.Context) error {
 	}()
 
 	// Define NATS subjects for this compute node
-	inSubject := nclprotocol.NatsSubjectOrchestratorInMsgs(dp.config.NodeID)
-	outSubject := nclprotocol.NatsSubjectOrchestratorOutMsgs(dp.config.NodeID)
+	inSubject := nclprotocol.NatsSubjectOrchestratorInMsgs(dp.config.SubjectPrefix, dp.config.NodeID)
+	outSubject := nclprotocol.NatsSubjectOrchestratorOutMsgs(dp.config.SubjectPrefix, dp.config.NodeID)
 
 	// Set up subscriber for incoming messages
 	if err = dp.setupSubscriber(ctx, inSubject); err != nil {
@@ -137,12 +144,18 @@ func (dp *DataPlane) Start(ctx context.Context) error {
 }
 
 func (dp *DataPlane) setupSubscriber(ctx context.Context, subject string) error {
+	handler := dp.config.MessageHandler
+	if dp.config.MessageVerifier != nil {
+		handler = nclprotocol.NewReplayGuard(handler, dp.incomingSequenceTracker)
+	}
+
 	var err error
 	dp.subscriber, err = ncl.NewSubscriber(dp.config.Client, ncl.SubscriberConfig{
 		Name:               fmt.Sprintf("orchestrator-%s", dp.config.NodeID),
 		MessageRegistry:    dp.config.MessageRegistry,
 		MessageSerializer:  dp.config.MessageSerializer,
-		MessageHandler:     dp.config.MessageHandler,
+		MessageVerifier:    dp.config.MessageVerifier,
+		MessageHandler:     handler,
 		ProcessingNotifier: dp.incomingSequenceTracker,
 	})
 	if err != nil {
@@ -158,6 +171,7 @@ func (dp *DataPlane) setupPublisher(subject string) error {
 		Name:              fmt.Sprintf("orchestrator-%s", dp.config.NodeID),
 		MessageRegistry:   dp.config.MessageRegistry,
 		MessageSerializer: dp.config.MessageSerializer,
+		MessageSigner:     dp.config.MessageSigner,
 		Destination:       subject,
 	})
 	if err != nil {
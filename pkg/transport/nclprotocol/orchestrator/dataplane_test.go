@@ -88,7 +88,7 @@ func (s *DataPlaneTestSuite) setupMessagePassing() {
 	s.publisher, err = ncl.NewPublisher(s.natsConn, ncl.PublisherConfig{
 		Name:            "test-publisher",
 		MessageRegistry: s.config.MessageRegistry,
-		Destination:     nclprotocol.NatsSubjectOrchestratorInMsgs(s.config.NodeID),
+		Destination:     nclprotocol.NatsSubjectOrchestratorInMsgs(s.config.SubjectPrefix, s.config.NodeID),
 	})
 	s.Require().NoError(err)
 
@@ -101,7 +101,7 @@ func (s *DataPlaneTestSuite) setupMessagePassing() {
 	})
 	s.Require().NoError(err)
 
-	err = s.consumer.Subscribe(s.ctx, nclprotocol.NatsSubjectOrchestratorOutMsgs(s.config.NodeID))
+	err = s.consumer.Subscribe(s.ctx, nclprotocol.NatsSubjectOrchestratorOutMsgs(s.config.SubjectPrefix, s.config.NodeID))
 	s.Require().NoError(err)
 }
 
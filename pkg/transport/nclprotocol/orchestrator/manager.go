@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"sync"
@@ -32,7 +33,8 @@ type ComputeManager struct {
 	dataPlanes sync.Map      // map[string]*DataPlane
 
 	// Node management
-	nodeManager nodes.Manager // Tracks node state and health
+	nodeManager  nodes.Manager             // Tracks node state and health
+	replayWindow *nclprotocol.ReplayWindow // Rejects replayed control plane requests
 
 	// Lifecycle management
 	stopCh chan struct{}  // Signals background goroutines to stop
@@ -48,9 +50,10 @@ func NewComputeManager(cfg Config) (*ComputeManager, error) {
 	}
 
 	return &ComputeManager{
-		config:      cfg,
-		nodeManager: cfg.NodeManager,
-		stopCh:      make(chan struct{}),
+		config:       cfg,
+		nodeManager:  cfg.NodeManager,
+		replayWindow: nclprotocol.NewReplayWindow(cfg.ReplayWindow),
+		stopCh:       make(chan struct{}),
 	}, nil
 }
 
@@ -96,7 +99,9 @@ func (cm *ComputeManager) setupControlPlane(ctx context.Context) error {
 		Name:              "orchestrator-control",
 		MessageRegistry:   cm.config.MessageRegistry,
 		MessageSerializer: cm.config.MessageSerializer,
-		Subject:           nclprotocol.NatsSubjectOrchestratorInCtrl(),
+		MessageSigner:     nclprotocol.NewMessageSigner(cm.config.SigningKey, ""),
+		PayloadVerifier:   envelope.PayloadVerifierFunc(cm.verifyRequest),
+		Subject:           nclprotocol.NatsSubjectOrchestratorInCtrl(cm.config.SubjectPrefix),
 	})
 	if err != nil {
 		return fmt.Errorf("create control responder: %w", err)
@@ -169,11 +174,65 @@ func (cm *ComputeManager) Stop(ctx context.Context) error {
 	}
 }
 
+// verifyRequest verifies the signature of control plane requests. Handshake requests
+// are verified with the public key they carry, which the node manager checks against
+// the key the node first registered with. Other requests are verified with the key
+// exchanged during the node's last handshake, and must be sent by the node they are about.
+// Requests of nodes without a data plane are rejected, asking them to handshake again.
+// Signed requests must also be within the replay window, and carry a nonce that
+// wasn't seen before, so that captured requests can't be replayed.
+func (cm *ComputeManager) verifyRequest(encoded *envelope.EncodedMessage, message *envelope.Message) error {
+	var nodeID string
+	var verifier envelope.Verifier
+	switch request := message.Payload.(type) {
+	case *messages.HandshakeRequest:
+		nodeID = request.NodeInfo.ID()
+		if len(request.PublicKey) == 0 {
+			if cm.config.RequireSignedMessages {
+				return envelope.NewErrBadMessage(nclprotocol.ErrUnsignedPeer.Error())
+			}
+			return nil
+		}
+		if len(request.PublicKey) != ed25519.PublicKeySize {
+			return envelope.NewErrBadMessage("invalid node public key")
+		}
+		verifier = envelope.NewEd25519Verifier(request.PublicKey)
+	case *messages.HeartbeatRequest:
+		nodeID = request.NodeID
+	case *messages.UpdateNodeInfoRequest:
+		nodeID = request.NodeInfo.ID()
+	case *messages.ShutdownNoticeRequest:
+		nodeID = request.NodeID
+	default:
+		return nil
+	}
+
+	if verifier == nil {
+		dataPlane, exists := cm.getDataPlane(nodeID)
+		if !exists {
+			return NewErrHandshakeRequired(nodeID)
+		}
+		if dataPlane.config.MessageVerifier == nil {
+			// the node doesn't sign its messages, which was accepted during its handshake
+			return nil
+		}
+		verifier = dataPlane.config.MessageVerifier
+	}
+
+	if err := verifier.Verify(encoded); err != nil {
+		return err
+	}
+	if source := encoded.Metadata.Get(ncl.KeySource); source != nodeID {
+		return envelope.NewErrBadMessage(fmt.Sprintf("request about node %s was sent by %s", nodeID, source))
+	}
+	return cm.replayWindow.Check(encoded)
+}
+
 // handleHandshakeRequest processes incoming handshake requests from compute nodes.
 // For each new node, it:
 // 1. Validates the request through node manager
-// 2. Creates a new data plane if accepted
-// 3. Returns handshake response with connection details
+// 2. Creates a new data plane if accepted, compressing messages if the node supports it
+// 3. Returns handshake response with connection details and the orchestrator's public key
 func (cm *ComputeManager) handleHandshakeRequest(ctx context.Context, msg *envelope.Message) (*envelope.Message, error) {
 	request := msg.Payload.(*messages.HandshakeRequest)
 
@@ -188,11 +247,14 @@ func (cm *ComputeManager) handleHandshakeRequest(ctx context.Context, msg *envel
 	}
 
 	// Create data plane for accepted node
-	if err = cm.setupDataPlane(ctx, request.NodeInfo, response.StartingOrchestratorSeqNum); err != nil {
+	serializer := nclprotocol.NegotiateSerializer(
+		cm.config.MessageSerializer, cm.config.Compression, cm.config.CompressionThreshold, msg.Metadata)
+	if err = cm.setupDataPlane(ctx, *request, response, serializer); err != nil {
 		return nil, fmt.Errorf("setup data plane failed: %w", err)
 	}
 
-	return envelope.NewMessage(response), nil
+	response.PublicKey = nclprotocol.PublicKey(cm.config.SigningKey)
+	return nclprotocol.WithAcceptCompression(envelope.NewMessage(response)), nil
 }
 
 // setupDataPlane creates and starts a new data plane for a compute node.
@@ -200,19 +262,32 @@ func (cm *ComputeManager) handleHandshakeRequest(ctx context.Context, msg *envel
 // and replaced with the new one.
 func (cm *ComputeManager) setupDataPlane(
 	ctx context.Context,
-	nodeInfo models.NodeInfo,
-	lastReceivedSeqNum uint64,
+	request messages.HandshakeRequest,
+	response messages.HandshakeResponse,
+	serializer envelope.MessageSerializer,
 ) error {
+	nodeInfo := request.NodeInfo
+
+	// Verify messages of nodes that sign them with the key exchanged during the handshake
+	var verifier envelope.Verifier
+	if len(request.PublicKey) > 0 {
+		verifier = envelope.NewEd25519Verifier(request.PublicKey)
+	}
+
 	// Create new data plane configuration
 	dataPlane, err := NewDataPlane(DataPlaneConfig{
 		NodeID:                nodeInfo.ID(),
 		Client:                cm.natsConn,
+		SubjectPrefix:         cm.config.SubjectPrefix,
 		MessageRegistry:       cm.config.MessageRegistry,
-		MessageSerializer:     cm.config.MessageSerializer,
+		MessageSerializer:     serializer,
+		MessageSigner:         nclprotocol.NewMessageSigner(cm.config.SigningKey, nodeInfo.ID()),
+		MessageVerifier:       verifier,
+		LastComputeSeqNum:     response.LastComputeSeqNum,
 		MessageHandler:        cm.config.DataPlaneMessageHandler,
 		MessageCreatorFactory: cm.config.DataPlaneMessageCreatorFactory,
 		EventStore:            cm.config.EventStore,
-		StartSeqNum:           lastReceivedSeqNum,
+		StartSeqNum:           response.StartingOrchestratorSeqNum,
 		DispatcherConfig:      cm.config.DispatcherConfig,
 	})
 	if err != nil {
@@ -0,0 +1,158 @@
+//go:build unit || !integration
+
+package orchestrator
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/bacerrors"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
+	"github.com/bacalhau-project/bacalhau/pkg/models"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/orchestrator/nodes"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
+)
+
+const testNodeID = "node1"
+
+type VerifyRequestTestSuite struct {
+	suite.Suite
+	clock   *clock.Mock
+	manager *ComputeManager
+	pub     ed25519.PublicKey
+	signer  envelope.Signer
+}
+
+func TestVerifyRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyRequestTestSuite))
+}
+
+func (s *VerifyRequestTestSuite) SetupTest() {
+	pub, key, err := ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+	s.pub = pub
+	s.signer = nclprotocol.NewMessageSigner(key, "")
+
+	s.clock = clock.NewMock()
+	s.clock.Set(time.Now())
+	s.manager = &ComputeManager{
+		config: Config{
+			MessageRegistry:       nclprotocol.MustCreateMessageRegistry(),
+			RequireSignedMessages: true,
+		},
+		replayWindow: nclprotocol.NewReplayWindow(time.Minute).WithClock(s.clock),
+	}
+}
+
+// connect registers a data plane for the node, verifying its messages with the given key
+func (s *VerifyRequestTestSuite) connect(nodeID string, key ed25519.PublicKey) {
+	var verifier envelope.Verifier
+	if key != nil {
+		verifier = envelope.NewEd25519Verifier(key)
+	}
+	s.manager.dataPlanes.Store(nodeID, &DataPlane{config: DataPlaneConfig{NodeID: nodeID, MessageVerifier: verifier}})
+}
+
+// encode serializes a request sent by the node, signed with the signer if not nil
+func (s *VerifyRequestTestSuite) encode(payload any, messageType string, signer envelope.Signer) *envelope.EncodedMessage {
+	msg := envelope.NewMessage(payload).
+		WithMetadataValue(envelope.KeyMessageType, messageType).
+		WithMetadataValue(ncl.KeySource, testNodeID)
+	encoded, err := s.manager.config.MessageRegistry.Serialize(msg)
+	s.Require().NoError(err)
+	if signer != nil {
+		s.Require().NoError(signer.Sign(encoded))
+	}
+	return encoded
+}
+
+// verify deserializes the request and verifies it, as the control plane responder does
+func (s *VerifyRequestTestSuite) verify(encoded *envelope.EncodedMessage) error {
+	message, err := s.manager.config.MessageRegistry.Deserialize(encoded)
+	s.Require().NoError(err)
+	return s.manager.verifyRequest(encoded, message)
+}
+
+// requests returns a signed request of each control plane type sent after the handshake
+func (s *VerifyRequestTestSuite) requests() map[string]*envelope.EncodedMessage {
+	nodeInfo := models.NodeInfo{NodeID: testNodeID, NodeType: models.NodeTypeCompute}
+	return map[string]*envelope.EncodedMessage{
+		"heartbeat": s.encode(
+			messages.HeartbeatRequest{NodeID: testNodeID}, messages.HeartbeatRequestMessageType, s.signer),
+		"node info update": s.encode(
+			messages.UpdateNodeInfoRequest{NodeInfo: nodeInfo}, messages.NodeInfoUpdateRequestMessageType, s.signer),
+		"shutdown notice": s.encode(
+			messages.ShutdownNoticeRequest{NodeID: testNodeID}, messages.ShutdownNoticeRequestMessageType, s.signer),
+	}
+}
+
+func (s *VerifyRequestTestSuite) TestRejectsReplayedRequests() {
+	s.connect(testNodeID, s.pub)
+	for name, encoded := range s.requests() {
+		s.Run(name, func() {
+			s.Require().NoError(s.verify(encoded))
+			s.ErrorContains(s.verify(encoded), nclprotocol.ErrMsgReplayed)
+		})
+	}
+}
+
+func (s *VerifyRequestTestSuite) TestRejectsReplayedHandshake() {
+	encoded := s.encode(messages.HandshakeRequest{
+		NodeInfo:  models.NodeInfo{NodeID: testNodeID, NodeType: models.NodeTypeCompute},
+		PublicKey: s.pub,
+	}, messages.HandshakeRequestMessageType, s.signer)
+
+	s.Require().NoError(s.verify(encoded))
+	s.ErrorContains(s.verify(encoded), nclprotocol.ErrMsgReplayed)
+}
+
+func (s *VerifyRequestTestSuite) TestRejectsRequestsOutsideOfWindow() {
+	s.connect(testNodeID, s.pub)
+	requests := s.requests()
+	s.clock.Add(2 * time.Minute)
+	for name, encoded := range requests {
+		s.Run(name, func() {
+			s.ErrorContains(s.verify(encoded), nclprotocol.ErrMsgOutsideReplayWindow)
+		})
+	}
+}
+
+func (s *VerifyRequestTestSuite) TestRejectsRequestsOfNodesWithoutDataPlane() {
+	for name, encoded := range s.requests() {
+		s.Run(name, func() {
+			err := s.verify(encoded)
+			s.Require().Error(err)
+			s.True(bacerrors.IsErrorWithCode(err, nodes.HandshakeRequired))
+		})
+	}
+}
+
+func (s *VerifyRequestTestSuite) TestRejectsUnverifiedRequests() {
+	s.connect(testNodeID, s.pub)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+
+	for name, signer := range map[string]envelope.Signer{
+		"unsigned":          nil,
+		"signed by another": nclprotocol.NewMessageSigner(otherKey, ""),
+	} {
+		s.Run(name, func() {
+			s.Error(s.verify(
+				s.encode(messages.HeartbeatRequest{NodeID: testNodeID}, messages.HeartbeatRequestMessageType, signer)))
+		})
+	}
+}
+
+func (s *VerifyRequestTestSuite) TestAcceptsUnsignedNode() {
+	// nodes that don't sign their messages were accepted during their handshake
+	s.manager.config.RequireSignedMessages = false
+	s.connect(testNodeID, nil)
+	encoded := s.encode(messages.HeartbeatRequest{NodeID: testNodeID}, messages.HeartbeatRequestMessageType, nil)
+	s.NoError(s.verify(encoded))
+}
@@ -18,6 +18,7 @@ func CreateMessageRegistry() (*envelope.Registry, error) {
 		reg.Register(messages.BidResultMessageType, messages.BidResult{}),
 		reg.Register(messages.RunResultMessageType, messages.RunResult{}),
 		reg.Register(messages.ComputeErrorMessageType, messages.ComputeError{}),
+		reg.Register(messages.ExecutionLogsMessageType, messages.ExecutionLogs{}),
 
 		// Control plane messages
 		reg.Register(messages.HandshakeRequestMessageType, messages.HandshakeRequest{}),
@@ -0,0 +1,293 @@
+// Package nclprotocol implements the protocol between compute nodes and orchestrators.
+//
+// Messages are signed with the ed25519 key of their sender, and verified with the public
+// key the peer sent in its handshake. Keys are trusted on first use: the orchestrator pins
+// the key of a compute node on its first handshake, and compute nodes trust the key of the
+// orchestrator they connect to. There is no trust anchor, such as a certificate authority,
+// so signing protects established connections against forged and replayed messages, but
+// doesn't authenticate the first handshake. It doesn't protect against a tenant with access
+// to the NATS cluster who performs the handshake itself, for a node ID that wasn't seen yet.
+package nclprotocol
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
+)
+
+// Error message constants
+const (
+	ErrMsgHandshakeNotCompleted = "message received before completing the handshake with the peer"
+	ErrMsgWrongRecipient        = "message is addressed to another node"
+	ErrMsgMissingNonce          = "message is missing its nonce or timestamp"
+	ErrMsgOutsideReplayWindow   = "message timestamp is outside of the accepted window"
+	ErrMsgReplayed              = "message was already received"
+)
+
+// DefaultReplayWindow is how far the timestamp of a signed request may be from the
+// receiver's clock before it is rejected, and how long its nonce is remembered
+const DefaultReplayWindow = 5 * time.Minute
+
+// ErrUnsignedPeer is returned when a peer that doesn't sign its messages
+// connects to a node requiring signed messages
+var ErrUnsignedPeer = errors.New("peer does not sign its messages, which are required to be signed")
+
+// messageSigner signs messages with the node's private key, and addresses them
+// to their recipient so that they can't be replayed to other nodes. Each message
+// is given a nonce and a timestamp, so that requests can't be replayed to the same node.
+type messageSigner struct {
+	signer    *envelope.Ed25519Signer
+	recipient string
+}
+
+// NewMessageSigner returns a signer signing messages with the given private key.
+// If recipient is not empty, messages are addressed to it.
+// Returns nil if key is nil, in which case messages are not signed.
+func NewMessageSigner(key ed25519.PrivateKey, recipient string) envelope.Signer {
+	if key == nil {
+		return nil
+	}
+	return &messageSigner{
+		signer:    envelope.NewEd25519Signer(key),
+		recipient: recipient,
+	}
+}
+
+// Sign addresses the message to the recipient, adds a nonce and a timestamp and signs it
+func (s *messageSigner) Sign(message *envelope.EncodedMessage) error {
+	if message == nil {
+		return envelope.NewErrBadMessage(envelope.ErrNilMessage)
+	}
+	if message.Metadata == nil {
+		message.Metadata = &envelope.Metadata{}
+	}
+	if s.recipient != "" {
+		message.Metadata.Set(KeyRecipient, s.recipient)
+	}
+	message.Metadata.Set(KeyNonce, uuid.NewString())
+	message.Metadata.SetTime(KeyTimestamp, time.Now().UTC())
+	return s.signer.Sign(message)
+}
+
+// PublicKey returns the public key of the given private key,
+// or nil if the key is nil, to be exchanged during the handshake
+func PublicKey(key ed25519.PrivateKey) ed25519.PublicKey {
+	if key == nil {
+		return nil
+	}
+	return key.Public().(ed25519.PublicKey)
+}
+
+// PeerVerifier verifies the messages received from a peer with the public key
+// it sent during the handshake. Messages received before the handshake are held
+// until it completes or the ready timeout expires, and are then rejected.
+// Peers that didn't send a public key, such as older versions, are trusted unless
+// signed messages are required.
+type PeerVerifier struct {
+	recipient     string
+	requireSigned bool
+	readyTimeout  time.Duration
+
+	mu       sync.RWMutex
+	readyCh  chan struct{}
+	verifier envelope.Verifier
+}
+
+// NewPeerVerifier creates a new verifier of messages received from a peer.
+// If recipient is not empty, signed messages must be addressed to it.
+func NewPeerVerifier(recipient string, requireSigned bool) *PeerVerifier {
+	return &PeerVerifier{
+		recipient:     recipient,
+		requireSigned: requireSigned,
+		readyCh:       make(chan struct{}),
+	}
+}
+
+// WithReadyTimeout sets how long messages received before the handshake
+// are held waiting for the peer key before being rejected
+func (v *PeerVerifier) WithReadyTimeout(timeout time.Duration) *PeerVerifier {
+	v.readyTimeout = timeout
+	return v
+}
+
+// SetPeerKey sets the public key exchanged with the peer during the handshake.
+// A nil key means the peer doesn't sign its messages.
+func (v *PeerVerifier) SetPeerKey(key ed25519.PublicKey) error {
+	var verifier envelope.Verifier
+	if len(key) == 0 {
+		if v.requireSigned {
+			return ErrUnsignedPeer
+		}
+	} else {
+		if len(key) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid peer public key of %d bytes", len(key))
+		}
+		verifier = envelope.NewEd25519Verifier(key)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.verifier = verifier
+	if !v.ready() {
+		close(v.readyCh)
+	}
+	return nil
+}
+
+// Ready returns true if the peer key was set
+func (v *PeerVerifier) Ready() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.ready()
+}
+
+func (v *PeerVerifier) ready() bool {
+	select {
+	case <-v.readyCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// Verify returns an error if the message is not signed by the peer,
+// or is addressed to another node
+func (v *PeerVerifier) Verify(message *envelope.EncodedMessage) error {
+	if !v.Ready() {
+		select {
+		case <-v.readyCh:
+		case <-time.After(v.readyTimeout):
+			return envelope.NewErrBadMessage(ErrMsgHandshakeNotCompleted)
+		}
+	}
+
+	v.mu.RLock()
+	verifier := v.verifier
+	v.mu.RUnlock()
+
+	if verifier == nil {
+		return nil
+	}
+	if err := verifier.Verify(message); err != nil {
+		return err
+	}
+	if v.recipient != "" && message.Metadata.Get(KeyRecipient) != v.recipient {
+		return envelope.NewErrBadMessage(ErrMsgWrongRecipient)
+	}
+	return nil
+}
+
+// ReplayWindow rejects signed requests whose timestamp is too far from the local clock,
+// or whose nonce was already seen, so that requests captured from the network can't be
+// replayed. As the nonce and timestamp are part of the signed metadata, they can't be
+// modified without invalidating the message signature. Nonces are only remembered for
+// as long as their request is within the window, after which the timestamp rejects it.
+type ReplayWindow struct {
+	window time.Duration
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	nonces    map[string]time.Time // nonce -> time after which it can be forgotten
+	nextPrune time.Time
+}
+
+// NewReplayWindow creates a new replay window accepting requests
+// signed at most window before or after the local time
+func NewReplayWindow(window time.Duration) *ReplayWindow {
+	return &ReplayWindow{
+		window: window,
+		clock:  clock.New(),
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// WithClock sets the clock used to check request timestamps
+func (w *ReplayWindow) WithClock(clock clock.Clock) *ReplayWindow {
+	w.clock = clock
+	return w
+}
+
+// Check returns an error if the message is outside of the window, or was already received.
+// It must only be called once the signature of the message was verified.
+func (w *ReplayWindow) Check(message *envelope.EncodedMessage) error {
+	if message == nil || message.Metadata == nil {
+		return envelope.NewErrBadMessage(ErrMsgMissingNonce)
+	}
+	nonce := message.Metadata.Get(KeyNonce)
+	timestamp := message.Metadata.GetTime(KeyTimestamp)
+	if nonce == "" || timestamp.IsZero() {
+		return envelope.NewErrBadMessage(ErrMsgMissingNonce)
+	}
+
+	now := w.clock.Now()
+	if timestamp.Before(now.Add(-w.window)) || timestamp.After(now.Add(w.window)) {
+		return envelope.NewErrBadMessage(ErrMsgOutsideReplayWindow)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now.After(w.nextPrune) {
+		for n, expiry := range w.nonces {
+			if now.After(expiry) {
+				delete(w.nonces, n)
+			}
+		}
+		w.nextPrune = now.Add(w.window)
+	}
+	if _, seen := w.nonces[nonce]; seen {
+		return envelope.NewErrBadMessage(ErrMsgReplayed)
+	}
+	w.nonces[nonce] = timestamp.Add(w.window)
+	return nil
+}
+
+// replayGuard skips signed messages with a sequence number that was already processed, so that
+// messages captured from the network can't be replayed. As sequence numbers are part of the
+// signed metadata, they can't be modified without invalidating the message signature.
+// Unsigned messages are not guarded, as they can be forged anyway.
+type replayGuard struct {
+	handler ncl.MessageHandler
+	tracker *SequenceTracker
+}
+
+// NewReplayGuard wraps a message handler to skip signed messages with sequence numbers
+// lower than or equal to the last sequence number processed by the tracker
+func NewReplayGuard(handler ncl.MessageHandler, tracker *SequenceTracker) ncl.MessageHandler {
+	return &replayGuard{handler: handler, tracker: tracker}
+}
+
+// ShouldProcess returns false for messages that were already processed
+func (g *replayGuard) ShouldProcess(ctx context.Context, message *envelope.Message) bool {
+	if message.Metadata.Has(envelope.KeySignature) && message.Metadata.Has(KeySeqNum) {
+		seqNum := message.Metadata.GetUint64(KeySeqNum)
+		if lastSeqNum := g.tracker.GetLastSeqNum(); seqNum <= lastSeqNum {
+			log.Warn().
+				Uint64("seqNum", seqNum).
+				Uint64("lastSeqNum", lastSeqNum).
+				Str("type", message.Metadata.Get(envelope.KeyMessageType)).
+				Msg("Skipping message with an already processed sequence number")
+			return false
+		}
+	}
+	return g.handler.ShouldProcess(ctx, message)
+}
+
+// HandleMessage handles the message with the wrapped handler
+func (g *replayGuard) HandleMessage(ctx context.Context, message *envelope.Message) error {
+	return g.handler.HandleMessage(ctx, message)
+}
+
+// compile-time interface assertions
+var _ envelope.Signer = (*messageSigner)(nil)
+var _ envelope.Verifier = (*PeerVerifier)(nil)
+var _ ncl.MessageHandler = (*replayGuard)(nil)
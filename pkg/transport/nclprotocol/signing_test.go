@@ -0,0 +1,175 @@
+//go:build unit || !integration
+
+package nclprotocol_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
+	"github.com/bacalhau-project/bacalhau/pkg/models/messages"
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
+)
+
+type SigningTestSuite struct {
+	suite.Suite
+	registry *envelope.Registry
+	pub      ed25519.PublicKey
+	key      ed25519.PrivateKey
+}
+
+func TestSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningTestSuite))
+}
+
+func (s *SigningTestSuite) SetupTest() {
+	var err error
+	s.registry = nclprotocol.MustCreateMessageRegistry()
+	s.pub, s.key, err = ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+}
+
+// encode serializes a message and signs it with the signer
+func (s *SigningTestSuite) encode(signer envelope.Signer, seqNum uint64) *envelope.EncodedMessage {
+	msg := envelope.NewMessage(messages.HeartbeatRequest{NodeID: "node1"}).
+		WithMetadataValue(envelope.KeyMessageType, messages.HeartbeatRequestMessageType)
+	msg.Metadata.SetInt64(nclprotocol.KeySeqNum, int64(seqNum))
+	encoded, err := s.registry.Serialize(msg)
+	s.Require().NoError(err)
+	if signer != nil {
+		s.Require().NoError(signer.Sign(encoded))
+	}
+	return encoded
+}
+
+func (s *SigningTestSuite) TestNilKey() {
+	s.Nil(nclprotocol.NewMessageSigner(nil, "node1"))
+	s.Nil(nclprotocol.PublicKey(nil))
+	s.Equal(s.pub, nclprotocol.PublicKey(s.key))
+}
+
+func (s *SigningTestSuite) TestPeerVerifier() {
+	verifier := nclprotocol.NewPeerVerifier("node1", false)
+	encoded := s.encode(nclprotocol.NewMessageSigner(s.key, "node1"), 1)
+
+	// messages are rejected before the handshake
+	s.False(verifier.Ready())
+	s.Error(verifier.Verify(encoded))
+
+	s.Require().NoError(verifier.SetPeerKey(s.pub))
+	s.True(verifier.Ready())
+	s.NoError(verifier.Verify(encoded))
+
+	// unsigned messages, and messages addressed to other nodes are rejected
+	s.Error(verifier.Verify(s.encode(nil, 1)))
+	s.Error(verifier.Verify(s.encode(nclprotocol.NewMessageSigner(s.key, "node2"), 1)))
+	s.Error(verifier.Verify(s.encode(nclprotocol.NewMessageSigner(s.key, ""), 1)))
+
+	// messages signed with another key are rejected
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	s.Require().NoError(err)
+	s.Error(verifier.Verify(s.encode(nclprotocol.NewMessageSigner(otherKey, "node1"), 1)))
+
+	// tampered messages are rejected
+	encoded.Metadata.SetInt64(nclprotocol.KeySeqNum, 2)
+	s.Error(verifier.Verify(encoded))
+}
+
+func (s *SigningTestSuite) TestPeerVerifierWaitsForHandshake() {
+	verifier := nclprotocol.NewPeerVerifier("", false).WithReadyTimeout(time.Second)
+	encoded := s.encode(nclprotocol.NewMessageSigner(s.key, ""), 1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.NoError(verifier.SetPeerKey(s.pub))
+	}()
+	s.NoError(verifier.Verify(encoded))
+}
+
+func (s *SigningTestSuite) TestUnsignedPeer() {
+	// unsigned peers are trusted unless signed messages are required
+	verifier := nclprotocol.NewPeerVerifier("node1", false)
+	s.Require().NoError(verifier.SetPeerKey(nil))
+	s.NoError(verifier.Verify(s.encode(nil, 1)))
+
+	verifier = nclprotocol.NewPeerVerifier("node1", true)
+	s.ErrorIs(verifier.SetPeerKey(nil), nclprotocol.ErrUnsignedPeer)
+	s.False(verifier.Ready())
+
+	s.Error(verifier.SetPeerKey(ed25519.PublicKey("short")))
+}
+
+func (s *SigningTestSuite) TestReplayGuard() {
+	handler := ncl.MessageHandlerFunc(func(ctx context.Context, message *envelope.Message) error {
+		return nil
+	})
+	tracker := nclprotocol.NewSequenceTracker().WithLastSeqNum(5)
+	guard := nclprotocol.NewReplayGuard(handler, tracker)
+	signer := nclprotocol.NewMessageSigner(s.key, "")
+
+	decode := func(encoded *envelope.EncodedMessage) *envelope.Message {
+		msg, err := s.registry.Deserialize(encoded)
+		s.Require().NoError(err)
+		return msg
+	}
+
+	// signed messages with already processed sequence numbers are skipped
+	s.False(guard.ShouldProcess(context.Background(), decode(s.encode(signer, 4))))
+	s.False(guard.ShouldProcess(context.Background(), decode(s.encode(signer, 5))))
+	s.True(guard.ShouldProcess(context.Background(), decode(s.encode(signer, 6))))
+
+	// unsigned messages are not guarded
+	s.True(guard.ShouldProcess(context.Background(), decode(s.encode(nil, 4))))
+
+	tracker.OnProcessed(context.Background(), decode(s.encode(signer, 6)))
+	s.False(guard.ShouldProcess(context.Background(), decode(s.encode(signer, 6))))
+}
+
+func (s *SigningTestSuite) TestSignerAddsNonceAndTimestamp() {
+	signer := nclprotocol.NewMessageSigner(s.key, "")
+	first, second := s.encode(signer, 1), s.encode(signer, 1)
+
+	s.NotEmpty(first.Metadata.Get(nclprotocol.KeyNonce))
+	s.NotEqual(first.Metadata.Get(nclprotocol.KeyNonce), second.Metadata.Get(nclprotocol.KeyNonce))
+	s.WithinDuration(time.Now(), first.Metadata.GetTime(nclprotocol.KeyTimestamp), time.Minute)
+
+	// the nonce and timestamp are covered by the signature
+	verifier := envelope.NewEd25519Verifier(s.pub)
+	s.NoError(verifier.Verify(first))
+	first.Metadata.Set(nclprotocol.KeyNonce, second.Metadata.Get(nclprotocol.KeyNonce))
+	s.Error(verifier.Verify(first))
+	second.Metadata.SetTime(nclprotocol.KeyTimestamp, time.Now().Add(time.Hour))
+	s.Error(verifier.Verify(second))
+}
+
+func (s *SigningTestSuite) TestReplayWindow() {
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Now())
+	window := nclprotocol.NewReplayWindow(time.Minute).WithClock(mockClock)
+	signer := nclprotocol.NewMessageSigner(s.key, "")
+
+	// a request is accepted once
+	encoded := s.encode(signer, 1)
+	s.NoError(window.Check(encoded))
+	s.ErrorContains(window.Check(encoded), nclprotocol.ErrMsgReplayed)
+	s.NoError(window.Check(s.encode(signer, 1)))
+
+	// requests without a nonce or timestamp are rejected
+	s.ErrorContains(window.Check(s.encode(nil, 1)), nclprotocol.ErrMsgMissingNonce)
+
+	// requests outside of the window are rejected, even once their nonce is forgotten
+	mockClock.Add(2 * time.Minute)
+	s.ErrorContains(window.Check(encoded), nclprotocol.ErrMsgOutsideReplayWindow)
+	mockClock.Add(time.Hour)
+	s.ErrorContains(window.Check(encoded), nclprotocol.ErrMsgOutsideReplayWindow)
+
+	// as are requests from the future
+	mockClock.Set(time.Now().Add(-2 * time.Minute))
+	s.ErrorContains(window.Check(s.encode(signer, 1)), nclprotocol.ErrMsgOutsideReplayWindow)
+}
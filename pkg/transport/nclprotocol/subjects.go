@@ -2,28 +2,58 @@ package nclprotocol
 
 import (
 	"fmt"
+	"strings"
 )
 
-func NatsSubjectOrchestratorInCtrl() string {
-	return "bacalhau.global.compute.*.out.ctrl"
+// DefaultSubjectPrefix is the prefix of the NATS subjects used by the protocol
+// when no prefix is configured. Clusters sharing a NATS cluster must use distinct prefixes.
+const DefaultSubjectPrefix = "bacalhau.global"
+
+// SubjectPrefix returns the configured prefix, or the default prefix if none is configured
+func SubjectPrefix(prefix string) string {
+	if prefix == "" {
+		return DefaultSubjectPrefix
+	}
+	return prefix
+}
+
+// ValidateSubjectPrefix returns an error if the prefix is not made of valid NATS subject tokens.
+// An empty prefix is valid and means the default prefix is used.
+func ValidateSubjectPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	for _, token := range strings.Split(prefix, ".") {
+		if token == "" {
+			return fmt.Errorf("subject prefix %q cannot contain empty tokens", prefix)
+		}
+		if strings.ContainsAny(token, "*> \t\r\n") {
+			return fmt.Errorf("subject prefix %q cannot contain wildcards or whitespace", prefix)
+		}
+	}
+	return nil
+}
+
+func NatsSubjectOrchestratorInCtrl(prefix string) string {
+	return fmt.Sprintf("%s.compute.*.out.ctrl", SubjectPrefix(prefix))
 }
 
-func NatsSubjectOrchestratorInMsgs(computeNodeID string) string {
-	return fmt.Sprintf("bacalhau.global.compute.%s.out.msgs", computeNodeID)
+func NatsSubjectOrchestratorInMsgs(prefix, computeNodeID string) string {
+	return fmt.Sprintf("%s.compute.%s.out.msgs", SubjectPrefix(prefix), computeNodeID)
 }
 
-func NatsSubjectOrchestratorOutMsgs(computeNodeID string) string {
-	return fmt.Sprintf("bacalhau.global.compute.%s.in.msgs", computeNodeID)
+func NatsSubjectOrchestratorOutMsgs(prefix, computeNodeID string) string {
+	return fmt.Sprintf("%s.compute.%s.in.msgs", SubjectPrefix(prefix), computeNodeID)
 }
 
-func NatsSubjectComputeInMsgs(computeNodeID string) string {
-	return fmt.Sprintf("bacalhau.global.compute.%s.in.msgs", computeNodeID)
+func NatsSubjectComputeInMsgs(prefix, computeNodeID string) string {
+	return fmt.Sprintf("%s.compute.%s.in.msgs", SubjectPrefix(prefix), computeNodeID)
 }
 
-func NatsSubjectComputeOutCtrl(computeNodeID string) string {
-	return fmt.Sprintf("bacalhau.global.compute.%s.out.ctrl", computeNodeID)
+func NatsSubjectComputeOutCtrl(prefix, computeNodeID string) string {
+	return fmt.Sprintf("%s.compute.%s.out.ctrl", SubjectPrefix(prefix), computeNodeID)
 }
 
-func NatsSubjectComputeOutMsgs(computeNodeID string) string {
-	return fmt.Sprintf("bacalhau.global.compute.%s.out.msgs", computeNodeID)
+func NatsSubjectComputeOutMsgs(prefix, computeNodeID string) string {
+	return fmt.Sprintf("%s.compute.%s.out.msgs", SubjectPrefix(prefix), computeNodeID)
 }
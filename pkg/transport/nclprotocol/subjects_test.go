@@ -0,0 +1,45 @@
+//go:build unit || !integration
+
+package nclprotocol_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bacalhau-project/bacalhau/pkg/transport/nclprotocol"
+)
+
+type SubjectsTestSuite struct {
+	suite.Suite
+}
+
+func TestSubjectsTestSuite(t *testing.T) {
+	suite.Run(t, new(SubjectsTestSuite))
+}
+
+func (s *SubjectsTestSuite) TestDefaultPrefix() {
+	s.Equal("bacalhau.global.compute.*.out.ctrl", nclprotocol.NatsSubjectOrchestratorInCtrl(""))
+	s.Equal("bacalhau.global.compute.node1.out.ctrl", nclprotocol.NatsSubjectComputeOutCtrl("", "node1"))
+	s.Equal("bacalhau.global.compute.node1.in.msgs", nclprotocol.NatsSubjectComputeInMsgs("", "node1"))
+}
+
+func (s *SubjectsTestSuite) TestCustomPrefix() {
+	prefix := "team-a.bacalhau"
+	s.Equal("team-a.bacalhau.compute.*.out.ctrl", nclprotocol.NatsSubjectOrchestratorInCtrl(prefix))
+
+	// subjects of both sides must match
+	s.Equal(nclprotocol.NatsSubjectComputeOutMsgs(prefix, "node1"), nclprotocol.NatsSubjectOrchestratorInMsgs(prefix, "node1"))
+	s.Equal(nclprotocol.NatsSubjectComputeInMsgs(prefix, "node1"), nclprotocol.NatsSubjectOrchestratorOutMsgs(prefix, "node1"))
+	s.NotEqual(nclprotocol.NatsSubjectComputeInMsgs(prefix, "node1"), nclprotocol.NatsSubjectComputeInMsgs("", "node1"))
+}
+
+func (s *SubjectsTestSuite) TestValidateSubjectPrefix() {
+	s.NoError(nclprotocol.ValidateSubjectPrefix(""))
+	s.NoError(nclprotocol.ValidateSubjectPrefix("team-a"))
+	s.NoError(nclprotocol.ValidateSubjectPrefix("team-a.bacalhau"))
+
+	for _, prefix := range []string{".team", "team.", "team..a", "team.*", "team.>", "team a"} {
+		s.Error(nclprotocol.ValidateSubjectPrefix(prefix), prefix)
+	}
+}
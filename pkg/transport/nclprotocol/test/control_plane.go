@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"sync"
 
@@ -45,6 +46,12 @@ type MockResponderBehavior struct {
 	OnHeartbeat func(messages.HeartbeatRequest)      // Called when heartbeat received
 	OnNodeInfo  func(messages.UpdateNodeInfoRequest) // Called when node info update received
 	OnShutdown  func(messages.ShutdownNoticeRequest)
+
+	// SigningKey signs responses if set when the responder is created
+	SigningKey ed25519.PrivateKey
+
+	// SubjectPrefix is the prefix of the subject the responder listens on when created
+	SubjectPrefix string
 }
 
 // MockResponder provides a configurable mock implementation of the control plane responder.
@@ -97,7 +104,8 @@ func NewMockResponder(ctx context.Context, conn *nats.Conn, behavior *MockRespon
 		Name:              "mock-responder",
 		MessageRegistry:   nclprotocol.MustCreateMessageRegistry(),
 		MessageSerializer: envelope.NewSerializer(),
-		Subject:           nclprotocol.NatsSubjectOrchestratorInCtrl(),
+		MessageSigner:     nclprotocol.NewMessageSigner(behavior.SigningKey, ""),
+		Subject:           nclprotocol.NatsSubjectOrchestratorInCtrl(behavior.SubjectPrefix),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create responder: %w", err)
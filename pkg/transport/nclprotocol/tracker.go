@@ -39,10 +39,17 @@ func (s *SequenceTracker) GetLastSeqNum() uint64 {
 }
 
 // OnProcessed implements ncl.ProcessingNotifier to track message sequence numbers.
-// Called after each successful message processing operation.
+// Called after each successful message processing operation, including skipped messages,
+// so the sequence number only moves forward.
 func (s *SequenceTracker) OnProcessed(ctx context.Context, message *envelope.Message) {
 	if message.Metadata.Has(KeySeqNum) {
-		s.UpdateLastSeqNum(message.Metadata.GetUint64(KeySeqNum))
+		seqNum := message.Metadata.GetUint64(KeySeqNum)
+		for {
+			last := s.lastSeqNum.Load()
+			if seqNum <= last || s.lastSeqNum.CompareAndSwap(last, seqNum) {
+				break
+			}
+		}
 	} else {
 		log.Trace().Msgf("No sequence number found in message metadata %v", message.Metadata)
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/bacalhau-project/bacalhau/pkg/lib/envelope"
+	"github.com/bacalhau-project/bacalhau/pkg/lib/ncl"
 	"github.com/bacalhau-project/bacalhau/pkg/lib/watcher"
 )
 
@@ -55,6 +56,12 @@ type ConnectionHealth struct {
 
 const (
 	KeySeqNum = "Bacalhau-SeqNum"
+	// KeyRecipient holds the ID of the node a signed message is addressed to
+	KeyRecipient = ncl.KeyRecipient
+	// KeyNonce holds a unique value of each signed message, so that replayed messages can be detected
+	KeyNonce = "Bacalhau-Nonce"
+	// KeyTimestamp holds the time a message was signed at
+	KeyTimestamp = "Bacalhau-Timestamp"
 )
 
 // MessageCreator defines how events from the watcher are converted into
@@ -0,0 +1,8 @@
+;; Calls a function forever, so it only stops when it runs out of fuel
+;; or reaches its timeout.
+(module
+  (func $tick)
+  (func $start (export "_start")
+    (loop $burn
+      (call $tick)
+      (br $burn))))
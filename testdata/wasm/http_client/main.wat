@@ -0,0 +1,26 @@
+;; Forwards a request to the bacalhau_http host module, and copies the body of
+;; the response to the memory at out_ptr. Returns the status code of the
+;; response, or the error code of the request.
+(module
+  (import "bacalhau_http" "request"
+    (func $request (param i32 i32 i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "bacalhau_http" "read_response_body"
+    (func $read (param i32 i32) (result i32)))
+  (memory (export "memory") 1)
+  (func $fetch (export "fetch")
+    (param $method_ptr i32) (param $method_len i32)
+    (param $url_ptr i32) (param $url_len i32)
+    (param $headers_ptr i32) (param $headers_len i32)
+    (param $body_ptr i32) (param $body_len i32)
+    (param $out_ptr i32) (param $out_cap i32)
+    (result i32)
+    (local $status i32)
+    (local.set $status
+      (call $request
+        (local.get $method_ptr) (local.get $method_len)
+        (local.get $url_ptr) (local.get $url_len)
+        (local.get $headers_ptr) (local.get $headers_len)
+        (local.get $body_ptr) (local.get $body_len)))
+    (if (i32.gt_s (local.get $status) (i32.const 0))
+      (then (drop (call $read (local.get $out_ptr) (local.get $out_cap)))))
+    (local.get $status)))
@@ -0,0 +1,10 @@
+;; Grows its memory one page at a time until the memory limit is reached,
+;; and then traps like a module failing to allocate.
+(module
+  (memory (export "memory") 1)
+  (func $start (export "_start")
+    (block $oom
+      (loop $grow
+        (br_if $oom (i32.eq (memory.grow (i32.const 1)) (i32.const -1)))
+        (br $grow)))
+    unreachable))